@@ -2,7 +2,13 @@ package com.hoanhao.authservice.repository;
 
 import com.hoanhao.authservice.entity.UserRole;
 import org.springframework.data.jpa.repository.JpaRepository;
+import org.springframework.data.jpa.repository.Query;
+import org.springframework.data.repository.query.Param;
+
+import java.util.List;
 
 public interface UserRoleRepository extends JpaRepository<UserRole, Long> {
-    // Bạn có thể thêm các truy vấn tùy chỉnh nếu cần
+    // Lấy tên các role của user để đưa vào claim "roles" của access token
+    @Query("SELECT ur.role.name FROM UserRole ur WHERE ur.user.id = :userId")
+    List<String> findRoleNamesByUserId(@Param("userId") Long userId);
 }
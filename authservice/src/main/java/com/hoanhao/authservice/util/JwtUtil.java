@@ -2,6 +2,7 @@ package com.hoanhao.authservice.util;
 
 import com.hoanhao.authservice.entity.User;
 import com.hoanhao.authservice.repository.UserRepository;
+import com.hoanhao.authservice.repository.UserRoleRepository;
 import io.jsonwebtoken.Jwts;
 import io.jsonwebtoken.SignatureAlgorithm;
 import lombok.Getter;
@@ -27,6 +28,9 @@ public class JwtUtil {
     @Autowired
     private UserRepository userRepository;
 
+    @Autowired
+    private UserRoleRepository userRoleRepository;
+
     public String generateAccessToken(String username) {
         User user = userRepository.findByUsernameOrEmailOrPhone(username)
                 .orElseThrow(() -> new RuntimeException("User not found with username: " + username));
@@ -38,6 +42,7 @@ public class JwtUtil {
         return Jwts.builder()
                 .setSubject(username)
                 .claim("userId", user.getId())
+                .claim("roles", userRoleRepository.findRoleNamesByUserId(user.getId()))
                 .setIssuer("hoanhao-auth-service")
                 .setIssuedAt(new Date())
                 .setExpiration(new Date(System.currentTimeMillis() + accessTokenExpiration))
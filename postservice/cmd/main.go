@@ -10,6 +10,10 @@ import (
 	"postservice/internal/grpcclient"
 	"postservice/internal/handler"
 	"postservice/internal/repository"
+	"postservice/internal/service"
+	"postservice/internal/util"
+	"shared/jwtauth"
+	"shared/ratelimit"
 	"syscall"
 	"time"
 
@@ -32,6 +36,20 @@ func main() {
 		}
 	}()
 
+	// Xác thực chữ ký JWT tại service để request không qua Kong không giả mạo được userId
+	if err := jwtauth.Init(jwtauth.Config{
+		Secret:      cfg.JWTSecret,
+		JWKSURL:     cfg.JWTJWKSURL,
+		JWKSFile:    cfg.JWTJWKSFile,
+		Issuer:      cfg.JWTIssuer,
+		JWKSRefresh: cfg.JWTJWKSRefresh,
+	}); err != nil {
+		log.Fatalf("Failed to initialize JWT verifier: %v", err)
+	}
+
+	// Giới hạn số điểm ảnh để không giải mã ảnh khai báo kích thước khổng lồ
+	util.SetMaxImagePixels(int64(cfg.ImageMaxPixels))
+
 	// Khởi tạo repository
 	repo := repository.NewPostRepository(db)
 
@@ -44,11 +62,38 @@ func main() {
 		log.Fatalf("Failed to initialize gRPC client: %v", err)
 	}
 
+	// Khởi tạo service upload trực tiếp và janitor dọn các upload hết hạn
+	uploadSvc := service.NewUploadService(repository.NewMediaUploadRepository(db), cfg.UploadSessionTTL)
+	janitorCtx, stopJanitor := context.WithCancel(context.Background())
+	defer stopJanitor()
+	go uploadSvc.StartJanitor(janitorCtx, cfg.UploadJanitorInterval)
+
+	// Job dọn media mồ côi chạy định kỳ nếu được bật (có thể chạy tay bằng cmd/mediagc)
+	if cfg.MediaGCInterval > 0 {
+		mediaGC := service.NewMediaGCService(repository.NewMediaReferenceRepository(db), cfg.MediaGCGracePeriod, cfg.ContentRestoreWindow, cfg.MediaGCMaxDeletes)
+		go mediaGC.StartSchedule(janitorCtx, cfg.MediaGCInterval, cfg.MediaGCDryRun)
+	}
+
+	// Bộ lọc nội dung chạy khi tạo/sửa bài đăng và bình luận
+	contentFilter := newContentFilter(cfg)
+
+	// Giới hạn tần suất theo người dùng, dùng Redis để chia sẻ giữa nhiều instance
+	limiter := newRateLimiter(cfg)
+
+	// Cache quan hệ chặn giữa người dùng, lấy từ UserService qua gRPC
+	blocks := util.NewBlockChecker(cfg.BlockCacheTTL)
+
+	// Báo cáo nội dung và hàng đợi kiểm duyệt
+	reportSvc := service.NewReportService(repository.NewReportRepository(db), repo, blocks, cfg.ReportAutoHideThreshold)
+
 	// Khởi tạo Gin router
 	r := gin.Default()
 
 	// Đăng ký các route HTTP
-	handler.SetupRoutes(r, repo)
+	handler.SetupRoutes(r, repo, uploadSvc, reportSvc, contentFilter, limiter, blocks)
+
+	// API quản trị nội dung dành cho admin
+	handler.SetupAdminRoutes(r, service.NewAdminService(repository.NewAdminRepository(db), cfg.ContentRestoreWindow))
 
 	// Khởi tạo HTTP server
 	server := &http.Server{
@@ -79,8 +124,64 @@ func main() {
 		log.Printf("HTTP server shutdown error: %v", err)
 	}
 
+	// Dừng janitor và các job nền
+	stopJanitor()
+
 	// Đóng gRPC connection
 	grpcclient.Close()
 
 	log.Println("Server stopped")
 }
+
+// newContentFilter dựng pipeline lọc nội dung từ cấu hình, dừng chương trình nếu hành động không hợp lệ
+func newContentFilter(cfg *config.Config) service.ContentFilter {
+	bannedWordsAction := mustParseFilterAction("CONTENT_FILTER_BANNED_WORDS_ACTION", cfg.ContentFilterBannedWordsAction)
+	blockedDomainsAction := mustParseFilterAction("CONTENT_FILTER_BLOCKED_DOMAINS_ACTION", cfg.ContentFilterBlockedDomainsAction)
+	spamAction := mustParseFilterAction("CONTENT_FILTER_SPAM_ACTION", cfg.ContentFilterSpamAction)
+
+	return service.NewFilterPipeline(
+		service.NewBannedWordFilter(cfg.ContentFilterBannedWords, bannedWordsAction),
+		service.NewLinkBlocklistFilter(cfg.ContentFilterBlockedDomains, blockedDomainsAction),
+		service.NewSpamFilter(service.SpamFilterConfig{
+			MaxLinks:          cfg.ContentFilterMaxLinks,
+			MaxLinkDensity:    cfg.ContentFilterMaxLinkDensity,
+			MaxRepeatRatio:    cfg.ContentFilterMaxRepeatRatio,
+			MaxRepeatedChars:  cfg.ContentFilterMaxRepeatedChars,
+			MaxDuplicateLines: cfg.ContentFilterMaxDuplicateLines,
+		}, spamAction),
+	)
+}
+
+func mustParseFilterAction(key, value string) service.FilterAction {
+	action, err := service.ParseFilterAction(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return action
+}
+
+// newRateLimiter dựng bộ giới hạn tần suất theo cấu hình, dừng chương trình nếu không kết nối được Redis
+func newRateLimiter(cfg *config.Config) *ratelimit.Limiter {
+	var store ratelimit.Store
+	switch cfg.RateLimitStore {
+	case "redis":
+		redisStore, err := ratelimit.NewRedisStore(cfg.RateLimitRedisURL)
+		if err != nil {
+			log.Fatalf("Failed to initialize rate limit store: %v", err)
+		}
+		store = redisStore
+	case "memory":
+		store = ratelimit.NewMemoryStore()
+	default:
+		log.Fatalf("Invalid RATE_LIMIT_STORE: %q (expected memory or redis)", cfg.RateLimitStore)
+	}
+
+	return ratelimit.NewLimiter(store, map[string]ratelimit.Limit{
+		handler.RateLimitPostCreate: cfg.RateLimitPostCreate,
+		handler.RateLimitComment:    cfg.RateLimitComment,
+		handler.RateLimitLike:       cfg.RateLimitLike,
+		handler.RateLimitShare:      cfg.RateLimitShare,
+		handler.RateLimitReport:     cfg.RateLimitReport,
+		handler.RateLimitUpload:     cfg.RateLimitUpload,
+	})
+}
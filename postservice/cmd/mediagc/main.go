@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"postservice/internal/config"
+	"postservice/internal/grpcclient"
+	"postservice/internal/repository"
+	"postservice/internal/service"
+)
+
+// mediagc chạy job dọn media mồ côi một lần và in báo cáo dạng JSON.
+// Mặc định chỉ chạy dry-run, cần truyền -dry-run=false để thực sự xóa.
+func main() {
+	cfg := config.Load()
+
+	dryRun := flag.Bool("dry-run", true, "chỉ báo cáo, không xóa file")
+	grace := flag.Duration("grace", cfg.MediaGCGracePeriod, "bỏ qua các file mới hơn khoảng thời gian này")
+	maxDeletes := flag.Int("max-deletes", cfg.MediaGCMaxDeletes, "số file tối đa bị xóa (<= 0 là không giới hạn)")
+	output := flag.String("output", "", "ghi báo cáo ra file thay vì stdout")
+	flag.Parse()
+
+	db, err := config.InitDB(cfg)
+	if err != nil {
+		log.Fatalf("Cannot connect to database: %v", err)
+	}
+	defer db.Close()
+
+	if err := grpcclient.InitUserServiceClient(cfg.UserServiceAddr); err != nil {
+		log.Fatalf("Failed to initialize gRPC client: %v", err)
+	}
+	defer grpcclient.Close()
+
+	gc := service.NewMediaGCService(repository.NewMediaReferenceRepository(db), *grace, cfg.ContentRestoreWindow, *maxDeletes)
+	report, err := gc.Run(*dryRun)
+	if err != nil {
+		log.Fatalf("Media GC failed: %v", err)
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatalf("Cannot create report file: %v", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+}
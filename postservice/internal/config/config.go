@@ -2,8 +2,13 @@ package config
 
 import (
 	"fmt"
+	"log"
 	"os"
 	"postservice/internal/model"
+	"shared/ratelimit"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/mysql"
@@ -19,6 +24,50 @@ type Config struct {
 	DBName          string
 	ServerPort      string
 	UserServiceAddr string // Thêm địa chỉ UserService
+
+	// Xác thực chữ ký JWT ngay tại service, bắt buộc có secret hoặc JWKS
+	JWTSecret      string        // Khóa HS256 dùng chung với authservice
+	JWTJWKSURL     string        // URL JWKS cho token RS256
+	JWTJWKSFile    string        // File JWKS cho token RS256
+	JWTIssuer      string        // Giá trị iss bắt buộc
+	JWTJWKSRefresh time.Duration // Chu kỳ tải lại JWKS từ URL
+
+	UploadSessionTTL      time.Duration // Thời gian sống của slot upload trực tiếp
+	UploadJanitorInterval time.Duration // Chu kỳ dọn các upload chưa được xác nhận/gắn vào bài đăng
+	ImageMaxPixels        int           // Số điểm ảnh (rộng x cao) tối đa của ảnh upload qua server
+
+	MediaGCInterval    time.Duration // Chu kỳ chạy job dọn media mồ côi, 0 là tắt
+	MediaGCGracePeriod time.Duration // File mới hơn khoảng này sẽ không bị xóa
+	MediaGCMaxDeletes  int           // Số file tối đa bị xóa mỗi lần chạy
+	MediaGCDryRun      bool          // Chỉ báo cáo, không xóa
+
+	ContentRestoreWindow time.Duration // Thời hạn khôi phục nội dung bị xóa mềm, media được giữ lại tới khi hết hạn
+
+	ReportAutoHideThreshold int // Số người báo cáo khác nhau để tự động ẩn nội dung, 0 là tắt
+
+	BlockCacheTTL time.Duration // Thời gian cache quan hệ chặn lấy từ UserService
+
+	// Bộ lọc nội dung bài đăng/bình luận, hành động: allow, shadow_hide, review, reject
+	ContentFilterBannedWords          []string // Từ/cụm từ cấm, so khớp không phân biệt dấu tiếng Việt
+	ContentFilterBannedWordsAction    string
+	ContentFilterBlockedDomains       []string // Domain bị chặn, áp dụng cả subdomain
+	ContentFilterBlockedDomainsAction string
+	ContentFilterSpamAction           string
+	ContentFilterMaxLinks             int     // Số link tối đa trong một nội dung, 0 là tắt
+	ContentFilterMaxLinkDensity       float64 // Tỉ lệ link/số từ tối đa, 0 là tắt
+	ContentFilterMaxRepeatRatio       float64 // Tỉ lệ tối đa của từ lặp nhiều nhất, 0 là tắt
+	ContentFilterMaxRepeatedChars     int     // Số ký tự giống nhau liên tiếp tối đa, 0 là tắt
+	ContentFilterMaxDuplicateLines    float64 // Tỉ lệ dòng trùng lặp tối đa, 0 là tắt
+
+	// Giới hạn tần suất theo người dùng cho từng nhóm route, dạng "<số request>/<khoảng>", vd: "10/m"
+	RateLimitStore      string // memory hoặc redis
+	RateLimitRedisURL   string // redis://[:password@]host:port[/db]
+	RateLimitPostCreate ratelimit.Limit
+	RateLimitComment    ratelimit.Limit
+	RateLimitLike       ratelimit.Limit
+	RateLimitShare      ratelimit.Limit
+	RateLimitReport     ratelimit.Limit
+	RateLimitUpload     ratelimit.Limit
 }
 
 // Load đọc cấu hình từ .env
@@ -37,6 +86,48 @@ func Load() *Config {
 		DBName:          os.Getenv("DB_NAME"),
 		ServerPort:      getEnvOrDefault("SERVER_PORT", ":8082"),                 // Default port nếu không có
 		UserServiceAddr: getEnvOrDefault("USER_SERVICE_ADDR", "localhost:50051"), // Default gRPC addr
+
+		JWTSecret:      os.Getenv("JWT_SECRET"),
+		JWTJWKSURL:     os.Getenv("JWT_JWKS_URL"),
+		JWTJWKSFile:    os.Getenv("JWT_JWKS_FILE"),
+		JWTIssuer:      getEnvOrDefault("JWT_ISSUER", "hoanhao-auth-service"),
+		JWTJWKSRefresh: getDurationOrDefault("JWT_JWKS_REFRESH", 10*time.Minute),
+
+		UploadSessionTTL:      getDurationOrDefault("UPLOAD_SESSION_TTL", 30*time.Minute),
+		UploadJanitorInterval: getDurationOrDefault("UPLOAD_JANITOR_INTERVAL", 10*time.Minute),
+		ImageMaxPixels:        getIntOrDefault("IMAGE_MAX_PIXELS", 50_000_000),
+
+		MediaGCInterval:    getDurationOrDefault("MEDIA_GC_INTERVAL", 0),
+		MediaGCGracePeriod: getDurationOrDefault("MEDIA_GC_GRACE_PERIOD", 72*time.Hour),
+		MediaGCMaxDeletes:  getIntOrDefault("MEDIA_GC_MAX_DELETES", 500),
+		MediaGCDryRun:      getBoolOrDefault("MEDIA_GC_DRY_RUN", true),
+
+		ContentRestoreWindow: getDurationOrDefault("CONTENT_RESTORE_WINDOW", 30*24*time.Hour),
+
+		ReportAutoHideThreshold: getIntOrDefault("REPORT_AUTO_HIDE_THRESHOLD", 5),
+
+		BlockCacheTTL: getDurationOrDefault("BLOCK_CACHE_TTL", time.Minute),
+
+		ContentFilterBannedWords: append(getListOrDefault("CONTENT_FILTER_BANNED_WORDS", nil),
+			readListFile(os.Getenv("CONTENT_FILTER_BANNED_WORDS_FILE"))...),
+		ContentFilterBannedWordsAction:    getEnvOrDefault("CONTENT_FILTER_BANNED_WORDS_ACTION", "reject"),
+		ContentFilterBlockedDomains:       getListOrDefault("CONTENT_FILTER_BLOCKED_DOMAINS", nil),
+		ContentFilterBlockedDomainsAction: getEnvOrDefault("CONTENT_FILTER_BLOCKED_DOMAINS_ACTION", "reject"),
+		ContentFilterSpamAction:           getEnvOrDefault("CONTENT_FILTER_SPAM_ACTION", "review"),
+		ContentFilterMaxLinks:             getIntOrDefault("CONTENT_FILTER_MAX_LINKS", 5),
+		ContentFilterMaxLinkDensity:       getFloatOrDefault("CONTENT_FILTER_MAX_LINK_DENSITY", 0.5),
+		ContentFilterMaxRepeatRatio:       getFloatOrDefault("CONTENT_FILTER_MAX_REPEAT_RATIO", 0.6),
+		ContentFilterMaxRepeatedChars:     getIntOrDefault("CONTENT_FILTER_MAX_REPEATED_CHARS", 15),
+		ContentFilterMaxDuplicateLines:    getFloatOrDefault("CONTENT_FILTER_MAX_DUPLICATE_LINES", 0.5),
+
+		RateLimitStore:      getEnvOrDefault("RATE_LIMIT_STORE", "memory"),
+		RateLimitRedisURL:   getEnvOrDefault("RATE_LIMIT_REDIS_URL", "redis://localhost:6379/0"),
+		RateLimitPostCreate: getRateLimitOrDefault("RATE_LIMIT_POST_CREATE", "10/m"),
+		RateLimitComment:    getRateLimitOrDefault("RATE_LIMIT_COMMENT", "30/m"),
+		RateLimitLike:       getRateLimitOrDefault("RATE_LIMIT_LIKE", "120/m"),
+		RateLimitShare:      getRateLimitOrDefault("RATE_LIMIT_SHARE", "20/m"),
+		RateLimitReport:     getRateLimitOrDefault("RATE_LIMIT_REPORT", "10/m"),
+		RateLimitUpload:     getRateLimitOrDefault("RATE_LIMIT_UPLOAD", "30/m"),
 	}
 }
 
@@ -48,6 +139,107 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getDurationOrDefault đọc duration (vd: "30m", "1h") từ env hoặc trả về default nếu không hợp lệ
+func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid duration for %s: %q, using default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return d
+}
+
+// getIntOrDefault đọc số nguyên từ env hoặc trả về default nếu không hợp lệ
+func getIntOrDefault(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid integer for %s: %q, using default %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+// getBoolOrDefault đọc giá trị bool từ env hoặc trả về default nếu không hợp lệ
+func getBoolOrDefault(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid bool for %s: %q, using default %t", key, value, defaultValue)
+		return defaultValue
+	}
+	return b
+}
+
+// getFloatOrDefault đọc số thực từ env hoặc trả về default nếu không hợp lệ
+func getFloatOrDefault(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Printf("Invalid float for %s: %q, using default %g", key, value, defaultValue)
+		return defaultValue
+	}
+	return f
+}
+
+// getRateLimitOrDefault đọc giới hạn tần suất từ env hoặc dùng default nếu không hợp lệ
+func getRateLimitOrDefault(key, defaultValue string) ratelimit.Limit {
+	value := getEnvOrDefault(key, defaultValue)
+	limit, err := ratelimit.Parse(value)
+	if err != nil {
+		log.Printf("Invalid rate limit for %s: %v, using default %s", key, err, defaultValue)
+		limit, _ = ratelimit.Parse(defaultValue)
+	}
+	return limit
+}
+
+// getListOrDefault đọc danh sách phân tách bằng dấu phẩy từ env, bỏ các phần tử rỗng
+func getListOrDefault(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// readListFile đọc danh sách từ file, mỗi dòng một phần tử, bỏ dòng trống và dòng bắt đầu bằng #
+func readListFile(path string) []string {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Printf("Cannot read list file %s: %v", path, err)
+		return nil
+	}
+	var items []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			items = append(items, line)
+		}
+	}
+	return items
+}
+
 // InitDB khởi tạo kết nối đến MySQL
 func InitDB(cfg *Config) (*gorm.DB, error) {
 	// Chuỗi kết nối MySQL
@@ -60,7 +252,10 @@ func InitDB(cfg *Config) (*gorm.DB, error) {
 		return nil, err
 	}
 
-	// Auto migrate bảng posts
-	db.AutoMigrate(&model.Post{})
+	// Auto migrate các bảng
+	db.AutoMigrate(&model.Post{}, &model.PostMedia{}, &model.MediaUpload{},
+		&model.Comment{}, &model.Report{}, &model.ModerationAction{}, &model.AdminAuditLog{})
+	// AutoMigrate không sửa cột đã có, thêm giá trị CUSTOM vào enum visibility của bảng cũ
+	db.Model(&model.Post{}).ModifyColumn("visibility", "enum('PUBLIC','FRIENDS','PRIVATE','CUSTOM') DEFAULT 'PUBLIC'")
 	return db, nil
 }
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"postservice/internal/model"
+	"postservice/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAdminRoutes đăng ký các API quản trị nội dung, chỉ dành cho admin
+func SetupAdminRoutes(r *gin.Engine, svc service.AdminService) {
+	adminGroup := r.Group("/admin")
+	adminGroup.Use(JWTMiddleware(), RequireRole(RoleAdmin))
+	{
+		adminGroup.DELETE("/posts/:uuid", AdminDeletePost(svc))
+		adminGroup.POST("/posts/:uuid/restore", AdminRestorePost(svc))
+		adminGroup.DELETE("/comments/:id", AdminDeleteComment(svc))
+		adminGroup.POST("/comments/:id/restore", AdminRestoreComment(svc))
+		adminGroup.GET("/audit-logs", ListAdminAuditLogs(svc))
+	}
+}
+
+// AdminDeletePost xóa bất kỳ bài đăng nào, ?hard=true để xóa hẳn thay vì xóa mềm
+func AdminDeletePost(svc service.AdminService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		adminID, note, ok := bindAdminAction(c)
+		if !ok {
+			return
+		}
+
+		hard, _ := strconv.ParseBool(c.DefaultQuery("hard", "false"))
+		entry, err := svc.DeletePost(adminID, c.Param("uuid"), hard, note)
+		if err != nil {
+			log.Printf("Admin %d failed to delete post %s: %v", adminID, c.Param("uuid"), err)
+			c.JSON(adminErrorStatus(err), gin.H{"error": "Failed to delete post: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, entry)
+	}
+}
+
+// AdminRestorePost khôi phục bài đăng đã bị xóa mềm
+func AdminRestorePost(svc service.AdminService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		adminID, note, ok := bindAdminAction(c)
+		if !ok {
+			return
+		}
+
+		entry, err := svc.RestorePost(adminID, c.Param("uuid"), note)
+		if err != nil {
+			c.JSON(adminErrorStatus(err), gin.H{"error": "Failed to restore post: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, entry)
+	}
+}
+
+// AdminDeleteComment xóa bất kỳ bình luận nào, ?hard=true để xóa hẳn cùng các phản hồi
+func AdminDeleteComment(svc service.AdminService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		commentID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+			return
+		}
+
+		adminID, note, ok := bindAdminAction(c)
+		if !ok {
+			return
+		}
+
+		hard, _ := strconv.ParseBool(c.DefaultQuery("hard", "false"))
+		entry, err := svc.DeleteComment(adminID, commentID, hard, note)
+		if err != nil {
+			log.Printf("Admin %d failed to delete comment %d: %v", adminID, commentID, err)
+			c.JSON(adminErrorStatus(err), gin.H{"error": "Failed to delete comment: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, entry)
+	}
+}
+
+// AdminRestoreComment khôi phục bình luận đã bị xóa mềm
+func AdminRestoreComment(svc service.AdminService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		commentID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+			return
+		}
+
+		adminID, note, ok := bindAdminAction(c)
+		if !ok {
+			return
+		}
+
+		entry, err := svc.RestoreComment(adminID, commentID, note)
+		if err != nil {
+			c.JSON(adminErrorStatus(err), gin.H{"error": "Failed to restore comment: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, entry)
+	}
+}
+
+// ListAdminAuditLogs lấy nhật ký quản trị
+func ListAdminAuditLogs(svc service.AdminService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		adminID, _ := strconv.ParseUint(c.DefaultQuery("admin_id", "0"), 10, 64)
+		targetType := c.Query("target_type")
+		targetID, _ := strconv.ParseUint(c.DefaultQuery("target_id", "0"), 10, 64)
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+		offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+		logs, total, err := svc.ListAuditLogs(adminID, targetType, targetID, limit, offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list audit logs: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"limit":  limit,
+			"logs":   logs,
+			"offset": offset,
+			"total":  total,
+		})
+	}
+}
+
+// bindAdminAction lấy ID admin và ghi chú (body JSON không bắt buộc), tự trả lỗi nếu không hợp lệ
+func bindAdminAction(c *gin.Context) (uint64, string, bool) {
+	adminID, err := getUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return 0, "", false
+	}
+
+	var req model.AdminActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return 0, "", false
+	}
+	return adminID, req.Note, true
+}
+
+// adminErrorStatus ánh xạ lỗi thao tác quản trị sang HTTP status
+func adminErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrContentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAlreadyDeleted), errors.Is(err, service.ErrNotDeleted):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrRestoreExpired):
+		return http.StatusGone
+	default:
+		return http.StatusInternalServerError
+	}
+}
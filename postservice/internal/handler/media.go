@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"postservice/internal/model"
+	"postservice/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+)
+
+// UpdatePostMedia sửa alt text/chú thích của một media trong bài đăng
+func UpdatePostMedia(svc service.PostService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := getUserID(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		mediaID, err := strconv.ParseUint(c.Param("media_id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid media ID"})
+			return
+		}
+
+		var req model.UpdateMediaRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+
+		media, err := svc.UpdatePostMedia(c.Param("uuid"), mediaID, userID, req)
+		if err != nil {
+			log.Printf("Failed to update media %d: %v", mediaID, err)
+			c.JSON(mediaErrorStatus(err), gin.H{"error": "Failed to update media: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, media)
+	}
+}
+
+// DeletePostMedia xóa một media khỏi bài đăng
+func DeletePostMedia(svc service.PostService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := getUserID(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		mediaID, err := strconv.ParseUint(c.Param("media_id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid media ID"})
+			return
+		}
+
+		if err := svc.DeletePostMedia(c.Param("uuid"), mediaID, userID); err != nil {
+			log.Printf("Failed to delete media %d: %v", mediaID, err)
+			c.JSON(mediaErrorStatus(err), gin.H{"error": "Failed to delete media: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Media deleted successfully"})
+	}
+}
+
+// ReorderPostMedia sắp xếp lại thứ tự hiển thị media của bài đăng
+func ReorderPostMedia(svc service.PostService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := getUserID(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		var req model.ReorderMediaRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+
+		post, err := svc.ReorderPostMedia(c.Param("uuid"), userID, req.MediaIDs)
+		if err != nil {
+			log.Printf("Failed to reorder media: %v", err)
+			c.JSON(mediaErrorStatus(err), gin.H{"error": "Failed to reorder media: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, post)
+	}
+}
+
+// mediaErrorStatus ánh xạ lỗi khi thao tác media của bài đăng sang HTTP status
+func mediaErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, service.ErrMediaNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrForbidden), errors.Is(err, service.ErrNotGroupMember), errors.Is(err, service.ErrMutedInGroup):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrContentRejected):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrAltTextTooLong), errors.Is(err, service.ErrCaptionTooLong),
+		errors.Is(err, service.ErrInvalidMediaOrder), errors.Is(err, service.ErrInvalidAudience):
+		return http.StatusBadRequest
+	default:
+		return uploadErrorStatus(err)
+	}
+}
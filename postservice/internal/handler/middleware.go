@@ -2,72 +2,134 @@
 package handler
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
 	"net/http"
+	"shared/jwtauth"
 	"strings"
 )
 
-// JWTMiddleware trích xuất userId từ token JWT
+// JWTMiddleware xác thực token JWT (chữ ký, exp, iss nếu đã gọi jwtauth.Init) và gắn
+// userId, roles, claims vào context
 func JWTMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header missing"})
+		claims, id, err := parseBearerToken(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			c.Abort()
 			return
 		}
 
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		if tokenString == authHeader {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid Authorization header format"})
-			c.Abort()
-			return
+		setAuthContext(c, claims, id)
+		c.Next()
+	}
+}
+
+// OptionalJWTMiddleware gắn userId vào context nếu request có token hợp lệ, dùng cho các route
+// công khai nhưng trả về kết quả khác nhau tùy người xem. Token thiếu hoặc sai không bị từ chối.
+func OptionalJWTMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if claims, id, err := parseBearerToken(c); err == nil {
+			setAuthContext(c, claims, id)
 		}
+		c.Next()
+	}
+}
 
-		// Parse token mà không verify (vì Kong đã làm điều này)
-		token, _, err := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token format"})
+// setAuthContext gắn thông tin người dùng từ token vào context để các handler phân quyền
+func setAuthContext(c *gin.Context, claims jwt.MapClaims, id uint) {
+	c.Set("userId", id)
+	c.Set("roles", rolesFromClaims(claims))
+	c.Set("claims", claims)
+}
+
+// parseBearerToken đọc và xác thực token trong header Authorization, trả về claims và userId
+func parseBearerToken(c *gin.Context) (jwt.MapClaims, uint, error) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		return nil, 0, errors.New("Authorization header missing")
+	}
+
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	if tokenString == authHeader {
+		return nil, 0, errors.New("Invalid Authorization header format")
+	}
+
+	claims, err := jwtauth.Parse(tokenString)
+	if err != nil {
+		return nil, 0, errors.New("Invalid token: " + err.Error())
+	}
+
+	userID, ok := claims["userId"]
+	if !ok {
+		return nil, 0, errors.New("User ID not found in token")
+	}
+
+	var id uint
+	switch v := userID.(type) {
+	case float64:
+		id = uint(v)
+	case int64:
+		id = uint(v)
+	case uint:
+		id = v
+	default:
+		return nil, 0, errors.New("Invalid user ID type in token")
+	}
+
+	if id == 0 {
+		return nil, 0, errors.New("Invalid user ID value")
+	}
+	return claims, id, nil
+}
+
+// RequireRole chỉ cho phép request đi tiếp khi token có ít nhất một trong các role.
+// Phải đặt sau JWTMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !hasAnyRole(c, roles...) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
 			c.Abort()
 			return
 		}
+		c.Next()
+	}
+}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			userID, ok := claims["userId"]
-			if !ok {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found in token"})
-				c.Abort()
-				return
+// hasAnyRole kiểm tra người dùng hiện tại có một trong các role
+func hasAnyRole(c *gin.Context, roles ...string) bool {
+	value, exists := c.Get("roles")
+	if !exists {
+		return false
+	}
+	userRoles, _ := value.([]string)
+	for _, userRole := range userRoles {
+		for _, role := range roles {
+			if strings.EqualFold(userRole, role) {
+				return true
 			}
+		}
+	}
+	return false
+}
 
-			var id uint
-			switch v := userID.(type) {
-			case float64:
-				id = uint(v)
-			case int64:
-				id = uint(v)
-			case uint:
-				id = v
-			default:
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid user ID type in token"})
-				c.Abort()
-				return
+// rolesFromClaims đọc claim "roles" (mảng hoặc chuỗi phân tách bằng dấu phẩy)
+func rolesFromClaims(claims jwt.MapClaims) []string {
+	var roles []string
+	switch v := claims["roles"].(type) {
+	case []interface{}:
+		for _, r := range v {
+			if role, ok := r.(string); ok && role != "" {
+				roles = append(roles, role)
 			}
-
-			if id == 0 {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid user ID value"})
-				c.Abort()
-				return
+		}
+	case string:
+		for _, role := range strings.Split(v, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				roles = append(roles, role)
 			}
-
-			c.Set("userId", id)
-		} else {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
-			c.Abort()
-			return
 		}
-
-		c.Next()
 	}
+	return roles
 }
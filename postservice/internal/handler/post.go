@@ -8,57 +8,79 @@ import (
 	"postservice/internal/model"
 	"postservice/internal/repository"
 	"postservice/internal/service"
+	"postservice/internal/util"
+	"shared/ratelimit"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 // SetupRoutes đăng ký các route cho Gin
-func SetupRoutes(r *gin.Engine, repo repository.PostRepository) {
-	svc := service.NewPostService(repo)
+func SetupRoutes(r *gin.Engine, repo repository.PostRepository, uploadSvc service.UploadService, reportSvc service.ReportService, contentFilter service.ContentFilter, limiter *ratelimit.Limiter, blocks *util.BlockChecker) {
+	svc := service.NewPostService(repo, uploadSvc, contentFilter, reportSvc, blocks)
 
-	// Route công khai - Chuyển sang sử dụng UUID
-	r.GET("/post/:uuid", GetPostByUUID(svc))
-	r.GET("/post/:uuid/comments", GetCommentsByUUID(svc))
-	r.GET("/post/:uuid/shares", GetSharesByUUID(svc))
-	r.GET("/post/user/:user_id/posts", GetUserPosts(svc))
-	r.GET("/post/user/username/:username/posts", GetPostsByUsername(svc))
+	// Route công khai - Chuyển sang sử dụng UUID. Các route đọc token nếu có để tác giả vẫn
+	// thấy nội dung của mình bị bộ lọc ẩn và để ẩn nội dung của người có quan hệ chặn
+	r.GET("/post/:uuid", OptionalJWTMiddleware(), GetPostByUUID(svc))
+	r.GET("/post/:uuid/comments", OptionalJWTMiddleware(), GetCommentsByUUID(svc))
+	r.GET("/post/:uuid/shares", OptionalJWTMiddleware(), GetSharesByUUID(svc))
+	r.GET("/post/user/:user_id/posts", OptionalJWTMiddleware(), GetUserPosts(svc))
+	r.GET("/post/user/username/:username/posts", OptionalJWTMiddleware(), GetPostsByUsername(svc))
 
 	// Giữ các route legacy tương thích ngược nếu cần
-	r.GET("/post/id/:id", GetPostByID(svc))
-	r.GET("/post/id/:id/comments", GetComments(svc))
-	r.GET("/post/id/:id/shares", GetShares(svc))
+	r.GET("/post/id/:id", OptionalJWTMiddleware(), GetPostByID(svc))
+	r.GET("/post/id/:id/comments", OptionalJWTMiddleware(), GetComments(svc))
+	r.GET("/post/id/:id/shares", OptionalJWTMiddleware(), GetShares(svc))
 
 	// Nhóm route yêu cầu xác thực JWT
 	postGroup := r.Group("/post")
 	postGroup.Use(JWTMiddleware())
 	{
-		postGroup.POST("", CreatePost(svc))
+		postGroup.POST("", RateLimit(limiter, RateLimitPostCreate), CreatePost(svc))
 		postGroup.PUT("/:uuid", UpdatePostByUUID(svc))
 		postGroup.DELETE("/:uuid", DeletePostByUUID(svc))
-		postGroup.POST("/:uuid/comment", CreateCommentByUUID(svc))
-		postGroup.POST("/:uuid/like", LikePostByUUID(svc))
-		postGroup.DELETE("/:uuid/like", UnlikePostByUUID(svc))
-		postGroup.POST("/:uuid/share", SharePostByUUID(svc))
+		postGroup.POST("/:uuid/comment", RateLimit(limiter, RateLimitComment), CreateCommentByUUID(svc))
+		postGroup.POST("/:uuid/like", RateLimit(limiter, RateLimitLike), LikePostByUUID(svc))
+		postGroup.DELETE("/:uuid/like", RateLimit(limiter, RateLimitLike), UnlikePostByUUID(svc))
+		postGroup.POST("/:uuid/share", RateLimit(limiter, RateLimitShare), SharePostByUUID(svc))
+		postGroup.PATCH("/:uuid/media/:media_id", UpdatePostMedia(svc))
+		postGroup.DELETE("/:uuid/media/:media_id", DeletePostMedia(svc))
+		postGroup.PUT("/:uuid/media/order", ReorderPostMedia(svc))
+		postGroup.POST("/:uuid/report", RateLimit(limiter, RateLimitReport), ReportPost(reportSvc))
 		postGroup.GET("/feed", GetFeed(svc))
 
+		// Upload trực tiếp lên storage: xin slot -> client upload -> xác nhận
+		postGroup.POST("/uploads", RateLimit(limiter, RateLimitUpload), RequestUpload(uploadSvc))
+		postGroup.POST("/uploads/:id/confirm", ConfirmUpload(uploadSvc))
+
+		// Hàng đợi kiểm duyệt, chỉ dành cho moderator/admin
+		moderationGroup := postGroup.Group("/moderation")
+		moderationGroup.Use(RequireRole(RoleModerator, RoleAdmin))
+		{
+			moderationGroup.GET("/reports", ListReports(reportSvc))
+			moderationGroup.GET("/reports/:id", GetReport(reportSvc))
+			moderationGroup.POST("/reports/:id/decision", DecideReport(reportSvc))
+			moderationGroup.GET("/actions", ListModerationActions(reportSvc))
+		}
+
 		// Giữ các route legacy tương thích ngược
 		postGroup.PUT("/id/:id", UpdatePost(svc))
 		postGroup.DELETE("/id/:id", DeletePost(svc))
-		postGroup.POST("/id/:id/comment", CreateComment(svc))
-		postGroup.POST("/id/:id/like", LikePost(svc))
-		postGroup.DELETE("/id/:id/like", UnlikePost(svc))
-		postGroup.POST("/id/:id/share", SharePost(svc))
+		postGroup.POST("/id/:id/comment", RateLimit(limiter, RateLimitComment), CreateComment(svc))
+		postGroup.POST("/id/:id/like", RateLimit(limiter, RateLimitLike), LikePost(svc))
+		postGroup.DELETE("/id/:id/like", RateLimit(limiter, RateLimitLike), UnlikePost(svc))
+		postGroup.POST("/id/:id/share", RateLimit(limiter, RateLimitShare), SharePost(svc))
 	}
 
 	commentGroup := r.Group("/comment")
 	commentGroup.Use(JWTMiddleware())
 	{
-		commentGroup.POST("/:id/reply", ReplyComment(svc))
+		commentGroup.POST("/:id/reply", RateLimit(limiter, RateLimitComment), ReplyComment(svc))
 		commentGroup.PUT("/:id", UpdateComment(svc))
 		commentGroup.DELETE("/:id", DeleteComment(svc))
-		commentGroup.POST("/:id/like", LikeComment(svc))
-		commentGroup.DELETE("/:id/like", UnlikeComment(svc))
+		commentGroup.POST("/:id/like", RateLimit(limiter, RateLimitLike), LikeComment(svc))
+		commentGroup.DELETE("/:id/like", RateLimit(limiter, RateLimitLike), UnlikeComment(svc))
+		commentGroup.POST("/:id/report", RateLimit(limiter, RateLimitReport), ReportComment(reportSvc))
 	}
 }
 
@@ -71,7 +93,8 @@ func GetPostByUUID(svc service.PostService) gin.HandlerFunc {
 			return
 		}
 
-		post, err := svc.GetPostByUUID(uuid)
+		viewerID, _ := getUserID(c)
+		post, err := svc.GetPostByUUID(uuid, viewerID)
 		if err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
 			return
@@ -121,11 +144,37 @@ func UpdatePostByUUID(svc service.PostService) gin.HandlerFunc {
 			Visibility: visibility[0],
 		}
 
+		// Danh sách bạn bè được xem và bị loại trừ khi visibility là CUSTOM
+		if err := parseAudience(form.Value, &req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		// Lấy media_urls nếu có
 		if mediaURLs, exists := form.Value["media_urls"]; exists {
 			req.MediaURLs = mediaURLs
 		}
 
+		// Lấy media_ids (upload trực tiếp đã xác nhận) nếu có
+		mediaIDs, err := parseMediaIDs(form.Value["media_ids"])
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.MediaIDs = mediaIDs
+
+		// Alt text, chú thích cho media mới và các thao tác cập nhật từng phần
+		req.MediaAltTexts = form.Value["media_alt_texts"]
+		req.MediaCaptions = form.Value["media_captions"]
+		if req.MediaOrder, err = parseMediaIDs(form.Value["media_order"]); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if req.RemoveMediaIDs, err = parseMediaIDs(form.Value["remove_media_ids"]); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		// Lấy files từ form
 		var files []interface{}
 		fileHeaders, exists := form.File["images"]
@@ -153,7 +202,7 @@ func UpdatePostByUUID(svc service.PostService) gin.HandlerFunc {
 		post, err := svc.UpdatePostByUUID(uuid, userID, req, files)
 		if err != nil {
 			log.Printf("Failed to update post: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update post: " + err.Error()})
+			c.JSON(mediaErrorStatus(err), gin.H{"error": "Failed to update post: " + err.Error()})
 			return
 		}
 
@@ -245,7 +294,7 @@ func CreateCommentByUUID(svc service.PostService) gin.HandlerFunc {
 
 		comment, err := svc.CreateCommentByUUID(uuid, userID, content[0], parentID, files)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create comment: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to create comment: " + err.Error()})
 			return
 		}
 
@@ -264,9 +313,10 @@ func GetCommentsByUUID(svc service.PostService) gin.HandlerFunc {
 		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 		offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-		comments, total, err := svc.GetCommentsByPostUUID(uuid, limit, offset)
+		viewerID, _ := getUserID(c)
+		comments, total, err := svc.GetCommentsByPostUUID(uuid, viewerID, limit, offset)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Failed to get comments: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusNotFound), gin.H{"error": "Failed to get comments: " + err.Error()})
 			return
 		}
 
@@ -294,7 +344,7 @@ func LikePostByUUID(svc service.PostService) gin.HandlerFunc {
 		}
 
 		if err := svc.LikePostByUUID(uuid, userID); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to like post: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to like post: " + err.Error()})
 			return
 		}
 
@@ -349,7 +399,7 @@ func SharePostByUUID(svc service.PostService) gin.HandlerFunc {
 
 		share, err := svc.SharePostByUUID(uuid, userID, req.Content)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to share post: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to share post: " + err.Error()})
 			return
 		}
 
@@ -368,9 +418,10 @@ func GetSharesByUUID(svc service.PostService) gin.HandlerFunc {
 		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 		offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-		shares, total, err := svc.GetSharesByPostUUID(uuid, limit, offset)
+		viewerID, _ := getUserID(c)
+		shares, total, err := svc.GetSharesByPostUUID(uuid, viewerID, limit, offset)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Failed to get shares: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusNotFound), gin.H{"error": "Failed to get shares: " + err.Error()})
 			return
 		}
 
@@ -406,6 +457,52 @@ func getUserID(c *gin.Context) (uint64, error) {
 	}
 }
 
+// contentErrorStatus trả về 422 khi nội dung bị bộ lọc từ chối, 403 khi tương tác với người có
+// quan hệ chặn hoặc không được đăng vào nhóm, 503 khi không kiểm tra được quan hệ chặn, ngược lại trả về fallback
+func contentErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, service.ErrContentRejected):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrBlocked), errors.Is(err, service.ErrNotGroupMember), errors.Is(err, service.ErrMutedInGroup):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrBlockCheckUnavailable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, service.ErrNotInAudience):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidAudience), errors.Is(err, util.ErrInvalidImage):
+		return http.StatusBadRequest
+	case errors.Is(err, util.ErrImageTooLarge):
+		return http.StatusRequestEntityTooLarge
+	}
+	return fallback
+}
+
+// parseAudience đọc audience_list_ids và excluded_list_ids từ form vào req
+func parseAudience(values map[string][]string, req *model.CreatePostRequest) error {
+	var err error
+	if req.AudienceListIDs, err = parseIDs(values["audience_list_ids"], "friend list"); err != nil {
+		return err
+	}
+	req.ExcludedListIDs, err = parseIDs(values["excluded_list_ids"], "friend list")
+	return err
+}
+
+// parseGroupID đọc group_id từ form, không có thì trả về nil (bài đăng cá nhân)
+func parseGroupID(values []string) (*uint64, error) {
+	ids, err := parseIDs(values, "group")
+	if err != nil {
+		return nil, err
+	}
+	switch len(ids) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &ids[0], nil
+	default:
+		return nil, errors.New("only one group_id is allowed")
+	}
+}
+
 // Handler legacy
 func CreatePost(svc service.PostService) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -441,6 +538,30 @@ func CreatePost(svc service.PostService) gin.HandlerFunc {
 			Visibility: visibility[0],
 		}
 
+		// Danh sách bạn bè được xem và bị loại trừ khi visibility là CUSTOM
+		if err := parseAudience(form.Value, &req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Nhóm đăng bài vào nếu có
+		if req.GroupID, err = parseGroupID(form.Value["group_id"]); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Lấy media_ids (upload trực tiếp đã xác nhận) nếu có
+		mediaIDs, err := parseMediaIDs(form.Value["media_ids"])
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.MediaIDs = mediaIDs
+
+		// Alt text, chú thích cho media theo thứ tự: images, media_urls, media_ids
+		req.MediaAltTexts = form.Value["media_alt_texts"]
+		req.MediaCaptions = form.Value["media_captions"]
+
 		// Lấy files từ form
 		var files []interface{}
 		fileHeaders, exists := form.File["images"]
@@ -470,7 +591,7 @@ func CreatePost(svc service.PostService) gin.HandlerFunc {
 		post, err := svc.CreatePost(userID, req, files)
 		if err != nil {
 			log.Printf("Failed to create post: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create post: " + err.Error()})
+			c.JSON(mediaErrorStatus(err), gin.H{"error": "Failed to create post: " + err.Error()})
 			return
 		}
 
@@ -486,7 +607,8 @@ func GetPostByID(svc service.PostService) gin.HandlerFunc {
 			return
 		}
 
-		post, err := svc.GetPostByID(postID)
+		viewerID, _ := getUserID(c)
+		post, err := svc.GetPostByID(postID, viewerID)
 		if err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
 			return
@@ -536,6 +658,12 @@ func UpdatePost(svc service.PostService) gin.HandlerFunc {
 			Visibility: visibility[0],
 		}
 
+		// Danh sách bạn bè được xem và bị loại trừ khi visibility là CUSTOM
+		if err := parseAudience(form.Value, &req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		// Lấy media_urls nếu có
 		if mediaURLs, exists := form.Value["media_urls"]; exists {
 			req.MediaURLs = mediaURLs
@@ -567,7 +695,7 @@ func UpdatePost(svc service.PostService) gin.HandlerFunc {
 		// Gọi service để cập nhật post
 		post, err := svc.UpdatePost(postID, userID, req, files)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update post: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to update post: " + err.Error()})
 			return
 		}
 
@@ -659,7 +787,7 @@ func CreateComment(svc service.PostService) gin.HandlerFunc {
 
 		comment, err := svc.CreateComment(postID, userID, content[0], parentID, files)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create comment: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to create comment: " + err.Error()})
 			return
 		}
 
@@ -678,9 +806,10 @@ func GetComments(svc service.PostService) gin.HandlerFunc {
 		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 		offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-		comments, total, err := svc.GetCommentsByPostID(postID, limit, offset)
+		viewerID, _ := getUserID(c)
+		comments, total, err := svc.GetCommentsByPostID(postID, viewerID, limit, offset)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Failed to get comments: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusNotFound), gin.H{"error": "Failed to get comments: " + err.Error()})
 			return
 		}
 
@@ -708,7 +837,7 @@ func LikePost(svc service.PostService) gin.HandlerFunc {
 		}
 
 		if err := svc.LikePost(postID, userID); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to like post: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to like post: " + err.Error()})
 			return
 		}
 
@@ -763,7 +892,7 @@ func SharePost(svc service.PostService) gin.HandlerFunc {
 
 		share, err := svc.SharePost(postID, userID, req.Content)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to share post: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to share post: " + err.Error()})
 			return
 		}
 
@@ -782,9 +911,10 @@ func GetShares(svc service.PostService) gin.HandlerFunc {
 		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 		offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-		shares, total, err := svc.GetSharesByPostID(postID, limit, offset)
+		viewerID, _ := getUserID(c)
+		shares, total, err := svc.GetSharesByPostID(postID, viewerID, limit, offset)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Failed to get shares: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusNotFound), gin.H{"error": "Failed to get shares: " + err.Error()})
 			return
 		}
 
@@ -808,9 +938,10 @@ func GetUserPosts(svc service.PostService) gin.HandlerFunc {
 		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 		offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-		posts, total, err := svc.GetPostsByUserID(userID, limit, offset)
+		viewerID, _ := getUserID(c)
+		posts, total, err := svc.GetPostsByUserID(userID, viewerID, limit, offset)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Failed to get user posts: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusNotFound), gin.H{"error": "Failed to get user posts: " + err.Error()})
 			return
 		}
 
@@ -835,6 +966,7 @@ func GetFeed(svc service.PostService) gin.HandlerFunc {
 		mode := c.DefaultQuery("mode", "newest")
 		validModes := map[string]bool{
 			"newest":        true,
+			"home":          true, // bạn bè và người đang theo dõi, mới nhất trước
 			"latest":        true, // legacy mode, giữ tương thích ngược
 			"popular":       true,
 			"popular_today": true,
@@ -858,7 +990,7 @@ func GetFeed(svc service.PostService) gin.HandlerFunc {
 
 		posts, total, err := svc.GetFeed(userID, mode, limit, offset)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch feed: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to fetch feed: " + err.Error()})
 			return
 		}
 
@@ -928,7 +1060,7 @@ func ReplyComment(svc service.PostService) gin.HandlerFunc {
 
 		comment, err := svc.CreateComment(parent.PostID, userID, content[0], &parentID, files)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create reply: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to create reply: " + err.Error()})
 			return
 		}
 
@@ -960,7 +1092,7 @@ func UpdateComment(svc service.PostService) gin.HandlerFunc {
 
 		comment, err := svc.UpdateComment(commentID, userID, req.Content)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update comment: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to update comment: " + err.Error()})
 			return
 		}
 
@@ -1006,7 +1138,7 @@ func LikeComment(svc service.PostService) gin.HandlerFunc {
 		}
 
 		if err := svc.LikeComment(commentID, userID); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to like comment: " + err.Error()})
+			c.JSON(contentErrorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to like comment: " + err.Error()})
 			return
 		}
 
@@ -1049,7 +1181,8 @@ func GetPostsByUsername(svc service.PostService) gin.HandlerFunc {
 		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 		offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-		posts, total, err := svc.GetPostsByUsername(username, limit, offset)
+		viewerID, _ := getUserID(c)
+		posts, total, err := svc.GetPostsByUsername(username, viewerID, limit, offset)
 		if err != nil {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Failed to get posts: " + err.Error()})
 			return
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"fmt"
+	"shared/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Các nhóm route bị giới hạn tần suất, cấu hình qua RATE_LIMIT_<NHÓM>
+const (
+	RateLimitPostCreate = "post_create"
+	RateLimitComment    = "comment"
+	RateLimitLike       = "like"
+	RateLimitShare      = "share"
+	RateLimitReport     = "report"
+	RateLimitUpload     = "upload"
+)
+
+// RateLimit giới hạn tần suất request của nhóm route, mỗi người dùng (userId từ JWTMiddleware, nếu không
+// có thì theo IP) một bucket
+func RateLimit(limiter *ratelimit.Limiter, group string) gin.HandlerFunc {
+	return ratelimit.Middleware(limiter, group, rateLimitKey, "Too many requests, please try again later")
+}
+
+// rateLimitKey trả về khóa bucket theo người dùng đã đăng nhập, nếu không có thì theo IP
+func rateLimitKey(c *gin.Context) string {
+	if userID, err := getUserID(c); err == nil {
+		return fmt.Sprintf("user:%d", userID)
+	}
+	return "ip:" + c.ClientIP()
+}
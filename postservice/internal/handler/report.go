@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"postservice/internal/model"
+	"postservice/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Các role (từ claim "roles" của JWT) được phép truy cập API kiểm duyệt
+const (
+	RoleAdmin     = "ADMIN"
+	RoleModerator = "MODERATOR"
+)
+
+// ReportPost báo cáo một bài đăng
+func ReportPost(svc service.ReportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := getUserID(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		var req model.CreateReportRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+
+		report, err := svc.ReportPost(c.Param("uuid"), userID, req)
+		if err != nil {
+			c.JSON(reportErrorStatus(err), gin.H{"error": "Failed to report post: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusCreated, report)
+	}
+}
+
+// ReportComment báo cáo một bình luận
+func ReportComment(svc service.ReportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := getUserID(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		commentID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+			return
+		}
+
+		var req model.CreateReportRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+
+		report, err := svc.ReportComment(commentID, userID, req)
+		if err != nil {
+			c.JSON(reportErrorStatus(err), gin.H{"error": "Failed to report comment: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusCreated, report)
+	}
+}
+
+// ListReports lấy hàng đợi báo cáo cho moderator
+func ListReports(svc service.ReportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status := c.DefaultQuery("status", model.ReportStatusOpen)
+		targetType := c.Query("target_type")
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+		offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+		reports, total, err := svc.ListReports(status, targetType, limit, offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list reports: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"limit":   limit,
+			"offset":  offset,
+			"reports": reports,
+			"total":   total,
+		})
+	}
+}
+
+// GetReport lấy chi tiết báo cáo kèm các báo cáo cùng nội dung và lịch sử kiểm duyệt
+func GetReport(svc service.ReportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+			return
+		}
+
+		detail, err := svc.GetReport(id)
+		if err != nil {
+			c.JSON(reportErrorStatus(err), gin.H{"error": "Failed to get report: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, detail)
+	}
+}
+
+// DecideReport áp dụng quyết định của moderator (HIDE, DELETE, DISMISS)
+func DecideReport(svc service.ReportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		moderatorID, err := getUserID(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+			return
+		}
+
+		var req model.ModerationDecisionRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+
+		action, err := svc.Decide(id, moderatorID, req)
+		if err != nil {
+			log.Printf("Failed to apply moderation decision on report %d: %v", id, err)
+			c.JSON(reportErrorStatus(err), gin.H{"error": "Failed to apply decision: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, action)
+	}
+}
+
+// ListModerationActions lấy nhật ký quyết định kiểm duyệt
+func ListModerationActions(svc service.ReportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		targetType := c.Query("target_type")
+		targetID, _ := strconv.ParseUint(c.DefaultQuery("target_id", "0"), 10, 64)
+		moderatorID, _ := strconv.ParseUint(c.DefaultQuery("moderator_id", "0"), 10, 64)
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+		offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+		actions, total, err := svc.ListActions(targetType, targetID, moderatorID, limit, offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list moderation actions: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"limit":   limit,
+			"offset":  offset,
+			"actions": actions,
+			"total":   total,
+		})
+	}
+}
+
+// reportErrorStatus ánh xạ lỗi báo cáo/kiểm duyệt sang HTTP status
+func reportErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrReportNotFound), errors.Is(err, service.ErrTargetNotFound), errors.Is(err, service.ErrNotInAudience):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrBlocked):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrBlockCheckUnavailable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, service.ErrAlreadyReported):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrInvalidReportReason), errors.Is(err, service.ErrCannotReportOwn):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"postservice/internal/model"
+	"postservice/internal/service"
+	"postservice/internal/util"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestUpload cấp slot upload trực tiếp lên storage
+func RequestUpload(svc service.UploadService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := getUserID(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		var req model.UploadSlotRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+
+		slot, err := svc.RequestUpload(userID, req)
+		if err != nil {
+			log.Printf("Failed to request upload slot: %v", err)
+			c.JSON(uploadErrorStatus(err), gin.H{"error": "Failed to request upload: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusCreated, slot)
+	}
+}
+
+// ConfirmUpload xác nhận client đã upload xong file lên storage
+func ConfirmUpload(svc service.UploadService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := getUserID(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid upload ID"})
+			return
+		}
+
+		upload, err := svc.ConfirmUpload(id, userID)
+		if err != nil {
+			log.Printf("Failed to confirm upload %d: %v", id, err)
+			c.JSON(uploadErrorStatus(err), gin.H{"error": "Failed to confirm upload: " + err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, upload)
+	}
+}
+
+// uploadErrorStatus ánh xạ lỗi upload sang HTTP status
+func uploadErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUploadNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrUploadForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrUploadExpired):
+		return http.StatusGone
+	case errors.Is(err, service.ErrUploadTooLarge), errors.Is(err, util.ErrImageTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, service.ErrUnsupportedMediaType), errors.Is(err, service.ErrUploadNotConfirmed),
+		errors.Is(err, util.ErrInvalidImage):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// parseMediaIDs đọc field media_ids từ form, hỗ trợ cả lặp field lẫn danh sách phân tách bằng dấu phẩy
+func parseMediaIDs(values []string) ([]uint64, error) {
+	return parseIDs(values, "media")
+}
+
+// parseIDs đọc danh sách ID từ form, kind dùng trong thông báo lỗi
+func parseIDs(values []string, kind string) ([]uint64, error) {
+	var ids []uint64
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			id, err := strconv.ParseUint(part, 10, 64)
+			if err != nil {
+				return nil, errors.New("invalid " + kind + " ID: " + part)
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// Các hành động quản trị trên bài đăng/bình luận
+const (
+	AdminActionSoftDelete = "SOFT_DELETE" // Đánh dấu is_deleted, có thể khôi phục
+	AdminActionHardDelete = "HARD_DELETE" // Xóa hẳn nội dung cùng lượt thích, chia sẻ, bình luận con
+	AdminActionRestore    = "RESTORE"     // Khôi phục nội dung đã xóa mềm
+)
+
+// AdminAuditLog ánh xạ bảng admin_audit_logs, lưu vết mọi thao tác của admin
+type AdminAuditLog struct {
+	ID         uint64    `json:"id" gorm:"primary_key"`
+	AdminID    uint64    `json:"admin_id" gorm:"not null;index"`
+	Action     string    `json:"action" gorm:"type:varchar(20);not null"`
+	TargetType string    `json:"target_type" gorm:"type:varchar(10);not null;index:idx_admin_audit_target"`
+	TargetID   uint64    `json:"target_id" gorm:"not null;index:idx_admin_audit_target"`
+	OwnerID    uint64    `json:"owner_id"` // Tác giả của nội dung tại thời điểm thao tác
+	Note       string    `json:"note" gorm:"type:text"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (AdminAuditLog) TableName() string {
+	return "admin_audit_logs"
+}
+
+// AdminActionRequest là body (không bắt buộc) của các API quản trị
+type AdminActionRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
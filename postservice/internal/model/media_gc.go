@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// OrphanMedia là một file trên storage không còn được tham chiếu ở đâu
+type OrphanMedia struct {
+	PublicID     string    `json:"public_id"`
+	URL          string    `json:"url"`
+	ResourceType string    `json:"resource_type"`
+	Bytes        int64     `json:"bytes"`
+	CreatedAt    time.Time `json:"created_at"`
+	Deleted      bool      `json:"deleted"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// MediaGCReport là kết quả của một lần chạy job dọn media mồ côi
+type MediaGCReport struct {
+	StartedAt       time.Time     `json:"started_at"`
+	FinishedAt      time.Time     `json:"finished_at"`
+	DryRun          bool          `json:"dry_run"`
+	GracePeriod     string        `json:"grace_period"`
+	ScannedAssets   int           `json:"scanned_assets"`
+	ReferencedCount int           `json:"referenced_count"`
+	SkippedRecent   int           `json:"skipped_recent"` // File mới hơn grace period nên chưa xét
+	OrphanCount     int           `json:"orphan_count"`
+	OrphanBytes     int64         `json:"orphan_bytes"`
+	DeletedCount    int           `json:"deleted_count"`
+	FailedCount     int           `json:"failed_count"`
+	Orphans         []OrphanMedia `json:"orphans"`
+}
@@ -1,6 +1,11 @@
 package model
 
-import "time"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
 
 // UserInfo chứa thông tin cơ bản của user
 type UserInfo struct {
@@ -16,40 +21,164 @@ type Post struct {
 	UUID       string      `json:"uuid" gorm:"type:varchar(36);unique;not null;index"`
 	UserID     uint64      `json:"user_id" gorm:"not null"`
 	Content    string      `json:"content" gorm:"type:text;not null"`
-	Visibility string      `json:"visibility" gorm:"type:enum('PUBLIC','FRIENDS','PRIVATE');default:'PUBLIC'"`
+	Visibility string      `json:"visibility" gorm:"type:enum('PUBLIC','FRIENDS','PRIVATE','CUSTOM');default:'PUBLIC'"`
 	CreatedAt  time.Time   `json:"created_at"`
 	UpdatedAt  time.Time   `json:"updated_at"`
 	IsDeleted  bool        `json:"is_deleted" gorm:"default:0"`
 	Media      []PostMedia `json:"media" gorm:"foreignKey:PostID"`
+
+	// Thời điểm bị xóa mềm, media của bài đăng được giữ lại tới hết thời hạn khôi phục
+	SoftDeletedAt *time.Time `json:"-" gorm:"index"`
+
+	// Nhóm chứa bài đăng, nil là bài đăng trên trang cá nhân
+	GroupID *uint64 `json:"group_id,omitempty" gorm:"index"`
+
+	// Danh sách bạn bè (ở UserService) xác định người xem của bài đăng CUSTOM
+	AudienceListIDs IDList `json:"audience_list_ids,omitempty" gorm:"type:json"`
+	ExcludedListIDs IDList `json:"excluded_list_ids,omitempty" gorm:"type:json"`
+
+	// Bị ẩn bởi kiểm duyệt (tự động khi đủ số báo cáo, bộ lọc nội dung hoặc do moderator)
+	IsHidden bool `json:"is_hidden" gorm:"default:0;index"`
+	// Bị bộ lọc nội dung ẩn khỏi feed/danh sách, chỉ tác giả còn nhìn thấy
+	ShadowHidden bool `json:"-" gorm:"default:0"`
 }
 
 func (Post) TableName() string {
 	return "posts"
 }
 
+// VisibilityCustom là bài đăng chỉ hiển thị với bạn bè thuộc AudienceListIDs (rỗng là tất cả bạn bè)
+// và không thuộc ExcludedListIDs
+const VisibilityCustom = "CUSTOM"
+
+// IDList là danh sách ID, lưu dưới dạng JSON
+type IDList []uint64
+
+// Value chuyển đổi IDList thành giá trị để lưu vào cơ sở dữ liệu
+func (l IDList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan đọc dữ liệu từ cơ sở dữ liệu và chuyển đổi thành IDList
+func (l *IDList) Scan(value interface{}) error {
+	if value == nil {
+		*l = nil
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, l)
+}
+
+// MutedTargets là người dùng và nhóm bị người xem tắt tiếng/tạm ẩn, bài đăng của họ bị loại khỏi feed
+type MutedTargets struct {
+	UserIDs  []uint64
+	GroupIDs []uint64
+}
+
+// FeedSources là những người mà người xem đang theo dõi và bạn bè của người xem, dùng cho home feed
+type FeedSources struct {
+	FollowingIDs []uint64 // Chỉ lấy bài đăng PUBLIC
+	FriendIDs    []uint64 // Lấy bài đăng PUBLIC, FRIENDS và CUSTOM (lọc theo ViewerAudience)
+}
+
+// ViewerAudience là bạn bè của người xem, các danh sách bạn bè và các nhóm có người xem là thành viên,
+// dùng để lọc bài đăng CUSTOM và bài đăng trong nhóm ngay trong truy vấn. Chỉ có ViewerID thì chỉ thấy
+// bài CUSTOM và bài trong nhóm của chính mình.
+type ViewerAudience struct {
+	ViewerID  uint64
+	FriendIDs []uint64
+	ListIDs   []uint64
+	GroupIDs  []uint64
+}
+
 // PostResponse dùng để trả về dữ liệu bài đăng với thông tin bổ sung
 type PostResponse struct {
-	ID            uint64      `json:"id"`
-	UUID          string      `json:"uuid"`
-	UserID        uint64      `json:"user_id"`
-	Author        *UserInfo   `json:"author"` // Thêm thông tin user
-	Content       string      `json:"content"`
-	Visibility    string      `json:"visibility"`
-	CreatedAt     time.Time   `json:"created_at"`
-	UpdatedAt     time.Time   `json:"updated_at"`
-	Media         []PostMedia `json:"media"`
-	TotalLikes    int         `json:"total_likes"`
-	TotalComments int         `json:"total_comments"`
-	TotalShares   int         `json:"total_shares"`
+	ID         uint64    `json:"id"`
+	UUID       string    `json:"uuid"`
+	UserID     uint64    `json:"user_id"`
+	GroupID    *uint64   `json:"group_id,omitempty"`
+	Author     *UserInfo `json:"author"` // Thêm thông tin user
+	Content    string    `json:"content"`
+	Visibility string    `json:"visibility"`
+	// Chỉ có với bài đăng CUSTOM và chỉ trả về cho tác giả
+	AudienceListIDs IDList      `json:"audience_list_ids,omitempty"`
+	ExcludedListIDs IDList      `json:"excluded_list_ids,omitempty"`
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+	Media           []PostMedia `json:"media"`
+	TotalLikes      int         `json:"total_likes"`
+	TotalComments   int         `json:"total_comments"`
+	TotalShares     int         `json:"total_shares"`
+
+	// Bài đăng bị bộ lọc nội dung giữ lại chờ kiểm duyệt, chưa hiển thị với người khác
+	PendingReview bool `json:"pending_review,omitempty"`
 }
 
 // PostMedia ánh xạ bảng post_media
 type PostMedia struct {
-	ID        uint64    `json:"id" gorm:"primary_key"`
-	PostID    uint64    `json:"post_id" gorm:"not null"`
-	MediaURL  string    `json:"media_url" gorm:"type:varchar(255);not null"`
-	MediaType string    `json:"media_type" gorm:"type:enum('IMAGE','VIDEO');default:'IMAGE'"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        uint64        `json:"id" gorm:"primary_key"`
+	PostID    uint64        `json:"post_id" gorm:"not null"`
+	MediaURL  string        `json:"media_url" gorm:"type:varchar(255);not null"`
+	MediaType string        `json:"media_type" gorm:"type:enum('IMAGE','VIDEO');default:'IMAGE'"`
+	Width     int           `json:"width"`
+	Height    int           `json:"height"`
+	Variants  MediaVariants `json:"variants" gorm:"type:json"`
+	CreatedAt time.Time     `json:"created_at"`
+
+	// Placeholder để client hiển thị trước khi ảnh tải xong
+	BlurHash      string `json:"blur_hash" gorm:"type:varchar(64)"`
+	DominantColor string `json:"dominant_color" gorm:"type:varchar(7)"`
+
+	// Mô tả thay thế cho trình đọc màn hình, chú thích và thứ tự hiển thị trong bài đăng
+	AltText  string `json:"alt_text" gorm:"type:varchar(1000)"`
+	Caption  string `json:"caption" gorm:"type:text"`
+	Position int    `json:"position" gorm:"not null;default:0"`
+}
+
+// MediaVariant là một phiên bản kích thước khác của ảnh (thumbnail, medium, large)
+type MediaVariant struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// MediaVariants là danh sách variant, lưu dưới dạng JSON
+type MediaVariants []MediaVariant
+
+// Value chuyển đổi MediaVariants thành giá trị để lưu vào cơ sở dữ liệu
+func (v MediaVariants) Value() (driver.Value, error) {
+	if v == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan đọc dữ liệu từ cơ sở dữ liệu và chuyển đổi thành MediaVariants
+func (v *MediaVariants) Scan(value interface{}) error {
+	if value == nil {
+		*v = nil
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, v)
 }
 
 func (PostMedia) TableName() string {
@@ -69,6 +198,14 @@ type Comment struct {
 	UpdatedAt       time.Time     `json:"updated_at"`
 	IsDeleted       bool          `json:"is_deleted" gorm:"default:0"`
 	Likes           []CommentLike `json:"likes" gorm:"foreignKey:CommentID"`
+
+	// Thời điểm bị xóa mềm, ảnh của bình luận được giữ lại tới hết thời hạn khôi phục
+	SoftDeletedAt *time.Time `json:"-" gorm:"index"`
+
+	// Bị ẩn bởi kiểm duyệt (tự động khi đủ số báo cáo, bộ lọc nội dung hoặc do moderator)
+	IsHidden bool `json:"is_hidden" gorm:"default:0;index"`
+	// Bị bộ lọc nội dung ẩn khỏi feed/danh sách, chỉ tác giả còn nhìn thấy
+	ShadowHidden bool `json:"-" gorm:"default:0"`
 }
 
 func (Comment) TableName() string {
@@ -115,5 +252,29 @@ func (CommentLike) TableName() string {
 type CreatePostRequest struct {
 	Content    string   `json:"content" binding:"required"`
 	MediaURLs  []string `json:"media_urls"`
-	Visibility string   `json:"visibility" binding:"oneof=PUBLIC FRIENDS PRIVATE"`
+	MediaIDs   []uint64 `json:"media_ids"` // ID các upload đã xác nhận qua API upload trực tiếp
+	Visibility string   `json:"visibility" binding:"oneof=PUBLIC FRIENDS PRIVATE CUSTOM"`
+	GroupID    *uint64  `json:"group_id"` // Nhóm đăng bài vào, chỉ dùng khi tạo bài
+	// Chỉ dùng với visibility CUSTOM, là ID các danh sách bạn bè của người đăng
+	AudienceListIDs []uint64 `json:"audience_list_ids"`
+	ExcludedListIDs []uint64 `json:"excluded_list_ids"`
+
+	// Alt text và chú thích cho các media mới, theo thứ tự: images, media_urls, media_ids
+	MediaAltTexts []string `json:"media_alt_texts"`
+	MediaCaptions []string `json:"media_captions"`
+	// Chỉ dùng khi cập nhật: có một trong hai field thì media cũ được giữ lại,
+	// media mới được thêm vào cuối thay vì thay thế toàn bộ
+	MediaOrder     []uint64 `json:"media_order"`      // Thứ tự mới của các media hiện có
+	RemoveMediaIDs []uint64 `json:"remove_media_ids"` // Các media hiện có cần xóa
+}
+
+// UpdateMediaRequest dùng cho API sửa alt text/chú thích của một media, field nil thì giữ nguyên
+type UpdateMediaRequest struct {
+	AltText *string `json:"alt_text"`
+	Caption *string `json:"caption"`
+}
+
+// ReorderMediaRequest dùng cho API sắp xếp lại media của bài đăng
+type ReorderMediaRequest struct {
+	MediaIDs []uint64 `json:"media_ids" binding:"required"`
 }
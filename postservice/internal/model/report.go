@@ -0,0 +1,105 @@
+package model
+
+import "time"
+
+// Loại nội dung có thể bị báo cáo
+const (
+	ReportTargetPost    = "POST"
+	ReportTargetComment = "COMMENT"
+)
+
+// Trạng thái của báo cáo trong hàng đợi kiểm duyệt
+const (
+	ReportStatusOpen      = "OPEN"      // Chờ moderator xử lý
+	ReportStatusActioned  = "ACTIONED"  // Moderator đã ẩn/xóa nội dung
+	ReportStatusDismissed = "DISMISSED" // Moderator xác định nội dung không vi phạm
+)
+
+// Các lý do báo cáo
+const (
+	ReportReasonSpam           = "SPAM"
+	ReportReasonHarassment     = "HARASSMENT"
+	ReportReasonHateSpeech     = "HATE_SPEECH"
+	ReportReasonViolence       = "VIOLENCE"
+	ReportReasonNudity         = "NUDITY"
+	ReportReasonMisinformation = "MISINFORMATION"
+	ReportReasonSelfHarm       = "SELF_HARM"
+	ReportReasonOther          = "OTHER"
+)
+
+// ReportReasonAutoFilter là lý do của báo cáo do bộ lọc nội dung tạo ra, người dùng không chọn được
+const ReportReasonAutoFilter = "AUTO_FILTER"
+
+// IsValidReportReason kiểm tra lý do báo cáo có thuộc danh sách hỗ trợ
+func IsValidReportReason(reason string) bool {
+	switch reason {
+	case ReportReasonSpam, ReportReasonHarassment, ReportReasonHateSpeech, ReportReasonViolence,
+		ReportReasonNudity, ReportReasonMisinformation, ReportReasonSelfHarm, ReportReasonOther:
+		return true
+	}
+	return false
+}
+
+// Report ánh xạ bảng reports, mỗi người chỉ báo cáo một nội dung một lần
+type Report struct {
+	ID         uint64     `json:"id" gorm:"primary_key"`
+	TargetType string     `json:"target_type" gorm:"type:varchar(10);not null;unique_index:idx_report_target_reporter"`
+	TargetID   uint64     `json:"target_id" gorm:"not null;unique_index:idx_report_target_reporter"`
+	ReporterID uint64     `json:"reporter_id" gorm:"not null;unique_index:idx_report_target_reporter"`
+	Reason     string     `json:"reason" gorm:"type:varchar(20);not null"`
+	Details    string     `json:"details" gorm:"type:text"`
+	Status     string     `json:"status" gorm:"type:varchar(10);not null;default:'OPEN';index"`
+	ResolvedBy *uint64    `json:"resolved_by"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func (Report) TableName() string {
+	return "reports"
+}
+
+// Các hành động kiểm duyệt
+const (
+	ModerationActionAutoHide   = "AUTO_HIDE"   // Hệ thống tự ẩn khi đủ số báo cáo
+	ModerationActionAutoFilter = "AUTO_FILTER" // Bộ lọc nội dung giữ lại chờ kiểm duyệt
+	ModerationActionHide       = "HIDE"
+	ModerationActionDelete     = "DELETE"
+	ModerationActionDismiss    = "DISMISS" // Bỏ qua báo cáo, hiện lại nội dung nếu nó bị ẩn tự động do chính các báo cáo này
+)
+
+// ModerationAction ánh xạ bảng moderation_actions, lưu vết mọi quyết định kiểm duyệt
+type ModerationAction struct {
+	ID          uint64    `json:"id" gorm:"primary_key"`
+	TargetType  string    `json:"target_type" gorm:"type:varchar(10);not null;index:idx_moderation_target"`
+	TargetID    uint64    `json:"target_id" gorm:"not null;index:idx_moderation_target"`
+	ModeratorID uint64    `json:"moderator_id" gorm:"not null;index"` // 0 là hệ thống
+	Action      string    `json:"action" gorm:"type:varchar(20);not null"`
+	Note        string    `json:"note" gorm:"type:text"`
+	ReportCount int       `json:"report_count"` // Số báo cáo được xử lý bởi quyết định này
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (ModerationAction) TableName() string {
+	return "moderation_actions"
+}
+
+// CreateReportRequest dùng cho API báo cáo bài đăng/bình luận
+type CreateReportRequest struct {
+	Reason  string `json:"reason" binding:"required"`
+	Details string `json:"details" binding:"max=1000"`
+}
+
+// ModerationDecisionRequest dùng cho API moderator xử lý báo cáo
+type ModerationDecisionRequest struct {
+	Action string `json:"action" binding:"required,oneof=HIDE DELETE DISMISS"`
+	Note   string `json:"note" binding:"max=1000"`
+}
+
+// ReportDetail là báo cáo kèm các báo cáo khác về cùng nội dung và lịch sử kiểm duyệt
+type ReportDetail struct {
+	Report        Report             `json:"report"`
+	TargetReports []Report           `json:"target_reports"`
+	TargetHidden  bool               `json:"target_hidden"`
+	Actions       []ModerationAction `json:"actions"`
+}
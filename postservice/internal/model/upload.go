@@ -0,0 +1,60 @@
+package model
+
+import "time"
+
+// Trạng thái của một phiên upload
+const (
+	UploadStatusPending   = "PENDING"   // Đã cấp slot, client chưa xác nhận upload
+	UploadStatusConfirmed = "CONFIRMED" // Đã xác nhận file tồn tại trên storage
+	UploadStatusAttached  = "ATTACHED"  // Đã gắn vào bài đăng
+	UploadStatusExpired   = "EXPIRED"   // Hết hạn, đã được janitor dọn dẹp
+)
+
+// MediaUpload ánh xạ bảng media_uploads
+type MediaUpload struct {
+	ID           uint64     `json:"id" gorm:"primary_key"`
+	UserID       uint64     `json:"user_id" gorm:"not null;index"`
+	PublicID     string     `json:"public_id" gorm:"type:varchar(255);unique;not null"`
+	ResourceType string     `json:"resource_type" gorm:"type:varchar(10);not null;default:'image'"`
+	Status       string     `json:"status" gorm:"type:varchar(20);not null;default:'PENDING';index"`
+	MediaURL     string     `json:"media_url" gorm:"type:varchar(255)"`
+	Format       string     `json:"format" gorm:"type:varchar(20)"`
+	Bytes        int64      `json:"bytes"`
+	Width        int        `json:"width"`
+	Height       int        `json:"height"`
+	PostID       *uint64    `json:"post_id"`
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"index"`
+	ConfirmedAt  *time.Time `json:"confirmed_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	// Placeholder tính khi xác nhận upload (chỉ với ảnh)
+	BlurHash      string `json:"blur_hash" gorm:"type:varchar(64)"`
+	DominantColor string `json:"dominant_color" gorm:"type:varchar(7)"`
+}
+
+func (MediaUpload) TableName() string {
+	return "media_uploads"
+}
+
+// MediaType trả về loại media tương ứng trong bảng post_media
+func (u MediaUpload) MediaType() string {
+	if u.ResourceType == "video" {
+		return "VIDEO"
+	}
+	return "IMAGE"
+}
+
+// UploadSlotRequest dùng cho API xin cấp slot upload
+type UploadSlotRequest struct {
+	ContentType string `json:"content_type" binding:"required"`
+	FileSize    int64  `json:"file_size" binding:"required,gt=0"`
+}
+
+// UploadSlotResponse chứa thông tin để client upload trực tiếp lên storage
+type UploadSlotResponse struct {
+	UploadID  uint64            `json:"upload_id"`
+	UploadURL string            `json:"upload_url"`
+	Fields    map[string]string `json:"fields"` // Các field phải gửi kèm file trong form upload
+	ExpiresAt time.Time         `json:"expires_at"`
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"postservice/internal/model"
+
+	"github.com/jinzhu/gorm"
+)
+
+type AdminRepository interface {
+	FindPostIDByUUID(uuid string) (uint64, error)
+	FindContent(targetType string, targetID uint64) (ownerID uint64, deletedAt *time.Time, deleted bool, err error)
+	Apply(entry *model.AdminAuditLog) error
+	ListAuditLogs(adminID uint64, targetType string, targetID uint64, limit, offset int) ([]model.AdminAuditLog, int64, error)
+}
+
+type adminRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminRepository(db *gorm.DB) AdminRepository {
+	return &adminRepository{db: db}
+}
+
+// FindPostIDByUUID tìm ID bài đăng theo UUID, kể cả bài đã bị xóa mềm hoặc bị ẩn
+func (r *adminRepository) FindPostIDByUUID(uuid string) (uint64, error) {
+	var post model.Post
+	if err := r.db.Select("id").Where("uuid = ?", uuid).First(&post).Error; err != nil {
+		return 0, err
+	}
+	return post.ID, nil
+}
+
+// FindContent lấy tác giả, trạng thái và thời điểm xóa mềm của bài đăng/bình luận (kể cả khi đã bị xóa)
+func (r *adminRepository) FindContent(targetType string, targetID uint64) (uint64, *time.Time, bool, error) {
+	table, err := reportTargetTable(targetType)
+	if err != nil {
+		return 0, nil, false, err
+	}
+
+	var content struct {
+		UserID        uint64
+		IsDeleted     bool
+		SoftDeletedAt *time.Time
+	}
+	if err := r.db.Table(table).Select("user_id, is_deleted, soft_deleted_at").
+		Where("id = ?", targetID).Scan(&content).Error; err != nil {
+		return 0, nil, false, err
+	}
+	return content.UserID, content.SoftDeletedAt, content.IsDeleted, nil
+}
+
+// Apply thực hiện thao tác quản trị lên nội dung và ghi nhật ký trong cùng một transaction
+func (r *adminRepository) Apply(entry *model.AdminAuditLog) error {
+	table, err := reportTargetTable(entry.TargetType)
+	if err != nil {
+		return err
+	}
+
+	tx := r.db.Begin()
+	switch entry.Action {
+	case model.AdminActionSoftDelete:
+		err = tx.Table(table).Where("id = ?", entry.TargetID).Updates(softDeleteFields()).Error
+	case model.AdminActionRestore:
+		err = tx.Table(table).Where("id = ?", entry.TargetID).Updates(restoreFields()).Error
+	case model.AdminActionHardDelete:
+		if entry.TargetType == model.ReportTargetPost {
+			err = hardDeletePost(tx, entry.TargetID)
+		} else {
+			err = hardDeleteComment(tx, entry.TargetID)
+		}
+	default:
+		err = errors.New("unsupported admin action: " + entry.Action)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Create(entry).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// hardDeletePost xóa bài đăng cùng media, lượt thích, chia sẻ và toàn bộ bình luận. File media
+// trên storage không còn được tham chiếu sẽ được job dọn media mồ côi xóa sau.
+func hardDeletePost(tx *gorm.DB, postID uint64) error {
+	commentIDs := tx.Table("comments").Select("id").Where("post_id = ?", postID).SubQuery()
+	if err := tx.Where("comment_id IN ?", commentIDs).Delete(&model.CommentLike{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("post_id = ?", postID).Delete(&model.Comment{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("post_id = ?", postID).Delete(&model.PostLike{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("post_id = ?", postID).Delete(&model.PostShare{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("post_id = ?", postID).Delete(&model.PostMedia{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("id = ?", postID).Delete(&model.Post{}).Error
+}
+
+// hardDeleteComment xóa bình luận cùng toàn bộ phản hồi (mọi cấp) và lượt thích của chúng
+func hardDeleteComment(tx *gorm.DB, commentID uint64) error {
+	ids := []uint64{commentID}
+	for parents := ids; len(parents) > 0; {
+		var children []uint64
+		if err := tx.Model(&model.Comment{}).Where("parent_comment_id IN (?)", parents).
+			Pluck("id", &children).Error; err != nil {
+			return err
+		}
+		ids = append(ids, children...)
+		parents = children
+	}
+
+	if err := tx.Where("comment_id IN (?)", ids).Delete(&model.CommentLike{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("id IN (?)", ids).Delete(&model.Comment{}).Error
+}
+
+// ListAuditLogs lấy nhật ký quản trị, lọc theo admin hoặc nội dung nếu có, mới nhất trước
+func (r *adminRepository) ListAuditLogs(adminID uint64, targetType string, targetID uint64, limit, offset int) ([]model.AdminAuditLog, int64, error) {
+	var logs []model.AdminAuditLog
+	var total int64
+
+	query := r.db.Model(&model.AdminAuditLog{})
+	if adminID != 0 {
+		query = query.Where("admin_id = ?", adminID)
+	}
+	if targetType != "" {
+		query = query.Where("target_type = ?", targetType)
+	}
+	if targetID != 0 {
+		query = query.Where("target_id = ?", targetID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"time"
+
+	"postservice/internal/model"
+
+	"github.com/jinzhu/gorm"
+)
+
+// MediaReferenceRepository truy vấn các media đang được PostService sử dụng
+type MediaReferenceRepository interface {
+	ListReferencedURLs(restorableSince time.Time) ([]string, error)
+	ListPendingUploadPublicIDs() ([]string, error)
+}
+
+type mediaReferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewMediaReferenceRepository(db *gorm.DB) MediaReferenceRepository {
+	return &mediaReferenceRepository{db: db}
+}
+
+// ListReferencedURLs lấy URL media (kể cả variant) của các bài đăng và bình luận chưa bị xóa, hoặc bị
+// xóa mềm sau restorableSince nên vẫn còn khôi phục được. Nội dung bị xóa mềm trước khi có cột
+// soft_deleted_at không rõ thời điểm xóa nên luôn được giữ lại.
+func (r *mediaReferenceRepository) ListReferencedURLs(restorableSince time.Time) ([]string, error) {
+	var media []model.PostMedia
+	err := r.db.Table("post_media").
+		Select("post_media.media_url, post_media.variants").
+		Joins("JOIN posts ON posts.id = post_media.post_id").
+		Where("posts.is_deleted = false OR posts.soft_deleted_at IS NULL OR posts.soft_deleted_at > ?", restorableSince).
+		Find(&media).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var postURLs []string
+	for _, m := range media {
+		postURLs = append(postURLs, m.MediaURL)
+		// Các variant được upload riêng cũng là media đang được tham chiếu
+		for _, v := range m.Variants {
+			postURLs = append(postURLs, v.URL)
+		}
+	}
+
+	var commentURLs []string
+	err = r.db.Model(&model.Comment{}).
+		Where("media_url IS NOT NULL AND media_url <> ''").
+		Where("is_deleted = false OR soft_deleted_at IS NULL OR soft_deleted_at > ?", restorableSince).
+		Pluck("media_url", &commentURLs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return append(postURLs, commentURLs...), nil
+}
+
+// ListPendingUploadPublicIDs lấy publicID của các upload trực tiếp còn đang chờ gắn vào bài đăng
+// (các upload này do janitor upload quản lý, job dọn media không được đụng tới)
+func (r *mediaReferenceRepository) ListPendingUploadPublicIDs() ([]string, error) {
+	var publicIDs []string
+	err := r.db.Model(&model.MediaUpload{}).
+		Where("status IN (?)", []string{model.UploadStatusPending, model.UploadStatusConfirmed}).
+		Pluck("public_id", &publicIDs).Error
+	return publicIDs, err
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"errors"
+	"strconv"
 	"time"
 
 	"postservice/internal/model"
@@ -8,17 +10,24 @@ import (
 	"github.com/jinzhu/gorm"
 )
 
+// ErrUploadsUnavailable báo một upload cần gắn vào bài đăng không còn ở trạng thái CONFIRMED,
+// ví dụ đã được gắn vào bài đăng khác hoặc đã hết hạn
+var ErrUploadsUnavailable = errors.New("uploads are no longer available")
+
 type PostRepository interface {
 	FindByID(id uint64) (*model.PostResponse, error)
 	FindByUUID(uuid string) (*model.PostResponse, error)
-	CreatePost(post *model.Post) error
-	UpdatePost(post *model.Post) error
+	CreatePost(post *model.Post, uploadIDs []uint64) error
+	UpdatePost(post *model.Post, uploadIDs []uint64) error
 	DeletePost(id uint64) error
 	DeletePostByUUID(uuid string) error
 	DeletePostMedia(postID uint64) error
+	UpdatePostMedia(media *model.PostMedia) error
+	DeletePostMediaByIDs(postID uint64, ids []uint64) error
+	UpdateMediaPositions(postID uint64, ids []uint64) error
 	CreateComment(comment *model.Comment) error
-	FindCommentsByPostID(postID uint64, limit, offset int) ([]model.Comment, int64, error)
-	FindCommentsByPostUUID(uuid string, limit, offset int) ([]model.Comment, int64, error)
+	FindCommentsByPostID(postID, viewerID uint64, blockedIDs []uint64, limit, offset int) ([]model.Comment, int64, error)
+	FindCommentsByPostUUID(uuid string, viewerID uint64, blockedIDs []uint64, limit, offset int) ([]model.Comment, int64, error)
 	FindCommentByID(id uint64, comment *model.Comment) error
 	UpdateComment(comment *model.Comment) error
 	DeleteComment(id uint64) error
@@ -30,10 +39,10 @@ type PostRepository interface {
 	DeleteCommentLike(commentID, userID uint64) error
 	CreateShare(share *model.PostShare) error
 	CreateShareByUUID(uuid string, userID uint64, sharedContent string) error
-	FindSharesByPostID(postID uint64, limit, offset int) ([]model.PostShare, int64, error)
-	FindSharesByPostUUID(uuid string, limit, offset int) ([]model.PostShare, int64, error)
-	FindPostsByUserID(userID uint64, limit, offset int) ([]model.PostResponse, int64, error)
-	FindFeed(userID uint64, mode string, limit, offset int) ([]model.PostResponse, int64, error)
+	FindSharesByPostID(postID uint64, blockedIDs []uint64, limit, offset int) ([]model.PostShare, int64, error)
+	FindSharesByPostUUID(uuid string, blockedIDs []uint64, limit, offset int) ([]model.PostShare, int64, error)
+	FindPostsByUserID(userID uint64, audience model.ViewerAudience, limit, offset int) ([]model.PostResponse, int64, error)
+	FindFeed(mode string, limit, offset int, audience model.ViewerAudience, muted model.MutedTargets, sources *model.FeedSources, blockedIDs []uint64) ([]model.PostResponse, int64, error)
 }
 
 type postRepository struct {
@@ -46,27 +55,30 @@ func NewPostRepository(db *gorm.DB) PostRepository {
 
 func (r *postRepository) FindByID(id uint64) (*model.PostResponse, error) {
 	var post model.Post
-	if err := r.db.Preload("Media").Where("id = ? AND is_deleted = false", id).First(&post).Error; err != nil {
+	if err := r.db.Preload("Media", orderedMedia).Where("id = ? AND is_deleted = false AND is_hidden = false", id).First(&post).Error; err != nil {
 		return nil, err
 	}
 
 	var totalLikes, totalComments, totalShares int64
 	r.db.Model(&model.PostLike{}).Where("post_id = ?", id).Count(&totalLikes)
-	r.db.Model(&model.Comment{}).Where("post_id = ? AND is_deleted = false", id).Count(&totalComments)
+	r.db.Model(&model.Comment{}).Where("post_id = ? AND is_deleted = false AND is_hidden = false", id).Count(&totalComments)
 	r.db.Model(&model.PostShare{}).Where("post_id = ?", id).Count(&totalShares)
 
 	postResponse := &model.PostResponse{
-		ID:            post.ID,
-		UUID:          post.UUID,
-		UserID:        post.UserID,
-		Content:       post.Content,
-		Visibility:    post.Visibility,
-		CreatedAt:     post.CreatedAt,
-		UpdatedAt:     post.UpdatedAt,
-		Media:         post.Media,
-		TotalLikes:    int(totalLikes),
-		TotalComments: int(totalComments),
-		TotalShares:   int(totalShares),
+		ID:              post.ID,
+		UUID:            post.UUID,
+		UserID:          post.UserID,
+		Content:         post.Content,
+		Visibility:      post.Visibility,
+		GroupID:         post.GroupID,
+		AudienceListIDs: post.AudienceListIDs,
+		ExcludedListIDs: post.ExcludedListIDs,
+		CreatedAt:       post.CreatedAt,
+		UpdatedAt:       post.UpdatedAt,
+		Media:           post.Media,
+		TotalLikes:      int(totalLikes),
+		TotalComments:   int(totalComments),
+		TotalShares:     int(totalShares),
 	}
 
 	return postResponse, nil
@@ -74,38 +86,62 @@ func (r *postRepository) FindByID(id uint64) (*model.PostResponse, error) {
 
 func (r *postRepository) FindByUUID(uuid string) (*model.PostResponse, error) {
 	var post model.Post
-	if err := r.db.Preload("Media").Where("uuid = ? AND is_deleted = false", uuid).First(&post).Error; err != nil {
+	if err := r.db.Preload("Media", orderedMedia).Where("uuid = ? AND is_deleted = false AND is_hidden = false", uuid).First(&post).Error; err != nil {
 		return nil, err
 	}
 
 	var totalLikes, totalComments, totalShares int64
 	r.db.Model(&model.PostLike{}).Where("post_id = ?", post.ID).Count(&totalLikes)
-	r.db.Model(&model.Comment{}).Where("post_id = ? AND is_deleted = false", post.ID).Count(&totalComments)
+	r.db.Model(&model.Comment{}).Where("post_id = ? AND is_deleted = false AND is_hidden = false", post.ID).Count(&totalComments)
 	r.db.Model(&model.PostShare{}).Where("post_id = ?", post.ID).Count(&totalShares)
 
 	postResponse := &model.PostResponse{
-		ID:            post.ID,
-		UUID:          post.UUID,
-		UserID:        post.UserID,
-		Content:       post.Content,
-		Visibility:    post.Visibility,
-		CreatedAt:     post.CreatedAt,
-		UpdatedAt:     post.UpdatedAt,
-		Media:         post.Media,
-		TotalLikes:    int(totalLikes),
-		TotalComments: int(totalComments),
-		TotalShares:   int(totalShares),
+		ID:              post.ID,
+		UUID:            post.UUID,
+		UserID:          post.UserID,
+		Content:         post.Content,
+		Visibility:      post.Visibility,
+		GroupID:         post.GroupID,
+		AudienceListIDs: post.AudienceListIDs,
+		ExcludedListIDs: post.ExcludedListIDs,
+		CreatedAt:       post.CreatedAt,
+		UpdatedAt:       post.UpdatedAt,
+		Media:           post.Media,
+		TotalLikes:      int(totalLikes),
+		TotalComments:   int(totalComments),
+		TotalShares:     int(totalShares),
 	}
 
 	return postResponse, nil
 }
 
-func (r *postRepository) FindFeed(userID uint64, mode string, limit, offset int) ([]model.PostResponse, int64, error) {
+// FindFeed lấy feed của người xem audience.ViewerID. sources khác nil là home feed: chỉ gồm bài đăng của
+// chính người xem, của bạn bè và bài đăng công khai của những người đang theo dõi. Bài của blockedIDs
+// và bài CUSTOM mà người xem không thuộc đối tượng bị loại.
+func (r *postRepository) FindFeed(mode string, limit, offset int, audience model.ViewerAudience, muted model.MutedTargets, sources *model.FeedSources, blockedIDs []uint64) ([]model.PostResponse, int64, error) {
 	var posts []model.Post
 	var total int64
+	userID := audience.ViewerID
+
+	// Truy vấn tất cả bài đăng không bị xóa, bài bị bộ lọc ẩn chỉ hiện với tác giả
+	query := r.db.Preload("Media", orderedMedia).Where("is_deleted = false AND is_hidden = false").
+		Where("shadow_hidden = false OR user_id = ?", userID)
+
+	if sources != nil {
+		query = query.Where("user_id = ? OR (user_id IN (?) AND visibility IN ('PUBLIC','FRIENDS','CUSTOM')) OR (user_id IN (?) AND visibility = 'PUBLIC')",
+			userID, sources.FriendIDs, sources.FollowingIDs)
+	}
 
-	// Truy vấn tất cả bài đăng không bị xóa
-	query := r.db.Preload("Media").Where("is_deleted = false")
+	// Bỏ bài đăng của người dùng/nhóm bị tắt tiếng
+	if len(muted.UserIDs) > 0 {
+		query = query.Where("user_id NOT IN (?)", muted.UserIDs)
+	}
+	if len(muted.GroupIDs) > 0 {
+		query = query.Where("group_id IS NULL OR group_id NOT IN (?)", muted.GroupIDs)
+	}
+	query = excludeUsers(query, blockedIDs)
+	query = filterAudience(query, audience)
+	query = filterGroups(query, audience)
 
 	if err := query.Model(&model.Post{}).Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -153,21 +189,24 @@ func (r *postRepository) FindFeed(userID uint64, mode string, limit, offset int)
 	for _, post := range posts {
 		var totalLikes, totalComments, totalShares int64
 		r.db.Model(&model.PostLike{}).Where("post_id = ?", post.ID).Count(&totalLikes)
-		r.db.Model(&model.Comment{}).Where("post_id = ? AND is_deleted = false", post.ID).Count(&totalComments)
+		r.db.Model(&model.Comment{}).Where("post_id = ? AND is_deleted = false AND is_hidden = false", post.ID).Count(&totalComments)
 		r.db.Model(&model.PostShare{}).Where("post_id = ?", post.ID).Count(&totalShares)
 
 		postResponses = append(postResponses, model.PostResponse{
-			ID:            post.ID,
-			UUID:          post.UUID,
-			UserID:        post.UserID,
-			Content:       post.Content,
-			Visibility:    post.Visibility,
-			CreatedAt:     post.CreatedAt,
-			UpdatedAt:     post.UpdatedAt,
-			Media:         post.Media,
-			TotalLikes:    int(totalLikes),
-			TotalComments: int(totalComments),
-			TotalShares:   int(totalShares),
+			ID:              post.ID,
+			UUID:            post.UUID,
+			UserID:          post.UserID,
+			Content:         post.Content,
+			Visibility:      post.Visibility,
+			GroupID:         post.GroupID,
+			AudienceListIDs: post.AudienceListIDs,
+			ExcludedListIDs: post.ExcludedListIDs,
+			CreatedAt:       post.CreatedAt,
+			UpdatedAt:       post.UpdatedAt,
+			Media:           post.Media,
+			TotalLikes:      int(totalLikes),
+			TotalComments:   int(totalComments),
+			TotalShares:     int(totalShares),
 		})
 	}
 
@@ -187,17 +226,87 @@ func (r *postRepository) FindFeed(userID uint64, mode string, limit, offset int)
 	return postResponses, total, nil
 }
 
-// Các hàm khác giữ nguyên
-func (r *postRepository) CreatePost(post *model.Post) error {
-	return r.db.Create(post).Error
+// CreatePost tạo bài đăng và gắn các upload uploadIDs vào bài đăng trong cùng một transaction
+func (r *postRepository) CreatePost(post *model.Post, uploadIDs []uint64) error {
+	tx := r.db.Begin()
+	if err := tx.Create(post).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := attachUploads(tx, post.UserID, post.ID, uploadIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
 }
 
-func (r *postRepository) UpdatePost(post *model.Post) error {
-	return r.db.Save(post).Error
+// UpdatePost lưu bài đăng và gắn các upload uploadIDs vào bài đăng trong cùng một transaction. Chỉ các
+// cột người dùng được sửa và media được ghi, UUID, tác giả, nhóm và thời điểm tạo giữ nguyên.
+func (r *postRepository) UpdatePost(post *model.Post, uploadIDs []uint64) error {
+	tx := r.db.Begin()
+	err := tx.Model(&model.Post{}).Where("id = ?", post.ID).Updates(map[string]interface{}{
+		"content":           post.Content,
+		"visibility":        post.Visibility,
+		"audience_list_ids": post.AudienceListIDs,
+		"excluded_list_ids": post.ExcludedListIDs,
+		"updated_at":        post.UpdatedAt,
+		"is_hidden":         post.IsHidden,
+		"shadow_hidden":     post.ShadowHidden,
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	for i := range post.Media {
+		post.Media[i].PostID = post.ID
+		if err := tx.Save(&post.Media[i]).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := attachUploads(tx, post.UserID, post.ID, uploadIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// attachUploads khóa các upload CONFIRMED của userID rồi chuyển sang ATTACHED. Khóa hàng bảo đảm
+// hai bài đăng không gắn cùng một upload, và janitor không dọn upload đã được gắn.
+func attachUploads(tx *gorm.DB, userID, postID uint64, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var uploads []model.MediaUpload
+	if err := tx.Set("gorm:query_option", "FOR UPDATE").
+		Where("id IN (?) AND user_id = ? AND status = ?", ids, userID, model.UploadStatusConfirmed).
+		Find(&uploads).Error; err != nil {
+		return err
+	}
+	if len(uploads) != len(ids) {
+		return ErrUploadsUnavailable
+	}
+
+	return tx.Model(&model.MediaUpload{}).Where("id IN (?)", ids).Updates(map[string]interface{}{
+		"status":     model.UploadStatusAttached,
+		"post_id":    postID,
+		"updated_at": time.Now(),
+	}).Error
 }
 
 func (r *postRepository) DeletePost(id uint64) error {
-	return r.db.Model(&model.Post{}).Where("id = ?", id).Update("is_deleted", true).Error
+	return r.db.Model(&model.Post{}).Where("id = ?", id).Updates(softDeleteFields()).Error
+}
+
+// softDeleteFields là các cột cần cập nhật khi xóa mềm bài đăng/bình luận
+func softDeleteFields() map[string]interface{} {
+	return map[string]interface{}{"is_deleted": true, "soft_deleted_at": time.Now()}
+}
+
+// restoreFields là các cột cần cập nhật khi khôi phục bài đăng/bình luận đã xóa mềm
+func restoreFields() map[string]interface{} {
+	return map[string]interface{}{"is_deleted": false, "soft_deleted_at": nil}
 }
 
 func (r *postRepository) DeletePostMedia(postID uint64) error {
@@ -208,19 +317,104 @@ func (r *postRepository) DeletePostMedia(postID uint64) error {
 	return nil
 }
 
+func (r *postRepository) UpdatePostMedia(media *model.PostMedia) error {
+	return r.db.Save(media).Error
+}
+
+// DeletePostMediaByIDs xóa một số media của bài đăng
+func (r *postRepository) DeletePostMediaByIDs(postID uint64, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Where("post_id = ? AND id IN (?)", postID, ids).Delete(&model.PostMedia{}).Error
+}
+
+// UpdateMediaPositions đánh lại vị trí media theo đúng thứ tự ids trong một transaction
+func (r *postRepository) UpdateMediaPositions(postID uint64, ids []uint64) error {
+	tx := r.db.Begin()
+	for i, id := range ids {
+		if err := tx.Model(&model.PostMedia{}).Where("id = ? AND post_id = ?", id, postID).
+			Update("position", i).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// excludeUsers bỏ các bản ghi của userIDs (những người có quan hệ chặn với người xem)
+func excludeUsers(query *gorm.DB, userIDs []uint64) *gorm.DB {
+	if len(userIDs) == 0 {
+		return query
+	}
+	return query.Where("user_id NOT IN (?)", userIDs)
+}
+
+// filterAudience chỉ giữ bài đăng CUSTOM mà người xem thuộc đối tượng: là tác giả, hoặc là bạn của tác
+// giả, thuộc một trong các danh sách được xem (nếu có) và không thuộc danh sách bị loại trừ nào.
+// ID danh sách là duy nhất nên chỉ cần so với các danh sách có người xem là thành viên.
+func filterAudience(query *gorm.DB, audience model.ViewerAudience) *gorm.DB {
+	if audience.ViewerID == 0 || len(audience.FriendIDs) == 0 {
+		return query.Where("visibility <> ? OR user_id = ?", model.VisibilityCustom, audience.ViewerID)
+	}
+
+	included := "COALESCE(JSON_LENGTH(audience_list_ids), 0) = 0"
+	excluded := ""
+	args := []interface{}{model.VisibilityCustom, audience.ViewerID, audience.FriendIDs}
+	var excludedArgs []interface{}
+	for _, id := range audience.ListIDs {
+		candidate := strconv.FormatUint(id, 10)
+		included += " OR JSON_CONTAINS(COALESCE(audience_list_ids, '[]'), ?)"
+		args = append(args, candidate)
+		if excluded != "" {
+			excluded += " OR "
+		}
+		excluded += "JSON_CONTAINS(COALESCE(excluded_list_ids, '[]'), ?)"
+		excludedArgs = append(excludedArgs, candidate)
+	}
+
+	condition := "visibility <> ? OR user_id = ? OR (user_id IN (?) AND (" + included + ")"
+	if excluded != "" {
+		condition += " AND NOT (" + excluded + ")"
+		args = append(args, excludedArgs...)
+	}
+	condition += ")"
+	return query.Where(condition, args...)
+}
+
+// filterGroups chỉ giữ bài đăng trong nhóm khi người xem là tác giả hoặc là thành viên của nhóm, để bài
+// đăng trong nhóm riêng tư và bí mật không lộ ra feed hay trang cá nhân
+func filterGroups(query *gorm.DB, audience model.ViewerAudience) *gorm.DB {
+	if len(audience.GroupIDs) == 0 {
+		return query.Where("group_id IS NULL OR user_id = ?", audience.ViewerID)
+	}
+	return query.Where("group_id IS NULL OR user_id = ? OR group_id IN (?)", audience.ViewerID, audience.GroupIDs)
+}
+
+// orderedMedia sắp xếp media khi preload theo vị trí hiển thị
+func orderedMedia(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC, id ASC")
+}
+
 func (r *postRepository) CreateComment(comment *model.Comment) error {
 	return r.db.Create(comment).Error
 }
 
-func (r *postRepository) FindCommentsByPostID(postID uint64, limit, offset int) ([]model.Comment, int64, error) {
+// FindCommentsByPostID lấy bình luận của bài đăng, bình luận bị bộ lọc ẩn chỉ hiện với tác giả (viewerID).
+// Bình luận của blockedIDs bị loại.
+func (r *postRepository) FindCommentsByPostID(postID, viewerID uint64, blockedIDs []uint64, limit, offset int) ([]model.Comment, int64, error) {
 	var comments []model.Comment
 	var total int64
 
-	if err := r.db.Model(&model.Comment{}).Where("post_id = ? AND is_deleted = false", postID).Count(&total).Error; err != nil {
+	query := r.db.Where("post_id = ? AND is_deleted = false AND is_hidden = false", postID).
+		Where("shadow_hidden = false OR user_id = ?", viewerID)
+	query = excludeUsers(query, blockedIDs)
+
+	if err := query.Model(&model.Comment{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	if err := r.db.Preload("Likes").Where("post_id = ? AND is_deleted = false", postID).
+	if err := query.Preload("Likes").
 		Order("created_at DESC").Limit(limit).Offset(offset).Find(&comments).Error; err != nil {
 		return nil, 0, err
 	}
@@ -229,7 +423,7 @@ func (r *postRepository) FindCommentsByPostID(postID uint64, limit, offset int)
 }
 
 func (r *postRepository) FindCommentByID(id uint64, comment *model.Comment) error {
-	return r.db.Where("id = ? AND is_deleted = false", id).First(comment).Error
+	return r.db.Where("id = ? AND is_deleted = false AND is_hidden = false", id).First(comment).Error
 }
 
 func (r *postRepository) UpdateComment(comment *model.Comment) error {
@@ -237,7 +431,7 @@ func (r *postRepository) UpdateComment(comment *model.Comment) error {
 }
 
 func (r *postRepository) DeleteComment(id uint64) error {
-	return r.db.Model(&model.Comment{}).Where("id = ?", id).Update("is_deleted", true).Error
+	return r.db.Model(&model.Comment{}).Where("id = ?", id).Updates(softDeleteFields()).Error
 }
 
 func (r *postRepository) CreatePostLike(postID, userID uint64) error {
@@ -262,30 +456,41 @@ func (r *postRepository) CreateShare(share *model.PostShare) error {
 	return r.db.Create(share).Error
 }
 
-func (r *postRepository) FindSharesByPostID(postID uint64, limit, offset int) ([]model.PostShare, int64, error) {
+// FindSharesByPostID lấy lượt chia sẻ của bài đăng, bỏ các lượt chia sẻ của blockedIDs
+func (r *postRepository) FindSharesByPostID(postID uint64, blockedIDs []uint64, limit, offset int) ([]model.PostShare, int64, error) {
 	var shares []model.PostShare
 	var total int64
 
-	if err := r.db.Model(&model.PostShare{}).Where("post_id = ?", postID).Count(&total).Error; err != nil {
+	query := excludeUsers(r.db.Where("post_id = ?", postID), blockedIDs)
+	if err := query.Model(&model.PostShare{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	if err := r.db.Where("post_id = ?", postID).Order("created_at DESC").Limit(limit).Offset(offset).Find(&shares).Error; err != nil {
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&shares).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return shares, total, nil
 }
 
-func (r *postRepository) FindPostsByUserID(userID uint64, limit, offset int) ([]model.PostResponse, int64, error) {
+// FindPostsByUserID lấy bài đăng của người dùng mà người xem audience.ViewerID được xem, bài bị bộ lọc
+// ẩn chỉ hiện khi chính tác giả xem
+func (r *postRepository) FindPostsByUserID(userID uint64, audience model.ViewerAudience, limit, offset int) ([]model.PostResponse, int64, error) {
 	var posts []model.Post
 	var total int64
 
-	if err := r.db.Model(&model.Post{}).Where("user_id = ? AND is_deleted = false", userID).Count(&total).Error; err != nil {
+	query := r.db.Where("user_id = ? AND is_deleted = false AND is_hidden = false", userID)
+	if audience.ViewerID != userID {
+		query = query.Where("shadow_hidden = false")
+	}
+	query = filterAudience(query, audience)
+	query = filterGroups(query, audience)
+
+	if err := query.Model(&model.Post{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	if err := r.db.Preload("Media").Where("user_id = ? AND is_deleted = false", userID).
+	if err := query.Preload("Media", orderedMedia).
 		Order("created_at DESC").Limit(limit).Offset(offset).Find(&posts).Error; err != nil {
 		return nil, 0, err
 	}
@@ -294,21 +499,24 @@ func (r *postRepository) FindPostsByUserID(userID uint64, limit, offset int) ([]
 	for _, post := range posts {
 		var totalLikes, totalComments, totalShares int64
 		r.db.Model(&model.PostLike{}).Where("post_id = ?", post.ID).Count(&totalLikes)
-		r.db.Model(&model.Comment{}).Where("post_id = ? AND is_deleted = false", post.ID).Count(&totalComments)
+		r.db.Model(&model.Comment{}).Where("post_id = ? AND is_deleted = false AND is_hidden = false", post.ID).Count(&totalComments)
 		r.db.Model(&model.PostShare{}).Where("post_id = ?", post.ID).Count(&totalShares)
 
 		postResponses = append(postResponses, model.PostResponse{
-			ID:            post.ID,
-			UUID:          post.UUID,
-			UserID:        post.UserID,
-			Content:       post.Content,
-			Visibility:    post.Visibility,
-			CreatedAt:     post.CreatedAt,
-			UpdatedAt:     post.UpdatedAt,
-			Media:         post.Media,
-			TotalLikes:    int(totalLikes),
-			TotalComments: int(totalComments),
-			TotalShares:   int(totalShares),
+			ID:              post.ID,
+			UUID:            post.UUID,
+			UserID:          post.UserID,
+			Content:         post.Content,
+			Visibility:      post.Visibility,
+			GroupID:         post.GroupID,
+			AudienceListIDs: post.AudienceListIDs,
+			ExcludedListIDs: post.ExcludedListIDs,
+			CreatedAt:       post.CreatedAt,
+			UpdatedAt:       post.UpdatedAt,
+			Media:           post.Media,
+			TotalLikes:      int(totalLikes),
+			TotalComments:   int(totalComments),
+			TotalShares:     int(totalShares),
 		})
 	}
 
@@ -321,20 +529,20 @@ func (r *postRepository) DeletePostByUUID(uuid string) error {
 	if err := r.db.Where("uuid = ?", uuid).First(&post).Error; err != nil {
 		return err
 	}
-	return r.db.Model(&model.Post{}).Where("uuid = ?", uuid).Update("is_deleted", true).Error
+	return r.db.Model(&model.Post{}).Where("uuid = ?", uuid).Updates(softDeleteFields()).Error
 }
 
-func (r *postRepository) FindCommentsByPostUUID(uuid string, limit, offset int) ([]model.Comment, int64, error) {
+func (r *postRepository) FindCommentsByPostUUID(uuid string, viewerID uint64, blockedIDs []uint64, limit, offset int) ([]model.Comment, int64, error) {
 	var post model.Post
-	if err := r.db.Where("uuid = ? AND is_deleted = false", uuid).First(&post).Error; err != nil {
+	if err := r.db.Where("uuid = ? AND is_deleted = false AND is_hidden = false", uuid).First(&post).Error; err != nil {
 		return nil, 0, err
 	}
-	return r.FindCommentsByPostID(post.ID, limit, offset)
+	return r.FindCommentsByPostID(post.ID, viewerID, blockedIDs, limit, offset)
 }
 
 func (r *postRepository) CreatePostLikeByUUID(uuid string, userID uint64) error {
 	var post model.Post
-	if err := r.db.Where("uuid = ? AND is_deleted = false", uuid).First(&post).Error; err != nil {
+	if err := r.db.Where("uuid = ? AND is_deleted = false AND is_hidden = false", uuid).First(&post).Error; err != nil {
 		return err
 	}
 	return r.CreatePostLike(post.ID, userID)
@@ -342,7 +550,7 @@ func (r *postRepository) CreatePostLikeByUUID(uuid string, userID uint64) error
 
 func (r *postRepository) DeletePostLikeByUUID(uuid string, userID uint64) error {
 	var post model.Post
-	if err := r.db.Where("uuid = ? AND is_deleted = false", uuid).First(&post).Error; err != nil {
+	if err := r.db.Where("uuid = ? AND is_deleted = false AND is_hidden = false", uuid).First(&post).Error; err != nil {
 		return err
 	}
 	return r.DeletePostLike(post.ID, userID)
@@ -350,7 +558,7 @@ func (r *postRepository) DeletePostLikeByUUID(uuid string, userID uint64) error
 
 func (r *postRepository) CreateShareByUUID(uuid string, userID uint64, sharedContent string) error {
 	var post model.Post
-	if err := r.db.Where("uuid = ? AND is_deleted = false", uuid).First(&post).Error; err != nil {
+	if err := r.db.Where("uuid = ? AND is_deleted = false AND is_hidden = false", uuid).First(&post).Error; err != nil {
 		return err
 	}
 	share := &model.PostShare{
@@ -362,10 +570,10 @@ func (r *postRepository) CreateShareByUUID(uuid string, userID uint64, sharedCon
 	return r.db.Create(share).Error
 }
 
-func (r *postRepository) FindSharesByPostUUID(uuid string, limit, offset int) ([]model.PostShare, int64, error) {
+func (r *postRepository) FindSharesByPostUUID(uuid string, blockedIDs []uint64, limit, offset int) ([]model.PostShare, int64, error) {
 	var post model.Post
-	if err := r.db.Where("uuid = ? AND is_deleted = false", uuid).First(&post).Error; err != nil {
+	if err := r.db.Where("uuid = ? AND is_deleted = false AND is_hidden = false", uuid).First(&post).Error; err != nil {
 		return nil, 0, err
 	}
-	return r.FindSharesByPostID(post.ID, limit, offset)
+	return r.FindSharesByPostID(post.ID, blockedIDs, limit, offset)
 }
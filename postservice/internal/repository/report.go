@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"postservice/internal/model"
+
+	"github.com/jinzhu/gorm"
+)
+
+type ReportRepository interface {
+	Create(report *model.Report) error
+	Exists(targetType string, targetID, reporterID uint64) (bool, error)
+	FindByID(id uint64) (*model.Report, error)
+	List(status, targetType string, limit, offset int) ([]model.Report, int64, error)
+	FindByTarget(targetType string, targetID uint64) ([]model.Report, error)
+	CountOpenByTarget(targetType string, targetID uint64) (int, error)
+	FindTarget(targetType string, targetID uint64) (ownerID uint64, hidden bool, err error)
+	Reopen(targetType string, targetID, reporterID uint64, details string) error
+	ApplyDecision(action *model.ModerationAction, reportStatus string) error
+	ListActions(targetType string, targetID, moderatorID uint64, limit, offset int) ([]model.ModerationAction, int64, error)
+}
+
+type reportRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) ReportRepository {
+	return &reportRepository{db: db}
+}
+
+func (r *reportRepository) Create(report *model.Report) error {
+	return r.db.Create(report).Error
+}
+
+func (r *reportRepository) Exists(targetType string, targetID, reporterID uint64) (bool, error) {
+	var count int64
+	if err := r.db.Model(&model.Report{}).
+		Where("target_type = ? AND target_id = ? AND reporter_id = ?", targetType, targetID, reporterID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *reportRepository) FindByID(id uint64) (*model.Report, error) {
+	var report model.Report
+	if err := r.db.Where("id = ?", id).First(&report).Error; err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// List lấy hàng đợi báo cáo, lọc theo trạng thái/loại nội dung nếu có, báo cáo cũ nhất xếp trước
+func (r *reportRepository) List(status, targetType string, limit, offset int) ([]model.Report, int64, error) {
+	var reports []model.Report
+	var total int64
+
+	query := r.db.Model(&model.Report{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if targetType != "" {
+		query = query.Where("target_type = ?", targetType)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("created_at ASC").Limit(limit).Offset(offset).Find(&reports).Error; err != nil {
+		return nil, 0, err
+	}
+	return reports, total, nil
+}
+
+func (r *reportRepository) FindByTarget(targetType string, targetID uint64) ([]model.Report, error) {
+	var reports []model.Report
+	if err := r.db.Where("target_type = ? AND target_id = ?", targetType, targetID).
+		Order("created_at ASC").Find(&reports).Error; err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// CountOpenByTarget đếm số người khác nhau đang báo cáo một nội dung
+func (r *reportRepository) CountOpenByTarget(targetType string, targetID uint64) (int, error) {
+	var count int
+	if err := r.db.Model(&model.Report{}).
+		Where("target_type = ? AND target_id = ? AND status = ?", targetType, targetID, model.ReportStatusOpen).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Reopen mở lại báo cáo đã có của reporterID về nội dung với chi tiết mới
+func (r *reportRepository) Reopen(targetType string, targetID, reporterID uint64, details string) error {
+	return r.db.Model(&model.Report{}).
+		Where("target_type = ? AND target_id = ? AND reporter_id = ?", targetType, targetID, reporterID).
+		Updates(map[string]interface{}{
+			"status":      model.ReportStatusOpen,
+			"details":     details,
+			"resolved_by": nil,
+			"resolved_at": nil,
+			"updated_at":  time.Now(),
+		}).Error
+}
+
+// FindTarget lấy chủ sở hữu và trạng thái ẩn của nội dung bị báo cáo (kể cả khi đang bị ẩn)
+func (r *reportRepository) FindTarget(targetType string, targetID uint64) (uint64, bool, error) {
+	table, err := reportTargetTable(targetType)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var target struct {
+		UserID   uint64
+		IsHidden bool
+	}
+	if err := r.db.Table(table).Select("user_id, is_hidden").
+		Where("id = ? AND is_deleted = false", targetID).Scan(&target).Error; err != nil {
+		return 0, false, err
+	}
+	return target.UserID, target.IsHidden, nil
+}
+
+// hiddenByOpenReports kiểm tra nội dung đang bị ẩn do chính các báo cáo đang mở: tự động ẩn khi đủ số
+// báo cáo, hoặc bộ lọc nội dung giữ lại kèm báo cáo của hệ thống còn mở. Nội dung bị moderator ẩn
+// (hoặc không rõ nguồn ẩn) không được hiện lại khi bỏ qua báo cáo.
+func hiddenByOpenReports(tx *gorm.DB, targetType string, targetID uint64) (bool, error) {
+	var last model.ModerationAction
+	err := tx.Where("target_type = ? AND target_id = ? AND action IN (?)", targetType, targetID,
+		[]string{model.ModerationActionHide, model.ModerationActionAutoHide, model.ModerationActionAutoFilter}).
+		Order("id DESC").First(&last).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch last.Action {
+	case model.ModerationActionAutoHide:
+		return true, nil
+	case model.ModerationActionAutoFilter:
+		var count int
+		err := tx.Model(&model.Report{}).
+			Where("target_type = ? AND target_id = ? AND reporter_id = 0 AND status = ?", targetType, targetID, model.ReportStatusOpen).
+			Count(&count).Error
+		return count > 0, err
+	}
+	return false, nil
+}
+
+// ApplyDecision cập nhật nội dung theo hành động kiểm duyệt, chuyển các báo cáo đang mở sang
+// reportStatus (bỏ qua nếu rỗng) và ghi lại hành động, tất cả trong một transaction
+func (r *reportRepository) ApplyDecision(action *model.ModerationAction, reportStatus string) error {
+	table, err := reportTargetTable(action.TargetType)
+	if err != nil {
+		return err
+	}
+
+	tx := r.db.Begin()
+	target := tx.Table(table).Where("id = ?", action.TargetID)
+	switch action.Action {
+	case model.ModerationActionAutoHide, model.ModerationActionAutoFilter, model.ModerationActionHide:
+		err = target.Update("is_hidden", true).Error
+	case model.ModerationActionDismiss:
+		var unhide bool
+		if unhide, err = hiddenByOpenReports(tx, action.TargetType, action.TargetID); err == nil && unhide {
+			err = target.Update("is_hidden", false).Error
+		}
+	case model.ModerationActionDelete:
+		err = target.Updates(softDeleteFields()).Error
+	default:
+		err = errors.New("unsupported moderation action: " + action.Action)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if reportStatus != "" {
+		now := time.Now()
+		result := tx.Model(&model.Report{}).
+			Where("target_type = ? AND target_id = ? AND status = ?", action.TargetType, action.TargetID, model.ReportStatusOpen).
+			Updates(map[string]interface{}{
+				"status":      reportStatus,
+				"resolved_by": action.ModeratorID,
+				"resolved_at": now,
+				"updated_at":  now,
+			})
+		if result.Error != nil {
+			tx.Rollback()
+			return result.Error
+		}
+		action.ReportCount = int(result.RowsAffected)
+	}
+
+	if err := tx.Create(action).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// ListActions lấy nhật ký kiểm duyệt, lọc theo nội dung hoặc moderator nếu có, mới nhất trước
+func (r *reportRepository) ListActions(targetType string, targetID, moderatorID uint64, limit, offset int) ([]model.ModerationAction, int64, error) {
+	var actions []model.ModerationAction
+	var total int64
+
+	query := r.db.Model(&model.ModerationAction{})
+	if targetType != "" {
+		query = query.Where("target_type = ?", targetType)
+	}
+	if targetID != 0 {
+		query = query.Where("target_id = ?", targetID)
+	}
+	if moderatorID != 0 {
+		query = query.Where("moderator_id = ?", moderatorID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&actions).Error; err != nil {
+		return nil, 0, err
+	}
+	return actions, total, nil
+}
+
+// reportTargetTable trả về bảng chứa nội dung tương ứng với loại báo cáo
+func reportTargetTable(targetType string) (string, error) {
+	switch targetType {
+	case model.ReportTargetPost:
+		return "posts", nil
+	case model.ReportTargetComment:
+		return "comments", nil
+	default:
+		return "", errors.New("unsupported report target: " + targetType)
+	}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"time"
+
+	"postservice/internal/model"
+
+	"github.com/jinzhu/gorm"
+)
+
+type MediaUploadRepository interface {
+	Create(upload *model.MediaUpload) error
+	FindByID(id uint64) (*model.MediaUpload, error)
+	FindByIDs(ids []uint64) ([]model.MediaUpload, error)
+	Update(upload *model.MediaUpload) error
+	FindExpired(before time.Time, limit int) ([]model.MediaUpload, error)
+	UpdateStatus(id uint64, status string) error
+}
+
+type mediaUploadRepository struct {
+	db *gorm.DB
+}
+
+func NewMediaUploadRepository(db *gorm.DB) MediaUploadRepository {
+	return &mediaUploadRepository{db: db}
+}
+
+func (r *mediaUploadRepository) Create(upload *model.MediaUpload) error {
+	return r.db.Create(upload).Error
+}
+
+func (r *mediaUploadRepository) FindByID(id uint64) (*model.MediaUpload, error) {
+	var upload model.MediaUpload
+	if err := r.db.Where("id = ?", id).First(&upload).Error; err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+func (r *mediaUploadRepository) FindByIDs(ids []uint64) ([]model.MediaUpload, error) {
+	var uploads []model.MediaUpload
+	if len(ids) == 0 {
+		return uploads, nil
+	}
+	if err := r.db.Where("id IN (?)", ids).Find(&uploads).Error; err != nil {
+		return nil, err
+	}
+	return uploads, nil
+}
+
+func (r *mediaUploadRepository) Update(upload *model.MediaUpload) error {
+	return r.db.Save(upload).Error
+}
+
+// FindExpired lấy các upload chưa được gắn vào bài đăng và đã quá hạn
+func (r *mediaUploadRepository) FindExpired(before time.Time, limit int) ([]model.MediaUpload, error) {
+	var uploads []model.MediaUpload
+	err := r.db.Where("status IN (?) AND expires_at < ?",
+		[]string{model.UploadStatusPending, model.UploadStatusConfirmed}, before).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&uploads).Error
+	return uploads, err
+}
+
+func (r *mediaUploadRepository) UpdateStatus(id uint64, status string) error {
+	return r.db.Model(&model.MediaUpload{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     status,
+		"updated_at": time.Now(),
+	}).Error
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"errors"
+	"postservice/internal/model"
+	"postservice/internal/repository"
+	"time"
+)
+
+var (
+	ErrContentNotFound = errors.New("content not found")
+	ErrAlreadyDeleted  = errors.New("content is already deleted")
+	ErrNotDeleted      = errors.New("content is not deleted")
+	ErrRestoreExpired  = errors.New("restore window has passed, media of this content may have been removed")
+)
+
+// AdminService cho phép admin xóa (mềm/cứng) và khôi phục bất kỳ bài đăng, bình luận nào.
+// Mọi thao tác đều được ghi vào nhật ký quản trị.
+type AdminService interface {
+	DeletePost(adminID uint64, uuid string, hard bool, note string) (*model.AdminAuditLog, error)
+	RestorePost(adminID uint64, uuid string, note string) (*model.AdminAuditLog, error)
+	DeleteComment(adminID, commentID uint64, hard bool, note string) (*model.AdminAuditLog, error)
+	RestoreComment(adminID, commentID uint64, note string) (*model.AdminAuditLog, error)
+	ListAuditLogs(adminID uint64, targetType string, targetID uint64, limit, offset int) ([]model.AdminAuditLog, int64, error)
+}
+
+type adminService struct {
+	repo          repository.AdminRepository
+	restoreWindow time.Duration
+}
+
+// NewAdminService tạo service quản trị. Nội dung bị xóa mềm chỉ khôi phục được trong restoreWindow,
+// sau đó media của nó có thể đã bị job dọn media mồ côi xóa.
+func NewAdminService(repo repository.AdminRepository, restoreWindow time.Duration) AdminService {
+	return &adminService{repo: repo, restoreWindow: restoreWindow}
+}
+
+func (s *adminService) DeletePost(adminID uint64, uuid string, hard bool, note string) (*model.AdminAuditLog, error) {
+	postID, err := s.repo.FindPostIDByUUID(uuid)
+	if err != nil {
+		return nil, ErrContentNotFound
+	}
+	return s.delete(adminID, model.ReportTargetPost, postID, hard, note)
+}
+
+func (s *adminService) RestorePost(adminID uint64, uuid string, note string) (*model.AdminAuditLog, error) {
+	postID, err := s.repo.FindPostIDByUUID(uuid)
+	if err != nil {
+		return nil, ErrContentNotFound
+	}
+	return s.restore(adminID, model.ReportTargetPost, postID, note)
+}
+
+func (s *adminService) DeleteComment(adminID, commentID uint64, hard bool, note string) (*model.AdminAuditLog, error) {
+	return s.delete(adminID, model.ReportTargetComment, commentID, hard, note)
+}
+
+func (s *adminService) RestoreComment(adminID, commentID uint64, note string) (*model.AdminAuditLog, error) {
+	return s.restore(adminID, model.ReportTargetComment, commentID, note)
+}
+
+// delete xóa nội dung, xóa cứng được phép cả với nội dung đã bị xóa mềm trước đó
+func (s *adminService) delete(adminID uint64, targetType string, targetID uint64, hard bool, note string) (*model.AdminAuditLog, error) {
+	ownerID, _, deleted, err := s.repo.FindContent(targetType, targetID)
+	if err != nil {
+		return nil, ErrContentNotFound
+	}
+
+	action := model.AdminActionHardDelete
+	if !hard {
+		if deleted {
+			return nil, ErrAlreadyDeleted
+		}
+		action = model.AdminActionSoftDelete
+	}
+	return s.apply(adminID, action, targetType, targetID, ownerID, note)
+}
+
+func (s *adminService) restore(adminID uint64, targetType string, targetID uint64, note string) (*model.AdminAuditLog, error) {
+	ownerID, deletedAt, deleted, err := s.repo.FindContent(targetType, targetID)
+	if err != nil {
+		return nil, ErrContentNotFound
+	}
+	if !deleted {
+		return nil, ErrNotDeleted
+	}
+	if deletedAt != nil && time.Since(*deletedAt) > s.restoreWindow {
+		return nil, ErrRestoreExpired
+	}
+	return s.apply(adminID, model.AdminActionRestore, targetType, targetID, ownerID, note)
+}
+
+func (s *adminService) apply(adminID uint64, action, targetType string, targetID, ownerID uint64, note string) (*model.AdminAuditLog, error) {
+	entry := &model.AdminAuditLog{
+		AdminID:    adminID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		OwnerID:    ownerID,
+		Note:       note,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.repo.Apply(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *adminService) ListAuditLogs(adminID uint64, targetType string, targetID uint64, limit, offset int) ([]model.AdminAuditLog, int64, error) {
+	return s.repo.ListAuditLogs(adminID, targetType, targetID, limit, offset)
+}
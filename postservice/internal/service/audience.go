@@ -0,0 +1,99 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"postservice/internal/model"
+	"postservice/internal/util"
+)
+
+var (
+	// ErrInvalidAudience được trả về khi bài đăng CUSTOM tham chiếu danh sách bạn bè không thuộc về tác giả
+	ErrInvalidAudience = errors.New("audience lists must be friend lists you own")
+	// ErrNotInAudience được trả về khi người xem không thuộc đối tượng của bài đăng CUSTOM.
+	// Thông báo giống bài đăng không tồn tại để không lộ sự tồn tại của bài đăng.
+	ErrNotInAudience = errors.New("post not found")
+)
+
+// prepareAudience kiểm tra các danh sách bạn bè của bài đăng CUSTOM qua UserService.
+// Với các chế độ hiển thị khác, danh sách được xóa để không lưu dữ liệu thừa.
+func (s *postService) prepareAudience(userID uint64, req *model.CreatePostRequest) error {
+	if req.Visibility != model.VisibilityCustom {
+		req.AudienceListIDs = nil
+		req.ExcludedListIDs = nil
+		return nil
+	}
+
+	listIDs := append(append([]uint64{}, req.AudienceListIDs...), req.ExcludedListIDs...)
+	if len(listIDs) == 0 {
+		return nil
+	}
+	invalid, err := util.ValidateFriendLists(userID, listIDs)
+	if err != nil {
+		return fmt.Errorf("failed to validate audience lists: %w", err)
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidAudience, invalid)
+	}
+	return nil
+}
+
+// ensureCanView trả về ErrNotInAudience nếu viewerID không được xem bài đăng CUSTOM, hoặc bài đăng trong
+// nhóm mà viewerID không phải thành viên. Lỗi khi gọi UserService sẽ từ chối truy cập để không làm lộ
+// bài đăng riêng tư.
+func ensureCanView(viewerID uint64, post *model.PostResponse) error {
+	if post.UserID == viewerID {
+		return nil
+	}
+	if post.GroupID != nil {
+		if viewerID == 0 {
+			return ErrNotInAudience
+		}
+		isMember, _, err := util.CheckGroupPosting(*post.GroupID, viewerID)
+		if err != nil {
+			log.Printf("Failed to check membership of user %d in group %d: %v", viewerID, *post.GroupID, err)
+			return ErrNotInAudience
+		}
+		if !isMember {
+			return ErrNotInAudience
+		}
+	}
+	if post.Visibility != model.VisibilityCustom {
+		return nil
+	}
+	if viewerID == 0 {
+		return ErrNotInAudience
+	}
+
+	allowed, err := util.CheckAudiences(viewerID, []model.PostResponse{*post})
+	if err != nil {
+		log.Printf("Failed to check audience of post %d for user %d: %v", post.ID, viewerID, err)
+		return ErrNotInAudience
+	}
+	if !allowed[0] {
+		return ErrNotInAudience
+	}
+	return nil
+}
+
+// viewerAudience lấy bạn bè, các danh sách bạn bè và các nhóm chứa viewerID để lọc bài đăng CUSTOM và bài
+// đăng trong nhóm ngay trong truy vấn. Giống ensureCanView, lỗi khi gọi UserService thì người xem chỉ thấy
+// bài CUSTOM và bài trong nhóm của chính mình.
+func (s *postService) viewerAudience(viewerID uint64) model.ViewerAudience {
+	audience, err := util.FetchViewerAudience(viewerID)
+	if err != nil {
+		log.Printf("Failed to get audience of user %d: %v", viewerID, err)
+		return model.ViewerAudience{ViewerID: viewerID}
+	}
+	return audience
+}
+
+// hideAudienceLists xóa danh sách được xem và bị loại trừ khỏi bài đăng của người khác để không lộ
+// cách tác giả chia nhóm bạn bè
+func hideAudienceLists(viewerID uint64, post *model.PostResponse) {
+	if post.UserID != viewerID {
+		post.AudienceListIDs = nil
+		post.ExcludedListIDs = nil
+	}
+}
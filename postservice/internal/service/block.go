@@ -0,0 +1,37 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"postservice/internal/util"
+)
+
+var (
+	// ErrBlocked được trả về khi người dùng tương tác với nội dung của người đang chặn mình hoặc bị mình chặn
+	ErrBlocked = errors.New("cannot interact with this user's content")
+	// ErrBlockCheckUnavailable được trả về khi không kiểm tra được quan hệ chặn qua UserService
+	ErrBlockCheckUnavailable = errors.New("cannot verify block relations, try again later")
+)
+
+// ensureNotBlocked trả về ErrBlocked nếu userID và một trong các ownerIDs chặn nhau. Giống kiểm tra
+// đối tượng của bài đăng, không gọi được UserService thì từ chối tương tác.
+func ensureNotBlocked(blocks *util.BlockChecker, userID uint64, ownerIDs ...uint64) error {
+	blocked, err := blocks.BlockedUsers(userID, ownerIDs)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBlockCheckUnavailable, err)
+	}
+	if len(blocked) > 0 {
+		return ErrBlocked
+	}
+	return nil
+}
+
+// blockedUserIDs lấy những người có quan hệ chặn với viewerID để loại nội dung của họ ngay trong
+// truy vấn, nhờ vậy phân trang và tổng số không bị lệch. Không gọi được UserService thì trả lỗi.
+func (s *postService) blockedUserIDs(viewerID uint64) ([]uint64, error) {
+	ids, err := s.blocks.BlockedUserIDs(viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBlockCheckUnavailable, err)
+	}
+	return ids, nil
+}
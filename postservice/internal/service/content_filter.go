@@ -0,0 +1,291 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"postservice/internal/util"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// FilterAction là kết quả xử lý của bộ lọc nội dung, giá trị càng lớn càng nghiêm trọng
+type FilterAction int
+
+const (
+	FilterAllow      FilterAction = iota // Cho phép
+	FilterShadowHide                     // Lưu nhưng chỉ tác giả nhìn thấy
+	FilterReview                         // Lưu ở trạng thái ẩn và đưa vào hàng đợi kiểm duyệt
+	FilterReject                         // Từ chối, không lưu
+)
+
+// ErrContentRejected trả về khi nội dung bị bộ lọc từ chối
+var ErrContentRejected = errors.New("content rejected by filter")
+
+// ParseFilterAction đọc hành động từ cấu hình: allow, shadow_hide, review, reject
+func ParseFilterAction(value string) (FilterAction, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "allow":
+		return FilterAllow, nil
+	case "shadow_hide":
+		return FilterShadowHide, nil
+	case "review":
+		return FilterReview, nil
+	case "reject":
+		return FilterReject, nil
+	default:
+		return FilterAllow, fmt.Errorf("unknown filter action: %q", value)
+	}
+}
+
+func (a FilterAction) String() string {
+	switch a {
+	case FilterShadowHide:
+		return "shadow_hide"
+	case FilterReview:
+		return "review"
+	case FilterReject:
+		return "reject"
+	default:
+		return "allow"
+	}
+}
+
+// FilterResult là kết quả kiểm tra nội dung của một bộ lọc
+type FilterResult struct {
+	Action FilterAction
+	Rule   string // Tên bộ lọc đưa ra kết quả
+	Reason string
+}
+
+// ContentFilter kiểm tra nội dung bài đăng/bình luận trước khi lưu
+type ContentFilter interface {
+	Name() string
+	Check(content string) FilterResult
+}
+
+// FilterPipeline chạy lần lượt các bộ lọc và trả về kết quả nghiêm trọng nhất
+type FilterPipeline struct {
+	filters []ContentFilter
+}
+
+func NewFilterPipeline(filters ...ContentFilter) *FilterPipeline {
+	return &FilterPipeline{filters: filters}
+}
+
+func (p *FilterPipeline) Name() string {
+	return "pipeline"
+}
+
+// Check dừng ngay khi có bộ lọc từ chối nội dung
+func (p *FilterPipeline) Check(content string) FilterResult {
+	result := FilterResult{Action: FilterAllow}
+	for _, f := range p.filters {
+		r := f.Check(content)
+		if r.Action > result.Action {
+			result = r
+		}
+		if result.Action == FilterReject {
+			break
+		}
+	}
+	return result
+}
+
+// BannedWordFilter chặn các từ/cụm từ cấm, so khớp sau khi bỏ dấu tiếng Việt
+// để không lách được bằng cách viết có dấu/không dấu
+type BannedWordFilter struct {
+	words   map[string]bool
+	phrases []string
+	action  FilterAction
+}
+
+func NewBannedWordFilter(words []string, action FilterAction) *BannedWordFilter {
+	f := &BannedWordFilter{words: make(map[string]bool), action: action}
+	for _, w := range words {
+		tokens := util.NormalizeWords(w)
+		switch {
+		case len(tokens) == 1:
+			f.words[tokens[0]] = true
+		case len(tokens) > 1:
+			f.phrases = append(f.phrases, " "+strings.Join(tokens, " ")+" ")
+		}
+	}
+	return f
+}
+
+func (f *BannedWordFilter) Name() string {
+	return "banned_words"
+}
+
+func (f *BannedWordFilter) Check(content string) FilterResult {
+	tokens := util.NormalizeWords(content)
+	for _, t := range tokens {
+		if f.words[t] {
+			return FilterResult{Action: f.action, Rule: f.Name(), Reason: "contains banned word"}
+		}
+	}
+	if len(f.phrases) > 0 {
+		joined := " " + strings.Join(tokens, " ") + " "
+		for _, p := range f.phrases {
+			if strings.Contains(joined, p) {
+				return FilterResult{Action: f.action, Rule: f.Name(), Reason: "contains banned phrase"}
+			}
+		}
+	}
+	return FilterResult{Action: FilterAllow}
+}
+
+// linkPattern nhận diện link có hoặc không có scheme, vd: https://a.com/x, www.a.com, a.vn/x
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://)?(?:[a-z0-9-]+\.)+[a-z]{2,}(?:/[^\s]*)?`)
+
+// extractLinkHosts lấy host (viết thường, bỏ www.) của các link trong nội dung
+func extractLinkHosts(content string) []string {
+	var hosts []string
+	for _, link := range linkPattern.FindAllString(content, -1) {
+		if !strings.Contains(strings.ToLower(link), "://") {
+			link = "http://" + link
+		}
+		u, err := url.Parse(link)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		hosts = append(hosts, strings.TrimPrefix(strings.ToLower(u.Hostname()), "www."))
+	}
+	return hosts
+}
+
+// LinkBlocklistFilter chặn link tới các domain (và subdomain) trong danh sách
+type LinkBlocklistFilter struct {
+	domains []string
+	action  FilterAction
+}
+
+func NewLinkBlocklistFilter(domains []string, action FilterAction) *LinkBlocklistFilter {
+	f := &LinkBlocklistFilter{action: action}
+	for _, d := range domains {
+		d = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(d)), "www.")
+		if d != "" {
+			f.domains = append(f.domains, d)
+		}
+	}
+	return f
+}
+
+func (f *LinkBlocklistFilter) Name() string {
+	return "link_blocklist"
+}
+
+func (f *LinkBlocklistFilter) Check(content string) FilterResult {
+	for _, host := range extractLinkHosts(content) {
+		for _, d := range f.domains {
+			if host == d || strings.HasSuffix(host, "."+d) {
+				return FilterResult{Action: f.action, Rule: f.Name(), Reason: "links to blocked domain " + d}
+			}
+		}
+	}
+	return FilterResult{Action: FilterAllow}
+}
+
+// SpamFilterConfig cấu hình các ngưỡng nhận diện spam, giá trị <= 0 là tắt kiểm tra tương ứng
+type SpamFilterConfig struct {
+	MaxLinks          int     // Số link tối đa trong một nội dung
+	MaxLinkDensity    float64 // Tỉ lệ link/số từ tối đa (chỉ xét khi có từ 2 link)
+	MaxRepeatRatio    float64 // Tỉ lệ tối đa của từ xuất hiện nhiều nhất (chỉ xét khi có từ 8 từ)
+	MaxRepeatedChars  int     // Số ký tự giống nhau liên tiếp tối đa, vd: "!!!!!!!!"
+	MaxDuplicateLines float64 // Tỉ lệ dòng trùng lặp tối đa (chỉ xét khi có từ 3 dòng)
+}
+
+// SpamFilter nhận diện spam theo các heuristic: nhiều link, mật độ link cao, lặp từ/ký tự/dòng
+type SpamFilter struct {
+	cfg    SpamFilterConfig
+	action FilterAction
+}
+
+func NewSpamFilter(cfg SpamFilterConfig, action FilterAction) *SpamFilter {
+	return &SpamFilter{cfg: cfg, action: action}
+}
+
+func (f *SpamFilter) Name() string {
+	return "spam"
+}
+
+func (f *SpamFilter) Check(content string) FilterResult {
+	if reason := f.spamReason(content); reason != "" {
+		return FilterResult{Action: f.action, Rule: f.Name(), Reason: reason}
+	}
+	return FilterResult{Action: FilterAllow}
+}
+
+func (f *SpamFilter) spamReason(content string) string {
+	words := strings.Fields(content)
+	links := len(linkPattern.FindAllString(content, -1))
+
+	if f.cfg.MaxLinks > 0 && links > f.cfg.MaxLinks {
+		return fmt.Sprintf("too many links (%d)", links)
+	}
+	if f.cfg.MaxLinkDensity > 0 && links >= 2 && len(words) > 0 &&
+		float64(links)/float64(len(words)) > f.cfg.MaxLinkDensity {
+		return "link density too high"
+	}
+
+	if f.cfg.MaxRepeatRatio > 0 {
+		tokens := util.NormalizeWords(content)
+		if len(tokens) >= 8 {
+			counts := make(map[string]int)
+			maxCount := 0
+			for _, t := range tokens {
+				counts[t]++
+				if counts[t] > maxCount {
+					maxCount = counts[t]
+				}
+			}
+			if float64(maxCount)/float64(len(tokens)) > f.cfg.MaxRepeatRatio {
+				return "repeated words"
+			}
+		}
+	}
+
+	if f.cfg.MaxRepeatedChars > 0 && longestRuneRun(content) > f.cfg.MaxRepeatedChars {
+		return "repeated characters"
+	}
+
+	if f.cfg.MaxDuplicateLines > 0 {
+		var lines []string
+		for _, line := range strings.Split(content, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, strings.ToLower(line))
+			}
+		}
+		if len(lines) >= 3 {
+			unique := make(map[string]bool)
+			for _, line := range lines {
+				unique[line] = true
+			}
+			duplicates := len(lines) - len(unique)
+			if float64(duplicates)/float64(len(lines)) > f.cfg.MaxDuplicateLines {
+				return "repeated lines"
+			}
+		}
+	}
+	return ""
+}
+
+// longestRuneRun trả về độ dài chuỗi ký tự giống nhau liên tiếp dài nhất (bỏ qua khoảng trắng)
+func longestRuneRun(s string) int {
+	longest, run := 0, 0
+	var prev rune = utf8.RuneError
+	for _, r := range s {
+		if r == prev && r != ' ' && r != '\n' {
+			run++
+		} else {
+			run = 1
+		}
+		prev = r
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"postservice/internal/util"
+)
+
+var (
+	// ErrNotGroupMember được trả về khi đăng bài vào nhóm mà người đăng chưa là thành viên đã được duyệt
+	ErrNotGroupMember = errors.New("you are not a member of this group")
+	// ErrMutedInGroup được trả về khi người đăng đang bị tắt tiếng trong nhóm
+	ErrMutedInGroup = errors.New("you are muted in this group")
+)
+
+// ensureCanPostToGroup kiểm tra qua UserService userID là thành viên và không bị tắt tiếng trong nhóm
+// groupID. Bài đăng cá nhân (groupID nil) không cần kiểm tra, lỗi khi gọi UserService sẽ từ chối đăng.
+func ensureCanPostToGroup(userID uint64, groupID *uint64) error {
+	if groupID == nil {
+		return nil
+	}
+
+	isMember, isMuted, err := util.CheckGroupPosting(*groupID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check group membership: %w", err)
+	}
+	if !isMember {
+		return ErrNotGroupMember
+	}
+	if isMuted {
+		return ErrMutedInGroup
+	}
+	return nil
+}
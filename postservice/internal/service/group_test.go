@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"postservice/internal/grpcclient"
+	"postservice/internal/model"
+	"postservice/internal/repository"
+	pb "postservice/proto"
+
+	"google.golang.org/grpc"
+)
+
+// fakeUserService giả lập các RPC của UserService dùng khi đăng và xóa bài đăng trong nhóm
+type fakeUserService struct {
+	pb.UserServiceClient
+	members     map[uint64]bool
+	muted       map[uint64]bool
+	deleters    map[uint64]bool // Người có quyền delete_posts trong nhóm
+	unavailable bool
+}
+
+func (f *fakeUserService) CheckGroupPosting(ctx context.Context, in *pb.CheckGroupPostingRequest, opts ...grpc.CallOption) (*pb.CheckGroupPostingResponse, error) {
+	if f.unavailable {
+		return nil, errors.New("unavailable")
+	}
+	return &pb.CheckGroupPostingResponse{IsMember: f.members[in.UserId], IsMuted: f.muted[in.UserId]}, nil
+}
+
+func (f *fakeUserService) CheckGroupPermission(ctx context.Context, in *pb.CheckGroupPermissionRequest, opts ...grpc.CallOption) (*pb.CheckGroupPermissionResponse, error) {
+	if f.unavailable {
+		return nil, errors.New("unavailable")
+	}
+	return &pb.CheckGroupPermissionResponse{Allowed: in.Permission == groupPermissionDeletePosts && f.deleters[in.UserId]}, nil
+}
+
+func (f *fakeUserService) GetUsersByIDs(ctx context.Context, in *pb.GetUsersByIDsRequest, opts ...grpc.CallOption) (*pb.GetUsersByIDsResponse, error) {
+	return nil, errors.New("not needed in tests")
+}
+
+// fakePostRepo lưu bài đăng trong bộ nhớ
+type fakePostRepo struct {
+	repository.PostRepository
+	posts   map[uint64]*model.Post
+	deleted map[uint64]bool
+}
+
+func newFakePostRepo() *fakePostRepo {
+	return &fakePostRepo{posts: make(map[uint64]*model.Post), deleted: make(map[uint64]bool)}
+}
+
+func (r *fakePostRepo) CreatePost(post *model.Post, uploadIDs []uint64) error {
+	post.ID = uint64(len(r.posts) + 1)
+	r.posts[post.ID] = post
+	return nil
+}
+
+// UpdatePost thay cả bản ghi như Save, để kiểm tra service không làm mất trường nào khi sửa
+func (r *fakePostRepo) UpdatePost(post *model.Post, uploadIDs []uint64) error {
+	r.posts[post.ID] = post
+	return nil
+}
+
+func (r *fakePostRepo) FindByID(id uint64) (*model.PostResponse, error) {
+	post, ok := r.posts[id]
+	if !ok || r.deleted[id] {
+		return nil, errors.New("record not found")
+	}
+	return &model.PostResponse{ID: post.ID, UUID: post.UUID, UserID: post.UserID, GroupID: post.GroupID, Visibility: post.Visibility, Content: post.Content}, nil
+}
+
+func (r *fakePostRepo) FindByUUID(uuid string) (*model.PostResponse, error) {
+	for id, post := range r.posts {
+		if post.UUID == uuid {
+			return r.FindByID(id)
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (r *fakePostRepo) DeletePost(id uint64) error {
+	r.deleted[id] = true
+	return nil
+}
+
+// fakeUploads không có upload trực tiếp nào
+type fakeUploads struct {
+	UploadService
+}
+
+func (fakeUploads) ResolveForPost(userID uint64, ids []uint64) ([]model.MediaUpload, error) {
+	return nil, nil
+}
+
+const (
+	testGroupID   uint64 = 10
+	testAuthor    uint64 = 1
+	testModerator uint64 = 2
+	testMember    uint64 = 3
+	testOutsider  uint64 = 4
+)
+
+func newGroupTestService(t *testing.T, users *fakeUserService) (*postService, *fakePostRepo) {
+	t.Helper()
+	previous := grpcclient.UserServiceClient
+	grpcclient.UserServiceClient = users
+	t.Cleanup(func() { grpcclient.UserServiceClient = previous })
+
+	repo := newFakePostRepo()
+	return &postService{repo: repo, uploads: fakeUploads{}}, repo
+}
+
+func groupUsers() *fakeUserService {
+	return &fakeUserService{
+		members:  map[uint64]bool{testAuthor: true, testModerator: true, testMember: true},
+		muted:    map[uint64]bool{},
+		deleters: map[uint64]bool{testModerator: true},
+	}
+}
+
+func TestCreatePostInGroup(t *testing.T) {
+	groupID := testGroupID
+	tests := []struct {
+		name    string
+		userID  uint64
+		muted   bool
+		wantErr error
+	}{
+		{name: "member", userID: testMember},
+		{name: "not a member", userID: testOutsider, wantErr: ErrNotGroupMember},
+		{name: "muted member", userID: testMember, muted: true, wantErr: ErrMutedInGroup},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := groupUsers()
+			users.muted[testMember] = tt.muted
+			svc, repo := newGroupTestService(t, users)
+
+			resp, err := svc.CreatePost(tt.userID, model.CreatePostRequest{Content: "hello", Visibility: "PUBLIC", GroupID: &groupID}, nil)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CreatePost error = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.posts) != 0 {
+					t.Fatalf("post was stored despite error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreatePost: %v", err)
+			}
+			if resp.GroupID == nil || *resp.GroupID != groupID {
+				t.Fatalf("GroupID = %v, want %d", resp.GroupID, groupID)
+			}
+		})
+	}
+}
+
+func TestDeleteGroupPost(t *testing.T) {
+	tests := []struct {
+		name        string
+		inGroup     bool
+		deleterID   uint64
+		unavailable bool
+		wantDeleted bool
+	}{
+		{name: "author", inGroup: true, deleterID: testAuthor, wantDeleted: true},
+		{name: "moderator with delete_posts", inGroup: true, deleterID: testModerator, wantDeleted: true},
+		{name: "member without delete_posts", inGroup: true, deleterID: testMember},
+		{name: "moderator on personal post", deleterID: testModerator},
+		{name: "user service unavailable", inGroup: true, deleterID: testModerator, unavailable: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := groupUsers()
+			svc, repo := newGroupTestService(t, users)
+
+			req := model.CreatePostRequest{Content: "hello", Visibility: "PUBLIC"}
+			if tt.inGroup {
+				groupID := testGroupID
+				req.GroupID = &groupID
+			}
+			post, err := svc.CreatePost(testAuthor, req, nil)
+			if err != nil {
+				t.Fatalf("CreatePost: %v", err)
+			}
+
+			users.unavailable = tt.unavailable
+			err = svc.DeletePost(post.ID, tt.deleterID)
+			if tt.wantDeleted && err != nil {
+				t.Fatalf("DeletePost: %v", err)
+			}
+			if !tt.wantDeleted && err == nil {
+				t.Fatalf("DeletePost by user %d should be forbidden", tt.deleterID)
+			}
+			if repo.deleted[post.ID] != tt.wantDeleted {
+				t.Fatalf("deleted = %v, want %v", repo.deleted[post.ID], tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestUpdateGroupPostKeepsGroup(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(svc *postService, post *model.PostResponse, req model.CreatePostRequest) (*model.PostResponse, error)
+	}{
+		{name: "by id", update: func(svc *postService, post *model.PostResponse, req model.CreatePostRequest) (*model.PostResponse, error) {
+			return svc.UpdatePost(post.ID, testAuthor, req, nil)
+		}},
+		{name: "by uuid", update: func(svc *postService, post *model.PostResponse, req model.CreatePostRequest) (*model.PostResponse, error) {
+			return svc.UpdatePostByUUID(post.UUID, testAuthor, req, nil)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newGroupTestService(t, groupUsers())
+
+			groupID := testGroupID
+			post, err := svc.CreatePost(testAuthor, model.CreatePostRequest{Content: "hello", Visibility: "PUBLIC", GroupID: &groupID}, nil)
+			if err != nil {
+				t.Fatalf("CreatePost: %v", err)
+			}
+
+			updated, err := tt.update(svc, post, model.CreatePostRequest{Content: "edited", Visibility: "PUBLIC"})
+			if err != nil {
+				t.Fatalf("update: %v", err)
+			}
+			if updated.Content != "edited" {
+				t.Fatalf("Content = %q, want %q", updated.Content, "edited")
+			}
+
+			stored := repo.posts[post.ID]
+			if stored.GroupID == nil || *stored.GroupID != groupID {
+				t.Fatalf("GroupID after update = %v, want %d", stored.GroupID, groupID)
+			}
+			if stored.UUID != post.UUID {
+				t.Fatalf("UUID after update = %q, want %q", stored.UUID, post.UUID)
+			}
+		})
+	}
+}
+
+func TestViewGroupPost(t *testing.T) {
+	tests := []struct {
+		name        string
+		viewerID    uint64
+		unavailable bool
+		wantErr     error
+	}{
+		{name: "author", viewerID: testAuthor},
+		{name: "member", viewerID: testMember},
+		{name: "not a member", viewerID: testOutsider, wantErr: ErrNotInAudience},
+		{name: "anonymous", viewerID: 0, wantErr: ErrNotInAudience},
+		{name: "user service unavailable", viewerID: testMember, unavailable: true, wantErr: ErrNotInAudience},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := groupUsers()
+			svc, _ := newGroupTestService(t, users)
+
+			groupID := testGroupID
+			post, err := svc.CreatePost(testAuthor, model.CreatePostRequest{Content: "hello", Visibility: "PUBLIC", GroupID: &groupID}, nil)
+			if err != nil {
+				t.Fatalf("CreatePost: %v", err)
+			}
+
+			users.unavailable = tt.unavailable
+			_, err = svc.GetPostByID(post.ID, tt.viewerID)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("GetPostByID: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetPostByID error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
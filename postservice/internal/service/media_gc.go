@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"postservice/internal/model"
+	"postservice/internal/repository"
+	"postservice/internal/util"
+	"time"
+)
+
+// mediaGCScope là một vùng trên storage mà job dọn media quét qua
+type mediaGCScope struct {
+	prefix       string
+	resourceType string
+}
+
+// Các folder chứa media của PostService và UserService
+var mediaGCScopes = []mediaGCScope{
+	{prefix: "posts/", resourceType: "image"},
+	{prefix: "posts/", resourceType: "video"},
+	{prefix: "users/", resourceType: "image"},
+}
+
+type MediaGCService interface {
+	Run(dryRun bool) (*model.MediaGCReport, error)
+	StartSchedule(ctx context.Context, interval time.Duration, dryRun bool)
+}
+
+type mediaGCService struct {
+	refRepo            repository.MediaReferenceRepository
+	cloudinaryUploader *util.CloudinaryUploader
+	gracePeriod        time.Duration
+	restoreWindow      time.Duration
+	maxDeletes         int
+}
+
+// NewMediaGCService tạo job dọn media mồ côi. File mới hơn gracePeriod sẽ không bị xét, media của nội
+// dung bị xóa mềm được giữ lại trong restoreWindow để còn khôi phục được, mỗi lần chạy xóa tối đa
+// maxDeletes file (<= 0 là không giới hạn).
+func NewMediaGCService(refRepo repository.MediaReferenceRepository, gracePeriod, restoreWindow time.Duration, maxDeletes int) MediaGCService {
+	uploader, err := util.NewCloudinaryUploader()
+	if err != nil {
+		log.Fatalf("Failed to initialize Cloudinary uploader: %v", err)
+	}
+
+	return &mediaGCService{
+		refRepo:            refRepo,
+		cloudinaryUploader: uploader,
+		gracePeriod:        gracePeriod,
+		restoreWindow:      restoreWindow,
+		maxDeletes:         maxDeletes,
+	}
+}
+
+// Run so sánh các file trên storage với các URL đang được tham chiếu và xóa file mồ côi.
+// Khi dryRun = true chỉ trả về báo cáo, không xóa gì.
+func (s *mediaGCService) Run(dryRun bool) (*model.MediaGCReport, error) {
+	report := &model.MediaGCReport{
+		StartedAt:   time.Now(),
+		DryRun:      dryRun,
+		GracePeriod: s.gracePeriod.String(),
+		Orphans:     []model.OrphanMedia{},
+	}
+
+	// Thu thập tham chiếu trước, nếu thiếu bất kỳ nguồn nào thì dừng để tránh xóa nhầm
+	referenced, err := s.collectReferences()
+	if err != nil {
+		return nil, err
+	}
+	report.ReferencedCount = len(referenced)
+
+	cutoff := report.StartedAt.Add(-s.gracePeriod)
+	for _, scope := range mediaGCScopes {
+		assets, err := s.cloudinaryUploader.ListAssets(scope.prefix, scope.resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, asset := range assets {
+			report.ScannedAssets++
+			if referenced[asset.PublicID] {
+				continue
+			}
+			if asset.CreatedAt.After(cutoff) {
+				report.SkippedRecent++
+				continue
+			}
+
+			orphan := model.OrphanMedia{
+				PublicID:     asset.PublicID,
+				URL:          asset.SecureURL,
+				ResourceType: scope.resourceType,
+				Bytes:        asset.Bytes,
+				CreatedAt:    asset.CreatedAt,
+			}
+			report.OrphanCount++
+			report.OrphanBytes += asset.Bytes
+
+			if !dryRun && (s.maxDeletes <= 0 || report.DeletedCount < s.maxDeletes) {
+				if err := s.cloudinaryUploader.DeleteByPublicID(asset.PublicID, scope.resourceType); err != nil {
+					log.Printf("Failed to delete orphan media %s: %v", asset.PublicID, err)
+					orphan.Error = err.Error()
+					report.FailedCount++
+				} else {
+					orphan.Deleted = true
+					report.DeletedCount++
+				}
+			}
+			report.Orphans = append(report.Orphans, orphan)
+		}
+	}
+
+	report.FinishedAt = time.Now()
+	log.Printf("Media GC finished (dry_run=%t): scanned=%d orphans=%d deleted=%d failed=%d",
+		dryRun, report.ScannedAssets, report.OrphanCount, report.DeletedCount, report.FailedCount)
+	return report, nil
+}
+
+// StartSchedule chạy Run định kỳ cho tới khi ctx bị hủy
+func (s *mediaGCService) StartSchedule(ctx context.Context, interval time.Duration, dryRun bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Media GC schedule stopped")
+			return
+		case <-ticker.C:
+			if _, err := s.Run(dryRun); err != nil {
+				log.Printf("Media GC failed: %v", err)
+			}
+		}
+	}
+}
+
+// collectReferences gom publicID của mọi media đang được PostService và UserService sử dụng
+func (s *mediaGCService) collectReferences() (map[string]bool, error) {
+	referenced := make(map[string]bool)
+	addURLs := func(urls []string) {
+		for _, url := range urls {
+			if publicID := util.PublicIDFromURL(url); publicID != "" {
+				referenced[publicID] = true
+			}
+		}
+	}
+
+	urls, err := s.refRepo.ListReferencedURLs(time.Now().Add(-s.restoreWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list post media references: %v", err)
+	}
+	addURLs(urls)
+
+	publicIDs, err := s.refRepo.ListPendingUploadPublicIDs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending uploads: %v", err)
+	}
+	for _, publicID := range publicIDs {
+		referenced[publicID] = true
+	}
+
+	for _, source := range []string{"users", "groups"} {
+		urls, err := util.ListMediaReferences(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s media references from UserService: %v", source, err)
+		}
+		addURLs(urls)
+	}
+
+	return referenced, nil
+}
@@ -13,8 +13,8 @@ import (
 )
 
 type PostService interface {
-	GetPostByID(id uint64) (*model.PostResponse, error)
-	GetPostByUUID(uuid string) (*model.PostResponse, error)
+	GetPostByID(id, viewerID uint64) (*model.PostResponse, error)
+	GetPostByUUID(uuid string, viewerID uint64) (*model.PostResponse, error)
 	CreatePost(userID uint64, req model.CreatePostRequest, files []interface{}) (*model.PostResponse, error)
 	UpdatePost(id uint64, userID uint64, req model.CreatePostRequest, files []interface{}) (*model.PostResponse, error)
 	UpdatePostByUUID(uuid string, userID uint64, req model.CreatePostRequest, files []interface{}) (*model.PostResponse, error)
@@ -23,8 +23,8 @@ type PostService interface {
 	CreateComment(postID, userID uint64, content string, parentID *uint64, files []interface{}) (*model.Comment, error)
 	CreateCommentByUUID(uuid string, userID uint64, content string, parentID *uint64, files []interface{}) (*model.Comment, error)
 	UpdateComment(id uint64, userID uint64, content string) (*model.Comment, error)
-	GetCommentsByPostID(postID uint64, limit, offset int) ([]model.Comment, int64, error)
-	GetCommentsByPostUUID(uuid string, limit, offset int) ([]model.Comment, int64, error)
+	GetCommentsByPostID(postID, viewerID uint64, limit, offset int) ([]model.Comment, int64, error)
+	GetCommentsByPostUUID(uuid string, viewerID uint64, limit, offset int) ([]model.Comment, int64, error)
 	DeleteComment(id uint64, userID uint64) error
 	LikePost(postID, userID uint64) error
 	LikePostByUUID(uuid string, userID uint64) error
@@ -34,20 +34,30 @@ type PostService interface {
 	UnlikeComment(commentID, userID uint64) error
 	SharePost(postID, userID uint64, content string) (*model.PostShare, error)
 	SharePostByUUID(uuid string, userID uint64, content string) (*model.PostShare, error)
-	GetSharesByPostID(postID uint64, limit, offset int) ([]model.PostShare, int64, error)
-	GetSharesByPostUUID(uuid string, limit, offset int) ([]model.PostShare, int64, error)
-	GetPostsByUserID(userID uint64, limit, offset int) ([]model.PostResponse, int64, error)
-	GetPostsByUsername(username string, limit, offset int) ([]model.PostResponse, int64, error)
+	GetSharesByPostID(postID, viewerID uint64, limit, offset int) ([]model.PostShare, int64, error)
+	GetSharesByPostUUID(uuid string, viewerID uint64, limit, offset int) ([]model.PostShare, int64, error)
+	GetPostsByUserID(userID, viewerID uint64, limit, offset int) ([]model.PostResponse, int64, error)
+	GetPostsByUsername(username string, viewerID uint64, limit, offset int) ([]model.PostResponse, int64, error)
 	GetCommentByID(id uint64) (*model.Comment, error)
 	GetFeed(userID uint64, mode string, limit, offset int) ([]model.PostResponse, int64, error)
+	UpdatePostMedia(uuid string, mediaID, userID uint64, req model.UpdateMediaRequest) (*model.PostMedia, error)
+	DeletePostMedia(uuid string, mediaID, userID uint64) error
+	ReorderPostMedia(uuid string, userID uint64, ids []uint64) (*model.PostResponse, error)
 }
 
 type postService struct {
 	repo               repository.PostRepository
+	uploads            UploadService
+	contentFilter      ContentFilter
+	reports            ReportService
+	blocks             *util.BlockChecker
 	cloudinaryUploader *util.CloudinaryUploader
 }
 
-func NewPostService(repo repository.PostRepository) PostService {
+// NewPostService tạo service bài đăng. contentFilter kiểm tra nội dung bài đăng/bình luận khi tạo
+// và sửa (nil là không lọc), nội dung bị giữ lại được đưa vào hàng đợi kiểm duyệt qua reports.
+// blocks dùng để ẩn nội dung và chặn tương tác giữa những người chặn nhau (nil là không kiểm tra).
+func NewPostService(repo repository.PostRepository, uploads UploadService, contentFilter ContentFilter, reports ReportService, blocks *util.BlockChecker) PostService {
 	uploader, err := util.NewCloudinaryUploader()
 	if err != nil {
 		log.Fatalf("Failed to initialize Cloudinary uploader: %v", err)
@@ -55,33 +65,59 @@ func NewPostService(repo repository.PostRepository) PostService {
 
 	return &postService{
 		repo:               repo,
+		uploads:            uploads,
+		contentFilter:      contentFilter,
+		reports:            reports,
+		blocks:             blocks,
 		cloudinaryUploader: uploader,
 	}
 }
 
 func (s *postService) CreatePost(userID uint64, req model.CreatePostRequest, files []interface{}) (*model.PostResponse, error) {
+	if err := validateMediaTexts(req.MediaAltTexts, req.MediaCaptions); err != nil {
+		return nil, err
+	}
+	if err := s.prepareAudience(userID, &req); err != nil {
+		return nil, err
+	}
+	if err := ensureCanPostToGroup(userID, req.GroupID); err != nil {
+		return nil, err
+	}
+
+	// Lọc nội dung trước khi upload để không sinh file mồ côi khi bị từ chối
+	verdict, err := s.checkContent(req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	// Kiểm tra các upload trực tiếp trước khi upload file qua server
+	uploads, err := s.uploads.ResolveForPost(userID, req.MediaIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	post := &model.Post{
-		UserID:     userID,
-		UUID:       uuid.New().String(),
-		Content:    req.Content,
-		Visibility: req.Visibility,
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+		UserID:          userID,
+		UUID:            uuid.New().String(),
+		Content:         req.Content,
+		Visibility:      req.Visibility,
+		GroupID:         req.GroupID,
+		AudienceListIDs: req.AudienceListIDs,
+		ExcludedListIDs: req.ExcludedListIDs,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+
+		IsHidden:     verdict.Action == FilterReview,
+		ShadowHidden: verdict.Action == FilterShadowHide,
 	}
 
 	// Upload files lên Cloudinary nếu có
 	if len(files) > 0 {
-		urls, err := s.cloudinaryUploader.UploadImages(files)
+		images, err := s.cloudinaryUploader.UploadImages(files)
 		if err != nil {
-			return nil, errors.New("failed to upload images: " + err.Error())
-		}
-		for _, url := range urls {
-			post.Media = append(post.Media, model.PostMedia{
-				MediaURL:  url,
-				MediaType: "IMAGE",
-				CreatedAt: time.Now(),
-			})
+			return nil, fmt.Errorf("failed to upload images: %w", err)
 		}
+		post.Media = append(post.Media, mediaFromImages(images)...)
 	}
 
 	// Nếu không có file nhưng có MediaURLs từ request, dùng nó
@@ -95,12 +131,24 @@ func (s *postService) CreatePost(userID uint64, req model.CreatePostRequest, fil
 		}
 	}
 
-	if err := s.repo.CreatePost(post); err != nil {
-		return nil, err
+	// Gắn các media đã upload trực tiếp lên storage
+	post.Media = append(post.Media, mediaFromUploads(uploads)...)
+	if len(post.Media) > maxMediaPerPost {
+		return nil, fmt.Errorf("maximum of %d media allowed, got %d", maxMediaPerPost, len(post.Media))
 	}
+	applyMediaText(post.Media, req.MediaAltTexts, req.MediaCaptions)
+	assignPositions(post.Media)
 
-	resp, err := s.repo.FindByID(post.ID)
-	if err != nil {
+	if err := s.repo.CreatePost(post, uploadIDs(uploads)); err != nil {
+		return nil, attachError(err)
+	}
+
+	var resp *model.PostResponse
+	if post.IsHidden {
+		// Bài đăng bị giữ lại không đọc lại được qua FindByID
+		s.holdForReview(model.ReportTargetPost, post.ID, verdict)
+		resp = pendingPostResponse(post)
+	} else if resp, err = s.repo.FindByID(post.ID); err != nil {
 		return nil, err
 	}
 
@@ -114,11 +162,18 @@ func (s *postService) CreatePost(userID uint64, req model.CreatePostRequest, fil
 }
 
 // Các method khác giữ nguyên
-func (s *postService) GetPostByID(id uint64) (*model.PostResponse, error) {
+func (s *postService) GetPostByID(id, viewerID uint64) (*model.PostResponse, error) {
 	post, err := s.repo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
+	if err := ensureNotBlocked(s.blocks, viewerID, post.UserID); err != nil {
+		return nil, err
+	}
+	if err := ensureCanView(viewerID, post); err != nil {
+		return nil, err
+	}
+	hideAudienceLists(viewerID, post)
 	result, err := util.PopulateSingleUserInfo(*post, post.UserID)
 	if err != nil {
 		return post, nil
@@ -135,16 +190,31 @@ func (s *postService) UpdatePost(id uint64, userID uint64, req model.CreatePostR
 	if postResp.UserID != userID {
 		return nil, errors.New("forbidden")
 	}
+	if err := s.prepareAudience(userID, &req); err != nil {
+		return nil, err
+	}
+
+	verdict, err := s.checkContent(req.Content)
+	if err != nil {
+		return nil, err
+	}
 
 	// Tạo đối tượng post mới để cập nhật
 	post := &model.Post{
-		ID:         id,
-		UserID:     postResp.UserID,
-		Content:    req.Content,
-		Visibility: req.Visibility,
-		CreatedAt:  postResp.CreatedAt,
-		UpdatedAt:  time.Now(),
-		IsDeleted:  false,
+		ID:              id,
+		UUID:            postResp.UUID,
+		UserID:          postResp.UserID,
+		GroupID:         postResp.GroupID,
+		Content:         req.Content,
+		Visibility:      req.Visibility,
+		AudienceListIDs: req.AudienceListIDs,
+		ExcludedListIDs: req.ExcludedListIDs,
+		CreatedAt:       postResp.CreatedAt,
+		UpdatedAt:       time.Now(),
+		IsDeleted:       false,
+
+		IsHidden:     verdict.Action == FilterReview,
+		ShadowHidden: verdict.Action == FilterShadowHide,
 	}
 
 	// Lấy danh sách media hiện tại
@@ -154,7 +224,7 @@ func (s *postService) UpdatePost(id uint64, userID uint64, req model.CreatePostR
 	if len(files) > 0 {
 		// Xóa toàn bộ media cũ trên Cloudinary
 		for _, oldMedia := range currentMedia {
-			if err := s.cloudinaryUploader.DeleteImage(oldMedia.MediaURL); err != nil {
+			if err := s.cloudinaryUploader.DeleteImageWithVariants(oldMedia.MediaURL, oldMedia.Variants); err != nil {
 				log.Printf("Failed to delete old image %s from Cloudinary: %v", oldMedia.MediaURL, err)
 				// Tiếp tục xử lý dù có lỗi xóa Cloudinary, không return lỗi
 			}
@@ -167,23 +237,17 @@ func (s *postService) UpdatePost(id uint64, userID uint64, req model.CreatePostR
 		}
 
 		// Upload ảnh mới lên Cloudinary
-		urls, err := s.cloudinaryUploader.UploadImages(files)
+		images, err := s.cloudinaryUploader.UploadImages(files)
 		if err != nil {
-			return nil, errors.New("failed to upload new images: " + err.Error())
-		}
-		for _, url := range urls {
-			post.Media = append(post.Media, model.PostMedia{
-				MediaURL:  url,
-				MediaType: "IMAGE",
-				CreatedAt: time.Now(),
-			})
+			return nil, fmt.Errorf("failed to upload new images: %w", err)
 		}
+		post.Media = append(post.Media, mediaFromImages(images)...)
 	} else {
 		// Nếu không có file mới, giữ nguyên media cũ hoặc dùng MediaURLs từ request
 		if len(req.MediaURLs) > 0 {
 			// Xóa media cũ trên Cloudinary nếu có MediaURLs mới
 			for _, oldMedia := range currentMedia {
-				if err := s.cloudinaryUploader.DeleteImage(oldMedia.MediaURL); err != nil {
+				if err := s.cloudinaryUploader.DeleteImageWithVariants(oldMedia.MediaURL, oldMedia.Variants); err != nil {
 					log.Printf("Failed to delete old image %s from Cloudinary: %v", oldMedia.MediaURL, err)
 				}
 			}
@@ -209,13 +273,16 @@ func (s *postService) UpdatePost(id uint64, userID uint64, req model.CreatePostR
 	}
 
 	// Cập nhật bài đăng trong database
-	if err := s.repo.UpdatePost(post); err != nil {
+	if err := s.repo.UpdatePost(post, nil); err != nil {
 		return nil, err
 	}
 
 	// Lấy lại bài đăng đã cập nhật
-	resp, err := s.repo.FindByID(id)
-	if err != nil {
+	var resp *model.PostResponse
+	if post.IsHidden {
+		s.holdForReview(model.ReportTargetPost, post.ID, verdict)
+		resp = pendingPostResponse(post)
+	} else if resp, err = s.repo.FindByID(id); err != nil {
 		return nil, err
 	}
 
@@ -234,14 +301,63 @@ func (s *postService) DeletePost(id uint64, userID uint64) error {
 	if err != nil {
 		return err
 	}
-	if post.UserID != userID {
-		return errors.New("forbidden")
+	if err := ensureCanDelete(post, userID); err != nil {
+		return err
 	}
 	return s.repo.DeletePost(id)
 }
 
+// groupPermissionDeletePosts là quyền trong nhóm (do UserService quản lý) cho phép xóa bài đăng của thành viên khác
+const groupPermissionDeletePosts = "delete_posts"
+
+// ensureCanDelete cho phép tác giả xóa bài đăng của mình, và người có quyền delete_posts trong nhóm
+// xóa bài đăng trong nhóm. Lỗi khi gọi UserService sẽ từ chối xóa.
+func ensureCanDelete(post *model.PostResponse, userID uint64) error {
+	if post.UserID == userID {
+		return nil
+	}
+	if post.GroupID == nil {
+		return errors.New("forbidden")
+	}
+
+	allowed, err := util.HasGroupPermission(*post.GroupID, userID, groupPermissionDeletePosts)
+	if err != nil {
+		log.Printf("Failed to check delete permission of user %d in group %d: %v", userID, *post.GroupID, err)
+		return errors.New("forbidden")
+	}
+	if !allowed {
+		return errors.New("forbidden")
+	}
+	return nil
+}
+
 // CreateComment (cập nhật để hỗ trợ 1 ảnh)
 func (s *postService) CreateComment(postID, userID uint64, content string, parentID *uint64, files []interface{}) (*model.Comment, error) {
+	// Không bình luận được vào bài đăng, hoặc trả lời bình luận, của người có quan hệ chặn
+	post, err := s.repo.FindByID(postID)
+	if err != nil {
+		return nil, err
+	}
+	ownerIDs := []uint64{post.UserID}
+	if parentID != nil {
+		var parent model.Comment
+		if err := s.repo.FindCommentByID(*parentID, &parent); err != nil {
+			return nil, err
+		}
+		ownerIDs = append(ownerIDs, parent.UserID)
+	}
+	if err := ensureNotBlocked(s.blocks, userID, ownerIDs...); err != nil {
+		return nil, err
+	}
+	if err := ensureCanView(userID, post); err != nil {
+		return nil, err
+	}
+
+	verdict, err := s.checkContent(content)
+	if err != nil {
+		return nil, err
+	}
+
 	comment := &model.Comment{
 		PostID:          postID,
 		UserID:          userID,
@@ -249,6 +365,9 @@ func (s *postService) CreateComment(postID, userID uint64, content string, paren
 		Content:         content,
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
+
+		IsHidden:     verdict.Action == FilterReview,
+		ShadowHidden: verdict.Action == FilterShadowHide,
 	}
 
 	// Xử lý ảnh cho bình luận
@@ -256,16 +375,20 @@ func (s *postService) CreateComment(postID, userID uint64, content string, paren
 		if len(files) > 1 {
 			return nil, errors.New("maximum of 1 image allowed for comment")
 		}
-		urls, err := s.cloudinaryUploader.UploadImages(files)
+		// Ảnh bình luận chỉ cần bản đã xử lý (xóa metadata, giới hạn kích thước), không sinh variant
+		url, err := s.cloudinaryUploader.UploadImage(files[0], fmt.Sprintf("comment_image_%d", time.Now().UnixNano()))
 		if err != nil {
-			return nil, errors.New("failed to upload comment image: " + err.Error())
+			return nil, fmt.Errorf("failed to upload comment image: %w", err)
 		}
-		comment.MediaURL = &urls[0] // Chỉ lấy URL đầu tiên
+		comment.MediaURL = &url
 	}
 
 	if err := s.repo.CreateComment(comment); err != nil {
 		return nil, err
 	}
+	if comment.IsHidden {
+		s.holdForReview(model.ReportTargetComment, comment.ID, verdict)
+	}
 
 	result, err := util.PopulateSingleUserInfo(*comment, userID)
 	if err != nil {
@@ -282,11 +405,20 @@ func (s *postService) UpdateComment(id uint64, userID uint64, content string) (*
 	if comment.UserID != userID {
 		return nil, errors.New("forbidden")
 	}
+	verdict, err := s.checkContent(content)
+	if err != nil {
+		return nil, err
+	}
 	comment.Content = content
 	comment.UpdatedAt = time.Now()
+	comment.IsHidden = verdict.Action == FilterReview
+	comment.ShadowHidden = verdict.Action == FilterShadowHide
 	if err := s.repo.UpdateComment(comment); err != nil {
 		return nil, err
 	}
+	if comment.IsHidden {
+		s.holdForReview(model.ReportTargetComment, comment.ID, verdict)
+	}
 	result, err := util.PopulateSingleUserInfo(*comment, userID)
 	if err != nil {
 		return comment, nil
@@ -294,8 +426,25 @@ func (s *postService) UpdateComment(id uint64, userID uint64, content string) (*
 	return &result, nil
 }
 
-func (s *postService) GetCommentsByPostID(postID uint64, limit, offset int) ([]model.Comment, int64, error) {
-	comments, total, err := s.repo.FindCommentsByPostID(postID, limit, offset)
+func (s *postService) GetCommentsByPostID(postID, viewerID uint64, limit, offset int) ([]model.Comment, int64, error) {
+	// Người có quan hệ chặn với tác giả và người không thuộc đối tượng của bài đăng không xem được bình luận
+	// và lượt chia sẻ
+	post, err := s.repo.FindByID(postID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := ensureNotBlocked(s.blocks, viewerID, post.UserID); err != nil {
+		return nil, 0, err
+	}
+	if err := ensureCanView(viewerID, post); err != nil {
+		return nil, 0, err
+	}
+
+	blockedIDs, err := s.blockedUserIDs(viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	comments, total, err := s.repo.FindCommentsByPostID(postID, viewerID, blockedIDs, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -318,6 +467,16 @@ func (s *postService) DeleteComment(id uint64, userID uint64) error {
 }
 
 func (s *postService) LikePost(postID, userID uint64) error {
+	post, err := s.repo.FindByID(postID)
+	if err != nil {
+		return err
+	}
+	if err := ensureNotBlocked(s.blocks, userID, post.UserID); err != nil {
+		return err
+	}
+	if err := ensureCanView(userID, post); err != nil {
+		return err
+	}
 	return s.repo.CreatePostLike(postID, userID)
 }
 
@@ -326,6 +485,20 @@ func (s *postService) UnlikePost(postID, userID uint64) error {
 }
 
 func (s *postService) LikeComment(commentID, userID uint64) error {
+	var comment model.Comment
+	if err := s.repo.FindCommentByID(commentID, &comment); err != nil {
+		return err
+	}
+	post, err := s.repo.FindByID(comment.PostID)
+	if err != nil {
+		return err
+	}
+	if err := ensureNotBlocked(s.blocks, userID, comment.UserID, post.UserID); err != nil {
+		return err
+	}
+	if err := ensureCanView(userID, post); err != nil {
+		return err
+	}
 	return s.repo.CreateCommentLike(commentID, userID)
 }
 
@@ -334,6 +507,17 @@ func (s *postService) UnlikeComment(commentID, userID uint64) error {
 }
 
 func (s *postService) SharePost(postID, userID uint64, content string) (*model.PostShare, error) {
+	post, err := s.repo.FindByID(postID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureNotBlocked(s.blocks, userID, post.UserID); err != nil {
+		return nil, err
+	}
+	if err := ensureCanView(userID, post); err != nil {
+		return nil, err
+	}
+
 	share := &model.PostShare{
 		PostID:        postID,
 		UserID:        userID,
@@ -350,8 +534,23 @@ func (s *postService) SharePost(postID, userID uint64, content string) (*model.P
 	return &result, nil
 }
 
-func (s *postService) GetSharesByPostID(postID uint64, limit, offset int) ([]model.PostShare, int64, error) {
-	shares, total, err := s.repo.FindSharesByPostID(postID, limit, offset)
+func (s *postService) GetSharesByPostID(postID, viewerID uint64, limit, offset int) ([]model.PostShare, int64, error) {
+	post, err := s.repo.FindByID(postID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := ensureNotBlocked(s.blocks, viewerID, post.UserID); err != nil {
+		return nil, 0, err
+	}
+	if err := ensureCanView(viewerID, post); err != nil {
+		return nil, 0, err
+	}
+
+	blockedIDs, err := s.blockedUserIDs(viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	shares, total, err := s.repo.FindSharesByPostID(postID, blockedIDs, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -362,11 +561,22 @@ func (s *postService) GetSharesByPostID(postID uint64, limit, offset int) ([]mod
 	return result, total, nil
 }
 
-func (s *postService) GetPostsByUserID(userID uint64, limit, offset int) ([]model.PostResponse, int64, error) {
-	posts, total, err := s.repo.FindPostsByUserID(userID, limit, offset)
+func (s *postService) GetPostsByUserID(userID, viewerID uint64, limit, offset int) ([]model.PostResponse, int64, error) {
+	// Người có quan hệ chặn với chủ trang không thấy bài đăng nào
+	if err := ensureNotBlocked(s.blocks, viewerID, userID); err != nil {
+		if errors.Is(err, ErrBlocked) {
+			return []model.PostResponse{}, 0, nil
+		}
+		return nil, 0, err
+	}
+
+	posts, total, err := s.repo.FindPostsByUserID(userID, s.viewerAudience(viewerID), limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
+	for i := range posts {
+		hideAudienceLists(viewerID, &posts[i])
+	}
 	result, err := util.PopulateUserInfo(posts, func(p model.PostResponse) uint64 { return p.UserID })
 	if err != nil {
 		return posts, total, nil
@@ -387,10 +597,33 @@ func (s *postService) GetCommentByID(id uint64) (*model.Comment, error) {
 }
 
 func (s *postService) GetFeed(userID uint64, mode string, limit, offset int) ([]model.PostResponse, int64, error) {
-	posts, total, err := s.repo.FindFeed(userID, mode, limit, offset)
+	// Không lấy được danh sách tắt tiếng thì vẫn trả feed đầy đủ
+	muted, err := util.FetchMutedTargets(userID)
+	if err != nil {
+		log.Printf("Failed to get muted targets for user %d: %v", userID, err)
+	}
+
+	// Home feed gồm bài đăng của bạn bè và người đang theo dõi, lỗi thì quay về feed chung
+	var sources *model.FeedSources
+	if mode == "home" {
+		if fetched, err := util.FetchFeedSources(userID); err != nil {
+			log.Printf("Failed to get feed sources for user %d: %v", userID, err)
+		} else {
+			sources = &fetched
+		}
+	}
+
+	blockedIDs, err := s.blockedUserIDs(userID)
 	if err != nil {
 		return nil, 0, err
 	}
+	posts, total, err := s.repo.FindFeed(mode, limit, offset, s.viewerAudience(userID), muted, sources, blockedIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range posts {
+		hideAudienceLists(userID, &posts[i])
+	}
 	result, err := util.PopulateUserInfo(posts, func(p model.PostResponse) uint64 { return p.UserID })
 	if err != nil {
 		return posts, total, nil
@@ -399,11 +632,18 @@ func (s *postService) GetFeed(userID uint64, mode string, limit, offset int) ([]
 }
 
 // Các phương thức mới sử dụng UUID
-func (s *postService) GetPostByUUID(uuid string) (*model.PostResponse, error) {
+func (s *postService) GetPostByUUID(uuid string, viewerID uint64) (*model.PostResponse, error) {
 	post, err := s.repo.FindByUUID(uuid)
 	if err != nil {
 		return nil, err
 	}
+	if err := ensureNotBlocked(s.blocks, viewerID, post.UserID); err != nil {
+		return nil, err
+	}
+	if err := ensureCanView(viewerID, post); err != nil {
+		return nil, err
+	}
+	hideAudienceLists(viewerID, post)
 	result, err := util.PopulateSingleUserInfo(*post, post.UserID)
 	if err != nil {
 		return post, nil
@@ -421,26 +661,76 @@ func (s *postService) UpdatePostByUUID(uuid string, userID uint64, req model.Cre
 		return nil, errors.New("forbidden")
 	}
 
+	if err := validateMediaTexts(req.MediaAltTexts, req.MediaCaptions); err != nil {
+		return nil, err
+	}
+	if err := s.prepareAudience(userID, &req); err != nil {
+		return nil, err
+	}
+
+	verdict, err := s.checkContent(req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	// Kiểm tra các upload trực tiếp trước khi xóa media cũ
+	uploads, err := s.uploads.ResolveForPost(userID, req.MediaIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	// Tạo đối tượng post mới để cập nhật
 	post := &model.Post{
-		ID:         postResp.ID,
-		UUID:       uuid,
-		UserID:     postResp.UserID,
-		Content:    req.Content,
-		Visibility: req.Visibility,
-		CreatedAt:  postResp.CreatedAt,
-		UpdatedAt:  time.Now(),
-		IsDeleted:  false,
+		ID:              postResp.ID,
+		UUID:            uuid,
+		UserID:          postResp.UserID,
+		GroupID:         postResp.GroupID,
+		Content:         req.Content,
+		Visibility:      req.Visibility,
+		AudienceListIDs: req.AudienceListIDs,
+		ExcludedListIDs: req.ExcludedListIDs,
+		CreatedAt:       postResp.CreatedAt,
+		UpdatedAt:       time.Now(),
+		IsDeleted:       false,
+
+		IsHidden:     verdict.Action == FilterReview,
+		ShadowHidden: verdict.Action == FilterShadowHide,
 	}
 
 	// Lấy danh sách media hiện tại
 	currentMedia := postResp.Media
+	// Media bị xóa khi cập nhật từng phần, chỉ xóa sau khi lưu bài đăng thành công
+	var removedMedia []model.PostMedia
 
-	// Nếu có file ảnh mới
-	if len(files) > 0 {
+	if len(req.RemoveMediaIDs) > 0 || len(req.MediaOrder) > 0 {
+		// Cập nhật từng phần: giữ media cũ (trừ các media bị xóa), sắp xếp lại và thêm media mới vào cuối
+		if _, _, err := editMedia(currentMedia, req.RemoveMediaIDs, req.MediaOrder, nil); err != nil {
+			return nil, err
+		}
+
+		var added []model.PostMedia
+		if len(files) > 0 {
+			images, err := s.cloudinaryUploader.UploadImages(files)
+			if err != nil {
+				return nil, fmt.Errorf("failed to upload new images: %w", err)
+			}
+			added = append(added, mediaFromImages(images)...)
+		}
+		for _, url := range req.MediaURLs {
+			added = append(added, model.PostMedia{
+				MediaURL:  url,
+				MediaType: "IMAGE",
+				CreatedAt: time.Now(),
+			})
+		}
+		added = append(added, mediaFromUploads(uploads)...)
+		applyMediaText(added, req.MediaAltTexts, req.MediaCaptions)
+
+		post.Media, removedMedia, _ = editMedia(currentMedia, req.RemoveMediaIDs, req.MediaOrder, added)
+	} else if len(files) > 0 {
 		// Xóa toàn bộ media cũ trên Cloudinary
 		for _, oldMedia := range currentMedia {
-			if err := s.cloudinaryUploader.DeleteImage(oldMedia.MediaURL); err != nil {
+			if err := s.cloudinaryUploader.DeleteImageWithVariants(oldMedia.MediaURL, oldMedia.Variants); err != nil {
 				log.Printf("Failed to delete old image %s from Cloudinary: %v", oldMedia.MediaURL, err)
 				// Tiếp tục xử lý dù có lỗi xóa Cloudinary, không return lỗi
 			}
@@ -453,23 +743,19 @@ func (s *postService) UpdatePostByUUID(uuid string, userID uint64, req model.Cre
 		}
 
 		// Upload ảnh mới lên Cloudinary
-		urls, err := s.cloudinaryUploader.UploadImages(files)
+		images, err := s.cloudinaryUploader.UploadImages(files)
 		if err != nil {
-			return nil, errors.New("failed to upload new images: " + err.Error())
-		}
-		for _, url := range urls {
-			post.Media = append(post.Media, model.PostMedia{
-				MediaURL:  url,
-				MediaType: "IMAGE",
-				CreatedAt: time.Now(),
-			})
+			return nil, fmt.Errorf("failed to upload new images: %w", err)
 		}
+		post.Media = append(post.Media, mediaFromImages(images)...)
+		post.Media = append(post.Media, mediaFromUploads(uploads)...)
+		applyMediaText(post.Media, req.MediaAltTexts, req.MediaCaptions)
 	} else {
-		// Nếu không có file mới, giữ nguyên media cũ hoặc dùng MediaURLs từ request
-		if len(req.MediaURLs) > 0 {
+		// Nếu không có file mới, giữ nguyên media cũ hoặc dùng MediaURLs/MediaIDs từ request
+		if len(req.MediaURLs) > 0 || len(uploads) > 0 {
 			// Xóa media cũ trên Cloudinary nếu có MediaURLs mới
 			for _, oldMedia := range currentMedia {
-				if err := s.cloudinaryUploader.DeleteImage(oldMedia.MediaURL); err != nil {
+				if err := s.cloudinaryUploader.DeleteImageWithVariants(oldMedia.MediaURL, oldMedia.Variants); err != nil {
 					log.Printf("Failed to delete old image %s from Cloudinary: %v", oldMedia.MediaURL, err)
 				}
 			}
@@ -488,20 +774,37 @@ func (s *postService) UpdatePostByUUID(uuid string, userID uint64, req model.Cre
 					CreatedAt: time.Now(),
 				})
 			}
+			post.Media = append(post.Media, mediaFromUploads(uploads)...)
+			applyMediaText(post.Media, req.MediaAltTexts, req.MediaCaptions)
 		} else {
 			// Không có file mới và không có MediaURLs, giữ nguyên media cũ
 			post.Media = currentMedia
 		}
 	}
 
-	// Cập nhật bài đăng trong database
-	if err := s.repo.UpdatePost(post); err != nil {
-		return nil, err
+	if len(post.Media) > maxMediaPerPost {
+		return nil, fmt.Errorf("maximum of %d media allowed, got %d", maxMediaPerPost, len(post.Media))
+	}
+	assignPositions(post.Media)
+
+	// Cập nhật bài đăng và gắn upload trong cùng một transaction
+	if err := s.repo.UpdatePost(post, uploadIDs(uploads)); err != nil {
+		return nil, attachError(err)
+	}
+
+	if len(removedMedia) > 0 {
+		if err := s.repo.DeletePostMediaByIDs(post.ID, mediaIDs(removedMedia)); err != nil {
+			return nil, fmt.Errorf("failed to delete removed media: %v", err)
+		}
+		s.deleteStoredMedia(removedMedia)
 	}
 
 	// Lấy bài đăng đã cập nhật và trả về
-	updatedPost, err := s.repo.FindByUUID(uuid)
-	if err != nil {
+	var updatedPost *model.PostResponse
+	if post.IsHidden {
+		s.holdForReview(model.ReportTargetPost, post.ID, verdict)
+		updatedPost = pendingPostResponse(post)
+	} else if updatedPost, err = s.repo.FindByUUID(uuid); err != nil {
 		return nil, err
 	}
 
@@ -520,8 +823,8 @@ func (s *postService) DeletePostByUUID(uuid string, userID uint64) error {
 	if err != nil {
 		return err
 	}
-	if post.UserID != userID {
-		return errors.New("forbidden")
+	if err := ensureCanDelete(post, userID); err != nil {
+		return err
 	}
 
 	return s.repo.DeletePostByUUID(uuid)
@@ -538,8 +841,23 @@ func (s *postService) CreateCommentByUUID(uuid string, userID uint64, content st
 	return s.CreateComment(post.ID, userID, content, parentID, files)
 }
 
-func (s *postService) GetCommentsByPostUUID(uuid string, limit, offset int) ([]model.Comment, int64, error) {
-	comments, total, err := s.repo.FindCommentsByPostUUID(uuid, limit, offset)
+func (s *postService) GetCommentsByPostUUID(uuid string, viewerID uint64, limit, offset int) ([]model.Comment, int64, error) {
+	post, err := s.repo.FindByUUID(uuid)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := ensureNotBlocked(s.blocks, viewerID, post.UserID); err != nil {
+		return nil, 0, err
+	}
+	if err := ensureCanView(viewerID, post); err != nil {
+		return nil, 0, err
+	}
+
+	blockedIDs, err := s.blockedUserIDs(viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	comments, total, err := s.repo.FindCommentsByPostUUID(uuid, viewerID, blockedIDs, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -555,6 +873,16 @@ func (s *postService) GetCommentsByPostUUID(uuid string, limit, offset int) ([]m
 }
 
 func (s *postService) LikePostByUUID(uuid string, userID uint64) error {
+	post, err := s.repo.FindByUUID(uuid)
+	if err != nil {
+		return err
+	}
+	if err := ensureNotBlocked(s.blocks, userID, post.UserID); err != nil {
+		return err
+	}
+	if err := ensureCanView(userID, post); err != nil {
+		return err
+	}
 	return s.repo.CreatePostLikeByUUID(uuid, userID)
 }
 
@@ -568,6 +896,12 @@ func (s *postService) SharePostByUUID(uuid string, userID uint64, content string
 	if err != nil {
 		return nil, err
 	}
+	if err := ensureNotBlocked(s.blocks, userID, post.UserID); err != nil {
+		return nil, err
+	}
+	if err := ensureCanView(userID, post); err != nil {
+		return nil, err
+	}
 
 	share := &model.PostShare{
 		PostID:        post.ID,
@@ -593,8 +927,23 @@ func (s *postService) SharePostByUUID(uuid string, userID uint64, content string
 	return &enrichedShare, nil
 }
 
-func (s *postService) GetSharesByPostUUID(uuid string, limit, offset int) ([]model.PostShare, int64, error) {
-	shares, total, err := s.repo.FindSharesByPostUUID(uuid, limit, offset)
+func (s *postService) GetSharesByPostUUID(uuid string, viewerID uint64, limit, offset int) ([]model.PostShare, int64, error) {
+	post, err := s.repo.FindByUUID(uuid)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := ensureNotBlocked(s.blocks, viewerID, post.UserID); err != nil {
+		return nil, 0, err
+	}
+	if err := ensureCanView(viewerID, post); err != nil {
+		return nil, 0, err
+	}
+
+	blockedIDs, err := s.blockedUserIDs(viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	shares, total, err := s.repo.FindSharesByPostUUID(uuid, blockedIDs, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -610,7 +959,7 @@ func (s *postService) GetSharesByPostUUID(uuid string, limit, offset int) ([]mod
 }
 
 // GetPostsByUsername lấy danh sách bài đăng theo username
-func (s *postService) GetPostsByUsername(username string, limit, offset int) ([]model.PostResponse, int64, error) {
+func (s *postService) GetPostsByUsername(username string, viewerID uint64, limit, offset int) ([]model.PostResponse, int64, error) {
 	// Lấy userID từ username qua gRPC
 	userID, err := util.GetUserIDByUsername(username)
 	if err != nil {
@@ -618,5 +967,109 @@ func (s *postService) GetPostsByUsername(username string, limit, offset int) ([]
 	}
 
 	// Sử dụng hàm có sẵn để lấy bài đăng theo userID
-	return s.GetPostsByUserID(userID, limit, offset)
+	return s.GetPostsByUserID(userID, viewerID, limit, offset)
+}
+
+// checkContent chạy bộ lọc nội dung, trả về ErrContentRejected nếu nội dung bị từ chối
+func (s *postService) checkContent(content string) (FilterResult, error) {
+	if s.contentFilter == nil {
+		return FilterResult{Action: FilterAllow}, nil
+	}
+	verdict := s.contentFilter.Check(content)
+	if verdict.Action == FilterReject {
+		return verdict, fmt.Errorf("%w: %s", ErrContentRejected, verdict.Reason)
+	}
+	if verdict.Action != FilterAllow {
+		log.Printf("Content filter %s (%s): %s", verdict.Action, verdict.Rule, verdict.Reason)
+	}
+	return verdict, nil
+}
+
+// holdForReview đưa nội dung bị giữ lại vào hàng đợi kiểm duyệt, nội dung đã được lưu ở trạng thái
+// ẩn nên lỗi chỉ được ghi log
+func (s *postService) holdForReview(targetType string, targetID uint64, verdict FilterResult) {
+	if s.reports == nil {
+		return
+	}
+	details := verdict.Rule + ": " + verdict.Reason
+	if err := s.reports.HoldForReview(targetType, targetID, details); err != nil {
+		log.Printf("Failed to queue %s %d for review: %v", targetType, targetID, err)
+	}
+}
+
+// pendingPostResponse dựng response cho bài đăng vừa bị bộ lọc giữ lại chờ kiểm duyệt
+func pendingPostResponse(post *model.Post) *model.PostResponse {
+	return &model.PostResponse{
+		ID:              post.ID,
+		UUID:            post.UUID,
+		UserID:          post.UserID,
+		Content:         post.Content,
+		Visibility:      post.Visibility,
+		GroupID:         post.GroupID,
+		AudienceListIDs: post.AudienceListIDs,
+		ExcludedListIDs: post.ExcludedListIDs,
+		CreatedAt:       post.CreatedAt,
+		UpdatedAt:       post.UpdatedAt,
+		Media:           post.Media,
+
+		PendingReview: true,
+	}
+}
+
+// mediaFromUploads chuyển các upload đã xác nhận thành PostMedia
+func mediaFromUploads(uploads []model.MediaUpload) []model.PostMedia {
+	media := make([]model.PostMedia, 0, len(uploads))
+	for _, u := range uploads {
+		m := model.PostMedia{
+			MediaURL:  u.MediaURL,
+			MediaType: u.MediaType(),
+			Width:     u.Width,
+			Height:    u.Height,
+			CreatedAt: time.Now(),
+
+			BlurHash:      u.BlurHash,
+			DominantColor: u.DominantColor,
+		}
+		if m.MediaType == "IMAGE" {
+			m.Variants = util.TransformationVariants(u.MediaURL, u.Width, u.Height)
+		}
+		media = append(media, m)
+	}
+	return media
+}
+
+// mediaFromImages chuyển các ảnh đã xử lý và upload qua server thành PostMedia
+func mediaFromImages(images []util.UploadedImage) []model.PostMedia {
+	media := make([]model.PostMedia, 0, len(images))
+	for _, img := range images {
+		media = append(media, model.PostMedia{
+			MediaURL:  img.URL,
+			MediaType: "IMAGE",
+			Width:     img.Width,
+			Height:    img.Height,
+			Variants:  img.Variants,
+			CreatedAt: time.Now(),
+
+			BlurHash:      img.BlurHash,
+			DominantColor: img.DominantColor,
+		})
+	}
+	return media
+}
+
+// attachError báo upload không còn gắn được (đã gắn vào bài khác hoặc hết hạn) như upload chưa xác nhận
+func attachError(err error) error {
+	if errors.Is(err, repository.ErrUploadsUnavailable) {
+		return fmt.Errorf("%w: %v", ErrUploadNotConfirmed, err)
+	}
+	return err
+}
+
+// uploadIDs lấy danh sách ID từ các upload
+func uploadIDs(uploads []model.MediaUpload) []uint64 {
+	ids := make([]uint64, 0, len(uploads))
+	for _, u := range uploads {
+		ids = append(ids, u.ID)
+	}
+	return ids
 }
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"postservice/internal/model"
+	"unicode/utf8"
+)
+
+// Giới hạn độ dài alt text và chú thích của media (tính theo ký tự)
+const (
+	maxAltTextLength = 1000
+	maxCaptionLength = 2200
+)
+
+var (
+	ErrForbidden         = errors.New("forbidden")
+	ErrMediaNotFound     = errors.New("media not found in post")
+	ErrAltTextTooLong    = fmt.Errorf("alt text exceeds %d characters", maxAltTextLength)
+	ErrCaptionTooLong    = fmt.Errorf("caption exceeds %d characters", maxCaptionLength)
+	ErrInvalidMediaOrder = errors.New("invalid media order")
+)
+
+// UpdatePostMedia sửa alt text/chú thích của một media mà không cần upload lại
+func (s *postService) UpdatePostMedia(uuid string, mediaID, userID uint64, req model.UpdateMediaRequest) (*model.PostMedia, error) {
+	post, err := s.findOwnPost(uuid, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	media := findMedia(post.Media, mediaID)
+	if media == nil {
+		return nil, ErrMediaNotFound
+	}
+	if req.AltText != nil {
+		media.AltText = *req.AltText
+	}
+	if req.Caption != nil {
+		media.Caption = *req.Caption
+	}
+	if err := validateMediaTexts([]string{media.AltText}, []string{media.Caption}); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdatePostMedia(media); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// DeletePostMedia xóa một media khỏi bài đăng, các media còn lại được đánh lại vị trí
+func (s *postService) DeletePostMedia(uuid string, mediaID, userID uint64) error {
+	post, err := s.findOwnPost(uuid, userID)
+	if err != nil {
+		return err
+	}
+
+	media := findMedia(post.Media, mediaID)
+	if media == nil {
+		return ErrMediaNotFound
+	}
+	if err := s.repo.DeletePostMediaByIDs(post.ID, []uint64{mediaID}); err != nil {
+		return err
+	}
+
+	remaining := make([]uint64, 0, len(post.Media))
+	for _, m := range post.Media {
+		if m.ID != mediaID {
+			remaining = append(remaining, m.ID)
+		}
+	}
+	if err := s.repo.UpdateMediaPositions(post.ID, remaining); err != nil {
+		log.Printf("Failed to update media positions for post %s: %v", uuid, err)
+	}
+
+	s.deleteStoredMedia([]model.PostMedia{*media})
+	return nil
+}
+
+// ReorderPostMedia sắp xếp lại media theo ids; media không có trong ids giữ thứ tự cũ và xếp sau
+func (s *postService) ReorderPostMedia(uuid string, userID uint64, ids []uint64) (*model.PostResponse, error) {
+	post, err := s.findOwnPost(uuid, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered, err := orderMedia(post.Media, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateMediaPositions(post.ID, mediaIDs(ordered)); err != nil {
+		return nil, err
+	}
+
+	return s.GetPostByUUID(uuid, userID)
+}
+
+// findOwnPost lấy bài đăng theo UUID và kiểm tra người dùng là chủ bài đăng
+func (s *postService) findOwnPost(uuid string, userID uint64) (*model.PostResponse, error) {
+	post, err := s.repo.FindByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+	if post.UserID != userID {
+		return nil, ErrForbidden
+	}
+	return post, nil
+}
+
+// editMedia tính danh sách media mới khi cập nhật từng phần: bỏ các media trong removeIDs,
+// sắp xếp phần còn lại theo order rồi thêm media mới vào cuối. Trả về thêm các media bị xóa.
+func editMedia(current []model.PostMedia, removeIDs, order []uint64, added []model.PostMedia) ([]model.PostMedia, []model.PostMedia, error) {
+	remove := make(map[uint64]bool, len(removeIDs))
+	for _, id := range removeIDs {
+		if findMedia(current, id) == nil {
+			return nil, nil, fmt.Errorf("%w: %d", ErrMediaNotFound, id)
+		}
+		remove[id] = true
+	}
+
+	var kept, removed []model.PostMedia
+	for _, m := range current {
+		if remove[m.ID] {
+			removed = append(removed, m)
+		} else {
+			kept = append(kept, m)
+		}
+	}
+
+	kept, err := orderMedia(kept, order)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(kept, added...), removed, nil
+}
+
+// orderMedia sắp xếp media theo ids, media không được liệt kê giữ thứ tự cũ và xếp sau
+func orderMedia(media []model.PostMedia, ids []uint64) ([]model.PostMedia, error) {
+	ordered := make([]model.PostMedia, 0, len(media))
+	used := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		if used[id] {
+			return nil, fmt.Errorf("%w: duplicate media %d", ErrInvalidMediaOrder, id)
+		}
+		m := findMedia(media, id)
+		if m == nil {
+			return nil, fmt.Errorf("%w: %d", ErrMediaNotFound, id)
+		}
+		used[id] = true
+		ordered = append(ordered, *m)
+	}
+	for _, m := range media {
+		if !used[m.ID] {
+			ordered = append(ordered, m)
+		}
+	}
+	return ordered, nil
+}
+
+// applyMediaText gán alt text/chú thích theo thứ tự cho các media mới
+func applyMediaText(media []model.PostMedia, altTexts, captions []string) {
+	for i := range media {
+		if i < len(altTexts) {
+			media[i].AltText = altTexts[i]
+		}
+		if i < len(captions) {
+			media[i].Caption = captions[i]
+		}
+	}
+}
+
+// validateMediaTexts kiểm tra độ dài alt text và chú thích trước khi upload media
+func validateMediaTexts(altTexts, captions []string) error {
+	for _, text := range altTexts {
+		if utf8.RuneCountInString(text) > maxAltTextLength {
+			return ErrAltTextTooLong
+		}
+	}
+	for _, text := range captions {
+		if utf8.RuneCountInString(text) > maxCaptionLength {
+			return ErrCaptionTooLong
+		}
+	}
+	return nil
+}
+
+// assignPositions đánh vị trí hiển thị theo thứ tự trong danh sách
+func assignPositions(media []model.PostMedia) {
+	for i := range media {
+		media[i].Position = i
+	}
+}
+
+// deleteStoredMedia xóa file của các media trên Cloudinary, lỗi chỉ được ghi log
+func (s *postService) deleteStoredMedia(media []model.PostMedia) {
+	for _, m := range media {
+		if err := s.cloudinaryUploader.DeleteImageWithVariants(m.MediaURL, m.Variants); err != nil {
+			log.Printf("Failed to delete media %s from Cloudinary: %v", m.MediaURL, err)
+		}
+	}
+}
+
+func findMedia(media []model.PostMedia, id uint64) *model.PostMedia {
+	for i := range media {
+		if media[i].ID == id {
+			return &media[i]
+		}
+	}
+	return nil
+}
+
+func mediaIDs(media []model.PostMedia) []uint64 {
+	ids := make([]uint64, 0, len(media))
+	for _, m := range media {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
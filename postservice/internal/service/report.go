@@ -0,0 +1,248 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"postservice/internal/model"
+	"postservice/internal/repository"
+	"postservice/internal/util"
+	"time"
+)
+
+var (
+	ErrInvalidReportReason = errors.New("invalid report reason")
+	ErrAlreadyReported     = errors.New("content already reported by user")
+	ErrCannotReportOwn     = errors.New("cannot report own content")
+	ErrReportNotFound      = errors.New("report not found")
+	ErrTargetNotFound      = errors.New("reported content not found")
+)
+
+type ReportService interface {
+	ReportPost(uuid string, reporterID uint64, req model.CreateReportRequest) (*model.Report, error)
+	ReportComment(commentID, reporterID uint64, req model.CreateReportRequest) (*model.Report, error)
+	ListReports(status, targetType string, limit, offset int) ([]model.Report, int64, error)
+	GetReport(id uint64) (*model.ReportDetail, error)
+	Decide(reportID, moderatorID uint64, req model.ModerationDecisionRequest) (*model.ModerationAction, error)
+	ListActions(targetType string, targetID, moderatorID uint64, limit, offset int) ([]model.ModerationAction, int64, error)
+	HoldForReview(targetType string, targetID uint64, details string) error
+}
+
+type reportService struct {
+	repo              repository.ReportRepository
+	postRepo          repository.PostRepository
+	blocks            *util.BlockChecker
+	autoHideThreshold int
+}
+
+// NewReportService tạo service báo cáo nội dung. Nội dung tự động bị ẩn khi có
+// autoHideThreshold người khác nhau báo cáo (<= 0 là tắt tự động ẩn). blocks dùng để
+// không cho báo cáo nội dung của người có quan hệ chặn với người báo cáo (nil là không kiểm tra).
+func NewReportService(repo repository.ReportRepository, postRepo repository.PostRepository, blocks *util.BlockChecker, autoHideThreshold int) ReportService {
+	return &reportService{
+		repo:              repo,
+		postRepo:          postRepo,
+		blocks:            blocks,
+		autoHideThreshold: autoHideThreshold,
+	}
+}
+
+func (s *reportService) ReportPost(uuid string, reporterID uint64, req model.CreateReportRequest) (*model.Report, error) {
+	post, err := s.postRepo.FindByUUID(uuid)
+	if err != nil {
+		return nil, ErrTargetNotFound
+	}
+	if err := s.ensureCanReport(reporterID, post, post.UserID); err != nil {
+		return nil, err
+	}
+	return s.createReport(model.ReportTargetPost, post.ID, reporterID, req)
+}
+
+func (s *reportService) ReportComment(commentID, reporterID uint64, req model.CreateReportRequest) (*model.Report, error) {
+	var comment model.Comment
+	if err := s.postRepo.FindCommentByID(commentID, &comment); err != nil {
+		return nil, ErrTargetNotFound
+	}
+	post, err := s.postRepo.FindByID(comment.PostID)
+	if err != nil {
+		return nil, ErrTargetNotFound
+	}
+	if err := s.ensureCanReport(reporterID, post, comment.UserID, post.UserID); err != nil {
+		return nil, err
+	}
+	return s.createReport(model.ReportTargetComment, commentID, reporterID, req)
+}
+
+// ensureCanReport áp dụng cùng kiểm tra như khi xem nội dung: người báo cáo không được có quan hệ chặn
+// với ownerIDs và phải xem được bài đăng, để không ai đẩy nội dung mình không thấy tới ngưỡng tự động ẩn
+func (s *reportService) ensureCanReport(reporterID uint64, post *model.PostResponse, ownerIDs ...uint64) error {
+	if err := ensureNotBlocked(s.blocks, reporterID, ownerIDs...); err != nil {
+		return err
+	}
+	return ensureCanView(reporterID, post)
+}
+
+// createReport ghi nhận báo cáo và tự động ẩn nội dung khi đủ số người báo cáo
+func (s *reportService) createReport(targetType string, targetID, reporterID uint64, req model.CreateReportRequest) (*model.Report, error) {
+	if !model.IsValidReportReason(req.Reason) {
+		return nil, ErrInvalidReportReason
+	}
+
+	ownerID, hidden, err := s.repo.FindTarget(targetType, targetID)
+	if err != nil {
+		return nil, ErrTargetNotFound
+	}
+	if ownerID == reporterID {
+		return nil, ErrCannotReportOwn
+	}
+
+	exists, err := s.repo.Exists(targetType, targetID, reporterID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrAlreadyReported
+	}
+
+	report := &model.Report{
+		TargetType: targetType,
+		TargetID:   targetID,
+		ReporterID: reporterID,
+		Reason:     req.Reason,
+		Details:    req.Details,
+		Status:     model.ReportStatusOpen,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	if err := s.repo.Create(report); err != nil {
+		return nil, err
+	}
+
+	if !hidden && s.autoHideThreshold > 0 {
+		s.autoHide(targetType, targetID)
+	}
+	return report, nil
+}
+
+// autoHide ẩn nội dung khi số báo cáo đang mở đạt ngưỡng, lỗi chỉ được ghi log
+func (s *reportService) autoHide(targetType string, targetID uint64) {
+	count, err := s.repo.CountOpenByTarget(targetType, targetID)
+	if err != nil {
+		log.Printf("Failed to count reports for %s %d: %v", targetType, targetID, err)
+		return
+	}
+	if count < s.autoHideThreshold {
+		return
+	}
+
+	action := &model.ModerationAction{
+		TargetType:  targetType,
+		TargetID:    targetID,
+		Action:      model.ModerationActionAutoHide,
+		ReportCount: count,
+		CreatedAt:   time.Now(),
+	}
+	// Báo cáo vẫn giữ trạng thái OPEN để moderator xem xét
+	if err := s.repo.ApplyDecision(action, ""); err != nil {
+		log.Printf("Failed to auto-hide %s %d: %v", targetType, targetID, err)
+		return
+	}
+	log.Printf("Auto-hid %s %d after %d reports", targetType, targetID, count)
+}
+
+// HoldForReview đưa nội dung bị bộ lọc giữ lại vào hàng đợi kiểm duyệt bằng một báo cáo của
+// hệ thống (reporter 0). Moderator DISMISS báo cáo thì nội dung được hiện lại.
+func (s *reportService) HoldForReview(targetType string, targetID uint64, details string) error {
+	exists, err := s.repo.Exists(targetType, targetID, 0)
+	if err != nil {
+		return err
+	}
+	if exists {
+		// Nội dung bị giữ lại lần nữa sau khi sửa, mở lại báo cáo cũ của hệ thống
+		err = s.repo.Reopen(targetType, targetID, 0, details)
+	} else {
+		err = s.repo.Create(&model.Report{
+			TargetType: targetType,
+			TargetID:   targetID,
+			Reason:     model.ReportReasonAutoFilter,
+			Details:    details,
+			Status:     model.ReportStatusOpen,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.repo.ApplyDecision(&model.ModerationAction{
+		TargetType: targetType,
+		TargetID:   targetID,
+		Action:     model.ModerationActionAutoFilter,
+		Note:       details,
+		CreatedAt:  time.Now(),
+	}, "")
+}
+
+func (s *reportService) ListReports(status, targetType string, limit, offset int) ([]model.Report, int64, error) {
+	return s.repo.List(status, targetType, limit, offset)
+}
+
+func (s *reportService) GetReport(id uint64) (*model.ReportDetail, error) {
+	report, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrReportNotFound
+	}
+
+	targetReports, err := s.repo.FindByTarget(report.TargetType, report.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	actions, _, err := s.repo.ListActions(report.TargetType, report.TargetID, 0, 100, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &model.ReportDetail{
+		Report:        *report,
+		TargetReports: targetReports,
+		Actions:       actions,
+	}
+	if _, hidden, err := s.repo.FindTarget(report.TargetType, report.TargetID); err == nil {
+		detail.TargetHidden = hidden
+	}
+	return detail, nil
+}
+
+// Decide áp dụng quyết định của moderator lên nội dung của báo cáo. Quyết định xử lý
+// toàn bộ báo cáo đang mở về cùng nội dung và được ghi vào nhật ký kiểm duyệt.
+func (s *reportService) Decide(reportID, moderatorID uint64, req model.ModerationDecisionRequest) (*model.ModerationAction, error) {
+	report, err := s.repo.FindByID(reportID)
+	if err != nil {
+		return nil, ErrReportNotFound
+	}
+	if _, _, err := s.repo.FindTarget(report.TargetType, report.TargetID); err != nil {
+		return nil, ErrTargetNotFound
+	}
+
+	status := model.ReportStatusActioned
+	if req.Action == model.ModerationActionDismiss {
+		status = model.ReportStatusDismissed
+	}
+
+	action := &model.ModerationAction{
+		TargetType:  report.TargetType,
+		TargetID:    report.TargetID,
+		ModeratorID: moderatorID,
+		Action:      req.Action,
+		Note:        req.Note,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.repo.ApplyDecision(action, status); err != nil {
+		return nil, err
+	}
+	return action, nil
+}
+
+func (s *reportService) ListActions(targetType string, targetID, moderatorID uint64, limit, offset int) ([]model.ModerationAction, int64, error) {
+	return s.repo.ListActions(targetType, targetID, moderatorID, limit, offset)
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"postservice/internal/model"
+	"postservice/internal/repository"
+	"postservice/internal/util"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Giới hạn cho upload trực tiếp
+const (
+	maxImageUploadBytes = 10 << 20  // 10MB cho ảnh
+	maxVideoUploadBytes = 100 << 20 // 100MB cho video
+	maxMediaPerPost     = 8
+	janitorBatchSize    = 100
+)
+
+var (
+	ErrUploadNotFound       = errors.New("upload not found")
+	ErrUploadForbidden      = errors.New("upload does not belong to user")
+	ErrUploadNotConfirmed   = errors.New("upload is not confirmed")
+	ErrUploadExpired        = errors.New("upload session expired")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrUploadTooLarge       = errors.New("file size exceeds limit")
+)
+
+type UploadService interface {
+	RequestUpload(userID uint64, req model.UploadSlotRequest) (*model.UploadSlotResponse, error)
+	ConfirmUpload(id, userID uint64) (*model.MediaUpload, error)
+	ResolveForPost(userID uint64, ids []uint64) ([]model.MediaUpload, error)
+	CleanupExpired() (int, error)
+	StartJanitor(ctx context.Context, interval time.Duration)
+}
+
+type uploadService struct {
+	repo               repository.MediaUploadRepository
+	cloudinaryUploader *util.CloudinaryUploader
+	sessionTTL         time.Duration
+}
+
+func NewUploadService(repo repository.MediaUploadRepository, sessionTTL time.Duration) UploadService {
+	uploader, err := util.NewCloudinaryUploader()
+	if err != nil {
+		log.Fatalf("Failed to initialize Cloudinary uploader: %v", err)
+	}
+
+	return &uploadService{
+		repo:               repo,
+		cloudinaryUploader: uploader,
+		sessionTTL:         sessionTTL,
+	}
+}
+
+// RequestUpload cấp một slot upload: tạo bản ghi PENDING và chữ ký để client upload thẳng lên Cloudinary
+func (s *uploadService) RequestUpload(userID uint64, req model.UploadSlotRequest) (*model.UploadSlotResponse, error) {
+	resourceType, maxBytes := resourceTypeFor(req.ContentType)
+	if resourceType == "" {
+		return nil, ErrUnsupportedMediaType
+	}
+	if req.FileSize > maxBytes {
+		return nil, ErrUploadTooLarge
+	}
+
+	upload := &model.MediaUpload{
+		UserID:       userID,
+		PublicID:     fmt.Sprintf("posts/post_upload_%s", uuid.New().String()),
+		ResourceType: resourceType,
+		Status:       model.UploadStatusPending,
+		ExpiresAt:    time.Now().Add(s.sessionTTL),
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+
+	uploadURL, fields, err := s.cloudinaryUploader.SignUpload(upload.PublicID, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(upload); err != nil {
+		return nil, err
+	}
+
+	return &model.UploadSlotResponse{
+		UploadID:  upload.ID,
+		UploadURL: uploadURL,
+		Fields:    fields,
+		ExpiresAt: upload.ExpiresAt,
+	}, nil
+}
+
+// ConfirmUpload kiểm tra file đã thực sự có trên Cloudinary và chuyển upload sang CONFIRMED
+func (s *uploadService) ConfirmUpload(id, userID uint64) (*model.MediaUpload, error) {
+	upload, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrUploadNotFound
+	}
+	if upload.UserID != userID {
+		return nil, ErrUploadForbidden
+	}
+
+	switch upload.Status {
+	case model.UploadStatusConfirmed, model.UploadStatusAttached:
+		// Xác nhận lại nhiều lần không gây lỗi
+		return upload, nil
+	case model.UploadStatusExpired:
+		return nil, ErrUploadExpired
+	}
+	if time.Now().After(upload.ExpiresAt) {
+		return nil, ErrUploadExpired
+	}
+
+	asset, err := s.cloudinaryUploader.GetAsset(upload.PublicID, upload.ResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("file has not been uploaded: %v", err)
+	}
+
+	_, maxBytes := resourceTypeFor(upload.ResourceType + "/")
+	if asset.Bytes > maxBytes {
+		// File vượt giới hạn: xóa luôn trên storage
+		if err := s.cloudinaryUploader.DeleteByPublicID(upload.PublicID, upload.ResourceType); err != nil {
+			log.Printf("Failed to delete oversized upload %s: %v", upload.PublicID, err)
+		}
+		return nil, ErrUploadTooLarge
+	}
+
+	now := time.Now()
+	upload.Status = model.UploadStatusConfirmed
+	upload.MediaURL = asset.SecureURL
+	upload.Format = asset.Format
+	upload.Bytes = asset.Bytes
+	upload.Width = asset.Width
+	upload.Height = asset.Height
+	upload.ConfirmedAt = &now
+	if upload.ResourceType == "image" {
+		// Tính placeholder từ bản thumbnail để không phải tải ảnh gốc
+		if placeholder, err := util.PlaceholderFromURL(util.ThumbnailURL(asset.SecureURL)); err != nil {
+			log.Printf("Failed to compute placeholder for upload %d: %v", upload.ID, err)
+		} else {
+			upload.BlurHash = placeholder.BlurHash
+			upload.DominantColor = placeholder.DominantColor
+		}
+	}
+	// Gia hạn thêm một TTL để client kịp gắn media vào bài đăng
+	upload.ExpiresAt = now.Add(s.sessionTTL)
+	upload.UpdatedAt = now
+
+	if err := s.repo.Update(upload); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// ResolveForPost lấy các upload đã xác nhận của user theo đúng thứ tự ids để gắn vào bài đăng
+func (s *uploadService) ResolveForPost(userID uint64, ids []uint64) ([]model.MediaUpload, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	if len(ids) > maxMediaPerPost {
+		return nil, fmt.Errorf("maximum of %d media allowed, got %d", maxMediaPerPost, len(ids))
+	}
+
+	uploads, err := s.repo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint64]model.MediaUpload, len(uploads))
+	for _, u := range uploads {
+		byID[u.ID] = u
+	}
+
+	result := make([]model.MediaUpload, 0, len(ids))
+	seen := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		u, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrUploadNotFound, id)
+		}
+		if u.UserID != userID {
+			return nil, fmt.Errorf("%w: %d", ErrUploadForbidden, id)
+		}
+		if u.Status != model.UploadStatusConfirmed {
+			return nil, fmt.Errorf("%w: %d", ErrUploadNotConfirmed, id)
+		}
+		result = append(result, u)
+	}
+	return result, nil
+}
+
+// CleanupExpired xóa trên storage các upload chưa được gắn vào bài đăng và đã hết hạn
+func (s *uploadService) CleanupExpired() (int, error) {
+	uploads, err := s.repo.FindExpired(time.Now(), janitorBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	cleaned := 0
+	for _, u := range uploads {
+		// Upload PENDING có thể chưa có file trên storage, Destroy vẫn trả về "not found" mà không lỗi
+		if err := s.cloudinaryUploader.DeleteByPublicID(u.PublicID, u.ResourceType); err != nil {
+			log.Printf("Failed to delete expired upload %s: %v", u.PublicID, err)
+			continue
+		}
+		if err := s.repo.UpdateStatus(u.ID, model.UploadStatusExpired); err != nil {
+			log.Printf("Failed to mark upload %d as expired: %v", u.ID, err)
+			continue
+		}
+		cleaned++
+	}
+	return cleaned, nil
+}
+
+// StartJanitor chạy CleanupExpired định kỳ cho tới khi ctx bị hủy
+func (s *uploadService) StartJanitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Upload janitor stopped")
+			return
+		case <-ticker.C:
+			cleaned, err := s.CleanupExpired()
+			if err != nil {
+				log.Printf("Upload janitor failed: %v", err)
+				continue
+			}
+			if cleaned > 0 {
+				log.Printf("Upload janitor removed %d expired uploads", cleaned)
+			}
+		}
+	}
+}
+
+// resourceTypeFor ánh xạ content type sang resource type của Cloudinary và giới hạn dung lượng tương ứng
+func resourceTypeFor(contentType string) (string, int64) {
+	switch {
+	case strings.HasPrefix(contentType, "image/"):
+		return "image", maxImageUploadBytes
+	case strings.HasPrefix(contentType, "video/"):
+		return "video", maxVideoUploadBytes
+	default:
+		return "", 0
+	}
+}
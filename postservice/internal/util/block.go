@@ -0,0 +1,194 @@
+package util
+
+import (
+	"context"
+	"log"
+	"postservice/internal/grpcclient"
+	pb "postservice/proto"
+	"sync"
+	"time"
+)
+
+// maxBlockPairsPerCall khớp với giới hạn số cặp mỗi request GetBlockRelations của UserService
+const maxBlockPairsPerCall = 1000
+
+// blockPair là cặp người dùng đã chuẩn hóa (ID nhỏ trước) vì quan hệ chặn có hiệu lực hai chiều
+type blockPair [2]uint64
+
+func newBlockPair(a, b uint64) blockPair {
+	if a > b {
+		a, b = b, a
+	}
+	return blockPair{a, b}
+}
+
+type blockEntry struct {
+	blocked   bool
+	expiresAt time.Time
+}
+
+type blockListEntry struct {
+	userIDs   []uint64
+	expiresAt time.Time
+}
+
+// BlockChecker kiểm tra quan hệ chặn giữa người dùng qua gRPC tới UserService. Kết quả (kể cả
+// "không chặn") được cache theo cặp trong ttl để feed không phải gọi UserService mỗi request,
+// vì vậy chặn/bỏ chặn có thể mất tối đa ttl mới có hiệu lực ở PostService.
+type BlockChecker struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	cache     map[blockPair]blockEntry
+	lists     map[uint64]blockListEntry // Danh sách đầy đủ người có quan hệ chặn theo người xem
+	lastSweep time.Time
+}
+
+// NewBlockChecker tạo BlockChecker, ttl <= 0 là không cache
+func NewBlockChecker(ttl time.Duration) *BlockChecker {
+	return &BlockChecker{
+		ttl:       ttl,
+		cache:     make(map[blockPair]blockEntry),
+		lists:     make(map[uint64]blockListEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+// IsBlocked cho biết một trong hai người dùng có đang chặn người kia
+func (b *BlockChecker) IsBlocked(userID, otherUserID uint64) (bool, error) {
+	blocked, err := b.BlockedUsers(userID, []uint64{otherUserID})
+	return blocked[otherUserID], err
+}
+
+// BlockedUsers trả về các user trong userIDs có quan hệ chặn (theo cả hai chiều) với viewerID.
+// viewerID = 0 (chưa đăng nhập) hoặc BlockChecker nil thì không ai bị chặn.
+func (b *BlockChecker) BlockedUsers(viewerID uint64, userIDs []uint64) (map[uint64]bool, error) {
+	blocked := make(map[uint64]bool)
+	if b == nil || viewerID == 0 || len(userIDs) == 0 {
+		return blocked, nil
+	}
+
+	now := time.Now()
+	var missing []uint64
+	b.mu.Lock()
+	b.sweep(now)
+	seen := make(map[uint64]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		if _, ok := seen[id]; ok || id == viewerID {
+			continue
+		}
+		seen[id] = struct{}{}
+		if entry, ok := b.cache[newBlockPair(viewerID, id)]; ok && now.Before(entry.expiresAt) {
+			if entry.blocked {
+				blocked[id] = true
+			}
+			continue
+		}
+		missing = append(missing, id)
+	}
+	b.mu.Unlock()
+
+	if len(missing) == 0 {
+		return blocked, nil
+	}
+
+	fetched, err := fetchBlockedUsers(viewerID, missing)
+	if err != nil {
+		return blocked, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, id := range missing {
+		if fetched[id] {
+			blocked[id] = true
+		}
+		if b.ttl > 0 {
+			b.cache[newBlockPair(viewerID, id)] = blockEntry{blocked: fetched[id], expiresAt: now.Add(b.ttl)}
+		}
+	}
+	return blocked, nil
+}
+
+// BlockedUserIDs trả về mọi người dùng có quan hệ chặn (theo cả hai chiều) với viewerID, dùng để
+// loại nội dung của họ ngay trong truy vấn danh sách. viewerID = 0 hoặc BlockChecker nil thì trả về rỗng.
+func (b *BlockChecker) BlockedUserIDs(viewerID uint64) ([]uint64, error) {
+	if b == nil || viewerID == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	b.mu.Lock()
+	b.sweep(now)
+	entry, ok := b.lists[viewerID]
+	b.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.userIDs, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	resp, err := grpcclient.UserServiceClient.GetBlockedUserIDs(ctx, &pb.GetBlockedUserIDsRequest{UserId: viewerID})
+	if err != nil {
+		log.Printf("Failed to call GetBlockedUserIDs: %v", err)
+		return nil, err
+	}
+
+	if b.ttl > 0 {
+		b.mu.Lock()
+		b.lists[viewerID] = blockListEntry{userIDs: resp.UserIds, expiresAt: now.Add(b.ttl)}
+		b.mu.Unlock()
+	}
+	return resp.UserIds, nil
+}
+
+// sweep xóa các entry hết hạn để cache không phình ra theo số cặp người dùng. Phải giữ b.mu khi gọi.
+func (b *BlockChecker) sweep(now time.Time) {
+	if now.Sub(b.lastSweep) < time.Minute {
+		return
+	}
+	for pair, entry := range b.cache {
+		if !now.Before(entry.expiresAt) {
+			delete(b.cache, pair)
+		}
+	}
+	for viewerID, entry := range b.lists {
+		if !now.Before(entry.expiresAt) {
+			delete(b.lists, viewerID)
+		}
+	}
+	b.lastSweep = now
+}
+
+// fetchBlockedUsers gọi GetBlockRelations cho các cặp (viewerID, userID), chia lô theo giới hạn của UserService
+func fetchBlockedUsers(viewerID uint64, userIDs []uint64) (map[uint64]bool, error) {
+	blocked := make(map[uint64]bool)
+	for start := 0; start < len(userIDs); start += maxBlockPairsPerCall {
+		end := start + maxBlockPairsPerCall
+		if end > len(userIDs) {
+			end = len(userIDs)
+		}
+
+		pairs := make([]*pb.UserPair, 0, end-start)
+		for _, id := range userIDs[start:end] {
+			pairs = append(pairs, &pb.UserPair{UserId: viewerID, OtherUserId: id})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		resp, err := grpcclient.UserServiceClient.GetBlockRelations(ctx, &pb.GetBlockRelationsRequest{Pairs: pairs})
+		cancel()
+		if err != nil {
+			log.Printf("Failed to call GetBlockRelations: %v", err)
+			return nil, err
+		}
+
+		for _, relation := range resp.Blocks {
+			if relation.BlockerId == viewerID {
+				blocked[relation.BlockedId] = true
+			} else {
+				blocked[relation.BlockerId] = true
+			}
+		}
+	}
+	return blocked, nil
+}
@@ -1,15 +1,21 @@
 package util
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"log"
+	"net/url"
 	"os"
+	"postservice/internal/model"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cloudinary/cloudinary-go/v2"
 	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
 	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
 	"github.com/joho/godotenv"
 )
@@ -46,8 +52,85 @@ func NewCloudinaryUploader() (*CloudinaryUploader, error) {
 	return &CloudinaryUploader{client: cld}, nil
 }
 
-// UploadImage upload một file ảnh lên Cloudinary và trả về URL
+// UploadedImage là ảnh đã được xử lý và upload cùng các variant
+type UploadedImage struct {
+	URL           string
+	Width         int
+	Height        int
+	BlurHash      string
+	DominantColor string
+	Variants      model.MediaVariants
+}
+
+// UploadImage xử lý ảnh (xoay, giới hạn kích thước, xóa metadata) rồi upload lên Cloudinary và trả về URL
 func (u *CloudinaryUploader) UploadImage(file interface{}, publicID string) (string, error) {
+	reader, ok := file.(io.Reader)
+	if !ok {
+		// Không đọc được nội dung (vd: URL), upload nguyên trạng
+		return u.uploadToCloudinary(file, publicID)
+	}
+
+	original, _, err := ProcessImage(reader)
+	if err != nil {
+		return "", err
+	}
+	return u.uploadToCloudinary(bytes.NewReader(original.Data), publicID)
+}
+
+// UploadImageWithVariants xử lý ảnh, upload ảnh gốc và các variant (thumbnail/medium/large)
+func (u *CloudinaryUploader) UploadImageWithVariants(file interface{}, publicID string) (*UploadedImage, error) {
+	reader, ok := file.(io.Reader)
+	if !ok {
+		url, err := u.uploadToCloudinary(file, publicID)
+		if err != nil {
+			return nil, err
+		}
+		return &UploadedImage{URL: url}, nil
+	}
+
+	original, variants, err := ProcessImage(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := u.uploadToCloudinary(bytes.NewReader(original.Data), publicID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &UploadedImage{
+		URL:           url,
+		Width:         original.Width,
+		Height:        original.Height,
+		BlurHash:      original.Placeholder.BlurHash,
+		DominantColor: original.Placeholder.DominantColor,
+	}
+	generated := make(map[string]ProcessedImage, len(variants))
+	for _, v := range variants {
+		generated[v.Name] = v
+	}
+	for _, spec := range imageVariantSpecs {
+		v, ok := generated[spec.name]
+		if !ok {
+			// Ảnh gốc đã đủ nhỏ, variant dùng lại ảnh gốc
+			result.Variants = append(result.Variants, model.MediaVariant{
+				Name: spec.name, URL: url, Width: original.Width, Height: original.Height,
+			})
+			continue
+		}
+		variantURL, err := u.uploadToCloudinary(bytes.NewReader(v.Data), publicID+"_"+v.Name)
+		if err != nil {
+			return nil, err
+		}
+		result.Variants = append(result.Variants, model.MediaVariant{
+			Name: v.Name, URL: variantURL, Width: v.Width, Height: v.Height,
+		})
+	}
+	return result, nil
+}
+
+// uploadToCloudinary upload nguyên trạng một file lên Cloudinary và trả về URL
+func (u *CloudinaryUploader) uploadToCloudinary(file interface{}, publicID string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -79,8 +162,8 @@ func (u *CloudinaryUploader) UploadImage(file interface{}, publicID string) (str
 	return resp.SecureURL, nil
 }
 
-// UploadImages upload nhiều file ảnh và trả về danh sách URL
-func (u *CloudinaryUploader) UploadImages(files []interface{}) ([]string, error) {
+// UploadImages xử lý và upload nhiều file ảnh kèm variant
+func (u *CloudinaryUploader) UploadImages(files []interface{}) ([]UploadedImage, error) {
 	if len(files) == 0 {
 		log.Println("No files to upload")
 		return nil, nil
@@ -93,17 +176,61 @@ func (u *CloudinaryUploader) UploadImages(files []interface{}) ([]string, error)
 		return nil, fmt.Errorf("maximum of %d images allowed, got %d", maxImages, len(files))
 	}
 
-	var urls []string
+	var images []UploadedImage
 	for i, file := range files {
 		publicID := fmt.Sprintf("post_image_%d_%d", time.Now().UnixNano(), i)
-		url, err := u.UploadImage(file, publicID)
+		img, err := u.UploadImageWithVariants(file, publicID)
 		if err != nil {
 			log.Printf("Failed to upload image %d: %v", i, err)
 			return nil, err
 		}
-		urls = append(urls, url)
+		images = append(images, *img)
+	}
+	return images, nil
+}
+
+// DeleteImageWithVariants xóa ảnh gốc và các variant được upload riêng
+func (u *CloudinaryUploader) DeleteImageWithVariants(mediaURL string, variants model.MediaVariants) error {
+	if err := u.DeleteImage(mediaURL); err != nil {
+		return err
+	}
+
+	mainPublicID := PublicIDFromURL(mediaURL)
+	for _, v := range variants {
+		// Variant dùng lại ảnh gốc hoặc là transformation của ảnh gốc thì đã bị xóa cùng ảnh gốc
+		if PublicIDFromURL(v.URL) == mainPublicID || v.URL == mediaURL {
+			continue
+		}
+		if err := u.DeleteImage(v.URL); err != nil {
+			log.Printf("Failed to delete variant %s: %v", v.URL, err)
+		}
+	}
+	return nil
+}
+
+// TransformationVariants sinh variant bằng transformation của Cloudinary cho ảnh đã có sẵn trên storage
+// (dùng cho upload trực tiếp, khi server không xử lý được file trước khi lưu)
+func TransformationVariants(mediaURL string, width, height int) model.MediaVariants {
+	variants := make(model.MediaVariants, 0, len(imageVariantSpecs))
+	for _, spec := range imageVariantSpecs {
+		w, h := fitDimensions(width, height, spec.size)
+		if spec.crop {
+			w, h = spec.size, spec.size
+		}
+		variants = append(variants, model.MediaVariant{
+			Name:   spec.name,
+			URL:    withTransformation(mediaURL, variantTransformation(spec)),
+			Width:  w,
+			Height: h,
+		})
 	}
-	return urls, nil
+	return variants
+}
+
+// IncomingImageTransformation là transformation áp dụng khi client upload trực tiếp:
+// giới hạn kích thước ảnh gốc, Cloudinary tự xoay theo EXIF và loại bỏ metadata
+func IncomingImageTransformation() string {
+	return fmt.Sprintf("c_limit,w_%d,h_%d", maxImageDimension, maxImageDimension)
 }
 
 // DeleteImage xóa một ảnh trên Cloudinary dựa trên URL
@@ -112,7 +239,7 @@ func (u *CloudinaryUploader) DeleteImage(mediaURL string) error {
 	defer cancel()
 
 	// Lấy PublicID từ URL
-	publicID := extractPublicIDFromURL(mediaURL)
+	publicID := PublicIDFromURL(mediaURL)
 	if publicID == "" {
 		return fmt.Errorf("invalid media URL: %s", mediaURL)
 	}
@@ -130,18 +257,170 @@ func (u *CloudinaryUploader) DeleteImage(mediaURL string) error {
 	return nil
 }
 
-// extractPublicIDFromURL trích xuất PublicID từ SecureURL của Cloudinary
-func extractPublicIDFromURL(url string) string {
+// UploadedAsset chứa thông tin cơ bản của một file đã có trên Cloudinary
+type UploadedAsset struct {
+	PublicID     string
+	SecureURL    string
+	Format       string
+	ResourceType string
+	Bytes        int64
+	Width        int
+	Height       int
+	CreatedAt    time.Time
+}
+
+// SignUpload tạo chữ ký cho phép client upload trực tiếp một file lên Cloudinary
+// với publicID cố định. Trả về URL upload và các field cần gửi kèm trong form.
+func (u *CloudinaryUploader) SignUpload(publicID, resourceType string) (string, map[string]string, error) {
+	cloud := u.client.Config.Cloud
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	params := url.Values{}
+	params.Set("public_id", publicID)
+	params.Set("timestamp", timestamp)
+	if resourceType == "image" {
+		// Ảnh upload trực tiếp cũng được giới hạn kích thước và xóa metadata ngay khi lưu
+		params.Set("transformation", IncomingImageTransformation())
+	}
+
+	signature, err := api.SignParameters(params, cloud.APISecret)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to sign upload params: %v", err)
+	}
+
+	uploadURL := fmt.Sprintf("https://api.cloudinary.com/v1_1/%s/%s/upload", cloud.CloudName, resourceType)
+	fields := map[string]string{
+		"api_key":   cloud.APIKey,
+		"public_id": publicID,
+		"timestamp": timestamp,
+		"signature": signature,
+	}
+	if transformation := params.Get("transformation"); transformation != "" {
+		fields["transformation"] = transformation
+	}
+	return uploadURL, fields, nil
+}
+
+// GetAsset lấy thông tin file đã upload trên Cloudinary theo publicID
+func (u *CloudinaryUploader) GetAsset(publicID, resourceType string) (*UploadedAsset, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := u.client.Admin.Asset(ctx, admin.AssetParams{
+		AssetType: api.AssetType(resourceType),
+		PublicID:  publicID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get asset from Cloudinary: %v", err)
+	}
+	if resp.Error.Message != "" {
+		return nil, fmt.Errorf("Cloudinary error: %s", resp.Error.Message)
+	}
+	if resp.SecureURL == "" {
+		return nil, fmt.Errorf("asset %s not found", publicID)
+	}
+
+	return &UploadedAsset{
+		PublicID:     resp.PublicID,
+		SecureURL:    resp.SecureURL,
+		Format:       resp.Format,
+		ResourceType: resp.ResourceType,
+		Bytes:        int64(resp.Bytes),
+		Width:        resp.Width,
+		Height:       resp.Height,
+		CreatedAt:    resp.CreatedAt,
+	}, nil
+}
+
+// ListAssets liệt kê toàn bộ file trên Cloudinary có publicID bắt đầu bằng prefix (vd: "posts/")
+func (u *CloudinaryUploader) ListAssets(prefix, resourceType string) ([]UploadedAsset, error) {
+	var assets []UploadedAsset
+	nextCursor := ""
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		resp, err := u.client.Admin.Assets(ctx, admin.AssetsParams{
+			AssetType:    api.AssetType(resourceType),
+			DeliveryType: "upload",
+			Prefix:       prefix,
+			MaxResults:   500,
+			NextCursor:   nextCursor,
+		})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list assets from Cloudinary: %v", err)
+		}
+		if resp.Error.Message != "" {
+			return nil, fmt.Errorf("Cloudinary error: %s", resp.Error.Message)
+		}
+
+		for _, a := range resp.Assets {
+			assets = append(assets, UploadedAsset{
+				PublicID:     a.PublicID,
+				SecureURL:    a.SecureURL,
+				Format:       a.Format,
+				ResourceType: a.AssetType,
+				Bytes:        int64(a.Bytes),
+				CreatedAt:    a.CreatedAt,
+			})
+		}
+
+		if resp.NextCursor == "" {
+			return assets, nil
+		}
+		nextCursor = resp.NextCursor
+	}
+}
+
+// DeleteByPublicID xóa một file trên Cloudinary dựa trên publicID
+func (u *CloudinaryUploader) DeleteByPublicID(publicID, resourceType string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := u.client.Upload.Destroy(ctx, uploader.DestroyParams{
+		PublicID:     publicID,
+		ResourceType: resourceType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete asset from Cloudinary: %v", err)
+	}
+	if resp.Error.Message != "" {
+		return fmt.Errorf("Cloudinary error: %s", resp.Error.Message)
+	}
+	return nil
+}
+
+// PublicIDFromURL trích xuất PublicID từ SecureURL của Cloudinary
+func PublicIDFromURL(url string) string {
 	// Ví dụ URL: https://res.cloudinary.com/dgncir2mb/image/upload/v1234567890/posts/post_image_1234567890_0.jpg
-	parts := strings.Split(url, "/")
-	if len(parts) < 7 {
+	idx := strings.Index(url, "/upload/")
+	if idx == -1 {
 		return ""
 	}
-	// PublicID nằm ở phần sau "upload/", thường là "posts/post_image_..."
-	publicID := strings.Join(parts[7:], "/")
+	// PublicID nằm ở phần sau "upload/", bỏ qua segment version (v1234567890) nếu có
+	parts := strings.Split(url[idx+len("/upload/"):], "/")
+	// Bỏ qua các segment transformation (vd: c_limit,w_640) đứng trước version
+	for i, part := range parts {
+		if i < len(parts)-1 && isVersionSegment(part) {
+			parts = parts[i+1:]
+			break
+		}
+	}
+	for len(parts) > 1 && strings.Contains(parts[0], ",") {
+		parts = parts[1:]
+	}
+	publicID := strings.Join(parts, "/")
 	// Loại bỏ extension (nếu có)
-	if extIndex := strings.LastIndex(publicID, "."); extIndex != -1 {
+	if extIndex := strings.LastIndex(publicID, "."); extIndex != -1 && extIndex > strings.LastIndex(publicID, "/") {
 		publicID = publicID[:extIndex]
 	}
 	return publicID
 }
+
+// isVersionSegment kiểm tra segment có dạng v<số> của Cloudinary
+func isVersionSegment(segment string) bool {
+	if len(segment) < 2 || segment[0] != 'v' {
+		return false
+	}
+	_, err := strconv.ParseUint(segment[1:], 10, 64)
+	return err == nil
+}
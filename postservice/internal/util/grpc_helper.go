@@ -124,3 +124,144 @@ func GetUserIDByUsername(username string) (uint64, error) {
 
 	return 0, errors.New("user not found")
 }
+
+// ListMediaReferences lấy toàn bộ URL ảnh đang được UserService tham chiếu (source: "users" hoặc "groups")
+func ListMediaReferences(source string) ([]string, error) {
+	var urls []string
+	var afterID uint64
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		resp, err := grpcclient.UserServiceClient.ListMediaReferences(ctx, &pb.ListMediaReferencesRequest{
+			Source:  source,
+			AfterId: afterID,
+			Limit:   1000,
+		})
+		cancel()
+		if err != nil {
+			log.Printf("Failed to call ListMediaReferences: %v", err)
+			return nil, err
+		}
+
+		urls = append(urls, resp.Urls...)
+		if !resp.HasMore {
+			return urls, nil
+		}
+		afterID = resp.NextAfterId
+	}
+}
+
+// FetchMutedTargets lấy người dùng và nhóm mà userID đang tắt tiếng/tạm ẩn từ UserService
+func FetchMutedTargets(userID uint64) (model.MutedTargets, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	resp, err := grpcclient.UserServiceClient.GetMutedTargets(ctx, &pb.GetMutedTargetsRequest{UserId: userID})
+	if err != nil {
+		return model.MutedTargets{}, err
+	}
+	return model.MutedTargets{UserIDs: resp.UserIds, GroupIDs: resp.GroupIds}, nil
+}
+
+// FetchFeedSources lấy những người mà userID đang theo dõi và bạn bè của userID từ UserService
+func FetchFeedSources(userID uint64) (model.FeedSources, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	resp, err := grpcclient.UserServiceClient.GetFeedSources(ctx, &pb.GetFeedSourcesRequest{UserId: userID})
+	if err != nil {
+		return model.FeedSources{}, err
+	}
+	return model.FeedSources{FollowingIDs: resp.FollowingIds, FriendIDs: resp.FriendIds}, nil
+}
+
+// FetchViewerAudience lấy bạn bè của viewerID và các danh sách bạn bè có viewerID là thành viên từ UserService
+func FetchViewerAudience(viewerID uint64) (model.ViewerAudience, error) {
+	audience := model.ViewerAudience{ViewerID: viewerID}
+	if viewerID == 0 {
+		return audience, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	resp, err := grpcclient.UserServiceClient.GetViewerAudience(ctx, &pb.GetViewerAudienceRequest{ViewerId: viewerID})
+	if err != nil {
+		log.Printf("Failed to call GetViewerAudience: %v", err)
+		return audience, err
+	}
+	audience.FriendIDs = resp.FriendIds
+	audience.ListIDs = resp.ListIds
+	audience.GroupIDs = resp.GroupIds
+	return audience, nil
+}
+
+// ValidateFriendLists trả về các ID trong listIDs không phải danh sách bạn bè của ownerID
+func ValidateFriendLists(ownerID uint64, listIDs []uint64) ([]uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	resp, err := grpcclient.UserServiceClient.ValidateFriendLists(ctx, &pb.ValidateFriendListsRequest{OwnerId: ownerID, ListIds: listIDs})
+	if err != nil {
+		log.Printf("Failed to call ValidateFriendLists: %v", err)
+		return nil, err
+	}
+	return resp.InvalidListIds, nil
+}
+
+// CheckAudiences kiểm tra viewerID có được xem các bài đăng CUSTOM hay không, kết quả theo thứ tự posts
+func CheckAudiences(viewerID uint64, posts []model.PostResponse) ([]bool, error) {
+	checks := make([]*pb.AudienceCheck, 0, len(posts))
+	for _, post := range posts {
+		checks = append(checks, &pb.AudienceCheck{
+			OwnerId:        post.UserID,
+			IncludeListIds: post.AudienceListIDs,
+			ExcludeListIds: post.ExcludedListIDs,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	resp, err := grpcclient.UserServiceClient.CheckAudiences(ctx, &pb.CheckAudiencesRequest{ViewerId: viewerID, Checks: checks})
+	if err != nil {
+		log.Printf("Failed to call CheckAudiences: %v", err)
+		return nil, err
+	}
+	if len(resp.Allowed) != len(posts) {
+		return nil, errors.New("unexpected CheckAudiences response length")
+	}
+	return resp.Allowed, nil
+}
+
+// HasGroupPermission kiểm tra userID có quyền permission trong nhóm groupID hay không
+func HasGroupPermission(groupID, userID uint64, permission string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	resp, err := grpcclient.UserServiceClient.CheckGroupPermission(ctx, &pb.CheckGroupPermissionRequest{
+		GroupId:    groupID,
+		UserId:     userID,
+		Permission: permission,
+	})
+	if err != nil {
+		log.Printf("Failed to call CheckGroupPermission: %v", err)
+		return false, err
+	}
+	return resp.Allowed, nil
+}
+
+// CheckGroupPosting cho biết userID có là thành viên đã duyệt và có đang bị tắt tiếng trong nhóm groupID
+func CheckGroupPosting(groupID, userID uint64) (isMember, isMuted bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	resp, err := grpcclient.UserServiceClient.CheckGroupPosting(ctx, &pb.CheckGroupPostingRequest{
+		GroupId: groupID,
+		UserId:  userID,
+	})
+	if err != nil {
+		log.Printf("Failed to call CheckGroupPosting: %v", err)
+		return false, false, err
+	}
+	return resp.IsMember, resp.IsMuted, nil
+}
@@ -0,0 +1,178 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // Đăng ký decoder GIF
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	_ "golang.org/x/image/webp" // Đăng ký decoder WebP
+)
+
+// Kích thước tối đa (cạnh dài) của ảnh gốc sau khi xử lý
+const maxImageDimension = 2048
+
+// Chất lượng nén JPEG cho ảnh gốc và các variant
+const jpegQuality = 85
+
+// imageVariantSpec mô tả một variant cần sinh ra
+type imageVariantSpec struct {
+	name string
+	size int
+	crop bool // true: cắt vuông đúng size x size, false: thu nhỏ giữ tỉ lệ trong khung size x size
+}
+
+// Các variant được sinh cho mỗi ảnh
+var imageVariantSpecs = []imageVariantSpec{
+	{name: "thumbnail", size: 160, crop: true},
+	{name: "medium", size: 640},
+	{name: "large", size: 1280},
+}
+
+// ErrInvalidImage trả về khi file không phải ảnh hợp lệ
+var ErrInvalidImage = errors.New("invalid image file")
+
+// ErrImageTooLarge trả về khi ảnh có số điểm ảnh vượt giới hạn
+var ErrImageTooLarge = errors.New("image dimensions exceed limit")
+
+// maxImagePixels là số điểm ảnh (rộng x cao) tối đa được giải mã. Kích thước được đọc từ header trước
+// khi giải mã nên file nhỏ khai báo kích thước khổng lồ (decompression bomb) bị từ chối ngay.
+var maxImagePixels int64 = 50_000_000
+
+// SetMaxImagePixels đặt số điểm ảnh tối đa được xử lý, gọi khi khởi động (<= 0 giữ mặc định)
+func SetMaxImagePixels(pixels int64) {
+	if pixels > 0 {
+		maxImagePixels = pixels
+	}
+}
+
+// ProcessedImage là một ảnh đã được xử lý và encode lại
+type ProcessedImage struct {
+	Name   string // Tên variant, rỗng với ảnh gốc
+	Data   []byte
+	Width  int
+	Height int
+	// Placeholder chỉ được tính cho ảnh gốc
+	Placeholder Placeholder
+}
+
+// ProcessImage xử lý ảnh trước khi upload: xoay theo EXIF, giới hạn kích thước,
+// encode lại để loại bỏ toàn bộ metadata (GPS, thiết bị...), sinh các variant và placeholder.
+// Variant nào không nhỏ hơn ảnh gốc sẽ không được sinh (dùng lại ảnh gốc).
+func ProcessImage(r io.Reader) (*ProcessedImage, []ProcessedImage, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read image: %v", err)
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width <= 0 || config.Height <= 0 {
+		return nil, nil, ErrInvalidImage
+	}
+	if int64(config.Width)*int64(config.Height) > maxImagePixels {
+		return nil, nil, ErrImageTooLarge
+	}
+
+	img, err := imaging.Decode(bytes.NewReader(data), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, nil, ErrInvalidImage
+	}
+
+	// PNG giữ nguyên định dạng để không mất kênh alpha, các định dạng khác chuyển về JPEG
+	encodeFormat := imaging.JPEG
+	if format == "png" {
+		encodeFormat = imaging.PNG
+	}
+
+	img = imaging.Fit(img, maxImageDimension, maxImageDimension, imaging.Lanczos)
+	original, err := encodeImage("", img, encodeFormat)
+	if err != nil {
+		return nil, nil, err
+	}
+	original.Placeholder = ComputePlaceholder(img)
+
+	var variants []ProcessedImage
+	for _, spec := range imageVariantSpecs {
+		bounds := img.Bounds()
+		if bounds.Dx() <= spec.size && bounds.Dy() <= spec.size {
+			continue
+		}
+
+		var resized image.Image
+		if spec.crop {
+			resized = imaging.Fill(img, spec.size, spec.size, imaging.Center, imaging.Lanczos)
+		} else {
+			resized = imaging.Fit(img, spec.size, spec.size, imaging.Lanczos)
+		}
+
+		variant, err := encodeImage(spec.name, resized, encodeFormat)
+		if err != nil {
+			return nil, nil, err
+		}
+		variants = append(variants, *variant)
+	}
+
+	return original, variants, nil
+}
+
+// encodeImage encode ảnh sang JPEG/PNG, không kèm metadata
+func encodeImage(name string, img image.Image, format imaging.Format) (*ProcessedImage, error) {
+	var buf bytes.Buffer
+	var err error
+	if format == imaging.PNG {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %v", err)
+	}
+
+	bounds := img.Bounds()
+	return &ProcessedImage{
+		Name:   name,
+		Data:   buf.Bytes(),
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+	}, nil
+}
+
+// variantTransformation trả về transformation Cloudinary tương ứng với một variant
+func variantTransformation(spec imageVariantSpec) string {
+	if spec.crop {
+		return fmt.Sprintf("c_fill,g_center,w_%d,h_%d", spec.size, spec.size)
+	}
+	return fmt.Sprintf("c_limit,w_%d,h_%d", spec.size, spec.size)
+}
+
+// fitDimensions tính kích thước sau khi thu nhỏ (width, height) để vừa khung size x size
+func fitDimensions(width, height, size int) (int, int) {
+	if width <= size && height <= size {
+		return width, height
+	}
+	if width >= height {
+		return size, height * size / width
+	}
+	return width * size / height, size
+}
+
+// withTransformation chèn transformation vào sau "/upload/" của URL Cloudinary
+func withTransformation(url, transformation string) string {
+	return strings.Replace(url, "/upload/", "/upload/"+transformation+"/", 1)
+}
+
+// ThumbnailURL trả về URL bản thumbnail (dựng bằng transformation) của một ảnh trên Cloudinary
+func ThumbnailURL(url string) string {
+	for _, spec := range imageVariantSpecs {
+		if spec.name == "thumbnail" {
+			return withTransformation(url, variantTransformation(spec))
+		}
+	}
+	return url
+}
@@ -0,0 +1,50 @@
+package util
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Các nhóm ký tự tiếng Việt có dấu và ký tự không dấu tương ứng
+var vietnameseDiacritics = map[rune]string{
+	'a': "àáảãạăằắẳẵặâầấẩẫậ",
+	'e': "èéẻẽẹêềếểễệ",
+	'i': "ìíỉĩị",
+	'o': "òóỏõọôồốổỗộơờớởỡợ",
+	'u': "ùúủũụưừứửữự",
+	'y': "ỳýỷỹỵ",
+	'd': "đ",
+}
+
+// vietnameseReplacer chuyển ký tự có dấu (đã viết thường) về không dấu
+var vietnameseReplacer = func() *strings.Replacer {
+	var pairs []string
+	for base, chars := range vietnameseDiacritics {
+		for _, c := range chars {
+			pairs = append(pairs, string(c), string(base))
+		}
+	}
+	return strings.NewReplacer(pairs...)
+}()
+
+// RemoveVietnameseDiacritics bỏ dấu tiếng Việt và chuyển về chữ thường, vd: "Đồ Ngốc" -> "do ngoc".
+// Văn bản được chuẩn hóa NFC trước để dạng tổ hợp (NFD, chữ cái kèm dấu rời, thường gặp từ bàn
+// phím macOS/iOS) cũng được bỏ dấu, dấu rời còn sót lại sau đó bị loại bỏ.
+func RemoveVietnameseDiacritics(s string) string {
+	s = vietnameseReplacer.Replace(strings.ToLower(norm.NFC.String(s)))
+	return strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// NormalizeWords bỏ dấu, viết thường và tách văn bản thành các từ (bỏ dấu câu, ký tự đặc biệt)
+func NormalizeWords(s string) []string {
+	return strings.FieldsFunc(RemoveVietnameseDiacritics(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,53 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestRemoveVietnameseDiacritics(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "nfc", in: norm.NFC.String("Đồ Ngốc"), want: "do ngoc"},
+		{name: "nfd", in: norm.NFD.String("Đồ Ngốc"), want: "do ngoc"},
+		{name: "nfd stacked marks", in: norm.NFD.String("Ậm Ừ Ặc"), want: "am u ac"},
+		{name: "mixed forms", in: norm.NFC.String("Tiếng ") + norm.NFD.String("Việt"), want: "tieng viet"},
+		{name: "stray combining mark", in: "x́", want: "x"},
+		{name: "no diacritics", in: "Hello World", want: "hello world"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RemoveVietnameseDiacritics(tt.in); got != tt.want {
+				t.Errorf("RemoveVietnameseDiacritics(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeWords(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{name: "nfc", in: norm.NFC.String("Đồ ngốc, đồ ngốc!"), want: []string{"do", "ngoc", "do", "ngoc"}},
+		{name: "nfd", in: norm.NFD.String("Đồ ngốc, đồ ngốc!"), want: []string{"do", "ngoc", "do", "ngoc"}},
+		{name: "punctuation only", in: "?!...", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeWords(tt.in)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NormalizeWords(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+// Package jwtauth kiểm tra access token JWT (HS256, RS256 qua JWKS, exp và iss), dùng chung cho các service Go.
+package jwtauth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Config cấu hình xác thực chữ ký JWT. Bắt buộc có Secret hoặc JWKS, service không chạy
+// nếu không kiểm tra được chữ ký.
+type Config struct {
+	Secret      string        // Khóa dùng chung cho HS256
+	JWKSURL     string        // URL JWKS chứa khóa công khai cho RS256
+	JWKSFile    string        // Đường dẫn file JWKS, dùng khi không có JWKSURL
+	Issuer      string        // Giá trị iss bắt buộc, rỗng là không kiểm tra
+	JWKSRefresh time.Duration // Chu kỳ tải lại JWKS từ URL
+}
+
+// Enabled cho biết có khóa để kiểm tra chữ ký
+func (c Config) Enabled() bool {
+	return c.Secret != "" || c.JWKSURL != "" || c.JWKSFile != ""
+}
+
+// Verifier kiểm tra chữ ký (HS256/RS256), exp và iss của access token
+type Verifier struct {
+	cfg    Config
+	parser *jwt.Parser
+
+	mu        sync.RWMutex
+	keys      map[string]*rsa.PublicKey // Khóa RS256 theo kid
+	fetchedAt time.Time
+}
+
+// minJWKSRefetch giới hạn số lần tải lại JWKS khi gặp kid lạ, tránh bị lợi dụng để spam JWKS URL
+const minJWKSRefetch = time.Minute
+
+var defaultVerifier *Verifier
+
+// NewVerifier tạo verifier và tải JWKS (nếu có) ngay để phát hiện cấu hình sai khi khởi động
+func NewVerifier(cfg Config) (*Verifier, error) {
+	if !cfg.Enabled() {
+		return nil, errors.New("no JWT secret or JWKS configured")
+	}
+
+	var methods []string
+	if cfg.Secret != "" {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	v := &Verifier{cfg: cfg}
+	if cfg.JWKSURL != "" || cfg.JWKSFile != "" {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+		if err := v.loadJWKS(); err != nil {
+			return nil, err
+		}
+	}
+	v.parser = jwt.NewParser(jwt.WithValidMethods(methods))
+	return v, nil
+}
+
+// Init khởi tạo verifier dùng chung cho JWTMiddleware, trả lỗi nếu chưa cấu hình khóa
+func Init(cfg Config) error {
+	if !cfg.Enabled() {
+		return errors.New("JWT_SECRET, JWT_JWKS_URL or JWT_JWKS_FILE must be set")
+	}
+	v, err := NewVerifier(cfg)
+	if err != nil {
+		return err
+	}
+	defaultVerifier = v
+	return nil
+}
+
+// Parse kiểm tra token bằng verifier dùng chung, từ chối mọi token khi verifier chưa được khởi tạo
+func Parse(tokenString string) (jwt.MapClaims, error) {
+	if defaultVerifier == nil {
+		return nil, errors.New("JWT verifier is not initialized")
+	}
+	return defaultVerifier.Verify(tokenString)
+}
+
+// Verify kiểm tra chữ ký, hạn dùng (bắt buộc có exp) và issuer của token
+func (v *Verifier) Verify(tokenString string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(tokenString, claims, v.keyFunc); err != nil {
+		return nil, err
+	}
+	// Parser chỉ kiểm tra exp khi có, access token bắt buộc phải có hạn dùng
+	if _, ok := claims["exp"]; !ok {
+		return nil, errors.New("token has no expiration")
+	}
+	if v.cfg.Issuer != "" && !claims.VerifyIssuer(v.cfg.Issuer, true) {
+		return nil, errors.New("invalid token issuer")
+	}
+	return claims, nil
+}
+
+func (v *Verifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if v.cfg.Secret == "" {
+			return nil, errors.New("HS256 tokens are not accepted")
+		}
+		return []byte(v.cfg.Secret), nil
+	case *jwt.SigningMethodRSA:
+		kid, _ := token.Header["kid"].(string)
+		return v.rsaKey(kid)
+	default:
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+}
+
+// rsaKey tìm khóa theo kid, tải lại JWKS từ URL khi hết hạn cache hoặc gặp kid lạ
+func (v *Verifier) rsaKey(kid string) (*rsa.PublicKey, error) {
+	key, stale := v.lookupKey(kid)
+	if key != nil && !stale {
+		return key, nil
+	}
+
+	if v.cfg.JWKSURL != "" {
+		v.mu.RLock()
+		canRefetch := time.Since(v.fetchedAt) >= minJWKSRefetch
+		v.mu.RUnlock()
+		if canRefetch || stale {
+			if err := v.loadJWKS(); err != nil {
+				log.Printf("Failed to refresh JWKS: %v", err)
+			}
+			key, _ = v.lookupKey(kid)
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("no RS256 key found for kid %q", kid)
+	}
+	return key, nil
+}
+
+// lookupKey tìm khóa trong cache, token không có kid chỉ dùng được khi JWKS có đúng một khóa
+func (v *Verifier) lookupKey(kid string) (*rsa.PublicKey, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	stale := v.cfg.JWKSURL != "" && v.cfg.JWKSRefresh > 0 && time.Since(v.fetchedAt) > v.cfg.JWKSRefresh
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, stale
+		}
+	}
+	return v.keys[kid], stale
+}
+
+// jwks là định dạng JSON Web Key Set (RFC 7517), chỉ đọc các khóa RSA
+type jwks struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+func (v *Verifier) loadJWKS() error {
+	var data []byte
+	var err error
+	if v.cfg.JWKSURL != "" {
+		data, err = fetchJWKS(v.cfg.JWKSURL)
+	} else {
+		data, err = os.ReadFile(v.cfg.JWKSFile)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load JWKS: %v", err)
+	}
+
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("invalid JWKS: %v", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return fmt.Errorf("invalid modulus for key %q: %v", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return fmt.Errorf("invalid exponent for key %q: %v", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return errors.New("JWKS contains no RSA signing keys")
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.fetchedAt = time.Now()
+	v.mu.Unlock()
+	return nil
+}
+
+func fetchJWKS(url string) ([]byte, error) {
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
@@ -0,0 +1,83 @@
+package jwtauth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const testJWTSecret = "test-secret"
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+func adminClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"userId": 1,
+		"roles":  []string{"admin"},
+		"iss":    "hoanhao-auth-service",
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestInitRequiresKey(t *testing.T) {
+	if err := Init(Config{}); err == nil {
+		t.Fatal("Init without secret or JWKS should fail")
+	}
+}
+
+func TestParseWithoutVerifier(t *testing.T) {
+	defaultVerifier = nil
+	token := signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), adminClaims())
+	if _, err := Parse(token); err == nil {
+		t.Fatal("Parse without verifier should reject every token")
+	}
+}
+
+func TestParse(t *testing.T) {
+	if err := Init(Config{Secret: testJWTSecret, Issuer: "hoanhao-auth-service"}); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	t.Cleanup(func() { defaultVerifier = nil })
+
+	noExp := adminClaims()
+	delete(noExp, "exp")
+	wrongIssuer := adminClaims()
+	wrongIssuer["iss"] = "attacker"
+	expired := adminClaims()
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "valid", token: signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), adminClaims())},
+		{name: "forged unsigned", token: signTestToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, adminClaims()), wantErr: true},
+		{name: "wrong secret", token: signTestToken(t, jwt.SigningMethodHS256, []byte("other-secret"), adminClaims()), wantErr: true},
+		{name: "no exp", token: signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), noExp), wantErr: true},
+		{name: "expired", token: signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), expired), wantErr: true},
+		{name: "wrong issuer", token: signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), wrongIssuer), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := Parse(tt.token)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Parse accepted token with roles %v", claims["roles"])
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Parse: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// KeyFunc trả về khóa bucket của request, vd: user:42 hoặc ip:10.0.0.1
+type KeyFunc func(c *gin.Context) string
+
+// Middleware giới hạn tần suất request của nhóm route theo token bucket, mỗi khóa do key trả về một bucket.
+// Vượt giới hạn thì trả về 429 kèm Retry-After và message, store lỗi thì cho request đi tiếp.
+func Middleware(limiter *Limiter, group string, key KeyFunc, message string) gin.HandlerFunc {
+	limit, enabled := limiter.Limit(group)
+	if !enabled {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		result, err := limiter.Take(group, key(c), limit)
+		if err != nil {
+			log.Printf("Rate limit store error for %s: %v", group, err)
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit.Requests))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		if !result.Allowed {
+			retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":       message,
+				"retry_after": retryAfter,
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const testMessage = "too many requests"
+
+// failingStore luôn lỗi, giả lập Redis không kết nối được
+type failingStore struct{}
+
+func (failingStore) Take(key string, limit Limit) (Result, error) {
+	return Result{}, errors.New("store unavailable")
+}
+
+func newTestRouter(limiter *Limiter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	key := func(c *gin.Context) string { return "user:" + c.GetHeader("X-User") }
+	r.GET("/", Middleware(limiter, "test", key, testMessage), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func serve(r *gin.Engine, user string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-User", user)
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware(t *testing.T) {
+	limiter := NewLimiter(NewMemoryStore(), map[string]Limit{"test": {Requests: 2, Per: time.Minute}})
+	r := newTestRouter(limiter)
+
+	for i := 0; i < 2; i++ {
+		if w := serve(r, "1"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want %d", i+1, w.Code, http.StatusOK)
+		}
+	}
+
+	w := serve(r, "1")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Errorf("missing Retry-After header")
+	}
+	if w.Header().Get("X-RateLimit-Remaining") != "0" {
+		t.Errorf("X-RateLimit-Remaining = %q, want 0", w.Header().Get("X-RateLimit-Remaining"))
+	}
+	if body := w.Body.String(); !strings.Contains(body, testMessage) {
+		t.Errorf("body %q does not contain message %q", body, testMessage)
+	}
+
+	// Mỗi khóa có bucket riêng
+	if w := serve(r, "2"); w.Code != http.StatusOK {
+		t.Fatalf("other key: status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestMiddlewareFailOpen(t *testing.T) {
+	limiter := NewLimiter(failingStore{}, map[string]Limit{"test": {Requests: 1, Per: time.Minute}})
+	r := newTestRouter(limiter)
+
+	for i := 0; i < 3; i++ {
+		if w := serve(r, "1"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want %d", i+1, w.Code, http.StatusOK)
+		}
+	}
+}
+
+func TestMiddlewareDisabled(t *testing.T) {
+	r := newTestRouter(nil)
+	for i := 0; i < 3; i++ {
+		if w := serve(r, "1"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want %d", i+1, w.Code, http.StatusOK)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Package ratelimit giới hạn tần suất request theo token bucket, dùng chung cho các service Go.
+// Bucket được lưu trong bộ nhớ của từng instance hoặc trên Redis để chia sẻ giữa các instance.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limit là giới hạn dạng token bucket: tối đa Requests request liên tiếp (burst),
+// sau đó hồi lại Requests token trong mỗi khoảng Per
+type Limit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// Enabled cho biết giới hạn có được bật
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Per > 0
+}
+
+func (l Limit) String() string {
+	if !l.Enabled() {
+		return "off"
+	}
+	return fmt.Sprintf("%d/%s", l.Requests, l.Per)
+}
+
+// refillPerMs là số token hồi lại mỗi mili giây
+func (l Limit) refillPerMs() float64 {
+	return float64(l.Requests) / float64(l.Per.Milliseconds())
+}
+
+// Parse đọc giới hạn dạng "<số request>/<khoảng thời gian>", vd: "10/m", "30/10s", "100/1h".
+// "off" hoặc "0" là tắt giới hạn.
+func Parse(value string) (Limit, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" || value == "off" || value == "0" {
+		return Limit{}, nil
+	}
+
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q, expected <requests>/<period>", value)
+	}
+	requests, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || requests < 0 {
+		return Limit{}, fmt.Errorf("invalid request count in rate limit %q", value)
+	}
+
+	period := strings.TrimSpace(parts[1])
+	// Cho phép viết tắt "m" thay cho "1m"
+	if period != "" && (period[0] < '0' || period[0] > '9') {
+		period = "1" + period
+	}
+	per, err := time.ParseDuration(period)
+	if err != nil || per < time.Millisecond {
+		return Limit{}, fmt.Errorf("invalid period in rate limit %q", value)
+	}
+	return Limit{Requests: requests, Per: per}, nil
+}
+
+// Result là kết quả lấy token từ bucket
+type Result struct {
+	Allowed    bool
+	Remaining  int           // Số token còn lại sau request này
+	RetryAfter time.Duration // Thời gian chờ tới khi có token, chỉ có khi bị từ chối
+}
+
+// Store lưu trạng thái các token bucket
+type Store interface {
+	Take(key string, limit Limit) (Result, error)
+}
+
+// takeToken tính lại bucket tại thời điểm now (ms) và lấy một token nếu có
+func takeToken(tokens float64, updatedAt, now int64, limit Limit) (float64, Result) {
+	rate := limit.refillPerMs()
+	if now > updatedAt {
+		tokens = math.Min(float64(limit.Requests), tokens+float64(now-updatedAt)*rate)
+	}
+	if tokens >= 1 {
+		tokens--
+		return tokens, Result{Allowed: true, Remaining: int(tokens)}
+	}
+	wait := math.Ceil((1 - tokens) / rate)
+	return tokens, Result{RetryAfter: time.Duration(wait) * time.Millisecond}
+}
+
+type memoryBucket struct {
+	tokens    float64
+	updatedAt int64 // Unix ms
+	fullAt    int64 // Thời điểm bucket hồi đầy, sau đó có thể xóa
+}
+
+// MemoryStore lưu bucket trong bộ nhớ của từng instance
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*memoryBucket), lastSweep: time.Now()}
+}
+
+func (s *MemoryStore) Take(key string, limit Limit) (Result, error) {
+	now := time.Now()
+	nowMs := now.UnixMilli()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Dọn các bucket đã hồi đầy để map không phình ra theo số người dùng
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, b := range s.buckets {
+			if b.fullAt <= nowMs {
+				delete(s.buckets, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: float64(limit.Requests), updatedAt: nowMs}
+		s.buckets[key] = b
+	}
+
+	var result Result
+	b.tokens, result = takeToken(b.tokens, b.updatedAt, nowMs, limit)
+	b.updatedAt = nowMs
+	b.fullAt = nowMs + int64(math.Ceil((float64(limit.Requests)-b.tokens)/limit.refillPerMs()))
+	return result, nil
+}
+
+// Limiter áp dụng giới hạn theo nhóm route trên một store dùng chung
+type Limiter struct {
+	store  Store
+	limits map[string]Limit
+}
+
+func NewLimiter(store Store, limits map[string]Limit) *Limiter {
+	return &Limiter{store: store, limits: limits}
+}
+
+// Limit trả về giới hạn của nhóm route, false nếu nhóm không bị giới hạn
+func (l *Limiter) Limit(group string) (Limit, bool) {
+	if l == nil {
+		return Limit{}, false
+	}
+	limit, ok := l.limits[group]
+	return limit, ok && limit.Enabled()
+}
+
+// Take lấy một token trong bucket của key (vd: user:42) thuộc nhóm route
+func (l *Limiter) Take(group, key string, limit Limit) (Result, error) {
+	return l.store.Take("ratelimit:"+group+":"+key, limit)
+}
@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Limit
+		wantErr bool
+	}{
+		{in: "10/m", want: Limit{Requests: 10, Per: time.Minute}},
+		{in: "30/10s", want: Limit{Requests: 30, Per: 10 * time.Second}},
+		{in: "off", want: Limit{}},
+		{in: "0", want: Limit{}},
+		{in: "10", wantErr: true},
+		{in: "x/m", wantErr: true},
+		{in: "10/0s", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+// testBurst lấy hết burst của bucket rồi kiểm tra request tiếp theo bị từ chối
+func testBurst(t *testing.T, store Store) {
+	t.Helper()
+	limit := Limit{Requests: 3, Per: time.Minute}
+	for i := 0; i < limit.Requests; i++ {
+		result, err := store.Take("user:1", limit)
+		if err != nil {
+			t.Fatalf("Take #%d: %v", i+1, err)
+		}
+		if !result.Allowed || result.Remaining != limit.Requests-i-1 {
+			t.Fatalf("Take #%d = %+v, want allowed with %d remaining", i+1, result, limit.Requests-i-1)
+		}
+	}
+
+	result, err := store.Take("user:1", limit)
+	if err != nil {
+		t.Fatalf("Take: %v", err)
+	}
+	if result.Allowed || result.RetryAfter <= 0 {
+		t.Fatalf("Take after burst = %+v, want denied with retry after", result)
+	}
+
+	// Bucket của key khác không bị ảnh hưởng
+	if result, err := store.Take("user:2", limit); err != nil || !result.Allowed {
+		t.Fatalf("Take other key = %+v, %v, want allowed", result, err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testBurst(t, NewMemoryStore())
+}
+
+func TestRedisStore(t *testing.T) {
+	server := miniredis.RunT(t)
+	store, err := NewRedisStore("redis://" + server.Addr())
+	if err != nil {
+		t.Fatalf("NewRedisStore: %v", err)
+	}
+	defer store.Close()
+
+	testBurst(t, store)
+}
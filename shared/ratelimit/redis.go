@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisTimeout là thời gian tối đa cho một lần lấy token trên Redis
+const redisTimeout = 2 * time.Second
+
+// tokenBucketScript tính lại bucket và lấy một token một cách nguyên tử trên Redis.
+// Thời gian do client truyền vào để script tương thích cả Redis cũ không cho ghi sau TIME.
+// Script được gọi bằng EVALSHA, chỉ gửi lại toàn bộ nội dung khi Redis chưa cache (NOSCRIPT).
+var tokenBucketScript = redis.NewScript(`
+local burst = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil then
+  tokens = burst
+  ts = now
+end
+if now > ts then
+  tokens = math.min(burst, tokens + (now - ts) * rate)
+end
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  wait = math.ceil((1 - tokens) / rate)
+end
+redis.call('HMSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate) + 1000)
+return {allowed, math.floor(tokens), wait}
+`)
+
+// RedisStore lưu bucket trên Redis (hoặc server tương thích như KeyDB, Valkey, Dragonfly) để giới
+// hạn được chia sẻ giữa các instance. Client của go-redis dùng pool kết nối nên các request không
+// phải chờ nhau.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore kết nối tới Redis theo URL dạng redis://[:password@]host:port[/db] (rediss:// cho TLS)
+func NewRedisStore(rawURL string) (*RedisStore, error) {
+	opts, err := redis.ParseURL(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL %q: %v", rawURL, err)
+	}
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to redis: %v", err)
+	}
+	return &RedisStore{client: client}, nil
+}
+
+func (s *RedisStore) Take(key string, limit Limit) (Result, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	values, err := tokenBucketScript.Run(ctx, s.client, []string{key},
+		limit.Requests, limit.refillPerMs(), time.Now().UnixMilli()).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(values) != 3 {
+		return Result{}, fmt.Errorf("unexpected redis reply: %v", values)
+	}
+	return Result{
+		Allowed:    values[0] == 1,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
+
+// Close đóng các kết nối tới Redis
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
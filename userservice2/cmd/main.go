@@ -1,15 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/mysql"
 	"github.com/joho/godotenv"
+	"shared/jwtauth"
+	"shared/ratelimit"
 
 	"userservice2/controllers"
 	"userservice2/grpc"
@@ -48,6 +52,34 @@ func main() {
 	}
 	log.Println("Database migration completed")
 
+	// Xác thực chữ ký JWT tại service để request không qua Kong không giả mạo được userId
+	jwtIssuer := os.Getenv("JWT_ISSUER")
+	if jwtIssuer == "" {
+		jwtIssuer = "hoanhao-auth-service"
+	}
+	jwksRefresh := 10 * time.Minute
+	if refreshStr := os.Getenv("JWT_JWKS_REFRESH"); refreshStr != "" {
+		if refresh, err := time.ParseDuration(refreshStr); err == nil {
+			jwksRefresh = refresh
+		}
+	}
+	if err := jwtauth.Init(jwtauth.Config{
+		Secret:      os.Getenv("JWT_SECRET"),
+		JWKSURL:     os.Getenv("JWT_JWKS_URL"),
+		JWKSFile:    os.Getenv("JWT_JWKS_FILE"),
+		Issuer:      jwtIssuer,
+		JWKSRefresh: jwksRefresh,
+	}); err != nil {
+		log.Fatalf("Failed to initialize JWT verifier: %v", err)
+	}
+
+	// Giới hạn số điểm ảnh để không giải mã ảnh khai báo kích thước khổng lồ
+	if maxPixelsStr := os.Getenv("IMAGE_MAX_PIXELS"); maxPixelsStr != "" {
+		if maxPixels, err := strconv.ParseInt(maxPixelsStr, 10, 64); err == nil {
+			utils.SetMaxImagePixels(maxPixels)
+		}
+	}
+
 	// Khởi tạo Cloudinary uploader
 	cloudinaryUploader, err := utils.NewCloudinaryUploader()
 	if err != nil {
@@ -82,19 +114,107 @@ func main() {
 	friendshipRepo := repositories.NewFriendshipRepository(db)
 	userGroupRepo := repositories.NewUserGroupRepository(db)
 	groupMemberRepo := repositories.NewGroupMemberRepository(db)
+	groupRoleRepo := repositories.NewGroupRoleRepository(db)
+	groupJoinRequestRepo := repositories.NewGroupJoinRequestRepository(db)
+	groupInvitationRepo := repositories.NewGroupInvitationRepository(db)
+	groupOwnershipRepo := repositories.NewGroupOwnershipRepository(db)
+	groupModerationRepo := repositories.NewGroupModerationRepository(db)
+	groupRuleRepo := repositories.NewGroupRuleRepository(db)
+	groupAnnouncementRepo := repositories.NewGroupAnnouncementRepository(db)
+	mediaReferenceRepo := repositories.NewMediaReferenceRepository(db)
+	userReportRepo := repositories.NewUserReportRepository(db)
+	adminRepo := repositories.NewAdminRepository(db)
+	muteRepo := repositories.NewMuteRepository(db)
+	followRepo := repositories.NewFollowRepository(db)
+	friendListRepo := repositories.NewFriendListRepository(db)
+	suggestionRepo := repositories.NewFriendSuggestionRepository(db)
+	eventRepo := repositories.NewEventRepository(db)
 
 	// Initialize services
-	userService := services.NewUserService(userRepo, friendshipRepo)
-	friendshipService := services.NewFriendshipService(friendshipRepo, userRepo)
-	groupService := services.NewGroupService(userGroupRepo, groupMemberRepo, userRepo)
+	userService := services.NewUserService(userRepo, friendshipRepo, followRepo)
+	friendshipService := services.NewFriendshipService(friendshipRepo, userRepo, followRepo)
+
+	// Yêu cầu tham gia nhóm chưa được xử lý và lời mời chưa được trả lời hết hạn sau GROUP_JOIN_REQUEST_TTL
+	// và GROUP_INVITATION_TTL, 0 là không hết hạn
+	joinRequestTTL := durationFromEnv("GROUP_JOIN_REQUEST_TTL", 30*24*time.Hour)
+	invitationTTL := durationFromEnv("GROUP_INVITATION_TTL", 14*24*time.Hour)
+	groupService := services.NewGroupService(
+		userGroupRepo, groupMemberRepo, userRepo, groupRoleRepo,
+		groupJoinRequestRepo, joinRequestTTL, groupInvitationRepo, invitationTTL,
+		friendshipRepo, groupOwnershipRepo, groupModerationRepo, groupRuleRepo, groupAnnouncementRepo,
+	)
+	mediaReferenceService := services.NewMediaReferenceService(mediaReferenceRepo)
+
+	// Số người báo cáo khác nhau để tự động ẩn trang cá nhân, 0 là tắt
+	reportAutoHideThreshold := 5
+	if thresholdStr := os.Getenv("REPORT_AUTO_HIDE_THRESHOLD"); thresholdStr != "" {
+		if threshold, err := strconv.Atoi(thresholdStr); err == nil {
+			reportAutoHideThreshold = threshold
+		}
+	}
+	userReportService := services.NewUserReportService(userReportRepo, userRepo, reportAutoHideThreshold)
+	adminService := services.NewAdminService(adminRepo, userRepo, userGroupRepo)
+	muteService := services.NewMuteService(muteRepo, userRepo, userGroupRepo, groupMemberRepo)
+	followService := services.NewFollowService(followRepo, friendshipRepo, userRepo)
+	friendListService := services.NewFriendListService(friendListRepo, friendshipRepo)
+	eventService := services.NewEventService(eventRepo, userGroupRepo, groupMemberRepo, friendshipRepo, groupModerationRepo, groupService)
+
+	// Gợi ý kết bạn được tính lại định kỳ cho người dùng hoạt động gần đây, interval 0 là tắt
+	suggestionActiveWindow := durationFromEnv("FRIEND_SUGGESTION_ACTIVE_WINDOW", 30*24*time.Hour)
+	suggestionService := services.NewFriendSuggestionService(suggestionRepo, userRepo, suggestionActiveWindow)
+	if interval := durationFromEnv("FRIEND_SUGGESTION_INTERVAL", 6*time.Hour); interval > 0 {
+		scheduleCtx, stopSchedule := context.WithCancel(context.Background())
+		defer stopSchedule()
+		go suggestionService.StartSchedule(scheduleCtx, interval)
+	}
+
+	// Tác vụ nền của nhóm: đánh dấu hết hạn yêu cầu tham gia, lời mời, gỡ tắt tiếng hết hạn và khôi phục admin/chủ nhóm
+	if interval := durationFromEnv("GROUP_MAINTENANCE_INTERVAL", time.Hour); interval > 0 {
+		maintenanceCtx, stopMaintenance := context.WithCancel(context.Background())
+		defer stopMaintenance()
+		go groupService.StartMaintenance(maintenanceCtx, interval)
+	}
 
 	// Initialize controllers
 	userController := controllers.NewUserController(userService, cloudinaryUploader)
 	friendshipController := controllers.NewFriendshipController(friendshipService)
 	groupController := controllers.NewGroupController(groupService)
+	reportController := controllers.NewReportController(userReportService)
+	adminController := controllers.NewAdminController(adminService)
+	muteController := controllers.NewMuteController(muteService)
+	followController := controllers.NewFollowController(followService)
+	friendListController := controllers.NewFriendListController(friendListService)
+	suggestionController := controllers.NewFriendSuggestionController(suggestionService)
+	eventController := controllers.NewEventController(eventService)
+
+	// Giới hạn tần suất theo người dùng cho các route dễ bị spam, dùng Redis để chia sẻ giữa nhiều instance
+	var rateLimitStore ratelimit.Store
+	switch store := os.Getenv("RATE_LIMIT_STORE"); store {
+	case "", "memory":
+		rateLimitStore = ratelimit.NewMemoryStore()
+	case "redis":
+		redisURL := os.Getenv("RATE_LIMIT_REDIS_URL")
+		if redisURL == "" {
+			redisURL = "redis://localhost:6379/0"
+		}
+		redisStore, err := ratelimit.NewRedisStore(redisURL)
+		if err != nil {
+			log.Fatalf("Failed to initialize rate limit store: %v", err)
+		}
+		defer redisStore.Close()
+		rateLimitStore = redisStore
+	default:
+		log.Fatalf("Invalid RATE_LIMIT_STORE: %q (expected memory or redis)", store)
+	}
+	limiter := ratelimit.NewLimiter(rateLimitStore, map[string]ratelimit.Limit{
+		middlewares.RateLimitFriendAction: rateLimitFromEnv("RATE_LIMIT_FRIEND_ACTION", "20/m"),
+		middlewares.RateLimitGroupJoin:    rateLimitFromEnv("RATE_LIMIT_GROUP_JOIN", "10/m"),
+		middlewares.RateLimitGroupCreate:  rateLimitFromEnv("RATE_LIMIT_GROUP_CREATE", "5/h"),
+		middlewares.RateLimitUserReport:   rateLimitFromEnv("RATE_LIMIT_USER_REPORT", "10/m"),
+	})
 
 	// Setup routes
-	routes.SetupRoutes(router, userController, friendshipController, groupController)
+	routes.SetupRoutes(router, userController, friendshipController, groupController, reportController, adminController, muteController, followController, friendListController, suggestionController, eventController, limiter)
 
 	// Khởi động gRPC server trong một goroutine
 	grpcPort := 50051 // Port mặc định
@@ -104,7 +224,7 @@ func main() {
 		}
 	}
 	log.Printf("Starting gRPC server on port %d", grpcPort)
-	go grpc.StartGRPCServer(userService, grpcPort)
+	go grpc.StartGRPCServer(userService, mediaReferenceService, friendshipService, muteService, followService, friendListService, groupService, grpcPort)
 
 	// Start HTTP server
 	port := os.Getenv("PORT")
@@ -117,3 +237,32 @@ func main() {
 		log.Fatalf("Failed to start HTTP server: %v", err)
 	}
 }
+
+// rateLimitFromEnv đọc giới hạn tần suất dạng "<số request>/<khoảng>" (vd: "10/m", "off" là tắt)
+// từ env, dùng default nếu không có hoặc không hợp lệ
+func rateLimitFromEnv(key, defaultValue string) ratelimit.Limit {
+	value := os.Getenv(key)
+	if value == "" {
+		value = defaultValue
+	}
+	limit, err := ratelimit.Parse(value)
+	if err != nil {
+		log.Printf("Invalid rate limit for %s: %v, using default %s", key, err, defaultValue)
+		limit, _ = ratelimit.Parse(defaultValue)
+	}
+	return limit
+}
+
+// durationFromEnv đọc khoảng thời gian (vd: "6h") từ env, dùng defaultValue nếu không có hoặc không hợp lệ
+func durationFromEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration for %s: %v, using default %s", key, err, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"userservice2/dto/request"
+	"userservice2/services"
+)
+
+// AdminController xử lý các API quản trị người dùng và nhóm
+type AdminController struct {
+	adminService services.AdminService
+}
+
+// NewAdminController tạo instance mới của AdminController
+func NewAdminController(adminService services.AdminService) *AdminController {
+	return &AdminController{
+		adminService: adminService,
+	}
+}
+
+// SetUserStatus xử lý việc kích hoạt/vô hiệu hóa tài khoản
+func (c *AdminController) SetUserStatus(ctx *gin.Context) {
+	adminID, userID, ok := adminTarget(ctx, "ID người dùng không hợp lệ")
+	if !ok {
+		return
+	}
+
+	var req request.AdminUserStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	entry, err := c.adminService.SetUserActive(ctx, adminID, userID, &req)
+	if err != nil {
+		ctx.JSON(adminErrorStatus(err), gin.H{"error": "Không thể cập nhật trạng thái tài khoản: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
+
+// SetUserVerification xử lý việc gắn/gỡ huy hiệu xác minh
+func (c *AdminController) SetUserVerification(ctx *gin.Context) {
+	adminID, userID, ok := adminTarget(ctx, "ID người dùng không hợp lệ")
+	if !ok {
+		return
+	}
+
+	var req request.AdminUserVerificationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	entry, err := c.adminService.SetUserVerified(ctx, adminID, userID, &req)
+	if err != nil {
+		ctx.JSON(adminErrorStatus(err), gin.H{"error": "Không thể cập nhật xác minh tài khoản: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
+
+// DeleteGroup xử lý việc admin xóa nhóm
+func (c *AdminController) DeleteGroup(ctx *gin.Context) {
+	adminID, groupID, ok := adminTarget(ctx, "ID nhóm không hợp lệ")
+	if !ok {
+		return
+	}
+
+	// Body chỉ chứa ghi chú nên không bắt buộc
+	var req request.AdminNoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	entry, err := c.adminService.DeleteGroup(ctx, adminID, groupID, &req)
+	if err != nil {
+		ctx.JSON(adminErrorStatus(err), gin.H{"error": "Không thể xóa nhóm: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
+
+// TransferGroup xử lý việc admin chuyển quyền sở hữu nhóm
+func (c *AdminController) TransferGroup(ctx *gin.Context) {
+	adminID, groupID, ok := adminTarget(ctx, "ID nhóm không hợp lệ")
+	if !ok {
+		return
+	}
+
+	var req request.AdminGroupTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	entry, err := c.adminService.TransferGroup(ctx, adminID, groupID, &req)
+	if err != nil {
+		ctx.JSON(adminErrorStatus(err), gin.H{"error": "Không thể chuyển quyền sở hữu nhóm: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
+
+// ListAuditLogs xử lý việc lấy nhật ký quản trị
+func (c *AdminController) ListAuditLogs(ctx *gin.Context) {
+	var req request.AdminAuditLogListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	logs, err := c.adminService.ListAuditLogs(ctx, &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể lấy nhật ký quản trị: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, logs)
+}
+
+// adminTarget lấy ID admin từ context và ID đối tượng từ path, tự trả lỗi nếu không hợp lệ
+func adminTarget(ctx *gin.Context, invalidIDMessage string) (int64, int64, bool) {
+	adminID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return 0, 0, false
+	}
+
+	targetID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": invalidIDMessage})
+		return 0, 0, false
+	}
+	return adminID.(int64), targetID, true
+}
+
+// adminErrorStatus ánh xạ lỗi thao tác quản trị sang HTTP status
+func adminErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound), errors.Is(err, services.ErrGroupNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrAlreadyGroupOwner):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrCannotDeactivateSelf):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
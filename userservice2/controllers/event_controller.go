@@ -0,0 +1,339 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"userservice2/dto/request"
+	"userservice2/services"
+)
+
+// EventController xử lý các request liên quan đến sự kiện
+type EventController struct {
+	eventService services.EventService
+}
+
+// NewEventController tạo instance mới của EventController
+func NewEventController(eventService services.EventService) *EventController {
+	return &EventController{
+		eventService: eventService,
+	}
+}
+
+// optionalUserID lấy userID nếu người dùng đã đăng nhập, 0 nếu chưa
+func optionalUserID(ctx *gin.Context) int64 {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		return 0
+	}
+	return userID.(int64)
+}
+
+// eventErrorStatus ánh xạ lỗi của EventService sang HTTP status, các lỗi nhóm dùng chung ánh xạ của API nhóm
+func eventErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrEventNotFound), errors.Is(err, services.ErrRSVPNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrEventPermissionDenied), errors.Is(err, services.ErrMutedInGroup):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrInvalidTimezone), errors.Is(err, services.ErrInvalidEventTime):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrEventEnded):
+		return http.StatusConflict
+	default:
+		return groupErrorStatus(err)
+	}
+}
+
+// respondEventError trả về lỗi của các API sự kiện với HTTP status phù hợp
+func respondEventError(ctx *gin.Context, err error, fallback string) {
+	status := eventErrorStatus(err)
+	if status == http.StatusInternalServerError {
+		ctx.JSON(status, gin.H{"error": fallback + ": " + err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"error": err.Error()})
+}
+
+// respondICS trả về nội dung lịch iCalendar dưới dạng tệp đính kèm
+func respondICS(ctx *gin.Context, filename string, data []byte) {
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", data)
+}
+
+// CreateGroupEvent xử lý việc tạo sự kiện cho nhóm
+func (c *EventController) CreateGroupEvent(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	var req request.EventCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	event, err := c.eventService.CreateGroupEvent(ctx, userID.(int64), groupID, &req)
+	if err != nil {
+		respondEventError(ctx, err, "Không thể tạo sự kiện")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, event)
+}
+
+// CreateUserEvent xử lý việc tạo sự kiện cá nhân
+func (c *EventController) CreateUserEvent(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	var req request.EventCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	event, err := c.eventService.CreateUserEvent(ctx, userID.(int64), &req)
+	if err != nil {
+		respondEventError(ctx, err, "Không thể tạo sự kiện")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, event)
+}
+
+// GetEvent xử lý việc lấy thông tin sự kiện
+func (c *EventController) GetEvent(ctx *gin.Context) {
+	eventID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID sự kiện không hợp lệ"})
+		return
+	}
+
+	event, err := c.eventService.GetEvent(ctx, optionalUserID(ctx), eventID)
+	if err != nil {
+		respondEventError(ctx, err, "Không thể lấy thông tin sự kiện")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, event)
+}
+
+// UpdateEvent xử lý việc cập nhật sự kiện
+func (c *EventController) UpdateEvent(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	eventID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID sự kiện không hợp lệ"})
+		return
+	}
+
+	var req request.EventUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	event, err := c.eventService.UpdateEvent(ctx, userID.(int64), eventID, &req)
+	if err != nil {
+		respondEventError(ctx, err, "Không thể cập nhật sự kiện")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, event)
+}
+
+// DeleteEvent xử lý việc xóa sự kiện
+func (c *EventController) DeleteEvent(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	eventID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID sự kiện không hợp lệ"})
+		return
+	}
+
+	if err := c.eventService.DeleteEvent(ctx, userID.(int64), eventID); err != nil {
+		respondEventError(ctx, err, "Không thể xóa sự kiện")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã xóa sự kiện"})
+}
+
+// ListGroupEvents xử lý việc lấy danh sách sự kiện của nhóm
+func (c *EventController) ListGroupEvents(ctx *gin.Context) {
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	var req request.EventListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	result, err := c.eventService.ListGroupEvents(ctx, optionalUserID(ctx), groupID, &req)
+	if err != nil {
+		respondEventError(ctx, err, "Không thể lấy danh sách sự kiện")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// ListMyEvents xử lý việc lấy danh sách sự kiện của người dùng hiện tại
+func (c *EventController) ListMyEvents(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	var req request.EventListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	result, err := c.eventService.ListMyEvents(ctx, userID.(int64), &req)
+	if err != nil {
+		respondEventError(ctx, err, "Không thể lấy danh sách sự kiện")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// RespondToEvent xử lý việc phản hồi sự kiện (sẽ tham gia, quan tâm, không tham gia)
+func (c *EventController) RespondToEvent(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	eventID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID sự kiện không hợp lệ"})
+		return
+	}
+
+	var req request.EventRSVPRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	event, err := c.eventService.RespondToEvent(ctx, userID.(int64), eventID, &req)
+	if err != nil {
+		respondEventError(ctx, err, "Không thể phản hồi sự kiện")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, event)
+}
+
+// CancelRSVP xử lý việc hủy phản hồi sự kiện
+func (c *EventController) CancelRSVP(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	eventID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID sự kiện không hợp lệ"})
+		return
+	}
+
+	if err := c.eventService.CancelRSVP(ctx, userID.(int64), eventID); err != nil {
+		respondEventError(ctx, err, "Không thể hủy phản hồi sự kiện")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã hủy phản hồi sự kiện"})
+}
+
+// ListAttendees xử lý việc lấy danh sách người đã phản hồi sự kiện
+func (c *EventController) ListAttendees(ctx *gin.Context) {
+	eventID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID sự kiện không hợp lệ"})
+		return
+	}
+
+	var req request.EventAttendeeListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	result, err := c.eventService.ListAttendees(ctx, optionalUserID(ctx), eventID, &req)
+	if err != nil {
+		respondEventError(ctx, err, "Không thể lấy danh sách người tham gia")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// ExportEventICS xử lý việc tải sự kiện dưới dạng tệp .ics
+func (c *EventController) ExportEventICS(ctx *gin.Context) {
+	eventID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID sự kiện không hợp lệ"})
+		return
+	}
+
+	data, err := c.eventService.ExportEventICS(ctx, optionalUserID(ctx), eventID)
+	if err != nil {
+		respondEventError(ctx, err, "Không thể xuất lịch sự kiện")
+		return
+	}
+
+	respondICS(ctx, fmt.Sprintf("event-%d.ics", eventID), data)
+}
+
+// ExportGroupICS xử lý việc tải lịch sự kiện của nhóm dưới dạng tệp .ics
+func (c *EventController) ExportGroupICS(ctx *gin.Context) {
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	data, err := c.eventService.ExportGroupICS(ctx, optionalUserID(ctx), groupID)
+	if err != nil {
+		respondEventError(ctx, err, "Không thể xuất lịch sự kiện của nhóm")
+		return
+	}
+
+	respondICS(ctx, fmt.Sprintf("group-%d-events.ics", groupID), data)
+}
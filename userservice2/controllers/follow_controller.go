@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"userservice2/dto/request"
+	"userservice2/services"
+)
+
+// FollowController xử lý các API theo dõi người dùng
+type FollowController struct {
+	followService services.FollowService
+}
+
+// NewFollowController tạo instance mới của FollowController
+func NewFollowController(followService services.FollowService) *FollowController {
+	return &FollowController{
+		followService: followService,
+	}
+}
+
+// Follow xử lý việc theo dõi một người dùng
+func (c *FollowController) Follow(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	if err := c.followService.Follow(ctx, userID.(int64), ctx.Param("username")); err != nil {
+		ctx.JSON(followErrorStatus(err), gin.H{"error": "Không thể theo dõi: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã theo dõi"})
+}
+
+// Unfollow xử lý việc bỏ theo dõi một người dùng
+func (c *FollowController) Unfollow(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	if err := c.followService.Unfollow(ctx, userID.(int64), ctx.Param("username")); err != nil {
+		ctx.JSON(followErrorStatus(err), gin.H{"error": "Không thể bỏ theo dõi: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã bỏ theo dõi"})
+}
+
+// GetFollowers xử lý việc lấy danh sách người theo dõi một người dùng
+func (c *FollowController) GetFollowers(ctx *gin.Context) {
+	var req request.FollowListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	followers, err := c.followService.GetFollowers(ctx, ctx.Param("username"), &req)
+	if err != nil {
+		ctx.JSON(followErrorStatus(err), gin.H{"error": "Không thể lấy danh sách người theo dõi: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, followers)
+}
+
+// GetFollowing xử lý việc lấy danh sách người mà một người dùng đang theo dõi
+func (c *FollowController) GetFollowing(ctx *gin.Context) {
+	var req request.FollowListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	following, err := c.followService.GetFollowing(ctx, ctx.Param("username"), &req)
+	if err != nil {
+		ctx.JSON(followErrorStatus(err), gin.H{"error": "Không thể lấy danh sách đang theo dõi: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, following)
+}
+
+// followErrorStatus ánh xạ lỗi của FollowService sang HTTP status
+func followErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound), errors.Is(err, services.ErrNotFollowing):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrCannotFollowSelf):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrFollowNotAllowed):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"userservice2/dto/request"
+	"userservice2/services"
+)
+
+// FriendListController xử lý các API danh sách bạn bè (Bạn thân, Gia đình...)
+type FriendListController struct {
+	friendListService services.FriendListService
+}
+
+// NewFriendListController tạo instance mới của FriendListController
+func NewFriendListController(friendListService services.FriendListService) *FriendListController {
+	return &FriendListController{
+		friendListService: friendListService,
+	}
+}
+
+// friendListParams đọc userID từ context và ID danh sách từ path
+func friendListParams(ctx *gin.Context) (int64, int64, bool) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return 0, 0, false
+	}
+
+	listID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID danh sách không hợp lệ"})
+		return 0, 0, false
+	}
+	return userID.(int64), listID, true
+}
+
+// CreateList xử lý việc tạo danh sách bạn bè
+func (c *FriendListController) CreateList(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	var req request.CreateFriendListRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	list, err := c.friendListService.CreateList(ctx, userID.(int64), &req)
+	if err != nil {
+		ctx.JSON(friendListErrorStatus(err), gin.H{"error": "Không thể tạo danh sách bạn bè: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, list)
+}
+
+// GetLists xử lý việc lấy các danh sách bạn bè của người dùng
+func (c *FriendListController) GetLists(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	lists, err := c.friendListService.GetLists(ctx, userID.(int64))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể lấy danh sách bạn bè: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"lists": lists})
+}
+
+// GetList xử lý việc lấy chi tiết một danh sách bạn bè
+func (c *FriendListController) GetList(ctx *gin.Context) {
+	userID, listID, ok := friendListParams(ctx)
+	if !ok {
+		return
+	}
+
+	list, err := c.friendListService.GetList(ctx, userID, listID)
+	if err != nil {
+		ctx.JSON(friendListErrorStatus(err), gin.H{"error": "Không thể lấy danh sách bạn bè: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, list)
+}
+
+// RenameList xử lý việc đổi tên danh sách bạn bè
+func (c *FriendListController) RenameList(ctx *gin.Context) {
+	userID, listID, ok := friendListParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req request.RenameFriendListRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	if err := c.friendListService.RenameList(ctx, userID, listID, &req); err != nil {
+		ctx.JSON(friendListErrorStatus(err), gin.H{"error": "Không thể đổi tên danh sách bạn bè: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã đổi tên danh sách bạn bè"})
+}
+
+// DeleteList xử lý việc xóa danh sách bạn bè
+func (c *FriendListController) DeleteList(ctx *gin.Context) {
+	userID, listID, ok := friendListParams(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.friendListService.DeleteList(ctx, userID, listID); err != nil {
+		ctx.JSON(friendListErrorStatus(err), gin.H{"error": "Không thể xóa danh sách bạn bè: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã xóa danh sách bạn bè"})
+}
+
+// AddMembers xử lý việc thêm bạn bè vào danh sách
+func (c *FriendListController) AddMembers(ctx *gin.Context) {
+	userID, listID, ok := friendListParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req request.FriendListMembersRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	if err := c.friendListService.AddMembers(ctx, userID, listID, &req); err != nil {
+		ctx.JSON(friendListErrorStatus(err), gin.H{"error": "Không thể thêm vào danh sách bạn bè: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã thêm vào danh sách bạn bè"})
+}
+
+// RemoveMember xử lý việc xóa một người khỏi danh sách bạn bè
+func (c *FriendListController) RemoveMember(ctx *gin.Context) {
+	userID, listID, ok := friendListParams(ctx)
+	if !ok {
+		return
+	}
+
+	memberID, err := strconv.ParseInt(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID người dùng không hợp lệ"})
+		return
+	}
+
+	if err := c.friendListService.RemoveMember(ctx, userID, listID, memberID); err != nil {
+		ctx.JSON(friendListErrorStatus(err), gin.H{"error": "Không thể xóa khỏi danh sách bạn bè: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã xóa khỏi danh sách bạn bè"})
+}
+
+// friendListErrorStatus ánh xạ lỗi của FriendListService sang HTTP status
+func friendListErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrFriendListNotFound), errors.Is(err, services.ErrNotFriendListMember):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrNotFriend), errors.Is(err, services.ErrFriendListLimit):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
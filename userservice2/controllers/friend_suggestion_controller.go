@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"userservice2/dto/request"
+	"userservice2/services"
+)
+
+// FriendSuggestionController xử lý các API gợi ý kết bạn
+type FriendSuggestionController struct {
+	suggestionService services.FriendSuggestionService
+}
+
+// NewFriendSuggestionController tạo instance mới của FriendSuggestionController
+func NewFriendSuggestionController(suggestionService services.FriendSuggestionService) *FriendSuggestionController {
+	return &FriendSuggestionController{
+		suggestionService: suggestionService,
+	}
+}
+
+// GetSuggestions lấy danh sách gợi ý kết bạn kèm lý do gợi ý
+func (c *FriendSuggestionController) GetSuggestions(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	var req request.FriendSuggestionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	result, err := c.suggestionService.GetSuggestions(ctx, userID.(int64), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể lấy gợi ý kết bạn: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// DismissSuggestion bỏ qua một gợi ý kết bạn
+func (c *FriendSuggestionController) DismissSuggestion(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	suggestedUserID, err := strconv.ParseInt(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID người dùng không hợp lệ"})
+		return
+	}
+
+	if err := c.suggestionService.Dismiss(ctx, userID.(int64), suggestedUserID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrUserNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrCannotDismissSelf):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể bỏ qua gợi ý kết bạn: " + err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã bỏ qua gợi ý kết bạn"})
+}
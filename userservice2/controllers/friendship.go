@@ -1,11 +1,12 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	_ "strconv"
 
 	"userservice2/dto/request"
-	_ "userservice2/dto/response"
+	"userservice2/dto/response"
 	"userservice2/services"
 
 	"github.com/gin-gonic/gin"
@@ -161,29 +162,6 @@ func (c *FriendshipController) GetFriendRequests(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, result)
 }
 
-// GetFriendSuggestions lấy danh sách gợi ý kết bạn
-func (c *FriendshipController) GetFriendSuggestions(ctx *gin.Context) {
-	userID, exists := ctx.Get("userID")
-	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
-		return
-	}
-
-	var req request.FriendSuggestionsRequest
-	if err := ctx.ShouldBindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
-		return
-	}
-
-	result, err := c.friendshipService.GetFriendSuggestions(ctx, userID.(int64), &req)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể lấy gợi ý kết bạn: " + err.Error()})
-		return
-	}
-
-	ctx.JSON(http.StatusOK, result)
-}
-
 // GetUserFriends lấy danh sách bạn bè của một người dùng cụ thể
 func (c *FriendshipController) GetUserFriends(ctx *gin.Context) {
 	userID, exists := ctx.Get("userID")
@@ -272,3 +250,69 @@ func (c *FriendshipController) GetMutualFriends(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"count": count})
 }
+
+// GetMutualFriendsList lấy danh sách bạn chung với một người dùng
+func (c *FriendshipController) GetMutualFriendsList(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	username := ctx.Param("username")
+	if username == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Username không hợp lệ"})
+		return
+	}
+
+	var req request.MutualFriendsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	friend, err := c.friendshipService.GetUserByUsername(ctx, username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể lấy thông tin người dùng: " + err.Error()})
+		return
+	}
+	if friend == nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Không tìm thấy người dùng"})
+		return
+	}
+
+	result, err := c.friendshipService.GetMutualFriends(ctx, userID.(int64), friend.ID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrMutualFriendsBlocked) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể lấy danh sách bạn chung: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// GetMutualFriendsCounts lấy số bạn chung với nhiều người dùng trong một request
+func (c *FriendshipController) GetMutualFriendsCounts(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	var req request.MutualFriendsCountsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	counts, err := c.friendshipService.GetMutualFriendsCounts(ctx, userID.(int64), req.UserIDs)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể lấy số bạn chung: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.MutualFriendsCountsResponse{Counts: counts})
+}
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"userservice2/dto/request"
+)
+
+// parseAnnouncementParams đọc ID nhóm và ID thông báo từ đường dẫn
+func parseAnnouncementParams(ctx *gin.Context) (groupID, announcementID int64, ok bool) {
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return 0, 0, false
+	}
+
+	announcementID, err = strconv.ParseInt(ctx.Param("announcement_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID thông báo không hợp lệ"})
+		return 0, 0, false
+	}
+
+	return groupID, announcementID, true
+}
+
+// ListGroupAnnouncements xử lý việc lấy thông báo của nhóm
+func (c *GroupController) ListGroupAnnouncements(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		userID = int64(0) // Không yêu cầu đăng nhập để xem thông báo của nhóm công khai
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	var req request.GroupAnnouncementListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	result, err := c.groupService.ListGroupAnnouncements(ctx, userID.(int64), groupID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể lấy thông báo của nhóm")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// CreateGroupAnnouncement xử lý việc ghim thông báo mới
+func (c *GroupController) CreateGroupAnnouncement(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	var req request.GroupAnnouncementCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	announcement, err := c.groupService.CreateGroupAnnouncement(ctx, userID.(int64), groupID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể ghim thông báo")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, announcement)
+}
+
+// UpdateGroupAnnouncement xử lý việc sửa hoặc gia hạn thông báo
+func (c *GroupController) UpdateGroupAnnouncement(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, announcementID, ok := parseAnnouncementParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req request.GroupAnnouncementUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	announcement, err := c.groupService.UpdateGroupAnnouncement(ctx, userID.(int64), groupID, announcementID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể cập nhật thông báo")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, announcement)
+}
+
+// DeleteGroupAnnouncement xử lý việc gỡ thông báo
+func (c *GroupController) DeleteGroupAnnouncement(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, announcementID, ok := parseAnnouncementParams(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.groupService.DeleteGroupAnnouncement(ctx, userID.(int64), groupID, announcementID); err != nil {
+		respondGroupError(ctx, err, "Không thể gỡ thông báo")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã gỡ thông báo"})
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -111,14 +112,14 @@ func (c *GroupController) DeleteGroup(ctx *gin.Context) {
 
 	err = c.groupService.DeleteGroup(ctx, userID.(int64), groupID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondGroupClientError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Xóa nhóm thành công"})
 }
 
-// ListGroups xử lý việc lấy danh sách tất cả các nhóm
+// ListGroups xử lý việc tìm kiếm nhóm theo từ khóa, chủ đề, thẻ và cách sắp xếp
 func (c *GroupController) ListGroups(ctx *gin.Context) {
 	var req request.GroupListRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
@@ -158,6 +159,29 @@ func (c *GroupController) ListMyGroups(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, groups)
 }
 
+// RecommendGroups xử lý việc lấy các nhóm gợi ý cho người dùng hiện tại
+func (c *GroupController) RecommendGroups(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	var req request.GroupRecommendationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	groups, err := c.groupService.RecommendGroups(ctx, userID.(int64), &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể lấy nhóm gợi ý")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, groups)
+}
+
 // JoinGroup xử lý việc xin tham gia nhóm
 func (c *GroupController) JoinGroup(ctx *gin.Context) {
 	userID, exists := ctx.Get("userID")
@@ -174,7 +198,7 @@ func (c *GroupController) JoinGroup(ctx *gin.Context) {
 
 	err := c.groupService.JoinGroup(ctx, userID.(int64), &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondGroupClientError(ctx, err)
 		return
 	}
 
@@ -197,7 +221,7 @@ func (c *GroupController) LeaveGroup(ctx *gin.Context) {
 
 	err = c.groupService.LeaveGroup(ctx, userID.(int64), groupID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondGroupClientError(ctx, err)
 		return
 	}
 
@@ -226,7 +250,7 @@ func (c *GroupController) InviteMember(ctx *gin.Context) {
 
 	err = c.groupService.InviteMember(ctx, userID.(int64), groupID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondGroupClientError(ctx, err)
 		return
 	}
 
@@ -301,7 +325,7 @@ func (c *GroupController) RemoveMember(ctx *gin.Context) {
 
 	err = c.groupService.RemoveMember(ctx, userID.(int64), groupID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondGroupClientError(ctx, err)
 		return
 	}
 
@@ -336,7 +360,7 @@ func (c *GroupController) UpdateMember(ctx *gin.Context) {
 
 	member, err := c.groupService.UpdateMember(ctx, userID.(int64), groupID, memberID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondGroupClientError(ctx, err)
 		return
 	}
 
@@ -345,6 +369,11 @@ func (c *GroupController) UpdateMember(ctx *gin.Context) {
 
 // GetGroupMembers xử lý việc lấy danh sách thành viên nhóm
 func (c *GroupController) GetGroupMembers(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		userID = int64(0) // Không yêu cầu đăng nhập, nhưng cần biết người xem để ẩn nhóm bí mật
+	}
+
 	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
@@ -357,11 +386,79 @@ func (c *GroupController) GetGroupMembers(ctx *gin.Context) {
 		return
 	}
 
-	members, err := c.groupService.GetGroupMembers(ctx, groupID, &req)
+	members, err := c.groupService.GetGroupMembers(ctx, userID.(int64), groupID, &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondGroupError(ctx, err, "Không thể lấy danh sách thành viên")
 		return
 	}
 
 	ctx.JSON(http.StatusOK, members)
 }
+
+// groupErrorStatus ánh xạ lỗi của các API nhóm sang mã HTTP
+func groupErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrGroupNotFound),
+		errors.Is(err, services.ErrGroupRoleNotFound),
+		errors.Is(err, services.ErrGroupMemberNotFound),
+		errors.Is(err, services.ErrGroupRoleNotAssigned),
+		errors.Is(err, services.ErrJoinRequestNotFound),
+		errors.Is(err, services.ErrInvitationNotFound),
+		errors.Is(err, services.ErrUserNotFound),
+		errors.Is(err, services.ErrOwnershipTransferNotFound),
+		errors.Is(err, services.ErrGroupBanNotFound),
+		errors.Is(err, services.ErrGroupRuleNotFound),
+		errors.Is(err, services.ErrGroupAnnouncementNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrGroupPermissionDenied),
+		errors.Is(err, services.ErrGroupInviteNotAllowed),
+		errors.Is(err, services.ErrNotGroupOwner),
+		errors.Is(err, services.ErrCannotRemoveOwner),
+		errors.Is(err, services.ErrCannotDemoteOwner),
+		errors.Is(err, services.ErrBannedFromGroup),
+		errors.Is(err, services.ErrCannotModerateOwner),
+		errors.Is(err, services.ErrCannotModerateAdmin),
+		errors.Is(err, services.ErrSecretGroupInviteOnly):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrInvalidGroupPermission),
+		errors.Is(err, services.ErrJoinAnswersRequired),
+		errors.Is(err, services.ErrCannotInviteSelf),
+		errors.Is(err, services.ErrCannotModerateSelf),
+		errors.Is(err, services.ErrGroupRulesNotAccepted),
+		errors.Is(err, services.ErrGroupRuleLimit),
+		errors.Is(err, services.ErrInvalidRuleOrder),
+		errors.Is(err, services.ErrGroupAnnouncementLimit),
+		errors.Is(err, services.ErrInvalidAnnouncementExpiration):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrGroupRoleNameTaken),
+		errors.Is(err, services.ErrJoinRequestExists),
+		errors.Is(err, services.ErrInvitationExists),
+		errors.Is(err, services.ErrAlreadyGroupMember),
+		errors.Is(err, services.ErrAlreadyGroupOwner),
+		errors.Is(err, services.ErrOwnerCannotLeave),
+		errors.Is(err, services.ErrLastGroupAdmin),
+		errors.Is(err, services.ErrUserBannedFromGroup):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondGroupError trả về lỗi của các API nhóm, lỗi không xác định được kèm thông điệp fallback
+func respondGroupError(ctx *gin.Context, err error, fallback string) {
+	status := groupErrorStatus(err)
+	if status == http.StatusInternalServerError {
+		ctx.JSON(status, gin.H{"error": fallback + ": " + err.Error()})
+		return
+	}
+	ctx.JSON(status, gin.H{"error": err.Error()})
+}
+
+// respondGroupClientError trả về lỗi của các API nhóm, lỗi không xác định được coi là yêu cầu không hợp lệ
+func respondGroupClientError(ctx *gin.Context, err error) {
+	status := groupErrorStatus(err)
+	if status == http.StatusInternalServerError {
+		status = http.StatusBadRequest
+	}
+	ctx.JSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"userservice2/dto/request"
+)
+
+// ListMyInvitations xử lý việc lấy danh sách lời mời vào nhóm của người dùng hiện tại
+func (c *GroupController) ListMyInvitations(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	var req request.GroupInvitationListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	result, err := c.groupService.ListMyInvitations(ctx, userID.(int64), &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể lấy danh sách lời mời")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// RespondInvitation xử lý việc chấp nhận/từ chối lời mời vào nhóm
+func (c *GroupController) RespondInvitation(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	invitationID, err := strconv.ParseInt(ctx.Param("invitation_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID lời mời không hợp lệ"})
+		return
+	}
+
+	action := ctx.Param("action")
+	if action != "accept" && action != "decline" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Hành động không hợp lệ"})
+		return
+	}
+
+	if action == "accept" {
+		// Body không bắt buộc, chỉ cần khi nhóm có nội quy ({"accept_rules": true})
+		var req request.GroupInvitationAcceptRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+			return
+		}
+
+		if err := c.groupService.AcceptInvitation(ctx, userID.(int64), invitationID, &req); err != nil {
+			respondGroupError(ctx, err, "Không thể chấp nhận lời mời")
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": "Đã tham gia nhóm"})
+		return
+	}
+
+	if err := c.groupService.DeclineInvitation(ctx, userID.(int64), invitationID); err != nil {
+		respondGroupError(ctx, err, "Không thể từ chối lời mời")
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã từ chối lời mời"})
+}
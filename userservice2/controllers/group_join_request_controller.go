@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"userservice2/dto/request"
+)
+
+// GetMembershipQuestions xử lý việc lấy câu hỏi cho người xin tham gia nhóm
+func (c *GroupController) GetMembershipQuestions(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		userID = int64(0) // Không yêu cầu đăng nhập, nhưng cần biết người xem để ẩn nhóm bí mật
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	questions, err := c.groupService.GetMembershipQuestions(ctx, userID.(int64), groupID)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể lấy câu hỏi tham gia nhóm")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"questions": questions})
+}
+
+// UpdateMembershipQuestions xử lý việc đặt câu hỏi cho người xin tham gia nhóm
+func (c *GroupController) UpdateMembershipQuestions(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	var req request.GroupQuestionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	questions, err := c.groupService.UpdateMembershipQuestions(ctx, userID.(int64), groupID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể cập nhật câu hỏi tham gia nhóm")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"questions": questions})
+}
+
+// GetMyJoinRequest xử lý việc xem yêu cầu tham gia đang chờ của người dùng hiện tại
+func (c *GroupController) GetMyJoinRequest(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	joinRequest, err := c.groupService.GetMyJoinRequest(ctx, userID.(int64), groupID)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể lấy yêu cầu tham gia")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, joinRequest)
+}
+
+// WithdrawJoinRequest xử lý việc rút lại yêu cầu tham gia nhóm
+func (c *GroupController) WithdrawJoinRequest(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	if err := c.groupService.WithdrawJoinRequest(ctx, userID.(int64), groupID); err != nil {
+		respondGroupError(ctx, err, "Không thể rút lại yêu cầu tham gia")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã rút lại yêu cầu tham gia nhóm"})
+}
+
+// ListJoinRequests xử lý việc lấy danh sách yêu cầu tham gia nhóm
+func (c *GroupController) ListJoinRequests(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	var req request.GroupJoinRequestListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	result, err := c.groupService.ListJoinRequests(ctx, userID.(int64), groupID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể lấy danh sách yêu cầu tham gia")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// HandleJoinRequests xử lý việc duyệt/từ chối nhiều yêu cầu tham gia cùng lúc
+func (c *GroupController) HandleJoinRequests(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	action := ctx.Param("action")
+	if action != "approve" && action != "reject" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Hành động không hợp lệ"})
+		return
+	}
+
+	var req request.GroupJoinRequestBulkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	if action == "approve" {
+		result, err := c.groupService.BulkApproveJoinRequests(ctx, userID.(int64), groupID, &req)
+		if err != nil {
+			respondGroupError(ctx, err, "Không thể chấp nhận yêu cầu tham gia")
+			return
+		}
+		ctx.JSON(http.StatusOK, result)
+		return
+	}
+
+	result, err := c.groupService.BulkRejectJoinRequests(ctx, userID.(int64), groupID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể từ chối yêu cầu tham gia")
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"userservice2/dto/request"
+)
+
+// ListBans xử lý việc lấy danh sách người bị cấm khỏi nhóm
+func (c *GroupController) ListBans(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	var req request.GroupBanListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	result, err := c.groupService.ListBans(ctx, userID.(int64), groupID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể lấy danh sách người bị cấm")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// BanMember xử lý việc cấm người dùng khỏi nhóm
+func (c *GroupController) BanMember(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	var req request.GroupBanRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	ban, err := c.groupService.BanMember(ctx, userID.(int64), groupID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể cấm thành viên")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, ban)
+}
+
+// UnbanMember xử lý việc gỡ cấm người dùng khỏi nhóm
+func (c *GroupController) UnbanMember(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	targetUserID, err := strconv.ParseInt(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID người dùng không hợp lệ"})
+		return
+	}
+
+	if err := c.groupService.UnbanMember(ctx, userID.(int64), groupID, targetUserID); err != nil {
+		respondGroupError(ctx, err, "Không thể gỡ cấm thành viên")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã gỡ cấm thành viên"})
+}
+
+// parseMemberParams đọc ID nhóm và ID thành viên từ đường dẫn
+func parseMemberParams(ctx *gin.Context) (groupID, memberID int64, ok bool) {
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return 0, 0, false
+	}
+
+	memberID, err = strconv.ParseInt(ctx.Param("member_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID thành viên không hợp lệ"})
+		return 0, 0, false
+	}
+
+	return groupID, memberID, true
+}
+
+// MuteMember xử lý việc tắt tiếng thành viên nhóm, có thể kèm thời hạn và lý do
+func (c *GroupController) MuteMember(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, memberID, ok := parseMemberParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req request.GroupMuteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	member, err := c.groupService.MuteMember(ctx, userID.(int64), groupID, memberID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể tắt tiếng thành viên")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
+
+// UnmuteMember xử lý việc gỡ tắt tiếng thành viên nhóm
+func (c *GroupController) UnmuteMember(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, memberID, ok := parseMemberParams(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.groupService.UnmuteMember(ctx, userID.(int64), groupID, memberID); err != nil {
+		respondGroupError(ctx, err, "Không thể gỡ tắt tiếng thành viên")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã gỡ tắt tiếng thành viên"})
+}
+
+// ListModerationLog xử lý việc lấy nhật ký kiểm duyệt của nhóm
+func (c *GroupController) ListModerationLog(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	var req request.GroupModerationLogListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	result, err := c.groupService.ListModerationLog(ctx, userID.(int64), groupID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể lấy nhật ký kiểm duyệt")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
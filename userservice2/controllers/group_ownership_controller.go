@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"userservice2/dto/request"
+)
+
+// GetOwnershipTransfer xử lý việc xem yêu cầu chuyển quyền sở hữu nhóm đang chờ
+func (c *GroupController) GetOwnershipTransfer(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	transfer, err := c.groupService.GetOwnershipTransfer(ctx, userID.(int64), groupID)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể lấy yêu cầu chuyển quyền sở hữu")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transfer)
+}
+
+// RequestOwnershipTransfer xử lý việc gửi yêu cầu chuyển quyền sở hữu nhóm cho một thành viên
+func (c *GroupController) RequestOwnershipTransfer(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	var req request.GroupMemberActionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	transfer, err := c.groupService.RequestOwnershipTransfer(ctx, userID.(int64), groupID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể gửi yêu cầu chuyển quyền sở hữu")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, transfer)
+}
+
+// CancelOwnershipTransfer xử lý việc chủ nhóm hủy yêu cầu chuyển quyền sở hữu
+func (c *GroupController) CancelOwnershipTransfer(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	if err := c.groupService.CancelOwnershipTransfer(ctx, userID.(int64), groupID); err != nil {
+		respondGroupError(ctx, err, "Không thể hủy yêu cầu chuyển quyền sở hữu")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã hủy yêu cầu chuyển quyền sở hữu"})
+}
+
+// RespondOwnershipTransfer xử lý việc người nhận chấp nhận/từ chối quyền sở hữu nhóm
+func (c *GroupController) RespondOwnershipTransfer(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	action := ctx.Param("action")
+	if action != "accept" && action != "decline" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Hành động không hợp lệ"})
+		return
+	}
+
+	if action == "accept" {
+		if err := c.groupService.AcceptOwnershipTransfer(ctx, userID.(int64), groupID); err != nil {
+			respondGroupError(ctx, err, "Không thể nhận quyền sở hữu")
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": "Bạn đã trở thành chủ nhóm"})
+		return
+	}
+
+	if err := c.groupService.DeclineOwnershipTransfer(ctx, userID.(int64), groupID); err != nil {
+		respondGroupError(ctx, err, "Không thể từ chối quyền sở hữu")
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã từ chối nhận quyền sở hữu"})
+}
@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"userservice2/dto/request"
+)
+
+// GetGroupRoles xử lý việc lấy danh sách vai trò trong nhóm
+func (c *GroupController) GetGroupRoles(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		userID = int64(0) // Không yêu cầu đăng nhập, nhưng cần biết người xem để ẩn nhóm bí mật
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	roles, err := c.groupService.GetGroupRoles(ctx, userID.(int64), groupID)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể lấy danh sách vai trò")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, roles)
+}
+
+// CreateGroupRole xử lý việc tạo vai trò mới trong nhóm
+func (c *GroupController) CreateGroupRole(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	var req request.GroupRoleCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	role, err := c.groupService.CreateGroupRole(ctx, userID.(int64), groupID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể tạo vai trò")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, role)
+}
+
+// UpdateGroupRole xử lý việc cập nhật vai trò trong nhóm
+func (c *GroupController) UpdateGroupRole(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	roleID, err := strconv.ParseInt(ctx.Param("role_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID vai trò không hợp lệ"})
+		return
+	}
+
+	var req request.GroupRoleUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	role, err := c.groupService.UpdateGroupRole(ctx, userID.(int64), groupID, roleID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể cập nhật vai trò")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, role)
+}
+
+// DeleteGroupRole xử lý việc xóa vai trò trong nhóm
+func (c *GroupController) DeleteGroupRole(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	roleID, err := strconv.ParseInt(ctx.Param("role_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID vai trò không hợp lệ"})
+		return
+	}
+
+	if err := c.groupService.DeleteGroupRole(ctx, userID.(int64), groupID, roleID); err != nil {
+		respondGroupError(ctx, err, "Không thể xóa vai trò")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Xóa vai trò thành công"})
+}
+
+// parseMemberRoleParams đọc ID nhóm, ID thành viên và ID vai trò từ đường dẫn
+func parseMemberRoleParams(ctx *gin.Context) (groupID, memberID, roleID int64, ok bool) {
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return 0, 0, 0, false
+	}
+
+	memberID, err = strconv.ParseInt(ctx.Param("member_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID thành viên không hợp lệ"})
+		return 0, 0, 0, false
+	}
+
+	roleID, err = strconv.ParseInt(ctx.Param("role_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID vai trò không hợp lệ"})
+		return 0, 0, 0, false
+	}
+
+	return groupID, memberID, roleID, true
+}
+
+// AssignRoleToMember xử lý việc gán vai trò cho thành viên nhóm
+func (c *GroupController) AssignRoleToMember(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, memberID, roleID, ok := parseMemberRoleParams(ctx)
+	if !ok {
+		return
+	}
+
+	req := request.GroupMemberRoleRequest{RoleID: roleID}
+	if err := c.groupService.AssignRoleToMember(ctx, userID.(int64), groupID, memberID, &req); err != nil {
+		respondGroupError(ctx, err, "Không thể gán vai trò")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Gán vai trò thành công"})
+}
+
+// RemoveRoleFromMember xử lý việc gỡ vai trò khỏi thành viên nhóm
+func (c *GroupController) RemoveRoleFromMember(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, memberID, roleID, ok := parseMemberRoleParams(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.groupService.RemoveRoleFromMember(ctx, userID.(int64), groupID, memberID, roleID); err != nil {
+		respondGroupError(ctx, err, "Không thể gỡ vai trò")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Gỡ vai trò thành công"})
+}
@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"userservice2/dto/request"
+)
+
+// parseRuleParams đọc ID nhóm và ID điều nội quy từ đường dẫn
+func parseRuleParams(ctx *gin.Context) (groupID, ruleID int64, ok bool) {
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return 0, 0, false
+	}
+
+	ruleID, err = strconv.ParseInt(ctx.Param("rule_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nội quy không hợp lệ"})
+		return 0, 0, false
+	}
+
+	return groupID, ruleID, true
+}
+
+// ListGroupRules xử lý việc lấy nội quy nhóm
+func (c *GroupController) ListGroupRules(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		userID = int64(0) // Không yêu cầu đăng nhập để đọc nội quy trước khi tham gia
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	rules, err := c.groupService.ListGroupRules(ctx, userID.(int64), groupID)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể lấy nội quy nhóm")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rules)
+}
+
+// CreateGroupRule xử lý việc thêm điều nội quy
+func (c *GroupController) CreateGroupRule(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	var req request.GroupRuleCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	rule, err := c.groupService.CreateGroupRule(ctx, userID.(int64), groupID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể thêm nội quy")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, rule)
+}
+
+// UpdateGroupRule xử lý việc sửa điều nội quy
+func (c *GroupController) UpdateGroupRule(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, ruleID, ok := parseRuleParams(ctx)
+	if !ok {
+		return
+	}
+
+	var req request.GroupRuleUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	rule, err := c.groupService.UpdateGroupRule(ctx, userID.(int64), groupID, ruleID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể cập nhật nội quy")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rule)
+}
+
+// DeleteGroupRule xử lý việc xóa điều nội quy
+func (c *GroupController) DeleteGroupRule(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, ruleID, ok := parseRuleParams(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.groupService.DeleteGroupRule(ctx, userID.(int64), groupID, ruleID); err != nil {
+		respondGroupError(ctx, err, "Không thể xóa nội quy")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã xóa nội quy"})
+}
+
+// ReorderGroupRules xử lý việc sắp xếp lại nội quy nhóm
+func (c *GroupController) ReorderGroupRules(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	var req request.GroupRuleOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	rules, err := c.groupService.ReorderGroupRules(ctx, userID.(int64), groupID, &req)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể sắp xếp nội quy")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rules)
+}
+
+// AcceptGroupRules xử lý việc thành viên đồng ý với nội quy hiện tại của nhóm
+func (c *GroupController) AcceptGroupRules(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID nhóm không hợp lệ"})
+		return
+	}
+
+	member, err := c.groupService.AcceptGroupRules(ctx, userID.(int64), groupID)
+	if err != nil {
+		respondGroupError(ctx, err, "Không thể ghi nhận đồng ý nội quy")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"userservice2/dto/request"
+	"userservice2/models"
+	"userservice2/services"
+)
+
+// MuteController xử lý các API tắt tiếng/tạm ẩn người dùng và nhóm
+type MuteController struct {
+	muteService services.MuteService
+}
+
+// NewMuteController tạo instance mới của MuteController
+func NewMuteController(muteService services.MuteService) *MuteController {
+	return &MuteController{
+		muteService: muteService,
+	}
+}
+
+// Mute xử lý việc tắt tiếng hoặc tạm ẩn một người dùng/nhóm
+func (c *MuteController) Mute(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	var req request.MuteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	mute, err := c.muteService.Mute(ctx, userID.(int64), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUserNotFound), errors.Is(err, services.ErrGroupNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrCannotMuteSelf):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể tắt tiếng: " + err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mute)
+}
+
+// Unmute xử lý việc bỏ tắt tiếng một người dùng/nhóm
+func (c *MuteController) Unmute(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	targetType := models.MuteTargetType(ctx.Param("target_type"))
+	if targetType != models.MuteTargetUser && targetType != models.MuteTargetGroup {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Loại đối tượng không hợp lệ"})
+		return
+	}
+	targetID, err := strconv.ParseInt(ctx.Param("target_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID đối tượng không hợp lệ"})
+		return
+	}
+
+	if err := c.muteService.Unmute(ctx, userID.(int64), targetType, targetID); err != nil {
+		if errors.Is(err, services.ErrMuteNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể bỏ tắt tiếng: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Đã bỏ tắt tiếng"})
+}
+
+// ListMutes xử lý việc lấy danh sách người dùng/nhóm đang bị tắt tiếng
+func (c *MuteController) ListMutes(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	var req request.MuteListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	mutes, err := c.muteService.ListMutes(ctx, userID.(int64), &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể lấy danh sách tắt tiếng: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mutes)
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"userservice2/dto/request"
+	"userservice2/services"
+)
+
+// ReportController xử lý các API báo cáo người dùng và kiểm duyệt
+type ReportController struct {
+	reportService services.UserReportService
+}
+
+// NewReportController tạo instance mới của ReportController
+func NewReportController(reportService services.UserReportService) *ReportController {
+	return &ReportController{
+		reportService: reportService,
+	}
+}
+
+// ReportUser xử lý việc báo cáo một người dùng
+func (c *ReportController) ReportUser(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	var req request.UserReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	report, err := c.reportService.ReportUser(ctx, userID.(int64), ctx.Param("username"), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUserNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrAlreadyReported):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrCannotReportSelf):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể gửi báo cáo: " + err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, report)
+}
+
+// ListReports xử lý việc lấy hàng đợi báo cáo người dùng
+func (c *ReportController) ListReports(ctx *gin.Context) {
+	var req request.ReportListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	reports, err := c.reportService.ListReports(ctx, &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể lấy danh sách báo cáo: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reports)
+}
+
+// GetReport xử lý việc lấy chi tiết một báo cáo
+func (c *ReportController) GetReport(ctx *gin.Context) {
+	reportID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID báo cáo không hợp lệ"})
+		return
+	}
+
+	report, err := c.reportService.GetReport(ctx, reportID)
+	if err != nil {
+		if errors.Is(err, services.ErrReportNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể lấy báo cáo: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// DecideReport xử lý quyết định của moderator (hide, dismiss) đối với một báo cáo
+func (c *ReportController) DecideReport(ctx *gin.Context) {
+	moderatorID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+		return
+	}
+
+	reportID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID báo cáo không hợp lệ"})
+		return
+	}
+
+	var req request.ModerationDecisionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Dữ liệu không hợp lệ: " + err.Error()})
+		return
+	}
+
+	action, err := c.reportService.Decide(ctx, moderatorID.(int64), reportID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrReportNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể xử lý báo cáo: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, action)
+}
+
+// ListModerationActions xử lý việc lấy nhật ký kiểm duyệt
+func (c *ReportController) ListModerationActions(ctx *gin.Context) {
+	var req request.ModerationActionListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Tham số không hợp lệ: " + err.Error()})
+		return
+	}
+
+	actions, err := c.reportService.ListActions(ctx, &req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể lấy nhật ký kiểm duyệt: " + err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, actions)
+}
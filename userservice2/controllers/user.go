@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -11,7 +12,7 @@ import (
 	_ "userservice2/dto/response"
 	"userservice2/utils"
 
-	_ "userservice2/models"
+	"userservice2/models"
 	"userservice2/services"
 
 	"github.com/gin-gonic/gin"
@@ -101,6 +102,12 @@ func (c *UserController) GetUser(ctx *gin.Context) {
 	// Kiểm tra xem người dùng đã đăng nhập chưa
 	currentUserID, exists := ctx.Get("userID")
 
+	// Trang cá nhân bị ẩn bởi kiểm duyệt hoặc bị admin vô hiệu hóa chỉ hiển thị với chính chủ
+	if (user.IsHidden || user.IsDeactivated) && (!exists || currentUserID.(int64) != user.ID) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Không tìm thấy người dùng"})
+		return
+	}
+
 	// Nếu người dùng đã đăng nhập, thêm thông tin về mối quan hệ bạn bè
 	if exists && currentUserID != nil {
 		loggedInUserID := currentUserID.(int64)
@@ -221,32 +228,45 @@ func (c *UserController) UploadProfilePicture(ctx *gin.Context) {
 	// Tạo public ID cho file (sử dụng userID để đảm bảo unique)
 	publicID := fmt.Sprintf("user_%d_profile_%d", userID.(int64), time.Now().UnixNano())
 
-	// Upload ảnh lên Cloudinary
-	fileURL, err := c.cloudinary.UploadImage(openedFile, publicID)
+	// Xử lý ảnh (xóa EXIF, giới hạn kích thước, sinh variant) rồi upload lên Cloudinary
+	uploaded, err := c.cloudinary.UploadImageWithVariants(openedFile, publicID)
 	if err != nil {
+		if errors.Is(err, utils.ErrInvalidImage) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "File ảnh không hợp lệ"})
+			return
+		}
+		if errors.Is(err, utils.ErrImageTooLarge) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Ảnh có kích thước quá lớn"})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể tải ảnh lên: " + err.Error()})
 		return
 	}
+	fileURL := uploaded.URL
 
 	// Xóa ảnh cũ nếu có
 	if user.ProfilePictureURL != "" {
 		// Xóa bất đồng bộ để không ảnh hưởng đến response
-		go func(oldURL string) {
-			if err := c.cloudinary.DeleteImage(oldURL); err != nil {
+		go func(oldURL string, oldVariants models.MediaVariants) {
+			if err := c.cloudinary.DeleteImageWithVariants(oldURL, oldVariants); err != nil {
 				log.Printf("Không thể xóa ảnh cũ: %v", err)
 			}
-		}(user.ProfilePictureURL)
+		}(user.ProfilePictureURL, user.ProfilePictureVariants)
 	}
 
 	// Cập nhật URL ảnh trong database
-	if err := c.userService.UploadProfilePicture(ctx, userID.(int64), fileURL); err != nil {
+	if err := c.userService.UploadProfilePicture(ctx, userID.(int64), uploaded.ToImageInfo()); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể cập nhật ảnh đại diện: " + err.Error()})
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Cập nhật ảnh đại diện thành công",
-		"url":     fileURL,
+		"message":   "Cập nhật ảnh đại diện thành công",
+		"url":       fileURL,
+		"width":     uploaded.Width,
+		"height":    uploaded.Height,
+		"variants":  uploaded.Variants,
+		"blur_hash": uploaded.BlurHash,
 	})
 }
 
@@ -296,32 +316,45 @@ func (c *UserController) UploadCoverPicture(ctx *gin.Context) {
 	// Tạo public ID cho file (sử dụng userID để đảm bảo unique)
 	publicID := fmt.Sprintf("user_%d_cover_%d", userID.(int64), time.Now().UnixNano())
 
-	// Upload ảnh lên Cloudinary
-	fileURL, err := c.cloudinary.UploadImage(openedFile, publicID)
+	// Xử lý ảnh (xóa EXIF, giới hạn kích thước, sinh variant) rồi upload lên Cloudinary
+	uploaded, err := c.cloudinary.UploadImageWithVariants(openedFile, publicID)
 	if err != nil {
+		if errors.Is(err, utils.ErrInvalidImage) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "File ảnh không hợp lệ"})
+			return
+		}
+		if errors.Is(err, utils.ErrImageTooLarge) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Ảnh có kích thước quá lớn"})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể tải ảnh lên: " + err.Error()})
 		return
 	}
+	fileURL := uploaded.URL
 
 	// Xóa ảnh cũ nếu có
 	if user.CoverPictureURL != "" {
 		// Xóa bất đồng bộ để không ảnh hưởng đến response
-		go func(oldURL string) {
-			if err := c.cloudinary.DeleteImage(oldURL); err != nil {
+		go func(oldURL string, oldVariants models.MediaVariants) {
+			if err := c.cloudinary.DeleteImageWithVariants(oldURL, oldVariants); err != nil {
 				log.Printf("Không thể xóa ảnh cũ: %v", err)
 			}
-		}(user.CoverPictureURL)
+		}(user.CoverPictureURL, user.CoverPictureVariants)
 	}
 
 	// Cập nhật URL ảnh trong database
-	if err := c.userService.UploadCoverPicture(ctx, userID.(int64), fileURL); err != nil {
+	if err := c.userService.UploadCoverPicture(ctx, userID.(int64), uploaded.ToImageInfo()); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Không thể cập nhật ảnh bìa: " + err.Error()})
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Cập nhật ảnh bìa thành công",
-		"url":     fileURL,
+		"message":   "Cập nhật ảnh bìa thành công",
+		"url":       fileURL,
+		"width":     uploaded.Width,
+		"height":    uploaded.Height,
+		"variants":  uploaded.Variants,
+		"blur_hash": uploaded.BlurHash,
 	})
 }
 
@@ -0,0 +1,33 @@
+package request
+
+// AdminUserStatusRequest là DTO cho việc admin kích hoạt/vô hiệu hóa tài khoản
+type AdminUserStatusRequest struct {
+	IsActive *bool  `json:"is_active" binding:"required"`
+	Note     string `json:"note" binding:"omitempty,max=1000"`
+}
+
+// AdminUserVerificationRequest là DTO cho việc admin gắn/gỡ huy hiệu xác minh
+type AdminUserVerificationRequest struct {
+	IsVerified *bool  `json:"is_verified" binding:"required"`
+	Note       string `json:"note" binding:"omitempty,max=1000"`
+}
+
+// AdminNoteRequest là DTO (không bắt buộc) chứa ghi chú cho thao tác quản trị
+type AdminNoteRequest struct {
+	Note string `json:"note" binding:"omitempty,max=1000"`
+}
+
+// AdminGroupTransferRequest là DTO cho việc admin chuyển quyền sở hữu nhóm
+type AdminGroupTransferRequest struct {
+	NewOwnerID int64  `json:"new_owner_id" binding:"required,min=1"`
+	Note       string `json:"note" binding:"omitempty,max=1000"`
+}
+
+// AdminAuditLogListRequest là DTO cho việc lấy nhật ký quản trị
+type AdminAuditLogListRequest struct {
+	AdminID    int64  `form:"admin_id" binding:"omitempty,min=1"`
+	TargetType string `form:"target_type" binding:"omitempty,oneof=user group"`
+	TargetID   int64  `form:"target_id" binding:"omitempty,min=1"`
+	Page       int    `form:"page,default=1" binding:"omitempty,min=1"`
+	PageSize   int    `form:"page_size,default=20" binding:"omitempty,min=1,max=100"`
+}
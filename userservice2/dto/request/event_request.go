@@ -0,0 +1,44 @@
+package request
+
+import "time"
+
+// EventCreateRequest là DTO cho việc tạo sự kiện nhóm hoặc sự kiện cá nhân
+type EventCreateRequest struct {
+	Title       string    `json:"title" binding:"required,min=3,max=200"`
+	Description string    `json:"description" binding:"max=5000"`
+	StartsAt    time.Time `json:"starts_at" binding:"required"`
+	EndsAt      time.Time `json:"ends_at" binding:"required"`
+	Timezone    string    `json:"timezone" binding:"omitempty,max=64"`
+	Location    string    `json:"location" binding:"max=255"`
+	CoverImage  string    `json:"cover_image" binding:"omitempty,url,max=255"`
+}
+
+// EventUpdateRequest là DTO cho việc cập nhật sự kiện, các trường bỏ trống được giữ nguyên
+type EventUpdateRequest struct {
+	Title       string     `json:"title" binding:"omitempty,min=3,max=200"`
+	Description string     `json:"description" binding:"max=5000"`
+	StartsAt    *time.Time `json:"starts_at" binding:"omitempty"`
+	EndsAt      *time.Time `json:"ends_at" binding:"omitempty"`
+	Timezone    string     `json:"timezone" binding:"omitempty,max=64"`
+	Location    string     `json:"location" binding:"max=255"`
+	CoverImage  string     `json:"cover_image" binding:"omitempty,url,max=255"`
+}
+
+// EventListRequest là DTO cho việc lấy danh sách sự kiện
+type EventListRequest struct {
+	Period   string `form:"period,default=upcoming" binding:"omitempty,oneof=upcoming past"`
+	Page     int    `form:"page,default=1" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size,default=10" binding:"omitempty,min=1,max=100"`
+}
+
+// EventRSVPRequest là DTO cho việc phản hồi sự kiện
+type EventRSVPRequest struct {
+	Status string `json:"status" binding:"required,oneof=going interested declined"`
+}
+
+// EventAttendeeListRequest là DTO cho việc lấy danh sách người đã phản hồi sự kiện
+type EventAttendeeListRequest struct {
+	Status   string `form:"status,default=going" binding:"omitempty,oneof=going interested declined"`
+	Page     int    `form:"page,default=1" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size,default=20" binding:"omitempty,min=1,max=100"`
+}
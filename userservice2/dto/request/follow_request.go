@@ -0,0 +1,7 @@
+package request
+
+// FollowListRequest là DTO cho việc lấy danh sách người theo dõi/đang theo dõi
+type FollowListRequest struct {
+	Page     int `form:"page,default=1" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size,default=20" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,17 @@
+package request
+
+// CreateFriendListRequest là DTO cho việc tạo danh sách bạn bè
+type CreateFriendListRequest struct {
+	Name      string  `json:"name" binding:"required,max=50"`
+	MemberIDs []int64 `json:"member_ids" binding:"omitempty,max=1000"`
+}
+
+// RenameFriendListRequest là DTO cho việc đổi tên danh sách bạn bè
+type RenameFriendListRequest struct {
+	Name string `json:"name" binding:"required,max=50"`
+}
+
+// FriendListMembersRequest là DTO cho việc thêm bạn bè vào danh sách
+type FriendListMembersRequest struct {
+	UserIDs []int64 `json:"user_ids" binding:"required,min=1,max=1000"`
+}
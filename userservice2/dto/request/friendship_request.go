@@ -23,3 +23,14 @@ type FriendRequestsListRequest struct {
 type FriendSuggestionsRequest struct {
 	Limit int `form:"limit,default=10" binding:"omitempty,min=1,max=50"`
 }
+
+// MutualFriendsRequest là DTO cho việc lấy danh sách bạn chung
+type MutualFriendsRequest struct {
+	Page     int `form:"page,default=1" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size,default=10" binding:"omitempty,min=1,max=100"`
+}
+
+// MutualFriendsCountsRequest là DTO cho việc đếm bạn chung với nhiều người dùng (?user_ids=1&user_ids=2)
+type MutualFriendsCountsRequest struct {
+	UserIDs []int64 `form:"user_ids" binding:"required,min=1,max=100"`
+}
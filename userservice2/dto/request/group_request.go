@@ -1,25 +1,36 @@
 package request
 
+import "time"
+
 // GroupCreateRequest là DTO cho việc tạo nhóm mới
 type GroupCreateRequest struct {
-	Name        string `json:"name" binding:"required,min=3,max=100"`
-	Description string `json:"description" binding:"max=1000"`
-	Privacy     string `json:"privacy" binding:"omitempty,oneof=public private"`
-	CoverImage  string `json:"cover_image" binding:"omitempty,url"`
+	Name        string   `json:"name" binding:"required,min=3,max=100"`
+	Description string   `json:"description" binding:"max=1000"`
+	Privacy     string   `json:"privacy" binding:"omitempty,oneof=public private secret"`
+	CoverImage  string   `json:"cover_image" binding:"omitempty,url"`
+	Category    string   `json:"category" binding:"omitempty,oneof=education entertainment gaming sports technology travel food health business arts local other"`
+	Tags        []string `json:"tags" binding:"omitempty,max=10,dive,min=1,max=30"`
 }
 
 // GroupUpdateRequest là DTO cho việc cập nhật thông tin nhóm
 type GroupUpdateRequest struct {
 	Name        string `json:"name" binding:"omitempty,min=3,max=100"`
 	Description string `json:"description" binding:"omitempty,max=1000"`
-	Privacy     string `json:"privacy" binding:"omitempty,oneof=public private"`
+	Privacy     string `json:"privacy" binding:"omitempty,oneof=public private secret"`
 	CoverImage  string `json:"cover_image" binding:"omitempty,url"`
+	Category    string `json:"category" binding:"omitempty,oneof=education entertainment gaming sports technology travel food health business arts local other"`
+	// nil giữ nguyên thẻ cũ, mảng rỗng xóa hết thẻ
+	Tags *[]string `json:"tags" binding:"omitempty,max=10,dive,min=1,max=30"`
 }
 
-// GroupListRequest là DTO cho việc lấy danh sách nhóm
+// GroupListRequest là DTO cho việc tìm kiếm danh sách nhóm. Sort mặc định là relevance khi có Query
+// và members khi không có.
 type GroupListRequest struct {
 	Query    string `form:"query" binding:"omitempty,max=100"`
 	Privacy  string `form:"privacy" binding:"omitempty,oneof=public private"`
+	Category string `form:"category" binding:"omitempty,oneof=education entertainment gaming sports technology travel food health business arts local other"`
+	Tag      string `form:"tag" binding:"omitempty,max=30"`
+	Sort     string `form:"sort" binding:"omitempty,oneof=relevance members activity newest"`
 	Page     int    `form:"page,default=1" binding:"omitempty,min=1"`
 	PageSize int    `form:"page_size,default=10" binding:"omitempty,min=1,max=100"`
 }
@@ -36,6 +47,43 @@ type GroupMemberListRequest struct {
 type GroupJoinRequest struct {
 	GroupID  int64  `json:"group_id" binding:"required"`
 	Nickname string `json:"nickname" binding:"omitempty,max=50"`
+	Message  string `json:"message" binding:"omitempty,max=1000"`
+	// Câu trả lời theo đúng thứ tự câu hỏi của nhóm riêng tư
+	Answers []string `json:"answers" binding:"omitempty,max=5,dive,max=1000"`
+	// Bắt buộc là true nếu nhóm có nội quy
+	AcceptRules bool `json:"accept_rules"`
+}
+
+// GroupQuestionsRequest là DTO cho việc đặt câu hỏi cho người xin tham gia nhóm, danh sách rỗng là bỏ câu hỏi
+type GroupQuestionsRequest struct {
+	Questions []string `json:"questions" binding:"omitempty,max=5,dive,required,max=250"`
+}
+
+// GroupJoinRequestListRequest là DTO cho việc lấy danh sách yêu cầu tham gia nhóm
+type GroupJoinRequestListRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending approved rejected withdrawn expired"`
+	Query    string `form:"query" binding:"omitempty,max=100"`
+	Sort     string `form:"sort" binding:"omitempty,oneof=newest oldest"`
+	Page     int    `form:"page,default=1" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size,default=10" binding:"omitempty,min=1,max=100"`
+}
+
+// GroupJoinRequestBulkRequest là DTO cho việc duyệt/từ chối nhiều yêu cầu tham gia cùng lúc
+type GroupJoinRequestBulkRequest struct {
+	RequestIDs []int64 `json:"request_ids" binding:"required,min=1,max=100"`
+}
+
+// GroupInvitationListRequest là DTO cho việc lấy danh sách lời mời vào nhóm của người dùng hiện tại
+type GroupInvitationListRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending accepted declined expired"`
+	Page     int    `form:"page,default=1" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size,default=10" binding:"omitempty,min=1,max=100"`
+}
+
+// GroupInvitationAcceptRequest là DTO cho việc chấp nhận lời mời vào nhóm, accept_rules bắt buộc là true nếu
+// nhóm có nội quy
+type GroupInvitationAcceptRequest struct {
+	AcceptRules bool `json:"accept_rules"`
 }
 
 // GroupMemberActionRequest là DTO cho các hành động liên quan đến thành viên nhóm
@@ -50,6 +98,31 @@ type GroupMemberUpdateRequest struct {
 	IsMuted  *bool  `json:"is_muted" binding:"omitempty"`
 }
 
+// GroupBanRequest là DTO cho việc cấm người dùng khỏi nhóm
+type GroupBanRequest struct {
+	UserID int64  `json:"user_id" binding:"required"`
+	Reason string `json:"reason" binding:"omitempty,max=255"`
+}
+
+// GroupBanListRequest là DTO cho việc lấy danh sách người bị cấm khỏi nhóm
+type GroupBanListRequest struct {
+	Page     int `form:"page,default=1" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size,default=20" binding:"omitempty,min=1,max=100"`
+}
+
+// GroupMuteRequest là DTO cho việc tắt tiếng thành viên, không có duration_minutes là tắt tiếng vô thời hạn
+type GroupMuteRequest struct {
+	DurationMinutes int    `json:"duration_minutes" binding:"omitempty,min=1,max=525600"`
+	Reason          string `json:"reason" binding:"omitempty,max=255"`
+}
+
+// GroupModerationLogListRequest là DTO cho việc lấy nhật ký kiểm duyệt của nhóm
+type GroupModerationLogListRequest struct {
+	Action   string `form:"action" binding:"omitempty,oneof=member_remove member_ban member_unban member_mute member_unmute mute_expire"`
+	Page     int    `form:"page,default=1" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size,default=20" binding:"omitempty,min=1,max=100"`
+}
+
 // GroupRoleCreateRequest là DTO cho việc tạo vai trò mới trong nhóm
 type GroupRoleCreateRequest struct {
 	Name        string          `json:"name" binding:"required,min=3,max=50"`
@@ -66,3 +139,51 @@ type GroupRoleUpdateRequest struct {
 type GroupMemberRoleRequest struct {
 	RoleID int64 `json:"role_id" binding:"required"`
 }
+
+// GroupRecommendationRequest là DTO cho việc lấy nhóm gợi ý
+type GroupRecommendationRequest struct {
+	Limit int `form:"limit,default=10" binding:"omitempty,min=1,max=50"`
+}
+
+// GroupRuleCreateRequest là DTO cho việc thêm điều nội quy vào cuối nội quy nhóm
+type GroupRuleCreateRequest struct {
+	Title       string `json:"title" binding:"required,min=3,max=150"`
+	Description string `json:"description" binding:"max=2000"`
+}
+
+// GroupRuleUpdateRequest là DTO cho việc sửa điều nội quy. Title rỗng giữ nguyên tiêu đề, Description nil giữ
+// nguyên mô tả còn chuỗi rỗng là xóa mô tả.
+type GroupRuleUpdateRequest struct {
+	Title       string  `json:"title" binding:"omitempty,min=3,max=150"`
+	Description *string `json:"description" binding:"omitempty,max=2000"`
+}
+
+// GroupRuleOrderRequest là DTO cho việc sắp xếp lại nội quy nhóm, rule_ids gồm đúng tất cả các điều theo thứ tự mới
+type GroupRuleOrderRequest struct {
+	RuleIDs []int64 `json:"rule_ids" binding:"required,min=1,max=20"`
+}
+
+// GroupAnnouncementCreateRequest là DTO cho việc ghim thông báo lên đầu trang nhóm, không có expires_at là ghim
+// cho tới khi bị gỡ
+type GroupAnnouncementCreateRequest struct {
+	Title     string     `json:"title" binding:"required,min=3,max=200"`
+	Content   string     `json:"content" binding:"required,max=5000"`
+	ExpiresAt *time.Time `json:"expires_at" binding:"omitempty"`
+}
+
+// GroupAnnouncementUpdateRequest là DTO cho việc sửa thông báo, các trường bỏ trống được giữ nguyên.
+// no_expiry = true để bỏ thời hạn của thông báo.
+type GroupAnnouncementUpdateRequest struct {
+	Title     string     `json:"title" binding:"omitempty,min=3,max=200"`
+	Content   string     `json:"content" binding:"omitempty,max=5000"`
+	ExpiresAt *time.Time `json:"expires_at" binding:"omitempty"`
+	NoExpiry  bool       `json:"no_expiry"`
+}
+
+// GroupAnnouncementListRequest là DTO cho việc lấy danh sách thông báo của nhóm
+type GroupAnnouncementListRequest struct {
+	// Lấy cả thông báo đã hết hạn, cần quyền manage_announcements
+	IncludeExpired bool `form:"include_expired"`
+	Page           int  `form:"page,default=1" binding:"omitempty,min=1"`
+	PageSize       int  `form:"page_size,default=10" binding:"omitempty,min=1,max=100"`
+}
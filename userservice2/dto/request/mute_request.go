@@ -0,0 +1,15 @@
+package request
+
+// MuteRequest là DTO cho việc tắt tiếng (vô thời hạn) hoặc tạm ẩn (30 ngày) một người dùng/nhóm
+type MuteRequest struct {
+	TargetType string `json:"target_type" binding:"required,oneof=user group"`
+	TargetID   int64  `json:"target_id" binding:"required,min=1"`
+	Kind       string `json:"kind" binding:"omitempty,oneof=mute snooze"`
+}
+
+// MuteListRequest là DTO cho việc lấy danh sách đang tắt tiếng
+type MuteListRequest struct {
+	TargetType string `form:"target_type" binding:"omitempty,oneof=user group"`
+	Page       int    `form:"page,default=1" binding:"omitempty,min=1"`
+	PageSize   int    `form:"page_size,default=20" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,28 @@
+package request
+
+// UserReportRequest là DTO cho việc báo cáo một người dùng
+type UserReportRequest struct {
+	Reason  string `json:"reason" binding:"required,oneof=spam harassment hate_speech impersonation fake_account nudity violence other"`
+	Details string `json:"details" binding:"omitempty,max=1000"`
+}
+
+// ReportListRequest là DTO cho việc lấy hàng đợi báo cáo
+type ReportListRequest struct {
+	Status   string `form:"status,default=open" binding:"omitempty,oneof=open actioned dismissed"`
+	Page     int    `form:"page,default=1" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size,default=20" binding:"omitempty,min=1,max=100"`
+}
+
+// ModerationDecisionRequest là DTO cho quyết định của moderator đối với một báo cáo
+type ModerationDecisionRequest struct {
+	Action string `json:"action" binding:"required,oneof=hide dismiss"`
+	Note   string `json:"note" binding:"omitempty,max=1000"`
+}
+
+// ModerationActionListRequest là DTO cho việc lấy nhật ký kiểm duyệt
+type ModerationActionListRequest struct {
+	UserID      int64 `form:"user_id" binding:"omitempty,min=1"`
+	ModeratorID int64 `form:"moderator_id" binding:"omitempty,min=1"`
+	Page        int   `form:"page,default=1" binding:"omitempty,min=1"`
+	PageSize    int   `form:"page_size,default=20" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,11 @@
+package response
+
+import "userservice2/models"
+
+// AdminAuditLogListResponse là DTO cho nhật ký quản trị
+type AdminAuditLogListResponse struct {
+	Logs  []models.AdminAuditLog `json:"logs"`
+	Total int64                  `json:"total"`
+	Page  int                    `json:"page"`
+	Size  int                    `json:"size"`
+}
@@ -0,0 +1,110 @@
+package response
+
+import (
+	"time"
+
+	"userservice2/models"
+)
+
+// EventGroupBrief là thông tin rút gọn của nhóm tổ chức sự kiện
+type EventGroupBrief struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Privacy    string `json:"privacy"`
+	CoverImage string `json:"cover_image,omitempty"`
+}
+
+// EventResponse là DTO cho thông tin sự kiện
+type EventResponse struct {
+	ID              int64            `json:"id"`
+	GroupID         *int64           `json:"group_id"`
+	Group           *EventGroupBrief `json:"group,omitempty"`
+	Title           string           `json:"title"`
+	Description     string           `json:"description"`
+	StartsAt        time.Time        `json:"starts_at"`
+	EndsAt          time.Time        `json:"ends_at"`
+	Timezone        string           `json:"timezone"`
+	Location        string           `json:"location"`
+	CoverImage      string           `json:"cover_image"`
+	GoingCount      int              `json:"going_count"`
+	InterestedCount int              `json:"interested_count"`
+	CreatedBy       int64            `json:"created_by"`
+	Creator         UserBrief        `json:"creator"`
+	// Phản hồi của người xem, rỗng nếu chưa phản hồi
+	MyRSVP    string    `json:"my_rsvp,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// EventListResponse là DTO cho danh sách sự kiện
+type EventListResponse struct {
+	Events []EventResponse `json:"events"`
+	Total  int64           `json:"total"`
+	Page   int             `json:"page"`
+	Size   int             `json:"size"`
+}
+
+// EventAttendeeResponse là DTO cho một người đã phản hồi sự kiện
+type EventAttendeeResponse struct {
+	User        UserBrief `json:"user"`
+	Status      string    `json:"status"`
+	RespondedAt time.Time `json:"responded_at"`
+}
+
+// EventAttendeeListResponse là DTO cho danh sách người đã phản hồi sự kiện
+type EventAttendeeListResponse struct {
+	Attendees []EventAttendeeResponse `json:"attendees"`
+	Total     int64                   `json:"total"`
+	Page      int                     `json:"page"`
+	Size      int                     `json:"size"`
+}
+
+// ConvertToEventResponse chuyển đổi từ model sang response, myRSVP là phản hồi của người xem
+func ConvertToEventResponse(event *models.Event, myRSVP models.EventRSVPStatus) EventResponse {
+	resp := EventResponse{
+		ID:              event.ID,
+		GroupID:         event.GroupID,
+		Title:           event.Title,
+		Description:     event.Description,
+		StartsAt:        event.StartsAt,
+		EndsAt:          event.EndsAt,
+		Timezone:        event.Timezone,
+		Location:        event.Location,
+		CoverImage:      event.CoverImage,
+		GoingCount:      event.GoingCount,
+		InterestedCount: event.InterestedCount,
+		CreatedBy:       event.CreatedBy,
+		Creator: UserBrief{
+			ID:                event.Creator.ID,
+			Username:          event.Creator.Username,
+			FullName:          event.Creator.FullName,
+			ProfilePictureURL: event.Creator.ProfilePictureURL,
+		},
+		MyRSVP:    string(myRSVP),
+		CreatedAt: event.CreatedAt,
+		UpdatedAt: event.UpdatedAt,
+	}
+	if event.Group != nil {
+		resp.Group = &EventGroupBrief{
+			ID:         event.Group.ID,
+			Name:       event.Group.Name,
+			Privacy:    string(event.Group.Privacy),
+			CoverImage: event.Group.CoverImage,
+		}
+	}
+	return resp
+}
+
+// ConvertToEventAttendeeResponse chuyển đổi từ model sang response
+func ConvertToEventAttendeeResponse(rsvp *models.EventRSVP) EventAttendeeResponse {
+	return EventAttendeeResponse{
+		User: UserBrief{
+			ID:                rsvp.User.ID,
+			Username:          rsvp.User.Username,
+			FullName:          rsvp.User.FullName,
+			ProfilePictureURL: rsvp.User.ProfilePictureURL,
+		},
+		Status:      string(rsvp.Status),
+		RespondedAt: rsvp.UpdatedAt,
+	}
+}
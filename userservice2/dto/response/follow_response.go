@@ -0,0 +1,9 @@
+package response
+
+// FollowListResponse là DTO cho danh sách người theo dõi/đang theo dõi
+type FollowListResponse struct {
+	Users []UserBasic `json:"users"`
+	Total int64       `json:"total"`
+	Page  int         `json:"page"`
+	Size  int         `json:"size"`
+}
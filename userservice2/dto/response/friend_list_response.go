@@ -0,0 +1,13 @@
+package response
+
+import "time"
+
+// FriendListDetailResponse là DTO cho một danh sách bạn bè
+type FriendListDetailResponse struct {
+	ID          int64       `json:"id"`
+	Name        string      `json:"name"`
+	MemberCount int         `json:"member_count"`
+	Members     []UserBasic `json:"members,omitempty"` // Chỉ có khi xem chi tiết
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
@@ -44,6 +44,8 @@ type FriendSuggestionResponse struct {
 	ProfilePictureURL  string `json:"profile_picture_url,omitempty"`
 	CoverPictureURL    string `json:"cover_picture_url,omitempty"`
 	MutualFriendsCount int    `json:"mutual_friends_count,omitempty"`
+	SharedGroupsCount  int    `json:"shared_groups_count,omitempty"`
+	Reason             string `json:"reason"`
 }
 
 // FriendSuggestionListResponse là DTO cho danh sách gợi ý kết bạn
@@ -51,6 +53,28 @@ type FriendSuggestionListResponse struct {
 	Suggestions []FriendSuggestionResponse `json:"suggestions"`
 }
 
+// MutualFriend là DTO cho một người bạn chung, không có email vì người xem không phải bạn của người này
+type MutualFriend struct {
+	ID                int64  `json:"id"`
+	Username          string `json:"username"`
+	FullName          string `json:"full_name"`
+	ProfilePictureURL string `json:"profile_picture_url,omitempty"`
+	CoverPictureURL   string `json:"cover_picture_url,omitempty"`
+}
+
+// MutualFriendListResponse là DTO cho danh sách bạn chung
+type MutualFriendListResponse struct {
+	Users    []MutualFriend `json:"users"`
+	Total    int64          `json:"total"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+}
+
+// MutualFriendsCountsResponse là DTO cho số bạn chung với nhiều người dùng, key là ID người dùng
+type MutualFriendsCountsResponse struct {
+	Counts map[int64]int `json:"counts"`
+}
+
 // ToFriendResponse chuyển đổi từ model Friendship sang FriendResponse
 func ToFriendResponse(friendship *models.Friendship, userID int64, mutualCount int) FriendResponse {
 	var friend models.User
@@ -84,8 +108,20 @@ func ToUserBasic(user *models.User) UserBasic {
 	}
 }
 
-// ToFriendSuggestionResponse chuyển đổi từ model User sang FriendSuggestionResponse
-func ToFriendSuggestionResponse(user *models.User, mutualCount int) FriendSuggestionResponse {
+// ToMutualFriend chuyển đổi từ model User sang MutualFriend
+func ToMutualFriend(user *models.User) MutualFriend {
+	return MutualFriend{
+		ID:                user.ID,
+		Username:          user.Username,
+		FullName:          user.FullName,
+		ProfilePictureURL: user.ProfilePictureURL,
+		CoverPictureURL:   user.CoverPictureURL,
+	}
+}
+
+// ToFriendSuggestionResponse chuyển đổi từ model FriendSuggestion sang FriendSuggestionResponse
+func ToFriendSuggestionResponse(suggestion *models.FriendSuggestion) FriendSuggestionResponse {
+	user := suggestion.SuggestedUser
 	return FriendSuggestionResponse{
 		ID:                 user.ID,
 		Username:           user.Username,
@@ -93,6 +129,8 @@ func ToFriendSuggestionResponse(user *models.User, mutualCount int) FriendSugges
 		FullName:           user.FullName,
 		ProfilePictureURL:  user.ProfilePictureURL,
 		CoverPictureURL:    user.CoverPictureURL,
-		MutualFriendsCount: mutualCount,
+		MutualFriendsCount: suggestion.MutualFriends,
+		SharedGroupsCount:  suggestion.SharedGroups,
+		Reason:             suggestion.Reason,
 	}
 }
@@ -7,22 +7,31 @@ import (
 
 // GroupResponse là DTO cho thông tin cơ bản của một nhóm
 type GroupResponse struct {
-	ID          int64     `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Privacy     string    `json:"privacy"`
-	CoverImage  string    `json:"cover_image"`
-	MemberCount int       `json:"member_count"`
-	CreatedBy   int64     `json:"created_by"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	Creator     UserBrief `json:"creator,omitempty"`
+	ID             int64      `json:"id"`
+	Name           string     `json:"name"`
+	Description    string     `json:"description"`
+	Privacy        string     `json:"privacy"`
+	CoverImage     string     `json:"cover_image"`
+	Category       string     `json:"category,omitempty"`
+	Tags           []string   `json:"tags"`
+	MemberCount    int        `json:"member_count"`
+	CreatedBy      int64      `json:"created_by"`
+	OwnerID        int64      `json:"owner_id"`
+	LastActivityAt *time.Time `json:"last_activity_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	Creator        UserBrief  `json:"creator,omitempty"`
 }
 
 // GroupDetailResponse là DTO cho thông tin chi tiết của một nhóm
 type GroupDetailResponse struct {
 	GroupResponse
-	CurrentUserMember *GroupMemberResponse `json:"current_user_member,omitempty"`
+	CurrentUserMember      *GroupMemberResponse `json:"current_user_member,omitempty"`
+	CurrentUserPermissions []string             `json:"current_user_permissions,omitempty"`
+	MembershipQuestions    []string             `json:"membership_questions,omitempty"`
+	// Nội quy và các thông báo đang được ghim ở đầu trang nhóm
+	Rules               []GroupRuleResponse         `json:"rules"`
+	PinnedAnnouncements []GroupAnnouncementResponse `json:"pinned_announcements"`
 }
 
 // GroupListResponse là DTO cho danh sách nhóm
@@ -33,18 +42,36 @@ type GroupListResponse struct {
 	Size   int             `json:"size"`
 }
 
+// GroupRecommendationResponse là DTO cho một nhóm được gợi ý kèm lý do gợi ý
+type GroupRecommendationResponse struct {
+	GroupResponse
+	FriendMemberCount int    `json:"friend_member_count"`
+	NearbyMemberCount int    `json:"nearby_member_count"`
+	Reason            string `json:"reason"`
+}
+
+// GroupRecommendationListResponse là DTO cho danh sách nhóm gợi ý
+type GroupRecommendationListResponse struct {
+	Groups []GroupRecommendationResponse `json:"groups"`
+}
+
 // GroupMemberResponse là DTO cho thông tin thành viên nhóm
 type GroupMemberResponse struct {
-	ID       int64      `json:"id"`
-	GroupID  int64      `json:"group_id"`
-	UserID   int64      `json:"user_id"`
-	Role     string     `json:"role"`
-	Nickname string     `json:"nickname"`
-	IsMuted  bool       `json:"is_muted"`
-	Status   string     `json:"status"`
-	JoinedAt time.Time  `json:"joined_at"`
-	LeftAt   *time.Time `json:"left_at,omitempty"`
-	User     UserBrief  `json:"user,omitempty"`
+	ID       int64  `json:"id"`
+	GroupID  int64  `json:"group_id"`
+	UserID   int64  `json:"user_id"`
+	Role     string `json:"role"`
+	Nickname string `json:"nickname"`
+	IsMuted  bool   `json:"is_muted"`
+	// Thời điểm hết tắt tiếng, không có khi tắt tiếng vô thời hạn
+	MutedUntil *time.Time `json:"muted_until,omitempty"`
+	MuteReason string     `json:"mute_reason,omitempty"`
+	Status     string     `json:"status"`
+	JoinedAt   time.Time  `json:"joined_at"`
+	LeftAt     *time.Time `json:"left_at,omitempty"`
+	// Thời điểm chấp nhận nội quy nhóm, không có nếu chưa chấp nhận
+	RulesAcceptedAt *time.Time `json:"rules_accepted_at,omitempty"`
+	User            UserBrief  `json:"user,omitempty"`
 }
 
 // GroupMemberListResponse là DTO cho danh sách thành viên nhóm
@@ -72,18 +99,161 @@ type GroupRoleListResponse struct {
 	Size  int                 `json:"size"`
 }
 
+// GroupJoinRequestResponse là DTO cho yêu cầu tham gia nhóm
+type GroupJoinRequestResponse struct {
+	ID         int64                      `json:"id"`
+	GroupID    int64                      `json:"group_id"`
+	UserID     int64                      `json:"user_id"`
+	Nickname   string                     `json:"nickname,omitempty"`
+	Message    string                     `json:"message"`
+	Answers    []models.JoinRequestAnswer `json:"answers"`
+	Status     string                     `json:"status"`
+	ReviewedBy *int64                     `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time                 `json:"reviewed_at,omitempty"`
+	ExpiresAt  *time.Time                 `json:"expires_at"`
+	CreatedAt  time.Time                  `json:"created_at"`
+	User       UserBrief                  `json:"user,omitempty"`
+}
+
+// GroupJoinRequestListResponse là DTO cho danh sách yêu cầu tham gia nhóm
+type GroupJoinRequestListResponse struct {
+	Requests []GroupJoinRequestResponse `json:"requests"`
+	Total    int64                      `json:"total"`
+	Page     int                        `json:"page"`
+	Size     int                        `json:"size"`
+}
+
+// GroupJoinRequestBulkResponse là DTO cho kết quả duyệt/từ chối nhiều yêu cầu tham gia
+type GroupJoinRequestBulkResponse struct {
+	ProcessedIDs []int64 `json:"processed_ids"`
+	SkippedIDs   []int64 `json:"skipped_ids"` // Không tồn tại, không thuộc nhóm hoặc không còn chờ xử lý
+}
+
+// GroupInvitationResponse là DTO cho lời mời vào nhóm
+type GroupInvitationResponse struct {
+	ID          int64         `json:"id"`
+	Group       GroupResponse `json:"group"`
+	Inviter     UserBrief     `json:"inviter"`
+	Status      string        `json:"status"`
+	ExpiresAt   *time.Time    `json:"expires_at"`
+	RespondedAt *time.Time    `json:"responded_at,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// GroupInvitationListResponse là DTO cho danh sách lời mời vào nhóm
+type GroupInvitationListResponse struct {
+	Invitations []GroupInvitationResponse `json:"invitations"`
+	Total       int64                     `json:"total"`
+	Page        int                       `json:"page"`
+	Size        int                       `json:"size"`
+}
+
+// GroupOwnershipTransferResponse là DTO cho yêu cầu chuyển quyền sở hữu nhóm
+type GroupOwnershipTransferResponse struct {
+	ID          int64      `json:"id"`
+	GroupID     int64      `json:"group_id"`
+	From        UserBrief  `json:"from"`
+	To          UserBrief  `json:"to"`
+	Status      string     `json:"status"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// GroupBanResponse là DTO cho lệnh cấm thành viên khỏi nhóm
+type GroupBanResponse struct {
+	ID        int64     `json:"id"`
+	GroupID   int64     `json:"group_id"`
+	User      UserBrief `json:"user"`
+	BannedBy  int64     `json:"banned_by"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GroupBanListResponse là DTO cho danh sách người bị cấm khỏi nhóm
+type GroupBanListResponse struct {
+	Bans  []GroupBanResponse `json:"bans"`
+	Total int64              `json:"total"`
+	Page  int                `json:"page"`
+	Size  int                `json:"size"`
+}
+
+// GroupModerationLogResponse là DTO cho một mục nhật ký kiểm duyệt của nhóm
+type GroupModerationLogResponse struct {
+	ID         int64      `json:"id"`
+	GroupID    int64      `json:"group_id"`
+	Actor      *UserBrief `json:"actor"` // null là hệ thống
+	TargetUser UserBrief  `json:"target_user"`
+	Action     string     `json:"action"`
+	Reason     string     `json:"reason"`
+	Details    string     `json:"details"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// GroupModerationLogListResponse là DTO cho nhật ký kiểm duyệt của nhóm
+type GroupModerationLogListResponse struct {
+	Logs  []GroupModerationLogResponse `json:"logs"`
+	Total int64                        `json:"total"`
+	Page  int                          `json:"page"`
+	Size  int                          `json:"size"`
+}
+
+// GroupRuleResponse là DTO cho một điều trong nội quy nhóm
+type GroupRuleResponse struct {
+	ID          int64     `json:"id"`
+	GroupID     int64     `json:"group_id"`
+	Position    int       `json:"position"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// GroupRuleListResponse là DTO cho nội quy nhóm theo thứ tự hiển thị
+type GroupRuleListResponse struct {
+	Rules []GroupRuleResponse `json:"rules"`
+}
+
+// GroupAnnouncementResponse là DTO cho thông báo ghim của nhóm
+type GroupAnnouncementResponse struct {
+	ID        int64      `json:"id"`
+	GroupID   int64      `json:"group_id"`
+	Title     string     `json:"title"`
+	Content   string     `json:"content"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	IsActive  bool       `json:"is_active"`
+	Author    UserBrief  `json:"author"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// GroupAnnouncementListResponse là DTO cho danh sách thông báo của nhóm
+type GroupAnnouncementListResponse struct {
+	Announcements []GroupAnnouncementResponse `json:"announcements"`
+	Total         int64                       `json:"total"`
+	Page          int                         `json:"page"`
+	Size          int                         `json:"size"`
+}
+
 // ConvertToGroupResponse chuyển đổi từ model sang response
 func ConvertToGroupResponse(group *models.UserGroup) GroupResponse {
+	tags := []string(group.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
 	return GroupResponse{
-		ID:          group.ID,
-		Name:        group.Name,
-		Description: group.Description,
-		Privacy:     string(group.Privacy),
-		CoverImage:  group.CoverImage,
-		MemberCount: group.MemberCount,
-		CreatedBy:   group.CreatedBy,
-		CreatedAt:   group.CreatedAt,
-		UpdatedAt:   group.UpdatedAt,
+		ID:             group.ID,
+		Name:           group.Name,
+		Description:    group.Description,
+		Privacy:        string(group.Privacy),
+		CoverImage:     group.CoverImage,
+		Category:       string(group.Category),
+		Tags:           tags,
+		MemberCount:    group.MemberCount,
+		CreatedBy:      group.CreatedBy,
+		OwnerID:        group.OwnerID,
+		LastActivityAt: group.LastActivityAt,
+		CreatedAt:      group.CreatedAt,
+		UpdatedAt:      group.UpdatedAt,
 		Creator: UserBrief{
 			ID:                group.Creator.ID,
 			Username:          group.Creator.Username,
@@ -98,15 +268,18 @@ func ConvertToGroupResponse(group *models.UserGroup) GroupResponse {
 // ConvertToGroupMemberResponse chuyển đổi từ model sang response
 func ConvertToGroupMemberResponse(member *models.GroupMember) GroupMemberResponse {
 	return GroupMemberResponse{
-		ID:       member.ID,
-		GroupID:  member.GroupID,
-		UserID:   member.UserID,
-		Role:     string(member.Role),
-		Nickname: member.Nickname,
-		IsMuted:  member.IsMuted,
-		Status:   string(member.Status),
-		JoinedAt: member.JoinedAt,
-		LeftAt:   member.LeftAt,
+		ID:              member.ID,
+		GroupID:         member.GroupID,
+		UserID:          member.UserID,
+		Role:            string(member.Role),
+		Nickname:        member.Nickname,
+		IsMuted:         member.IsMuted,
+		MutedUntil:      member.MutedUntil,
+		MuteReason:      member.MuteReason,
+		Status:          string(member.Status),
+		JoinedAt:        member.JoinedAt,
+		LeftAt:          member.LeftAt,
+		RulesAcceptedAt: member.RulesAcceptedAt,
 		User: UserBrief{
 			ID:                member.User.ID,
 			Username:          member.User.Username,
@@ -128,3 +301,162 @@ func ConvertToGroupRoleResponse(role *models.GroupRole) GroupRoleResponse {
 		CreatedAt:   role.CreatedAt,
 	}
 }
+
+// ConvertToGroupJoinRequestResponse chuyển đổi từ model sang response
+func ConvertToGroupJoinRequestResponse(joinRequest *models.GroupJoinRequest) GroupJoinRequestResponse {
+	answers := []models.JoinRequestAnswer(joinRequest.Answers)
+	if answers == nil {
+		answers = []models.JoinRequestAnswer{}
+	}
+	return GroupJoinRequestResponse{
+		ID:         joinRequest.ID,
+		GroupID:    joinRequest.GroupID,
+		UserID:     joinRequest.UserID,
+		Nickname:   joinRequest.Nickname,
+		Message:    joinRequest.Message,
+		Answers:    answers,
+		Status:     string(joinRequest.Status),
+		ReviewedBy: joinRequest.ReviewedBy,
+		ReviewedAt: joinRequest.ReviewedAt,
+		ExpiresAt:  joinRequest.ExpiresAt,
+		CreatedAt:  joinRequest.CreatedAt,
+		User: UserBrief{
+			ID:                joinRequest.User.ID,
+			Username:          joinRequest.User.Username,
+			Email:             joinRequest.User.Email,
+			FullName:          joinRequest.User.FullName,
+			ProfilePictureURL: joinRequest.User.ProfilePictureURL,
+			CoverPictureURL:   joinRequest.User.CoverPictureURL,
+		},
+	}
+}
+
+// ConvertToGroupInvitationResponse chuyển đổi từ model sang response
+func ConvertToGroupInvitationResponse(invitation *models.GroupInvitation) GroupInvitationResponse {
+	return GroupInvitationResponse{
+		ID:     invitation.ID,
+		Group:  ConvertToGroupResponse(&invitation.Group),
+		Status: string(invitation.Status),
+		Inviter: UserBrief{
+			ID:                invitation.Inviter.ID,
+			Username:          invitation.Inviter.Username,
+			Email:             invitation.Inviter.Email,
+			FullName:          invitation.Inviter.FullName,
+			ProfilePictureURL: invitation.Inviter.ProfilePictureURL,
+			CoverPictureURL:   invitation.Inviter.CoverPictureURL,
+		},
+		ExpiresAt:   invitation.ExpiresAt,
+		RespondedAt: invitation.RespondedAt,
+		CreatedAt:   invitation.CreatedAt,
+	}
+}
+
+// ConvertToGroupOwnershipTransferResponse chuyển đổi từ model sang response
+func ConvertToGroupOwnershipTransferResponse(transfer *models.GroupOwnershipTransfer) GroupOwnershipTransferResponse {
+	return GroupOwnershipTransferResponse{
+		ID:      transfer.ID,
+		GroupID: transfer.GroupID,
+		From: UserBrief{
+			ID:                transfer.FromUser.ID,
+			Username:          transfer.FromUser.Username,
+			Email:             transfer.FromUser.Email,
+			FullName:          transfer.FromUser.FullName,
+			ProfilePictureURL: transfer.FromUser.ProfilePictureURL,
+			CoverPictureURL:   transfer.FromUser.CoverPictureURL,
+		},
+		To: UserBrief{
+			ID:                transfer.ToUser.ID,
+			Username:          transfer.ToUser.Username,
+			Email:             transfer.ToUser.Email,
+			FullName:          transfer.ToUser.FullName,
+			ProfilePictureURL: transfer.ToUser.ProfilePictureURL,
+			CoverPictureURL:   transfer.ToUser.CoverPictureURL,
+		},
+		Status:      string(transfer.Status),
+		RespondedAt: transfer.RespondedAt,
+		CreatedAt:   transfer.CreatedAt,
+	}
+}
+
+// ConvertToGroupBanResponse chuyển đổi từ model sang response
+func ConvertToGroupBanResponse(ban *models.GroupBan) GroupBanResponse {
+	return GroupBanResponse{
+		ID:      ban.ID,
+		GroupID: ban.GroupID,
+		User: UserBrief{
+			ID:                ban.User.ID,
+			Username:          ban.User.Username,
+			Email:             ban.User.Email,
+			FullName:          ban.User.FullName,
+			ProfilePictureURL: ban.User.ProfilePictureURL,
+			CoverPictureURL:   ban.User.CoverPictureURL,
+		},
+		BannedBy:  ban.BannedBy,
+		Reason:    ban.Reason,
+		CreatedAt: ban.CreatedAt,
+	}
+}
+
+// ConvertToGroupModerationLogResponse chuyển đổi từ model sang response
+func ConvertToGroupModerationLogResponse(entry *models.GroupModerationLog) GroupModerationLogResponse {
+	resp := GroupModerationLogResponse{
+		ID:      entry.ID,
+		GroupID: entry.GroupID,
+		TargetUser: UserBrief{
+			ID:                entry.TargetUser.ID,
+			Username:          entry.TargetUser.Username,
+			Email:             entry.TargetUser.Email,
+			FullName:          entry.TargetUser.FullName,
+			ProfilePictureURL: entry.TargetUser.ProfilePictureURL,
+			CoverPictureURL:   entry.TargetUser.CoverPictureURL,
+		},
+		Action:    string(entry.Action),
+		Reason:    entry.Reason,
+		Details:   entry.Details,
+		CreatedAt: entry.CreatedAt,
+	}
+	if entry.Actor != nil {
+		resp.Actor = &UserBrief{
+			ID:                entry.Actor.ID,
+			Username:          entry.Actor.Username,
+			Email:             entry.Actor.Email,
+			FullName:          entry.Actor.FullName,
+			ProfilePictureURL: entry.Actor.ProfilePictureURL,
+			CoverPictureURL:   entry.Actor.CoverPictureURL,
+		}
+	}
+	return resp
+}
+
+// ConvertToGroupRuleResponse chuyển đổi từ model sang response
+func ConvertToGroupRuleResponse(rule *models.GroupRule) GroupRuleResponse {
+	return GroupRuleResponse{
+		ID:          rule.ID,
+		GroupID:     rule.GroupID,
+		Position:    rule.Position,
+		Title:       rule.Title,
+		Description: rule.Description,
+		CreatedAt:   rule.CreatedAt,
+		UpdatedAt:   rule.UpdatedAt,
+	}
+}
+
+// ConvertToGroupAnnouncementResponse chuyển đổi từ model sang response, now dùng để xác định thông báo còn được ghim
+func ConvertToGroupAnnouncementResponse(announcement *models.GroupAnnouncement, now time.Time) GroupAnnouncementResponse {
+	return GroupAnnouncementResponse{
+		ID:        announcement.ID,
+		GroupID:   announcement.GroupID,
+		Title:     announcement.Title,
+		Content:   announcement.Content,
+		ExpiresAt: announcement.ExpiresAt,
+		IsActive:  announcement.IsActive(now),
+		Author: UserBrief{
+			ID:                announcement.Author.ID,
+			Username:          announcement.Author.Username,
+			FullName:          announcement.Author.FullName,
+			ProfilePictureURL: announcement.Author.ProfilePictureURL,
+		},
+		CreatedAt: announcement.CreatedAt,
+		UpdatedAt: announcement.UpdatedAt,
+	}
+}
@@ -0,0 +1,11 @@
+package response
+
+import "userservice2/models"
+
+// MuteListResponse là DTO cho danh sách người dùng/nhóm đang bị tắt tiếng
+type MuteListResponse struct {
+	Mutes []models.UserMute `json:"mutes"`
+	Total int64             `json:"total"`
+	Page  int               `json:"page"`
+	Size  int               `json:"size"`
+}
@@ -0,0 +1,28 @@
+package response
+
+import "userservice2/models"
+
+// UserReportListResponse là DTO cho hàng đợi báo cáo người dùng
+type UserReportListResponse struct {
+	Reports []models.UserReport `json:"reports"`
+	Total   int64               `json:"total"`
+	Page    int                 `json:"page"`
+	Size    int                 `json:"size"`
+}
+
+// UserReportDetailResponse là DTO cho chi tiết báo cáo kèm các báo cáo khác về cùng người dùng
+// và lịch sử kiểm duyệt
+type UserReportDetailResponse struct {
+	Report       models.UserReport             `json:"report"`
+	UserReports  []models.UserReport           `json:"user_reports"`
+	UserIsHidden bool                          `json:"user_is_hidden"`
+	Actions      []models.UserModerationAction `json:"actions"`
+}
+
+// ModerationActionListResponse là DTO cho nhật ký kiểm duyệt
+type ModerationActionListResponse struct {
+	Actions []models.UserModerationAction `json:"actions"`
+	Total   int64                         `json:"total"`
+	Page    int                           `json:"page"`
+	Size    int                           `json:"size"`
+}
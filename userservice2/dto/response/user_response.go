@@ -2,6 +2,8 @@ package response
 
 import (
 	"time"
+
+	"userservice2/models"
 )
 
 // UserResponse đại diện cho dữ liệu phản hồi người dùng
@@ -22,8 +24,28 @@ type UserResponse struct {
 	Education         string            `json:"education,omitempty"`
 	Relationship      string            `json:"relationship,omitempty"`
 	FriendCount       int               `json:"friend_count"`
+	FollowerCount     int               `json:"follower_count"`
+	FollowingCount    int               `json:"following_count"`
+	FollowPolicy      string            `json:"follow_policy,omitempty"`
+	GroupInvitePolicy string            `json:"group_invite_policy,omitempty"`
 	IsVerified        bool              `json:"is_verified"`
 	CreatedAt         time.Time         `json:"created_at"`
+
+	// Variant kèm kích thước của ảnh đại diện và ảnh bìa
+	ProfilePictureVariants models.MediaVariants `json:"profile_picture_variants,omitempty"`
+	CoverPictureVariants   models.MediaVariants `json:"cover_picture_variants,omitempty"`
+
+	// Kích thước và BlurHash để hiển thị placeholder
+	ProfilePictureWidth    int    `json:"profile_picture_width,omitempty"`
+	ProfilePictureHeight   int    `json:"profile_picture_height,omitempty"`
+	ProfilePictureBlurHash string `json:"profile_picture_blur_hash,omitempty"`
+	CoverPictureWidth      int    `json:"cover_picture_width,omitempty"`
+	CoverPictureHeight     int    `json:"cover_picture_height,omitempty"`
+	CoverPictureBlurHash   string `json:"cover_picture_blur_hash,omitempty"`
+
+	IsHidden bool `json:"is_hidden,omitempty"`
+	// Tài khoản bị admin vô hiệu hóa
+	IsDeactivated bool `json:"is_deactivated,omitempty"`
 }
 
 // UserBrief đại diện cho thông tin tóm tắt về người dùng
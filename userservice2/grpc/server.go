@@ -11,18 +11,32 @@ import (
 	"userservice2/services"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // UserGRPCServer triển khai interface của gRPC server
 type UserGRPCServer struct {
 	proto.UnimplementedUserServiceServer
-	userService services.UserService
+	userService           services.UserService
+	mediaReferenceService services.MediaReferenceService
+	friendshipService     services.FriendshipService
+	muteService           services.MuteService
+	followService         services.FollowService
+	friendListService     services.FriendListService
+	groupService          services.GroupService
 }
 
 // NewUserGRPCServer tạo mới một instance của UserGRPCServer
-func NewUserGRPCServer(userService services.UserService) *UserGRPCServer {
+func NewUserGRPCServer(userService services.UserService, mediaReferenceService services.MediaReferenceService, friendshipService services.FriendshipService, muteService services.MuteService, followService services.FollowService, friendListService services.FriendListService, groupService services.GroupService) *UserGRPCServer {
 	return &UserGRPCServer{
-		userService: userService,
+		userService:           userService,
+		mediaReferenceService: mediaReferenceService,
+		friendshipService:     friendshipService,
+		muteService:           muteService,
+		followService:         followService,
+		friendListService:     friendListService,
+		groupService:          groupService,
 	}
 }
 
@@ -84,8 +98,224 @@ func (s *UserGRPCServer) GetUserIDByUsername(ctx context.Context, req *proto.Get
 	return response, nil
 }
 
+// ListMediaReferences trả về các URL ảnh đang được người dùng/nhóm tham chiếu, phân trang theo ID
+func (s *UserGRPCServer) ListMediaReferences(ctx context.Context, req *proto.ListMediaReferencesRequest) (*proto.ListMediaReferencesResponse, error) {
+	log.Printf("Received gRPC request for ListMediaReferences: source=%s after_id=%d", req.Source, req.AfterId)
+
+	urls, nextAfterID, hasMore, err := s.mediaReferenceService.ListMediaReferences(ctx, req.Source, int64(req.AfterId), int(req.Limit))
+	if err != nil {
+		log.Printf("Error listing media references: %v", err)
+		return nil, err
+	}
+
+	return &proto.ListMediaReferencesResponse{
+		Urls:        urls,
+		NextAfterId: uint64(nextAfterID),
+		HasMore:     hasMore,
+	}, nil
+}
+
+// maxBlockPairs giới hạn số cặp trong một request GetBlockRelations
+const maxBlockPairs = 1000
+
+// GetBlockRelations xử lý request lấy quan hệ chặn giữa các cặp người dùng
+func (s *UserGRPCServer) GetBlockRelations(ctx context.Context, req *proto.GetBlockRelationsRequest) (*proto.GetBlockRelationsResponse, error) {
+	if len(req.Pairs) > maxBlockPairs {
+		return nil, status.Errorf(codes.InvalidArgument, "too many pairs: %d (max %d)", len(req.Pairs), maxBlockPairs)
+	}
+
+	pairs := make([][2]int64, 0, len(req.Pairs))
+	for _, pair := range req.Pairs {
+		pairs = append(pairs, [2]int64{int64(pair.UserId), int64(pair.OtherUserId)})
+	}
+
+	blocks, err := s.friendshipService.GetBlockRelations(ctx, pairs)
+	if err != nil {
+		log.Printf("Error getting block relations: %v", err)
+		return nil, err
+	}
+
+	response := &proto.GetBlockRelationsResponse{
+		Blocks: make([]*proto.BlockRelation, 0, len(blocks)),
+	}
+	for _, block := range blocks {
+		response.Blocks = append(response.Blocks, &proto.BlockRelation{
+			BlockerId: uint64(block.UserID),
+			BlockedId: uint64(block.FriendID),
+		})
+	}
+	return response, nil
+}
+
+// GetBlockedUserIDs trả về mọi người dùng có quan hệ chặn với một người để lọc danh sách ngay trong truy vấn
+func (s *UserGRPCServer) GetBlockedUserIDs(ctx context.Context, req *proto.GetBlockedUserIDsRequest) (*proto.GetBlockedUserIDsResponse, error) {
+	ids, err := s.friendshipService.GetBlockedUserIDs(ctx, int64(req.UserId))
+	if err != nil {
+		log.Printf("Error getting blocked users for user %d: %v", req.UserId, err)
+		return nil, err
+	}
+
+	response := &proto.GetBlockedUserIDsResponse{UserIds: make([]uint64, 0, len(ids))}
+	for _, id := range ids {
+		response.UserIds = append(response.UserIds, uint64(id))
+	}
+	return response, nil
+}
+
+// GetMutedTargets trả về người dùng và nhóm đang bị tắt tiếng/tạm ẩn (chưa hết hạn) của một người
+func (s *UserGRPCServer) GetMutedTargets(ctx context.Context, req *proto.GetMutedTargetsRequest) (*proto.GetMutedTargetsResponse, error) {
+	userIDs, groupIDs, err := s.muteService.GetMutedTargets(ctx, int64(req.UserId))
+	if err != nil {
+		log.Printf("Error getting muted targets for user %d: %v", req.UserId, err)
+		return nil, err
+	}
+
+	response := &proto.GetMutedTargetsResponse{
+		UserIds:  make([]uint64, 0, len(userIDs)),
+		GroupIds: make([]uint64, 0, len(groupIDs)),
+	}
+	for _, id := range userIDs {
+		response.UserIds = append(response.UserIds, uint64(id))
+	}
+	for _, id := range groupIDs {
+		response.GroupIds = append(response.GroupIds, uint64(id))
+	}
+	return response, nil
+}
+
+// GetFeedSources trả về những người một người dùng đang theo dõi và bạn bè của họ để dựng home feed
+func (s *UserGRPCServer) GetFeedSources(ctx context.Context, req *proto.GetFeedSourcesRequest) (*proto.GetFeedSourcesResponse, error) {
+	followingIDs, friendIDs, err := s.followService.GetFeedSources(ctx, int64(req.UserId))
+	if err != nil {
+		log.Printf("Error getting feed sources for user %d: %v", req.UserId, err)
+		return nil, err
+	}
+
+	response := &proto.GetFeedSourcesResponse{
+		FollowingIds: make([]uint64, 0, len(followingIDs)),
+		FriendIds:    make([]uint64, 0, len(friendIDs)),
+	}
+	for _, id := range followingIDs {
+		response.FollowingIds = append(response.FollowingIds, uint64(id))
+	}
+	for _, id := range friendIDs {
+		response.FriendIds = append(response.FriendIds, uint64(id))
+	}
+	return response, nil
+}
+
+// ValidateFriendLists trả về các danh sách bạn bè không thuộc về owner_id, dùng khi tạo/sửa bài đăng CUSTOM
+func (s *UserGRPCServer) ValidateFriendLists(ctx context.Context, req *proto.ValidateFriendListsRequest) (*proto.ValidateFriendListsResponse, error) {
+	listIDs := make([]int64, 0, len(req.ListIds))
+	for _, id := range req.ListIds {
+		listIDs = append(listIDs, int64(id))
+	}
+
+	invalidIDs, err := s.friendListService.FilterOwnedLists(ctx, int64(req.OwnerId), listIDs)
+	if err != nil {
+		log.Printf("Error validating friend lists for user %d: %v", req.OwnerId, err)
+		return nil, err
+	}
+
+	response := &proto.ValidateFriendListsResponse{InvalidListIds: make([]uint64, 0, len(invalidIDs))}
+	for _, id := range invalidIDs {
+		response.InvalidListIds = append(response.InvalidListIds, uint64(id))
+	}
+	return response, nil
+}
+
+// maxAudienceChecks giới hạn số bài đăng trong một request CheckAudiences
+const maxAudienceChecks = 1000
+
+// CheckAudiences kiểm tra người xem có thuộc người xem của từng bài đăng CUSTOM, kết quả theo thứ tự checks
+func (s *UserGRPCServer) CheckAudiences(ctx context.Context, req *proto.CheckAudiencesRequest) (*proto.CheckAudiencesResponse, error) {
+	if len(req.Checks) > maxAudienceChecks {
+		return nil, status.Errorf(codes.InvalidArgument, "too many checks: %d (max %d)", len(req.Checks), maxAudienceChecks)
+	}
+
+	checks := make([]services.AudienceCheck, 0, len(req.Checks))
+	for _, check := range req.Checks {
+		checks = append(checks, services.AudienceCheck{
+			OwnerID:        int64(check.OwnerId),
+			IncludeListIDs: toInt64s(check.IncludeListIds),
+			ExcludeListIDs: toInt64s(check.ExcludeListIds),
+		})
+	}
+
+	allowed, err := s.friendListService.CheckAudiences(ctx, int64(req.ViewerId), checks)
+	if err != nil {
+		log.Printf("Error checking audiences for user %d: %v", req.ViewerId, err)
+		return nil, err
+	}
+	return &proto.CheckAudiencesResponse{Allowed: allowed}, nil
+}
+
+// GetViewerAudience trả về bạn bè, các danh sách bạn bè và các nhóm chứa người xem để lọc bài đăng CUSTOM
+// và bài đăng trong nhóm
+func (s *UserGRPCServer) GetViewerAudience(ctx context.Context, req *proto.GetViewerAudienceRequest) (*proto.GetViewerAudienceResponse, error) {
+	friendIDs, listIDs, err := s.friendListService.GetViewerAudience(ctx, int64(req.ViewerId))
+	if err != nil {
+		log.Printf("Error getting audience of user %d: %v", req.ViewerId, err)
+		return nil, err
+	}
+	groupIDs, err := s.groupService.GetMemberGroupIDs(ctx, int64(req.ViewerId))
+	if err != nil {
+		log.Printf("Error getting groups of user %d: %v", req.ViewerId, err)
+		return nil, err
+	}
+
+	response := &proto.GetViewerAudienceResponse{
+		FriendIds: make([]uint64, 0, len(friendIDs)),
+		ListIds:   make([]uint64, 0, len(listIDs)),
+		GroupIds:  make([]uint64, 0, len(groupIDs)),
+	}
+	for _, id := range friendIDs {
+		response.FriendIds = append(response.FriendIds, uint64(id))
+	}
+	for _, id := range listIDs {
+		response.ListIds = append(response.ListIds, uint64(id))
+	}
+	for _, id := range groupIDs {
+		response.GroupIds = append(response.GroupIds, uint64(id))
+	}
+	return response, nil
+}
+
+// CheckGroupPermission kiểm tra người dùng có một quyền trong nhóm
+func (s *UserGRPCServer) CheckGroupPermission(ctx context.Context, req *proto.CheckGroupPermissionRequest) (*proto.CheckGroupPermissionResponse, error) {
+	if req.Permission == "" {
+		return nil, status.Error(codes.InvalidArgument, "permission is required")
+	}
+
+	allowed, err := s.groupService.HasGroupPermission(ctx, int64(req.UserId), int64(req.GroupId), req.Permission)
+	if err != nil {
+		log.Printf("Error checking permission %s for user %d in group %d: %v", req.Permission, req.UserId, req.GroupId, err)
+		return nil, err
+	}
+	return &proto.CheckGroupPermissionResponse{Allowed: allowed}, nil
+}
+
+// CheckGroupPosting kiểm tra người dùng có là thành viên và có đang bị tắt tiếng trong nhóm
+func (s *UserGRPCServer) CheckGroupPosting(ctx context.Context, req *proto.CheckGroupPostingRequest) (*proto.CheckGroupPostingResponse, error) {
+	isMember, isMuted, err := s.groupService.GetPostingStatus(ctx, int64(req.UserId), int64(req.GroupId))
+	if err != nil {
+		log.Printf("Error checking posting status for user %d in group %d: %v", req.UserId, req.GroupId, err)
+		return nil, err
+	}
+	return &proto.CheckGroupPostingResponse{IsMember: isMember, IsMuted: isMuted}, nil
+}
+
+// toInt64s chuyển danh sách ID uint64 của proto sang int64 của model
+func toInt64s(ids []uint64) []int64 {
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, int64(id))
+	}
+	return result
+}
+
 // StartGRPCServer khởi động gRPC server
-func StartGRPCServer(userService services.UserService, port int) {
+func StartGRPCServer(userService services.UserService, mediaReferenceService services.MediaReferenceService, friendshipService services.FriendshipService, muteService services.MuteService, followService services.FollowService, friendListService services.FriendListService, groupService services.GroupService, port int) {
 	addr := fmt.Sprintf(":%d", port)
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
@@ -93,7 +323,7 @@ func StartGRPCServer(userService services.UserService, port int) {
 	}
 
 	grpcServer := grpc.NewServer()
-	userGRPCServer := NewUserGRPCServer(userService)
+	userGRPCServer := NewUserGRPCServer(userService, mediaReferenceService, friendshipService, muteService, followService, friendListService, groupService)
 	proto.RegisterUserServiceServer(grpcServer, userGRPCServer)
 
 	log.Printf("gRPC server listening on %s", addr)
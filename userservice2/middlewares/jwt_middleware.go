@@ -2,13 +2,16 @@ package middlewares
 
 import (
 	"log"
+	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
+	"shared/jwtauth"
 )
 
-// JWTMiddleware trích xuất userID từ token JWT nếu có
+// JWTMiddleware xác thực token JWT nếu có (chữ ký, exp, iss khi đã gọi jwtauth.Init) và gắn
+// userID, roles, claims vào context. Token không hợp lệ được bỏ qua như request chưa đăng nhập.
 func JWTMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -24,49 +27,105 @@ func JWTMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Parse token mà không verify (vì Kong đã làm điều này)
-		token, _, err := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
+		claims, err := jwtauth.Parse(tokenString)
 		if err != nil {
-			log.Printf("Invalid token format: %v", err)
+			log.Printf("Invalid token: %v", err)
 			c.Next() // Tiếp tục thay vì Abort
 			return
 		}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			userID, ok := claims["userId"]
-			if !ok {
-				log.Printf("User ID not found in token")
-				c.Next()
-				return
-			}
-
-			var id int64
-			switch v := userID.(type) {
-			case float64:
-				id = int64(v)
-			case int64:
-				id = v
-			case uint:
-				id = int64(v)
-			case int:
-				id = int64(v)
-			default:
-				log.Printf("Invalid user ID type in token: %v", userID)
-				c.Next()
-				return
-			}
+		userID, ok := claims["userId"]
+		if !ok {
+			log.Printf("User ID not found in token")
+			c.Next()
+			return
+		}
 
-			if id == 0 {
-				log.Printf("Invalid user ID value")
-				c.Next()
-				return
-			}
+		var id int64
+		switch v := userID.(type) {
+		case float64:
+			id = int64(v)
+		case int64:
+			id = v
+		case uint:
+			id = int64(v)
+		case int:
+			id = int64(v)
+		default:
+			log.Printf("Invalid user ID type in token: %v", userID)
+			c.Next()
+			return
+		}
 
-			c.Set("userID", id)
-		} else {
-			log.Printf("Invalid token claims")
+		if id == 0 {
+			log.Printf("Invalid user ID value")
+			c.Next()
+			return
 		}
 
+		c.Set("userID", id)
+		c.Set("roles", rolesFromClaims(claims))
+		c.Set("claims", claims)
+
+		c.Next()
+	}
+}
+
+// Các role (từ claim "roles" của JWT) dùng cho phân quyền
+const (
+	RoleAdmin     = "ADMIN"
+	RoleModerator = "MODERATOR"
+)
+
+// RequireRole chỉ cho phép request đi tiếp khi token có ít nhất một trong các role.
+// Phải đặt sau JWTMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, exists := c.Get("userID"); !exists {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Chưa xác thực"})
+			return
+		}
+		if !HasAnyRole(c, roles...) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Không có quyền truy cập"})
+			return
+		}
 		c.Next()
 	}
 }
+
+// HasAnyRole kiểm tra người dùng hiện tại có một trong các role
+func HasAnyRole(c *gin.Context, roles ...string) bool {
+	value, exists := c.Get("roles")
+	if !exists {
+		return false
+	}
+	userRoles, _ := value.([]string)
+	for _, userRole := range userRoles {
+		for _, role := range roles {
+			if strings.EqualFold(userRole, role) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rolesFromClaims đọc claim "roles" (mảng hoặc chuỗi phân tách bằng dấu phẩy)
+func rolesFromClaims(claims jwt.MapClaims) []string {
+	var roles []string
+	switch v := claims["roles"].(type) {
+	case []interface{}:
+		for _, r := range v {
+			if role, ok := r.(string); ok && role != "" {
+				roles = append(roles, role)
+			}
+		}
+	case string:
+		for _, role := range strings.Split(v, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				roles = append(roles, role)
+			}
+		}
+	}
+	return roles
+}
@@ -0,0 +1,30 @@
+package middlewares
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"shared/ratelimit"
+)
+
+// Các nhóm route bị giới hạn tần suất, cấu hình qua RATE_LIMIT_<NHÓM>
+const (
+	RateLimitFriendAction = "friend_action"
+	RateLimitGroupJoin    = "group_join"
+	RateLimitGroupCreate  = "group_create"
+	RateLimitUserReport   = "user_report"
+)
+
+// RateLimit giới hạn tần suất request của nhóm route, mỗi người dùng (userID từ JWTMiddleware, nếu không
+// có thì theo IP) một bucket
+func RateLimit(limiter *ratelimit.Limiter, group string) gin.HandlerFunc {
+	return ratelimit.Middleware(limiter, group, rateLimitKey, "Bạn thao tác quá nhanh, vui lòng thử lại sau")
+}
+
+// rateLimitKey trả về khóa bucket theo người dùng đã đăng nhập, nếu không có thì theo IP
+func rateLimitKey(c *gin.Context) string {
+	if userID, exists := c.Get("userID"); exists {
+		return fmt.Sprintf("user:%d", userID)
+	}
+	return "ip:" + c.ClientIP()
+}
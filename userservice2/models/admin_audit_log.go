@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+// AdminActionType đại diện cho thao tác quản trị
+type AdminActionType string
+
+const (
+	// Các thao tác quản trị
+	AdminActionUserDeactivate AdminActionType = "user_deactivate"
+	AdminActionUserActivate   AdminActionType = "user_activate"
+	AdminActionUserVerify     AdminActionType = "user_verify"
+	AdminActionUserUnverify   AdminActionType = "user_unverify"
+	AdminActionGroupDelete    AdminActionType = "group_delete"
+	AdminActionGroupTransfer  AdminActionType = "group_transfer"
+)
+
+// AdminTargetType đại diện cho loại đối tượng bị thao tác
+type AdminTargetType string
+
+const (
+	// Các loại đối tượng
+	AdminTargetUser  AdminTargetType = "user"
+	AdminTargetGroup AdminTargetType = "group"
+)
+
+// AdminAuditLog lưu vết mọi thao tác của admin
+type AdminAuditLog struct {
+	ID         int64           `json:"id" gorm:"primaryKey;autoIncrement"`
+	AdminID    int64           `json:"admin_id" gorm:"not null;index:idx_admin_audit_admin"`
+	Action     AdminActionType `json:"action" gorm:"size:30;not null"`
+	TargetType AdminTargetType `json:"target_type" gorm:"size:10;not null;index:idx_admin_audit_target"`
+	TargetID   int64           `json:"target_id" gorm:"not null;index:idx_admin_audit_target"`
+	Details    string          `json:"details" gorm:"type:text"` // Thông tin bổ sung, vd: chủ cũ/mới khi chuyển nhóm
+	Note       string          `json:"note" gorm:"type:text"`
+	CreatedAt  time.Time       `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (AdminAuditLog) TableName() string {
+	return "admin_audit_logs"
+}
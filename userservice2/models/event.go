@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+)
+
+// DefaultEventTimezone là múi giờ mặc định của sự kiện khi người tạo không chỉ định
+const DefaultEventTimezone = "Asia/Ho_Chi_Minh"
+
+// EventRSVPStatus đại diện cho phản hồi của người dùng với một sự kiện
+type EventRSVPStatus string
+
+const (
+	// Các trạng thái phản hồi sự kiện
+	EventRSVPGoing      EventRSVPStatus = "going"
+	EventRSVPInterested EventRSVPStatus = "interested"
+	EventRSVPDeclined   EventRSVPStatus = "declined"
+)
+
+// Event đại diện cho một sự kiện của nhóm, hoặc sự kiện cá nhân khi GroupID là nil
+type Event struct {
+	ID          int64  `json:"id" gorm:"primaryKey;autoIncrement"`
+	GroupID     *int64 `json:"group_id" gorm:"index:idx_event_group"`
+	CreatedBy   int64  `json:"created_by" gorm:"not null;index:idx_event_creator"`
+	Title       string `json:"title" gorm:"size:200;not null"`
+	Description string `json:"description" gorm:"type:text"`
+	// Thời điểm bắt đầu/kết thúc lưu theo UTC, Timezone (tên IANA) dùng để hiển thị giờ địa phương
+	StartsAt   time.Time `json:"starts_at" gorm:"not null;index:idx_event_starts_at"`
+	EndsAt     time.Time `json:"ends_at" gorm:"not null;index:idx_event_ends_at"`
+	Timezone   string    `json:"timezone" gorm:"size:64;not null"`
+	Location   string    `json:"location" gorm:"size:255"`
+	CoverImage string    `json:"cover_image" gorm:"size:255"`
+	// Số người tham gia/quan tâm, cập nhật cùng transaction với phản hồi
+	GoingCount      int        `json:"going_count" gorm:"default:0"`
+	InterestedCount int        `json:"interested_count" gorm:"default:0"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	Creator         User       `json:"creator" gorm:"foreignKey:CreatedBy"`
+	Group           *UserGroup `json:"group,omitempty" gorm:"foreignKey:GroupID"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (Event) TableName() string {
+	return "events"
+}
+
+// EventRSVP đại diện cho phản hồi của một người dùng với một sự kiện
+type EventRSVP struct {
+	ID        int64           `json:"id" gorm:"primaryKey;autoIncrement"`
+	EventID   int64           `json:"event_id" gorm:"not null;unique_index:idx_event_rsvp_pair"`
+	UserID    int64           `json:"user_id" gorm:"not null;unique_index:idx_event_rsvp_pair;index:idx_event_rsvp_user"`
+	Status    EventRSVPStatus `json:"status" gorm:"type:enum('going','interested','declined');not null;index:idx_event_rsvp_status"`
+	CreatedAt time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+	User      User            `json:"user" gorm:"foreignKey:UserID"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (EventRSVP) TableName() string {
+	return "event_rsvps"
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// FriendList là danh sách bạn bè do người dùng tự tạo (Bạn thân, Gia đình...), dùng làm
+// người xem cho bài đăng có visibility CUSTOM
+type FriendList struct {
+	ID        int64              `json:"id" gorm:"primaryKey;autoIncrement"`
+	OwnerID   int64              `json:"owner_id" gorm:"not null;index:idx_friend_list_owner"`
+	Name      string             `json:"name" gorm:"size:50;not null"`
+	CreatedAt time.Time          `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time          `json:"updated_at" gorm:"autoUpdateTime"`
+	Members   []FriendListMember `json:"-" gorm:"foreignKey:ListID"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (FriendList) TableName() string {
+	return "friend_lists"
+}
+
+// FriendListMember là một người bạn thuộc danh sách bạn bè
+type FriendListMember struct {
+	ID        int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	ListID    int64     `json:"list_id" gorm:"not null;unique_index:idx_friend_list_member"`
+	UserID    int64     `json:"user_id" gorm:"not null;unique_index:idx_friend_list_member;index:idx_friend_list_member_user"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	User      User      `json:"user" gorm:"foreignKey:UserID"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (FriendListMember) TableName() string {
+	return "friend_list_members"
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// FriendSuggestion là một gợi ý kết bạn đã được tính sẵn cho UserID, xếp theo Score giảm dần.
+// Các tín hiệu được lưu lại để trả về cùng gợi ý mà không phải tính lại.
+type FriendSuggestion struct {
+	ID              int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID          int64     `json:"user_id" gorm:"not null;unique_index:idx_friend_suggestion_pair;index:idx_friend_suggestion_score"`
+	SuggestedUserID int64     `json:"suggested_user_id" gorm:"not null;unique_index:idx_friend_suggestion_pair"`
+	Score           float64   `json:"score" gorm:"not null;index:idx_friend_suggestion_score"`
+	MutualFriends   int       `json:"mutual_friends" gorm:"default:0"`
+	SharedGroups    int       `json:"shared_groups" gorm:"default:0"`
+	Reason          string    `json:"reason" gorm:"size:255"`
+	ComputedAt      time.Time `json:"computed_at" gorm:"not null"`
+	SuggestedUser   User      `json:"suggested_user" gorm:"foreignKey:SuggestedUserID"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (FriendSuggestion) TableName() string {
+	return "friend_suggestions"
+}
+
+// FriendSuggestionDismissal ghi nhận người dùng đã bỏ qua một gợi ý, người đó sẽ không được gợi ý lại
+type FriendSuggestionDismissal struct {
+	ID              int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID          int64     `json:"user_id" gorm:"not null;unique_index:idx_friend_suggestion_dismissal"`
+	SuggestedUserID int64     `json:"suggested_user_id" gorm:"not null;unique_index:idx_friend_suggestion_dismissal"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (FriendSuggestionDismissal) TableName() string {
+	return "friend_suggestion_dismissals"
+}
+
+// FriendSuggestionState ghi lại lần tính gợi ý gần nhất của UserID, kể cả khi không có ứng viên nào,
+// để không phải tính lại ở mỗi request
+type FriendSuggestionState struct {
+	UserID     int64     `json:"user_id" gorm:"primary_key;auto_increment:false"`
+	ComputedAt time.Time `json:"computed_at" gorm:"not null"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (FriendSuggestionState) TableName() string {
+	return "friend_suggestion_states"
+}
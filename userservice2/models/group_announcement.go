@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// MaxActiveGroupAnnouncements là số thông báo còn hiệu lực tối đa được ghim cùng lúc trong một nhóm
+const MaxActiveGroupAnnouncements = 5
+
+// GroupAnnouncement là thông báo được ghim ở đầu trang nhóm cho tới khi hết hạn
+type GroupAnnouncement struct {
+	ID       int64  `json:"id" gorm:"primaryKey;autoIncrement"`
+	GroupID  int64  `json:"group_id" gorm:"not null;index:idx_group_announcement_group"`
+	AuthorID int64  `json:"author_id" gorm:"not null"`
+	Title    string `json:"title" gorm:"size:200;not null"`
+	Content  string `json:"content" gorm:"type:text;not null"`
+	// Thời điểm thông báo hết được ghim, nil là ghim cho tới khi bị gỡ
+	ExpiresAt *time.Time `json:"expires_at" gorm:"default:null;index:idx_group_announcement_expires_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	Author    User       `json:"author" gorm:"foreignKey:AuthorID"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (GroupAnnouncement) TableName() string {
+	return "group_announcements"
+}
+
+// IsActive kiểm tra thông báo còn được ghim tại thời điểm now
+func (a *GroupAnnouncement) IsActive(now time.Time) bool {
+	return a.ExpiresAt == nil || a.ExpiresAt.After(now)
+}
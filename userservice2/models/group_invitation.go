@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+// GroupInvitePolicy đại diện cho cài đặt ai được phép mời một người dùng vào nhóm
+type GroupInvitePolicy string
+
+const (
+	// Các cài đặt mời vào nhóm
+	GroupInvitePolicyEveryone GroupInvitePolicy = "everyone"
+	GroupInvitePolicyFriends  GroupInvitePolicy = "friends" // Chỉ bạn bè được mời
+	GroupInvitePolicyNobody   GroupInvitePolicy = "nobody"
+)
+
+// GroupInvitationStatus đại diện cho trạng thái của lời mời vào nhóm
+type GroupInvitationStatus string
+
+const (
+	// Các trạng thái lời mời
+	GroupInvitationStatusPending  GroupInvitationStatus = "pending"
+	GroupInvitationStatusAccepted GroupInvitationStatus = "accepted"
+	GroupInvitationStatusDeclined GroupInvitationStatus = "declined"
+	GroupInvitationStatusExpired  GroupInvitationStatus = "expired" // Quá hạn mà người được mời chưa trả lời
+)
+
+// GroupInvitation đại diện cho lời mời vào nhóm, người được mời chỉ trở thành thành viên khi chấp nhận
+type GroupInvitation struct {
+	ID          int64                 `json:"id" gorm:"primaryKey;autoIncrement"`
+	GroupID     int64                 `json:"group_id" gorm:"not null;index:idx_group_id"`
+	InviterID   int64                 `json:"inviter_id" gorm:"not null"`
+	InviteeID   int64                 `json:"invitee_id" gorm:"not null;index:idx_invitee_id"`
+	Status      GroupInvitationStatus `json:"status" gorm:"type:enum('pending','accepted','declined','expired');default:'pending';index:idx_status"`
+	ExpiresAt   *time.Time            `json:"expires_at" gorm:"default:null;index:idx_expires_at"` // nil là không hết hạn
+	RespondedAt *time.Time            `json:"responded_at" gorm:"default:null"`
+	CreatedAt   time.Time             `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time             `json:"updated_at" gorm:"autoUpdateTime"`
+	Group       UserGroup             `json:"group" gorm:"foreignKey:GroupID"`
+	Inviter     User                  `json:"inviter" gorm:"foreignKey:InviterID"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (GroupInvitation) TableName() string {
+	return "group_invitations"
+}
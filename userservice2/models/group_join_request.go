@@ -1,6 +1,9 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"time"
 )
 
@@ -9,22 +12,66 @@ type JoinRequestStatus string
 
 const (
 	// Các trạng thái yêu cầu tham gia
-	JoinRequestStatusPending  JoinRequestStatus = "pending"
-	JoinRequestStatusApproved JoinRequestStatus = "approved"
-	JoinRequestStatusRejected JoinRequestStatus = "rejected"
+	JoinRequestStatusPending   JoinRequestStatus = "pending"
+	JoinRequestStatusApproved  JoinRequestStatus = "approved"
+	JoinRequestStatusRejected  JoinRequestStatus = "rejected"
+	JoinRequestStatusWithdrawn JoinRequestStatus = "withdrawn" // Người gửi tự rút lại
+	JoinRequestStatusExpired   JoinRequestStatus = "expired"   // Quá hạn mà chưa được xử lý
 )
 
+// JoinRequestAnswer là câu trả lời của người xin tham gia cho một câu hỏi của nhóm.
+// Câu hỏi được lưu lại nguyên văn để vẫn đọc được khi nhóm đổi câu hỏi sau này.
+type JoinRequestAnswer struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+// JoinRequestAnswers là danh sách câu trả lời, lưu dưới dạng JSON
+type JoinRequestAnswers []JoinRequestAnswer
+
+// Value chuyển đổi JoinRequestAnswers thành giá trị để lưu vào cơ sở dữ liệu
+func (a JoinRequestAnswers) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan đọc dữ liệu từ cơ sở dữ liệu và chuyển đổi thành JoinRequestAnswers
+func (a *JoinRequestAnswers) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, a)
+}
+
 // GroupJoinRequest đại diện cho yêu cầu tham gia nhóm
 type GroupJoinRequest struct {
-	ID        int64             `json:"id" gorm:"primaryKey;autoIncrement"`
-	GroupID   int64             `json:"group_id" gorm:"not null;index:idx_group_id"`
-	UserID    int64             `json:"user_id" gorm:"not null;index:idx_user_id"`
-	Message   string            `json:"message" gorm:"type:text"`
-	Status    JoinRequestStatus `json:"status" gorm:"type:enum('pending','approved','rejected');default:'pending';index:idx_status"`
-	CreatedAt time.Time         `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time         `json:"updated_at" gorm:"autoUpdateTime"`
-	Group     UserGroup         `json:"group" gorm:"foreignKey:GroupID"`
-	User      User              `json:"user" gorm:"foreignKey:UserID"`
+	ID         int64              `json:"id" gorm:"primaryKey;autoIncrement"`
+	GroupID    int64              `json:"group_id" gorm:"not null;index:idx_group_id"`
+	UserID     int64              `json:"user_id" gorm:"not null;index:idx_user_id"`
+	Nickname   string             `json:"nickname" gorm:"size:50"`
+	Message    string             `json:"message" gorm:"type:text"`
+	Answers    JoinRequestAnswers `json:"answers" gorm:"type:json"`
+	Status     JoinRequestStatus  `json:"status" gorm:"type:enum('pending','approved','rejected','withdrawn','expired');default:'pending';index:idx_status"`
+	ReviewedBy *int64             `json:"reviewed_by" gorm:"default:null"`
+	ReviewedAt *time.Time         `json:"reviewed_at" gorm:"default:null"`
+	ExpiresAt  *time.Time         `json:"expires_at" gorm:"default:null;index:idx_expires_at"` // nil là không hết hạn
+	// Thời điểm người gửi chấp nhận nội quy nhóm, được chép sang thành viên khi yêu cầu được duyệt
+	RulesAcceptedAt *time.Time `json:"rules_accepted_at" gorm:"default:null"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	Group           UserGroup  `json:"group" gorm:"foreignKey:GroupID"`
+	User            User       `json:"user" gorm:"foreignKey:UserID"`
 }
 
 // TableName chỉ định tên bảng trong cơ sở dữ liệu
@@ -25,17 +25,22 @@ const (
 
 // GroupMember đại diện cho thành viên trong nhóm
 type GroupMember struct {
-	ID       int64             `json:"id" gorm:"primaryKey;autoIncrement"`
-	GroupID  int64             `json:"group_id" gorm:"not null;index:idx_group_id;uniqueIndex:unique_group_member"`
-	UserID   int64             `json:"user_id" gorm:"not null;index:idx_user_id;uniqueIndex:unique_group_member"`
-	Role     MemberRole        `json:"role" gorm:"type:enum('member','admin');default:'member';index:idx_role"`
-	Nickname string            `json:"nickname" gorm:"size:50"`
-	IsMuted  bool              `json:"is_muted" gorm:"default:false"`
-	Status   GroupMemberStatus `json:"status" gorm:"type:enum('pending','approved','rejected');default:'pending';index:idx_status"`
-	JoinedAt time.Time         `json:"joined_at" gorm:"autoCreateTime"`
-	LeftAt   *time.Time        `json:"left_at" gorm:"default:null"`
-	Group    UserGroup         `json:"group" gorm:"foreignKey:GroupID"`
-	User     User              `json:"user" gorm:"foreignKey:UserID"`
+	ID       int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	GroupID  int64      `json:"group_id" gorm:"not null;index:idx_group_id;uniqueIndex:unique_group_member"`
+	UserID   int64      `json:"user_id" gorm:"not null;index:idx_user_id;uniqueIndex:unique_group_member"`
+	Role     MemberRole `json:"role" gorm:"type:enum('member','admin');default:'member';index:idx_role"`
+	Nickname string     `json:"nickname" gorm:"size:50"`
+	IsMuted  bool       `json:"is_muted" gorm:"default:false"`
+	// Thời điểm hết tắt tiếng (nil là tắt tiếng vô thời hạn) và lý do tắt tiếng
+	MutedUntil *time.Time        `json:"muted_until" gorm:"default:null;index:idx_muted_until"`
+	MuteReason string            `json:"mute_reason" gorm:"size:255"`
+	Status     GroupMemberStatus `json:"status" gorm:"type:enum('pending','approved','rejected');default:'pending';index:idx_status"`
+	// Thời điểm thành viên chấp nhận nội quy nhóm, nil nếu chưa chấp nhận (nhóm chưa có nội quy khi tham gia)
+	RulesAcceptedAt *time.Time `json:"rules_accepted_at" gorm:"default:null"`
+	JoinedAt        time.Time  `json:"joined_at" gorm:"autoCreateTime"`
+	LeftAt          *time.Time `json:"left_at" gorm:"default:null"`
+	Group           UserGroup  `json:"group" gorm:"foreignKey:GroupID"`
+	User            User       `json:"user" gorm:"foreignKey:UserID"`
 }
 
 // TableName chỉ định tên bảng trong cơ sở dữ liệu
@@ -7,8 +7,8 @@ import (
 // GroupMemberRole đại diện cho việc gán vai trò cho thành viên nhóm
 type GroupMemberRole struct {
 	ID            int64       `json:"id" gorm:"primaryKey;autoIncrement"`
-	GroupMemberID int64       `json:"group_member_id" gorm:"not null;uniqueIndex:unique_member_role"`
-	GroupRoleID   int64       `json:"group_role_id" gorm:"not null;uniqueIndex:unique_member_role"`
+	GroupMemberID int64       `json:"group_member_id" gorm:"not null;unique_index:unique_member_role"`
+	GroupRoleID   int64       `json:"group_role_id" gorm:"not null;unique_index:unique_member_role"`
 	CreatedAt     time.Time   `json:"created_at" gorm:"autoCreateTime"`
 	GroupMember   GroupMember `json:"group_member" gorm:"foreignKey:GroupMemberID"`
 	GroupRole     GroupRole   `json:"group_role" gorm:"foreignKey:GroupRoleID"`
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+// GroupBan đại diện cho lệnh cấm một người dùng khỏi nhóm. Người bị cấm không thể tham gia, gửi yêu cầu
+// tham gia hay được mời lại cho tới khi được gỡ cấm.
+type GroupBan struct {
+	ID        int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	GroupID   int64     `json:"group_id" gorm:"not null;unique_index:unique_group_ban"`
+	UserID    int64     `json:"user_id" gorm:"not null;unique_index:unique_group_ban;index:idx_user_id"`
+	BannedBy  int64     `json:"banned_by" gorm:"not null"`
+	Reason    string    `json:"reason" gorm:"size:255"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	User      User      `json:"user" gorm:"foreignKey:UserID"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (GroupBan) TableName() string {
+	return "group_bans"
+}
+
+// GroupModerationAction đại diện cho thao tác kiểm duyệt trong nhóm
+type GroupModerationAction string
+
+const (
+	// Các thao tác kiểm duyệt
+	GroupModerationMemberRemove GroupModerationAction = "member_remove"
+	GroupModerationMemberBan    GroupModerationAction = "member_ban"
+	GroupModerationMemberUnban  GroupModerationAction = "member_unban"
+	GroupModerationMemberMute   GroupModerationAction = "member_mute"
+	GroupModerationMemberUnmute GroupModerationAction = "member_unmute"
+	GroupModerationMuteExpire   GroupModerationAction = "mute_expire" // Tác vụ nền gỡ tắt tiếng khi hết hạn
+)
+
+// GroupModerationLog lưu vết các thao tác kiểm duyệt trong nhóm
+type GroupModerationLog struct {
+	ID           int64                 `json:"id" gorm:"primaryKey;autoIncrement"`
+	GroupID      int64                 `json:"group_id" gorm:"not null;index:idx_group_moderation_group"`
+	ActorID      *int64                `json:"actor_id" gorm:"default:null"` // nil là hệ thống
+	TargetUserID int64                 `json:"target_user_id" gorm:"not null"`
+	Action       GroupModerationAction `json:"action" gorm:"size:30;not null"`
+	Reason       string                `json:"reason" gorm:"size:255"`
+	Details      string                `json:"details" gorm:"type:text"` // Thông tin bổ sung, vd: thời hạn tắt tiếng
+	CreatedAt    time.Time             `json:"created_at" gorm:"autoCreateTime;index:idx_group_moderation_group"`
+	Actor        *User                 `json:"actor,omitempty" gorm:"foreignKey:ActorID"`
+	TargetUser   User                  `json:"target_user" gorm:"foreignKey:TargetUserID"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (GroupModerationLog) TableName() string {
+	return "group_moderation_logs"
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// GroupOwnershipTransferStatus đại diện cho trạng thái của yêu cầu chuyển quyền sở hữu nhóm
+type GroupOwnershipTransferStatus string
+
+const (
+	// Các trạng thái chuyển quyền sở hữu
+	GroupOwnershipTransferStatusPending   GroupOwnershipTransferStatus = "pending"
+	GroupOwnershipTransferStatusAccepted  GroupOwnershipTransferStatus = "accepted"
+	GroupOwnershipTransferStatusDeclined  GroupOwnershipTransferStatus = "declined"
+	GroupOwnershipTransferStatusCancelled GroupOwnershipTransferStatus = "cancelled" // Chủ nhóm hủy hoặc quyền sở hữu đã đổi theo cách khác
+)
+
+// GroupOwnershipTransfer đại diện cho yêu cầu chuyển quyền sở hữu nhóm, chỉ có hiệu lực khi người nhận xác nhận
+type GroupOwnershipTransfer struct {
+	ID          int64                        `json:"id" gorm:"primaryKey;autoIncrement"`
+	GroupID     int64                        `json:"group_id" gorm:"not null;index:idx_group_id"`
+	FromUserID  int64                        `json:"from_user_id" gorm:"not null"`
+	ToUserID    int64                        `json:"to_user_id" gorm:"not null;index:idx_to_user_id"`
+	Status      GroupOwnershipTransferStatus `json:"status" gorm:"type:enum('pending','accepted','declined','cancelled');default:'pending';index:idx_status"`
+	RespondedAt *time.Time                   `json:"responded_at" gorm:"default:null"`
+	CreatedAt   time.Time                    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time                    `json:"updated_at" gorm:"autoUpdateTime"`
+	FromUser    User                         `json:"from_user" gorm:"foreignKey:FromUserID"`
+	ToUser      User                         `json:"to_user" gorm:"foreignKey:ToUserID"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (GroupOwnershipTransfer) TableName() string {
+	return "group_ownership_transfers"
+}
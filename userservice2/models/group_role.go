@@ -7,9 +7,54 @@ import (
 	"time"
 )
 
+// Các quyền có thể gán cho vai trò trong nhóm. Admin của nhóm luôn có tất cả các quyền.
+const (
+	GroupPermissionApproveMembers = "approve_members" // Duyệt/từ chối yêu cầu tham gia
+	GroupPermissionInviteMembers  = "invite_members"  // Mời người dùng vào nhóm
+	GroupPermissionRemoveMembers  = "remove_members"  // Xóa thành viên khỏi nhóm
+	GroupPermissionMuteMembers    = "mute_members"    // Tắt tiếng thành viên
+	GroupPermissionDeletePosts    = "delete_posts"    // Xóa bài đăng của người khác trong nhóm
+	GroupPermissionEditGroup      = "edit_group"      // Sửa tên, mô tả, ảnh bìa, quyền riêng tư
+	GroupPermissionManageRoles    = "manage_roles"    // Tạo/sửa/xóa vai trò và gán vai trò cho thành viên
+	GroupPermissionManageEvents   = "manage_events"   // Sửa/xóa sự kiện nhóm do người khác tạo
+	// Thêm/sửa/xóa/sắp xếp nội quy nhóm
+	GroupPermissionManageRules = "manage_rules"
+	// Đăng/sửa/gỡ thông báo ghim của nhóm
+	GroupPermissionManageAnnouncements = "manage_announcements"
+)
+
+// GroupPermissions là danh sách tất cả các quyền hợp lệ
+var GroupPermissions = []string{
+	GroupPermissionApproveMembers,
+	GroupPermissionInviteMembers,
+	GroupPermissionRemoveMembers,
+	GroupPermissionMuteMembers,
+	GroupPermissionDeletePosts,
+	GroupPermissionEditGroup,
+	GroupPermissionManageRoles,
+	GroupPermissionManageEvents,
+	GroupPermissionManageRules,
+	GroupPermissionManageAnnouncements,
+}
+
+// IsValidGroupPermission kiểm tra permission có thuộc danh sách quyền hợp lệ không
+func IsValidGroupPermission(permission string) bool {
+	for _, p := range GroupPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
 // RolePermissions đại diện cho các quyền của vai trò
 type RolePermissions map[string]bool
 
+// Has kiểm tra vai trò có quyền permission không
+func (p RolePermissions) Has(permission string) bool {
+	return p[permission]
+}
+
 // Value chuyển đổi RolePermissions thành giá trị để lưu vào cơ sở dữ liệu
 func (p RolePermissions) Value() (driver.Value, error) {
 	return json.Marshal(p)
@@ -28,8 +73,8 @@ func (p *RolePermissions) Scan(value interface{}) error {
 // GroupRole đại diện cho vai trò trong nhóm
 type GroupRole struct {
 	ID          int64           `json:"id" gorm:"primaryKey;autoIncrement"`
-	GroupID     int64           `json:"group_id" gorm:"not null;index:idx_group_id;uniqueIndex:unique_role_name"`
-	Name        string          `json:"name" gorm:"size:50;not null;uniqueIndex:unique_role_name"`
+	GroupID     int64           `json:"group_id" gorm:"not null;index:idx_group_id;unique_index:unique_role_name"`
+	Name        string          `json:"name" gorm:"size:50;not null;unique_index:unique_role_name"`
 	Permissions RolePermissions `json:"permissions" gorm:"type:json"`
 	CreatedAt   time.Time       `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt   time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
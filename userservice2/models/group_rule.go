@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// MaxGroupRules là số điều nội quy tối đa của một nhóm
+const MaxGroupRules = 20
+
+// GroupRule là một điều trong nội quy nhóm. Các điều được hiển thị theo Position tăng dần, bắt đầu từ 1.
+type GroupRule struct {
+	ID          int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	GroupID     int64     `json:"group_id" gorm:"not null;index:idx_group_rule_group"`
+	Position    int       `json:"position" gorm:"not null"`
+	Title       string    `json:"title" gorm:"size:150;not null"`
+	Description string    `json:"description" gorm:"type:text"`
+	CreatedBy   int64     `json:"created_by" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (GroupRule) TableName() string {
+	return "group_rules"
+}
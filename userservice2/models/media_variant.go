@@ -0,0 +1,52 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// MediaVariant đại diện cho một phiên bản kích thước khác của ảnh (thumbnail, medium, large)
+type MediaVariant struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// MediaVariants là danh sách variant, lưu dưới dạng JSON
+type MediaVariants []MediaVariant
+
+// Value chuyển đổi MediaVariants thành giá trị để lưu vào cơ sở dữ liệu
+func (v MediaVariants) Value() (driver.Value, error) {
+	if v == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan đọc dữ liệu từ cơ sở dữ liệu và chuyển đổi thành MediaVariants
+func (v *MediaVariants) Scan(value interface{}) error {
+	if value == nil {
+		*v = nil
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, v)
+}
+
+// ImageInfo chứa thông tin của một ảnh đã được xử lý và upload
+type ImageInfo struct {
+	URL      string
+	Width    int
+	Height   int
+	BlurHash string
+	Variants MediaVariants
+}
@@ -31,6 +31,27 @@ type User struct {
 	LastLoginAt       *time.Time `json:"last_login_at" gorm:"default:null"`
 	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt         time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Các variant kích thước (thumbnail/medium/large) của ảnh đại diện và ảnh bìa
+	ProfilePictureVariants MediaVariants `json:"profile_picture_variants" gorm:"type:json"`
+	CoverPictureVariants   MediaVariants `json:"cover_picture_variants" gorm:"type:json"`
+
+	// Kích thước và BlurHash của ảnh đại diện, ảnh bìa để client hiển thị placeholder
+	ProfilePictureWidth    int    `json:"profile_picture_width" gorm:"default:0"`
+	ProfilePictureHeight   int    `json:"profile_picture_height" gorm:"default:0"`
+	ProfilePictureBlurHash string `json:"profile_picture_blur_hash" gorm:"size:64"`
+	CoverPictureWidth      int    `json:"cover_picture_width" gorm:"default:0"`
+	CoverPictureHeight     int    `json:"cover_picture_height" gorm:"default:0"`
+	CoverPictureBlurHash   string `json:"cover_picture_blur_hash" gorm:"size:64"`
+
+	// Trang cá nhân bị ẩn bởi kiểm duyệt (tự động khi đủ số báo cáo hoặc do moderator)
+	IsHidden bool `json:"is_hidden" gorm:"default:false;index:idx_is_hidden"`
+
+	// Ai được phép theo dõi người dùng này
+	FollowPolicy FollowPolicy `json:"follow_policy" gorm:"type:enum('everyone','friends','nobody');default:'everyone'"`
+
+	// Ai được phép mời người dùng này vào nhóm
+	GroupInvitePolicy GroupInvitePolicy `json:"group_invite_policy" gorm:"type:enum('everyone','friends','nobody');default:'everyone'"`
 }
 
 // TableName chỉ định tên bảng trong cơ sở dữ liệu
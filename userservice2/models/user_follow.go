@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// FollowPolicy đại diện cho cài đặt ai được phép theo dõi một người dùng
+type FollowPolicy string
+
+const (
+	// Các cài đặt theo dõi
+	FollowPolicyEveryone FollowPolicy = "everyone"
+	FollowPolicyFriends  FollowPolicy = "friends" // Chỉ bạn bè (kết bạn luôn kéo theo theo dõi hai chiều)
+	FollowPolicyNobody   FollowPolicy = "nobody"
+)
+
+// UserFollow đại diện cho việc FollowerID theo dõi FolloweeID. Khác với Friendship, theo dõi
+// là một chiều và không cần được chấp nhận.
+type UserFollow struct {
+	ID         int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	FollowerID int64     `json:"follower_id" gorm:"not null;unique_index:idx_user_follow_pair"`
+	FolloweeID int64     `json:"followee_id" gorm:"not null;unique_index:idx_user_follow_pair;index:idx_user_follow_followee"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Follower   User      `json:"follower" gorm:"foreignKey:FollowerID"`
+	Followee   User      `json:"followee" gorm:"foreignKey:FolloweeID"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (UserFollow) TableName() string {
+	return "user_follows"
+}
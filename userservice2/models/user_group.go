@@ -1,6 +1,9 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"time"
 )
 
@@ -11,20 +14,109 @@ const (
 	// Các chế độ riêng tư của nhóm
 	GroupPrivacyPublic  GroupPrivacy = "public"
 	GroupPrivacyPrivate GroupPrivacy = "private"
+	// Nhóm bí mật không hiện với người ngoài nhóm và chỉ tham gia được qua lời mời
+	GroupPrivacySecret GroupPrivacy = "secret"
 )
 
+// GroupCategory là chủ đề chính của nhóm, dùng để lọc khi tìm kiếm nhóm
+type GroupCategory string
+
+const (
+	// Các chủ đề nhóm
+	GroupCategoryEducation     GroupCategory = "education"
+	GroupCategoryEntertainment GroupCategory = "entertainment"
+	GroupCategoryGaming        GroupCategory = "gaming"
+	GroupCategorySports        GroupCategory = "sports"
+	GroupCategoryTechnology    GroupCategory = "technology"
+	GroupCategoryTravel        GroupCategory = "travel"
+	GroupCategoryFood          GroupCategory = "food"
+	GroupCategoryHealth        GroupCategory = "health"
+	GroupCategoryBusiness      GroupCategory = "business"
+	GroupCategoryArts          GroupCategory = "arts"
+	GroupCategoryLocal         GroupCategory = "local"
+	GroupCategoryOther         GroupCategory = "other"
+)
+
+// MaxGroupTags là số thẻ tối đa của một nhóm
+const MaxGroupTags = 10
+
+// GroupTags là danh sách thẻ của nhóm (chữ thường, không trùng), lưu dưới dạng JSON
+type GroupTags []string
+
+// Value chuyển đổi GroupTags thành giá trị để lưu vào cơ sở dữ liệu
+func (t GroupTags) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan đọc dữ liệu từ cơ sở dữ liệu và chuyển đổi thành GroupTags
+func (t *GroupTags) Scan(value interface{}) error {
+	if value == nil {
+		*t = nil
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, t)
+}
+
+// GroupQuestions là danh sách câu hỏi cho người xin tham gia nhóm, lưu dưới dạng JSON
+type GroupQuestions []string
+
+// Value chuyển đổi GroupQuestions thành giá trị để lưu vào cơ sở dữ liệu
+func (q GroupQuestions) Value() (driver.Value, error) {
+	if q == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(q)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan đọc dữ liệu từ cơ sở dữ liệu và chuyển đổi thành GroupQuestions
+func (q *GroupQuestions) Scan(value interface{}) error {
+	if value == nil {
+		*q = nil
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, q)
+}
+
 // UserGroup đại diện cho nhóm người dùng
 type UserGroup struct {
 	ID          int64        `json:"id" gorm:"primaryKey;autoIncrement"`
 	Name        string       `json:"name" gorm:"size:100;not null"`
 	Description string       `json:"description" gorm:"type:text"`
-	Privacy     GroupPrivacy `json:"privacy" gorm:"type:enum('public','private');default:'public';index:idx_privacy"`
+	Privacy     GroupPrivacy `json:"privacy" gorm:"type:enum('public','private','secret');default:'public';index:idx_privacy"`
 	CoverImage  string       `json:"cover_image" gorm:"size:255"`
-	CreatedBy   int64        `json:"created_by" gorm:"not null;index:idx_created_by"`
-	MemberCount int          `json:"member_count" gorm:"default:0"`
-	CreatedAt   time.Time    `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
-	Creator     User         `json:"creator" gorm:"foreignKey:CreatedBy"`
+	// Chủ đề và thẻ giúp người dùng tìm thấy nhóm
+	Category GroupCategory `json:"category" gorm:"size:30;index:idx_category"`
+	Tags     GroupTags     `json:"tags" gorm:"type:json"`
+	// Câu hỏi người xin tham gia nhóm riêng tư phải trả lời
+	MembershipQuestions GroupQuestions `json:"membership_questions" gorm:"type:json"`
+	CreatedBy           int64          `json:"created_by" gorm:"not null;index:idx_created_by"`
+	// Chủ nhóm hiện tại, ban đầu là người tạo và chỉ đổi khi chuyển quyền sở hữu
+	OwnerID     int64 `json:"owner_id" gorm:"not null;default:0;index:idx_owner_id"`
+	MemberCount int   `json:"member_count" gorm:"default:0"`
+	// Lần gần nhất nhóm có hoạt động (thành viên mới, cập nhật thông tin), dùng để sắp xếp theo mức độ sôi nổi
+	LastActivityAt *time.Time `json:"last_activity_at" gorm:"index:idx_last_activity_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	Creator        User       `json:"creator" gorm:"foreignKey:CreatedBy"`
 }
 
 // TableName chỉ định tên bảng trong cơ sở dữ liệu
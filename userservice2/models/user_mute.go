@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+// MuteTargetType đại diện cho loại đối tượng bị tắt tiếng
+type MuteTargetType string
+
+const (
+	// Các loại đối tượng có thể tắt tiếng
+	MuteTargetUser  MuteTargetType = "user"
+	MuteTargetGroup MuteTargetType = "group"
+)
+
+// MuteKind đại diện cho kiểu tắt tiếng
+type MuteKind string
+
+const (
+	MuteKindMute   MuteKind = "mute"   // Tắt tiếng vô thời hạn cho tới khi bỏ tắt tiếng
+	MuteKindSnooze MuteKind = "snooze" // Tạm ẩn trong SnoozeDuration rồi tự hết hạn
+)
+
+// SnoozeDuration là thời gian tạm ẩn một người dùng/nhóm
+const SnoozeDuration = 30 * 24 * time.Hour
+
+// UserMute ẩn bài đăng của một người dùng hoặc nhóm khỏi feed của UserID mà không cần hủy kết bạn
+// hay chặn. Mỗi người chỉ có một bản ghi cho mỗi đối tượng, ExpiresAt nil là không hết hạn.
+type UserMute struct {
+	ID         int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID     int64          `json:"user_id" gorm:"not null;unique_index:idx_user_mute_target"`
+	TargetType MuteTargetType `json:"target_type" gorm:"type:enum('user','group');not null;unique_index:idx_user_mute_target"`
+	TargetID   int64          `json:"target_id" gorm:"not null;unique_index:idx_user_mute_target"`
+	Kind       MuteKind       `json:"kind" gorm:"type:enum('mute','snooze');default:'mute'"`
+	ExpiresAt  *time.Time     `json:"expires_at" gorm:"default:null;index:idx_user_mute_expires"`
+	CreatedAt  time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (UserMute) TableName() string {
+	return "user_mutes"
+}
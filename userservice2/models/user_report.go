@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+)
+
+// ReportReason đại diện cho lý do báo cáo người dùng
+type ReportReason string
+
+const (
+	// Các lý do báo cáo
+	ReportReasonSpam          ReportReason = "spam"
+	ReportReasonHarassment    ReportReason = "harassment"
+	ReportReasonHateSpeech    ReportReason = "hate_speech"
+	ReportReasonImpersonation ReportReason = "impersonation"
+	ReportReasonFakeAccount   ReportReason = "fake_account"
+	ReportReasonNudity        ReportReason = "nudity"
+	ReportReasonViolence      ReportReason = "violence"
+	ReportReasonOther         ReportReason = "other"
+)
+
+// ReportStatus đại diện cho trạng thái của báo cáo trong hàng đợi kiểm duyệt
+type ReportStatus string
+
+const (
+	// Các trạng thái báo cáo
+	ReportStatusOpen      ReportStatus = "open"
+	ReportStatusActioned  ReportStatus = "actioned"
+	ReportStatusDismissed ReportStatus = "dismissed"
+)
+
+// UserReport đại diện cho báo cáo một người dùng, mỗi người chỉ báo cáo một người một lần
+type UserReport struct {
+	ID             int64        `json:"id" gorm:"primaryKey;autoIncrement"`
+	ReportedUserID int64        `json:"reported_user_id" gorm:"not null;unique_index:idx_user_report_reporter"`
+	ReporterID     int64        `json:"reporter_id" gorm:"not null;unique_index:idx_user_report_reporter"`
+	Reason         ReportReason `json:"reason" gorm:"size:20;not null"`
+	Details        string       `json:"details" gorm:"type:text"`
+	Status         ReportStatus `json:"status" gorm:"type:enum('open','actioned','dismissed');default:'open';index:idx_user_report_status"`
+	ResolvedBy     *int64       `json:"resolved_by" gorm:"default:null"`
+	ResolvedAt     *time.Time   `json:"resolved_at" gorm:"default:null"`
+	CreatedAt      time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (UserReport) TableName() string {
+	return "user_reports"
+}
+
+// ModerationActionType đại diện cho hành động kiểm duyệt người dùng
+type ModerationActionType string
+
+const (
+	// Các hành động kiểm duyệt
+	ModerationActionAutoHide ModerationActionType = "auto_hide" // Hệ thống tự ẩn khi đủ số báo cáo
+	ModerationActionHide     ModerationActionType = "hide"
+	ModerationActionDismiss  ModerationActionType = "dismiss" // Bỏ qua báo cáo và hiện lại trang cá nhân
+)
+
+// UserModerationAction lưu vết các quyết định kiểm duyệt đối với người dùng
+type UserModerationAction struct {
+	ID          int64                `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID      int64                `json:"user_id" gorm:"not null;index:idx_moderation_user"`
+	ModeratorID int64                `json:"moderator_id" gorm:"not null;index:idx_moderation_moderator"` // 0 là hệ thống
+	Action      ModerationActionType `json:"action" gorm:"size:20;not null"`
+	Note        string               `json:"note" gorm:"type:text"`
+	ReportCount int                  `json:"report_count"`
+	CreatedAt   time.Time            `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName chỉ định tên bảng trong cơ sở dữ liệu
+func (UserModerationAction) TableName() string {
+	return "user_moderation_actions"
+}
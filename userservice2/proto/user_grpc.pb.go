@@ -19,8 +19,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_GetUsersByIDs_FullMethodName       = "/user.UserService/GetUsersByIDs"
-	UserService_GetUserIDByUsername_FullMethodName = "/user.UserService/GetUserIDByUsername"
+	UserService_GetUsersByIDs_FullMethodName        = "/user.UserService/GetUsersByIDs"
+	UserService_GetUserIDByUsername_FullMethodName  = "/user.UserService/GetUserIDByUsername"
+	UserService_ListMediaReferences_FullMethodName  = "/user.UserService/ListMediaReferences"
+	UserService_GetBlockRelations_FullMethodName    = "/user.UserService/GetBlockRelations"
+	UserService_GetBlockedUserIDs_FullMethodName    = "/user.UserService/GetBlockedUserIDs"
+	UserService_GetMutedTargets_FullMethodName      = "/user.UserService/GetMutedTargets"
+	UserService_GetFeedSources_FullMethodName       = "/user.UserService/GetFeedSources"
+	UserService_ValidateFriendLists_FullMethodName  = "/user.UserService/ValidateFriendLists"
+	UserService_CheckAudiences_FullMethodName       = "/user.UserService/CheckAudiences"
+	UserService_GetViewerAudience_FullMethodName    = "/user.UserService/GetViewerAudience"
+	UserService_CheckGroupPermission_FullMethodName = "/user.UserService/CheckGroupPermission"
+	UserService_CheckGroupPosting_FullMethodName    = "/user.UserService/CheckGroupPosting"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -29,6 +39,26 @@ const (
 type UserServiceClient interface {
 	GetUsersByIDs(ctx context.Context, in *GetUsersByIDsRequest, opts ...grpc.CallOption) (*GetUsersByIDsResponse, error)
 	GetUserIDByUsername(ctx context.Context, in *GetUserIDByUsernameRequest, opts ...grpc.CallOption) (*GetUserIDByUsernameResponse, error)
+	// Liệt kê URL ảnh đang được tham chiếu (avatar, ảnh bìa user, ảnh bìa nhóm) để dọn media mồ côi
+	ListMediaReferences(ctx context.Context, in *ListMediaReferencesRequest, opts ...grpc.CallOption) (*ListMediaReferencesResponse, error)
+	// Trả về quan hệ chặn (theo cả hai chiều) giữa các cặp người dùng để ẩn nội dung và chặn tương tác
+	GetBlockRelations(ctx context.Context, in *GetBlockRelationsRequest, opts ...grpc.CallOption) (*GetBlockRelationsResponse, error)
+	// Trả về mọi người dùng có quan hệ chặn (theo cả hai chiều) với một người để lọc danh sách trong truy vấn
+	GetBlockedUserIDs(ctx context.Context, in *GetBlockedUserIDsRequest, opts ...grpc.CallOption) (*GetBlockedUserIDsResponse, error)
+	// Trả về người dùng và nhóm mà một người đang tắt tiếng/tạm ẩn để loại khỏi feed
+	GetMutedTargets(ctx context.Context, in *GetMutedTargetsRequest, opts ...grpc.CallOption) (*GetMutedTargetsResponse, error)
+	// Trả về những người đang được theo dõi và bạn bè của một người dùng để dựng home feed
+	GetFeedSources(ctx context.Context, in *GetFeedSourcesRequest, opts ...grpc.CallOption) (*GetFeedSourcesResponse, error)
+	// Trả về các danh sách bạn bè không thuộc về người đăng, dùng khi tạo/sửa bài đăng CUSTOM
+	ValidateFriendLists(ctx context.Context, in *ValidateFriendListsRequest, opts ...grpc.CallOption) (*ValidateFriendListsResponse, error)
+	// Kiểm tra người xem có thuộc người xem (audience) của các bài đăng CUSTOM
+	CheckAudiences(ctx context.Context, in *CheckAudiencesRequest, opts ...grpc.CallOption) (*CheckAudiencesResponse, error)
+	// Trả về bạn bè, các danh sách bạn bè và các nhóm chứa người xem để lọc bài đăng CUSTOM và bài đăng trong nhóm
+	GetViewerAudience(ctx context.Context, in *GetViewerAudienceRequest, opts ...grpc.CallOption) (*GetViewerAudienceResponse, error)
+	// Kiểm tra người dùng có một quyền trong nhóm (admin hoặc qua vai trò tùy chỉnh)
+	CheckGroupPermission(ctx context.Context, in *CheckGroupPermissionRequest, opts ...grpc.CallOption) (*CheckGroupPermissionResponse, error)
+	// Kiểm tra người dùng có là thành viên đã duyệt và có đang bị tắt tiếng trong nhóm trước khi đăng bài
+	CheckGroupPosting(ctx context.Context, in *CheckGroupPostingRequest, opts ...grpc.CallOption) (*CheckGroupPostingResponse, error)
 }
 
 type userServiceClient struct {
@@ -59,12 +89,132 @@ func (c *userServiceClient) GetUserIDByUsername(ctx context.Context, in *GetUser
 	return out, nil
 }
 
+func (c *userServiceClient) ListMediaReferences(ctx context.Context, in *ListMediaReferencesRequest, opts ...grpc.CallOption) (*ListMediaReferencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMediaReferencesResponse)
+	err := c.cc.Invoke(ctx, UserService_ListMediaReferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetBlockRelations(ctx context.Context, in *GetBlockRelationsRequest, opts ...grpc.CallOption) (*GetBlockRelationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBlockRelationsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetBlockRelations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetBlockedUserIDs(ctx context.Context, in *GetBlockedUserIDsRequest, opts ...grpc.CallOption) (*GetBlockedUserIDsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBlockedUserIDsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetBlockedUserIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetMutedTargets(ctx context.Context, in *GetMutedTargetsRequest, opts ...grpc.CallOption) (*GetMutedTargetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMutedTargetsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetMutedTargets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetFeedSources(ctx context.Context, in *GetFeedSourcesRequest, opts ...grpc.CallOption) (*GetFeedSourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFeedSourcesResponse)
+	err := c.cc.Invoke(ctx, UserService_GetFeedSources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ValidateFriendLists(ctx context.Context, in *ValidateFriendListsRequest, opts ...grpc.CallOption) (*ValidateFriendListsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateFriendListsResponse)
+	err := c.cc.Invoke(ctx, UserService_ValidateFriendLists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CheckAudiences(ctx context.Context, in *CheckAudiencesRequest, opts ...grpc.CallOption) (*CheckAudiencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckAudiencesResponse)
+	err := c.cc.Invoke(ctx, UserService_CheckAudiences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetViewerAudience(ctx context.Context, in *GetViewerAudienceRequest, opts ...grpc.CallOption) (*GetViewerAudienceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetViewerAudienceResponse)
+	err := c.cc.Invoke(ctx, UserService_GetViewerAudience_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CheckGroupPermission(ctx context.Context, in *CheckGroupPermissionRequest, opts ...grpc.CallOption) (*CheckGroupPermissionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckGroupPermissionResponse)
+	err := c.cc.Invoke(ctx, UserService_CheckGroupPermission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CheckGroupPosting(ctx context.Context, in *CheckGroupPostingRequest, opts ...grpc.CallOption) (*CheckGroupPostingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckGroupPostingResponse)
+	err := c.cc.Invoke(ctx, UserService_CheckGroupPosting_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
 type UserServiceServer interface {
 	GetUsersByIDs(context.Context, *GetUsersByIDsRequest) (*GetUsersByIDsResponse, error)
 	GetUserIDByUsername(context.Context, *GetUserIDByUsernameRequest) (*GetUserIDByUsernameResponse, error)
+	// Liệt kê URL ảnh đang được tham chiếu (avatar, ảnh bìa user, ảnh bìa nhóm) để dọn media mồ côi
+	ListMediaReferences(context.Context, *ListMediaReferencesRequest) (*ListMediaReferencesResponse, error)
+	// Trả về quan hệ chặn (theo cả hai chiều) giữa các cặp người dùng để ẩn nội dung và chặn tương tác
+	GetBlockRelations(context.Context, *GetBlockRelationsRequest) (*GetBlockRelationsResponse, error)
+	// Trả về mọi người dùng có quan hệ chặn (theo cả hai chiều) với một người để lọc danh sách trong truy vấn
+	GetBlockedUserIDs(context.Context, *GetBlockedUserIDsRequest) (*GetBlockedUserIDsResponse, error)
+	// Trả về người dùng và nhóm mà một người đang tắt tiếng/tạm ẩn để loại khỏi feed
+	GetMutedTargets(context.Context, *GetMutedTargetsRequest) (*GetMutedTargetsResponse, error)
+	// Trả về những người đang được theo dõi và bạn bè của một người dùng để dựng home feed
+	GetFeedSources(context.Context, *GetFeedSourcesRequest) (*GetFeedSourcesResponse, error)
+	// Trả về các danh sách bạn bè không thuộc về người đăng, dùng khi tạo/sửa bài đăng CUSTOM
+	ValidateFriendLists(context.Context, *ValidateFriendListsRequest) (*ValidateFriendListsResponse, error)
+	// Kiểm tra người xem có thuộc người xem (audience) của các bài đăng CUSTOM
+	CheckAudiences(context.Context, *CheckAudiencesRequest) (*CheckAudiencesResponse, error)
+	// Trả về bạn bè, các danh sách bạn bè và các nhóm chứa người xem để lọc bài đăng CUSTOM và bài đăng trong nhóm
+	GetViewerAudience(context.Context, *GetViewerAudienceRequest) (*GetViewerAudienceResponse, error)
+	// Kiểm tra người dùng có một quyền trong nhóm (admin hoặc qua vai trò tùy chỉnh)
+	CheckGroupPermission(context.Context, *CheckGroupPermissionRequest) (*CheckGroupPermissionResponse, error)
+	// Kiểm tra người dùng có là thành viên đã duyệt và có đang bị tắt tiếng trong nhóm trước khi đăng bài
+	CheckGroupPosting(context.Context, *CheckGroupPostingRequest) (*CheckGroupPostingResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -81,6 +231,36 @@ func (UnimplementedUserServiceServer) GetUsersByIDs(context.Context, *GetUsersBy
 func (UnimplementedUserServiceServer) GetUserIDByUsername(context.Context, *GetUserIDByUsernameRequest) (*GetUserIDByUsernameResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserIDByUsername not implemented")
 }
+func (UnimplementedUserServiceServer) ListMediaReferences(context.Context, *ListMediaReferencesRequest) (*ListMediaReferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMediaReferences not implemented")
+}
+func (UnimplementedUserServiceServer) GetBlockRelations(context.Context, *GetBlockRelationsRequest) (*GetBlockRelationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockRelations not implemented")
+}
+func (UnimplementedUserServiceServer) GetBlockedUserIDs(context.Context, *GetBlockedUserIDsRequest) (*GetBlockedUserIDsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockedUserIDs not implemented")
+}
+func (UnimplementedUserServiceServer) GetMutedTargets(context.Context, *GetMutedTargetsRequest) (*GetMutedTargetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMutedTargets not implemented")
+}
+func (UnimplementedUserServiceServer) GetFeedSources(context.Context, *GetFeedSourcesRequest) (*GetFeedSourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeedSources not implemented")
+}
+func (UnimplementedUserServiceServer) ValidateFriendLists(context.Context, *ValidateFriendListsRequest) (*ValidateFriendListsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateFriendLists not implemented")
+}
+func (UnimplementedUserServiceServer) CheckAudiences(context.Context, *CheckAudiencesRequest) (*CheckAudiencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckAudiences not implemented")
+}
+func (UnimplementedUserServiceServer) GetViewerAudience(context.Context, *GetViewerAudienceRequest) (*GetViewerAudienceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetViewerAudience not implemented")
+}
+func (UnimplementedUserServiceServer) CheckGroupPermission(context.Context, *CheckGroupPermissionRequest) (*CheckGroupPermissionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckGroupPermission not implemented")
+}
+func (UnimplementedUserServiceServer) CheckGroupPosting(context.Context, *CheckGroupPostingRequest) (*CheckGroupPostingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckGroupPosting not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -138,6 +318,186 @@ func _UserService_GetUserIDByUsername_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ListMediaReferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMediaReferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListMediaReferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListMediaReferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListMediaReferences(ctx, req.(*ListMediaReferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetBlockRelations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRelationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetBlockRelations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetBlockRelations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetBlockRelations(ctx, req.(*GetBlockRelationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetBlockedUserIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockedUserIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetBlockedUserIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetBlockedUserIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetBlockedUserIDs(ctx, req.(*GetBlockedUserIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetMutedTargets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMutedTargetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetMutedTargets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetMutedTargets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetMutedTargets(ctx, req.(*GetMutedTargetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetFeedSources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeedSourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetFeedSources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetFeedSources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetFeedSources(ctx, req.(*GetFeedSourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ValidateFriendLists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateFriendListsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ValidateFriendLists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ValidateFriendLists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ValidateFriendLists(ctx, req.(*ValidateFriendListsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CheckAudiences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckAudiencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CheckAudiences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CheckAudiences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CheckAudiences(ctx, req.(*CheckAudiencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetViewerAudience_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetViewerAudienceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetViewerAudience(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetViewerAudience_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetViewerAudience(ctx, req.(*GetViewerAudienceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CheckGroupPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckGroupPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CheckGroupPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CheckGroupPermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CheckGroupPermission(ctx, req.(*CheckGroupPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CheckGroupPosting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckGroupPostingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CheckGroupPosting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CheckGroupPosting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CheckGroupPosting(ctx, req.(*CheckGroupPostingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +513,46 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserIDByUsername",
 			Handler:    _UserService_GetUserIDByUsername_Handler,
 		},
+		{
+			MethodName: "ListMediaReferences",
+			Handler:    _UserService_ListMediaReferences_Handler,
+		},
+		{
+			MethodName: "GetBlockRelations",
+			Handler:    _UserService_GetBlockRelations_Handler,
+		},
+		{
+			MethodName: "GetBlockedUserIDs",
+			Handler:    _UserService_GetBlockedUserIDs_Handler,
+		},
+		{
+			MethodName: "GetMutedTargets",
+			Handler:    _UserService_GetMutedTargets_Handler,
+		},
+		{
+			MethodName: "GetFeedSources",
+			Handler:    _UserService_GetFeedSources_Handler,
+		},
+		{
+			MethodName: "ValidateFriendLists",
+			Handler:    _UserService_ValidateFriendLists_Handler,
+		},
+		{
+			MethodName: "CheckAudiences",
+			Handler:    _UserService_CheckAudiences_Handler,
+		},
+		{
+			MethodName: "GetViewerAudience",
+			Handler:    _UserService_GetViewerAudience_Handler,
+		},
+		{
+			MethodName: "CheckGroupPermission",
+			Handler:    _UserService_CheckGroupPermission_Handler,
+		},
+		{
+			MethodName: "CheckGroupPosting",
+			Handler:    _UserService_CheckGroupPosting_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/user.proto",
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"userservice2/models"
+)
+
+// AdminRepository đại diện cho tầng truy cập dữ liệu của các thao tác quản trị.
+// Mỗi thao tác được ghi nhật ký trong cùng transaction với thay đổi dữ liệu.
+type AdminRepository interface {
+	SetUserActive(ctx context.Context, userID int64, active bool, entry *models.AdminAuditLog) error
+	SetUserVerified(ctx context.Context, userID int64, verified bool, entry *models.AdminAuditLog) error
+	DeleteGroup(ctx context.Context, groupID int64, entry *models.AdminAuditLog) error
+	TransferGroup(ctx context.Context, groupID, newOwnerID int64, entry *models.AdminAuditLog) error
+	ListAuditLogs(ctx context.Context, adminID int64, targetType models.AdminTargetType, targetID int64, page, pageSize int) ([]models.AdminAuditLog, int64, error)
+}
+
+// adminRepository triển khai AdminRepository
+type adminRepository struct {
+	db *gorm.DB
+}
+
+// NewAdminRepository tạo instance mới của AdminRepository
+func NewAdminRepository(db *gorm.DB) AdminRepository {
+	return &adminRepository{db: db}
+}
+
+// SetUserActive kích hoạt hoặc vô hiệu hóa tài khoản
+func (r *adminRepository) SetUserActive(ctx context.Context, userID int64, active bool, entry *models.AdminAuditLog) error {
+	return r.withAuditLog(entry, func(tx *gorm.DB) error {
+		return tx.Model(&models.User{}).Where("id = ?", userID).Update("is_active", active).Error
+	})
+}
+
+// SetUserVerified gắn hoặc gỡ huy hiệu xác minh
+func (r *adminRepository) SetUserVerified(ctx context.Context, userID int64, verified bool, entry *models.AdminAuditLog) error {
+	return r.withAuditLog(entry, func(tx *gorm.DB) error {
+		return tx.Model(&models.User{}).Where("id = ?", userID).Update("is_verified", verified).Error
+	})
+}
+
+// DeleteGroup xóa nhóm cùng mọi dữ liệu thuộc nhóm, xem deleteGroupCascade
+func (r *adminRepository) DeleteGroup(ctx context.Context, groupID int64, entry *models.AdminAuditLog) error {
+	return r.withAuditLog(entry, func(tx *gorm.DB) error {
+		return deleteGroupCascade(tx, groupID)
+	})
+}
+
+// TransferGroup chuyển quyền sở hữu nhóm cho newOwnerID mà không cần xác nhận. Chủ mới trở thành admin
+// của nhóm (được thêm làm thành viên nếu chưa có), chủ cũ vẫn giữ vai trò hiện tại và các yêu cầu chuyển
+// quyền sở hữu đang chờ bị hủy.
+func (r *adminRepository) TransferGroup(ctx context.Context, groupID, newOwnerID int64, entry *models.AdminAuditLog) error {
+	return r.withAuditLog(entry, func(tx *gorm.DB) error {
+		if err := tx.Model(&models.UserGroup{}).Where("id = ?", groupID).
+			Update("owner_id", newOwnerID).Error; err != nil {
+			return err
+		}
+		if err := cancelPendingTransfers(tx, groupID, time.Now()); err != nil {
+			return err
+		}
+
+		var member models.GroupMember
+		err := tx.Where("group_id = ? AND user_id = ?", groupID, newOwnerID).First(&member).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if err := tx.Create(&models.GroupMember{
+				GroupID: groupID,
+				UserID:  newOwnerID,
+				Role:    models.MemberRoleAdmin,
+				Status:  models.GroupMemberStatusApproved,
+			}).Error; err != nil {
+				return err
+			}
+			return tx.Model(&models.UserGroup{}).Where("id = ?", groupID).
+				UpdateColumn("member_count", gorm.Expr("member_count + 1")).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		if member.Status != models.GroupMemberStatusApproved {
+			if err := tx.Model(&models.UserGroup{}).Where("id = ?", groupID).
+				UpdateColumn("member_count", gorm.Expr("member_count + 1")).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&member).Updates(map[string]interface{}{
+			"role":    models.MemberRoleAdmin,
+			"status":  models.GroupMemberStatusApproved,
+			"left_at": nil,
+		}).Error
+	})
+}
+
+// ListAuditLogs lấy nhật ký quản trị, lọc theo admin hoặc đối tượng nếu có, mới nhất trước
+func (r *adminRepository) ListAuditLogs(ctx context.Context, adminID int64, targetType models.AdminTargetType, targetID int64, page, pageSize int) ([]models.AdminAuditLog, int64, error) {
+	var logs []models.AdminAuditLog
+	var total int64
+
+	query := r.db.Model(&models.AdminAuditLog{})
+	if adminID != 0 {
+		query = query.Where("admin_id = ?", adminID)
+	}
+	if targetType != "" {
+		query = query.Where("target_type = ?", targetType)
+	}
+	if targetID != 0 {
+		query = query.Where("target_id = ?", targetID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
+
+// withAuditLog chạy thay đổi và ghi nhật ký quản trị trong một transaction
+func (r *adminRepository) withAuditLog(entry *models.AdminAuditLog, fn func(tx *gorm.DB) error) error {
+	tx := r.db.Begin()
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Create(entry).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
@@ -0,0 +1,322 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"userservice2/models"
+	"userservice2/utils"
+)
+
+// Các khoảng thời gian khi liệt kê sự kiện
+const (
+	EventPeriodUpcoming = "upcoming" // Chưa kết thúc, sắp diễn ra trước
+	EventPeriodPast     = "past"     // Đã kết thúc, mới nhất trước
+)
+
+// EventRepository đại diện cho tầng truy cập dữ liệu sự kiện và phản hồi sự kiện
+type EventRepository interface {
+	Create(ctx context.Context, event *models.Event) error
+	FindByID(ctx context.Context, id int64) (*models.Event, error)
+	Update(ctx context.Context, event *models.Event) error
+	Delete(ctx context.Context, id int64) error
+	ListByGroup(ctx context.Context, groupID int64, period string, page, pageSize int) ([]models.Event, int64, error)
+	ListForUser(ctx context.Context, userID int64, period string, page, pageSize int) ([]models.Event, int64, error)
+	ListGroupCalendar(ctx context.Context, groupID int64, since time.Time, limit int) ([]models.Event, error)
+	FindRSVP(ctx context.Context, eventID, userID int64) (*models.EventRSVP, error)
+	FindRSVPStatuses(ctx context.Context, eventIDs []int64, userID int64) (map[int64]models.EventRSVPStatus, error)
+	SetRSVP(ctx context.Context, eventID, userID int64, status models.EventRSVPStatus) error
+	DeleteRSVP(ctx context.Context, eventID, userID int64) (bool, error)
+	ListRSVPs(ctx context.Context, eventID int64, status models.EventRSVPStatus, page, pageSize int) ([]models.EventRSVP, int64, error)
+}
+
+// eventRepository triển khai EventRepository
+type eventRepository struct {
+	db *gorm.DB
+}
+
+// NewEventRepository tạo instance mới của EventRepository
+func NewEventRepository(db *gorm.DB) EventRepository {
+	return &eventRepository{db: db}
+}
+
+// rsvpCounterColumn trả về cột đếm tương ứng với trạng thái phản hồi, rỗng nếu trạng thái không được đếm
+func rsvpCounterColumn(status models.EventRSVPStatus) string {
+	switch status {
+	case models.EventRSVPGoing:
+		return "going_count"
+	case models.EventRSVPInterested:
+		return "interested_count"
+	}
+	return ""
+}
+
+// adjustRSVPCounter cộng delta vào cột đếm của status trong transaction tx
+func adjustRSVPCounter(tx *gorm.DB, eventID int64, status models.EventRSVPStatus, delta int) error {
+	column := rsvpCounterColumn(status)
+	if column == "" {
+		return nil
+	}
+	query := tx.Model(&models.Event{}).Where("id = ?", eventID)
+	if delta < 0 {
+		query = query.Where(column + " > 0")
+	}
+	return query.UpdateColumn(column, gorm.Expr(column+" + ?", delta)).Error
+}
+
+// periodScope lọc sự kiện theo khoảng thời gian và sắp xếp phù hợp
+func periodScope(db *gorm.DB, period string, now time.Time) *gorm.DB {
+	if period == EventPeriodPast {
+		return db.Where("events.ends_at < ?", now)
+	}
+	return db.Where("events.ends_at >= ?", now)
+}
+
+// periodOrder là thứ tự sắp xếp tương ứng với period, chỉ dùng khi lấy danh sách (không dùng khi đếm)
+func periodOrder(period string) string {
+	if period == EventPeriodPast {
+		return "events.starts_at DESC, events.id DESC"
+	}
+	return "events.starts_at ASC, events.id ASC"
+}
+
+// Create tạo sự kiện, người tạo được ghi nhận là sẽ tham gia và nhóm (nếu có) được đánh dấu có hoạt động mới
+func (r *eventRepository) Create(ctx context.Context, event *models.Event) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	event.GoingCount = 1
+	if err := tx.Create(event).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rsvp := &models.EventRSVP{EventID: event.ID, UserID: event.CreatedBy, Status: models.EventRSVPGoing}
+	if err := tx.Create(rsvp).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if event.GroupID != nil {
+		if err := tx.Model(&models.UserGroup{}).Where("id = ?", *event.GroupID).
+			UpdateColumn("last_activity_at", time.Now()).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// FindByID tìm sự kiện theo ID kèm người tạo và nhóm
+func (r *eventRepository) FindByID(ctx context.Context, id int64) (*models.Event, error) {
+	var event models.Event
+	err := r.db.Preload("Creator").Preload("Group").First(&event, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &event, nil
+}
+
+// Update lưu các trường có thể chỉnh sửa của sự kiện, không động tới số người tham gia
+func (r *eventRepository) Update(ctx context.Context, event *models.Event) error {
+	return r.db.Model(event).Updates(map[string]interface{}{
+		"title":       event.Title,
+		"description": event.Description,
+		"starts_at":   event.StartsAt,
+		"ends_at":     event.EndsAt,
+		"timezone":    event.Timezone,
+		"location":    event.Location,
+		"cover_image": event.CoverImage,
+	}).Error
+}
+
+// Delete xóa sự kiện cùng các phản hồi của nó
+func (r *eventRepository) Delete(ctx context.Context, id int64) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := tx.Where("event_id = ?", id).Delete(&models.EventRSVP{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("id = ?", id).Delete(&models.Event{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// ListByGroup lấy sự kiện của nhóm theo khoảng thời gian có phân trang
+func (r *eventRepository) ListByGroup(ctx context.Context, groupID int64, period string, page, pageSize int) ([]models.Event, int64, error) {
+	query := periodScope(r.db.Model(&models.Event{}), period, time.Now()).Where("events.group_id = ?", groupID)
+	return r.list(query, period, page, pageSize)
+}
+
+// ListForUser lấy sự kiện userID đã tạo hoặc đã phản hồi sẽ tham gia/quan tâm
+func (r *eventRepository) ListForUser(ctx context.Context, userID int64, period string, page, pageSize int) ([]models.Event, int64, error) {
+	attending := r.db.Model(&models.EventRSVP{}).Select("event_id").
+		Where("user_id = ? AND status IN (?)", userID, []models.EventRSVPStatus{models.EventRSVPGoing, models.EventRSVPInterested}).
+		SubQuery()
+	query := periodScope(r.db.Model(&models.Event{}), period, time.Now()).
+		Where("events.created_by = ? OR events.id IN ?", userID, attending)
+	return r.list(query, period, page, pageSize)
+}
+
+// list đếm và lấy một trang sự kiện từ query
+func (r *eventRepository) list(query *gorm.DB, period string, page, pageSize int) ([]models.Event, int64, error) {
+	var events []models.Event
+	var total int64
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset, limit := utils.Pagination(page, pageSize)
+	err := query.Preload("Creator").Preload("Group").
+		Order(periodOrder(period)).
+		Offset(offset).Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+// ListGroupCalendar lấy tối đa limit sự kiện của nhóm kết thúc từ since trở đi, dùng để xuất lịch
+func (r *eventRepository) ListGroupCalendar(ctx context.Context, groupID int64, since time.Time, limit int) ([]models.Event, error) {
+	var events []models.Event
+	err := r.db.Preload("Creator").
+		Where("group_id = ? AND ends_at >= ?", groupID, since).
+		Order("starts_at ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// FindRSVP tìm phản hồi của userID với sự kiện
+func (r *eventRepository) FindRSVP(ctx context.Context, eventID, userID int64) (*models.EventRSVP, error) {
+	var rsvp models.EventRSVP
+	err := r.db.Where("event_id = ? AND user_id = ?", eventID, userID).First(&rsvp).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rsvp, nil
+}
+
+// FindRSVPStatuses lấy phản hồi của userID với nhiều sự kiện, sự kiện chưa phản hồi không có trong kết quả
+func (r *eventRepository) FindRSVPStatuses(ctx context.Context, eventIDs []int64, userID int64) (map[int64]models.EventRSVPStatus, error) {
+	statuses := make(map[int64]models.EventRSVPStatus, len(eventIDs))
+	if len(eventIDs) == 0 || userID == 0 {
+		return statuses, nil
+	}
+
+	var rsvps []models.EventRSVP
+	if err := r.db.Where("event_id IN (?) AND user_id = ?", eventIDs, userID).Find(&rsvps).Error; err != nil {
+		return nil, err
+	}
+	for _, rsvp := range rsvps {
+		statuses[rsvp.EventID] = rsvp.Status
+	}
+	return statuses, nil
+}
+
+// SetRSVP tạo hoặc đổi phản hồi của userID và cập nhật số người tham gia/quan tâm trong cùng transaction
+func (r *eventRepository) SetRSVP(ctx context.Context, eventID, userID int64, status models.EventRSVPStatus) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	var rsvp models.EventRSVP
+	err := tx.Set("gorm:query_option", "FOR UPDATE").
+		Where("event_id = ? AND user_id = ?", eventID, userID).First(&rsvp).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		rsvp = models.EventRSVP{EventID: eventID, UserID: userID, Status: status}
+		err = tx.Create(&rsvp).Error
+	case err == nil && rsvp.Status == status:
+		tx.Rollback()
+		return nil
+	case err == nil:
+		if err = adjustRSVPCounter(tx, eventID, rsvp.Status, -1); err == nil {
+			err = tx.Model(&rsvp).Update("status", status).Error
+		}
+	}
+	if err == nil {
+		err = adjustRSVPCounter(tx, eventID, status, 1)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// DeleteRSVP xóa phản hồi của userID và cập nhật số người tham gia/quan tâm.
+// Trả về false nếu userID chưa phản hồi sự kiện.
+func (r *eventRepository) DeleteRSVP(ctx context.Context, eventID, userID int64) (bool, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	var rsvp models.EventRSVP
+	err := tx.Set("gorm:query_option", "FOR UPDATE").
+		Where("event_id = ? AND user_id = ?", eventID, userID).First(&rsvp).Error
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if err := tx.Delete(&rsvp).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if err := adjustRSVPCounter(tx, eventID, rsvp.Status, -1); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit().Error
+}
+
+// ListRSVPs lấy những người đã phản hồi sự kiện với trạng thái status, phản hồi sớm nhất trước
+func (r *eventRepository) ListRSVPs(ctx context.Context, eventID int64, status models.EventRSVPStatus, page, pageSize int) ([]models.EventRSVP, int64, error) {
+	var rsvps []models.EventRSVP
+	var total int64
+
+	query := r.db.Model(&models.EventRSVP{}).Where("event_id = ? AND status = ?", eventID, status)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset, limit := utils.Pagination(page, pageSize)
+	err := query.Preload("User").
+		Order("created_at ASC, id ASC").
+		Offset(offset).Limit(limit).
+		Find(&rsvps).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return rsvps, total, nil
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"userservice2/models"
+)
+
+// FollowRepository đại diện cho tầng truy cập dữ liệu theo dõi người dùng
+type FollowRepository interface {
+	Create(ctx context.Context, followerID, followeeID int64) error
+	CreateMutual(ctx context.Context, userID, otherUserID int64) error
+	Delete(ctx context.Context, followerID, followeeID int64) (bool, error)
+	DeleteMutual(ctx context.Context, userID, otherUserID int64) error
+	Exists(ctx context.Context, followerID, followeeID int64) (bool, error)
+	ListFollowers(ctx context.Context, userID int64, page, pageSize int) ([]models.UserFollow, int64, error)
+	ListFollowing(ctx context.Context, userID int64, page, pageSize int) ([]models.UserFollow, int64, error)
+	CountFollowers(ctx context.Context, userID int64) (int, error)
+	CountFollowing(ctx context.Context, userID int64) (int, error)
+	FindFollowingIDs(ctx context.Context, userID int64) ([]int64, error)
+}
+
+// followRepository triển khai FollowRepository
+type followRepository struct {
+	db *gorm.DB
+}
+
+// NewFollowRepository tạo instance mới của FollowRepository
+func NewFollowRepository(db *gorm.DB) FollowRepository {
+	return &followRepository{db: db}
+}
+
+// Create tạo quan hệ theo dõi, không làm gì nếu đã theo dõi
+func (r *followRepository) Create(ctx context.Context, followerID, followeeID int64) error {
+	return createFollow(r.db, followerID, followeeID)
+}
+
+func createFollow(db *gorm.DB, followerID, followeeID int64) error {
+	var follow models.UserFollow
+	return db.Where(models.UserFollow{FollowerID: followerID, FolloweeID: followeeID}).FirstOrCreate(&follow).Error
+}
+
+// CreateMutual tạo quan hệ theo dõi hai chiều, dùng khi hai người trở thành bạn bè
+func (r *followRepository) CreateMutual(ctx context.Context, userID, otherUserID int64) error {
+	tx := r.db.Begin()
+	if err := createFollow(tx, userID, otherUserID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := createFollow(tx, otherUserID, userID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// Delete bỏ theo dõi, trả về false nếu chưa theo dõi
+func (r *followRepository) Delete(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	result := r.db.Where("follower_id = ? AND followee_id = ?", followerID, followeeID).Delete(&models.UserFollow{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// DeleteMutual xóa quan hệ theo dõi theo cả hai chiều
+func (r *followRepository) DeleteMutual(ctx context.Context, userID, otherUserID int64) error {
+	return r.db.Where("(follower_id = ? AND followee_id = ?) OR (follower_id = ? AND followee_id = ?)",
+		userID, otherUserID, otherUserID, userID).Delete(&models.UserFollow{}).Error
+}
+
+// Exists kiểm tra followerID có đang theo dõi followeeID không
+func (r *followRepository) Exists(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.UserFollow{}).
+		Where("follower_id = ? AND followee_id = ?", followerID, followeeID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ListFollowers lấy danh sách người theo dõi userID, mới nhất xếp trước
+func (r *followRepository) ListFollowers(ctx context.Context, userID int64, page, pageSize int) ([]models.UserFollow, int64, error) {
+	return r.list("followee_id = ?", userID, "Follower", page, pageSize)
+}
+
+// ListFollowing lấy danh sách người mà userID đang theo dõi, mới nhất xếp trước
+func (r *followRepository) ListFollowing(ctx context.Context, userID int64, page, pageSize int) ([]models.UserFollow, int64, error) {
+	return r.list("follower_id = ?", userID, "Followee", page, pageSize)
+}
+
+// list lấy danh sách theo dõi theo điều kiện, chỉ gồm tài khoản đang hoạt động
+func (r *followRepository) list(condition string, userID int64, preload string, page, pageSize int) ([]models.UserFollow, int64, error) {
+	var follows []models.UserFollow
+	var total int64
+
+	otherColumn := "user_follows.follower_id"
+	if preload == "Followee" {
+		otherColumn = "user_follows.followee_id"
+	}
+	query := r.db.Model(&models.UserFollow{}).
+		Joins("JOIN users ON users.id = "+otherColumn).
+		Where("user_follows."+condition+" AND users.is_active = true", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Preload(preload).Order("user_follows.created_at DESC").
+		Offset(offset).Limit(pageSize).Find(&follows).Error; err != nil {
+		return nil, 0, err
+	}
+	return follows, total, nil
+}
+
+// CountFollowers đếm số người theo dõi userID
+func (r *followRepository) CountFollowers(ctx context.Context, userID int64) (int, error) {
+	var count int
+	err := r.db.Model(&models.UserFollow{}).Where("followee_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// CountFollowing đếm số người mà userID đang theo dõi
+func (r *followRepository) CountFollowing(ctx context.Context, userID int64) (int, error) {
+	var count int
+	err := r.db.Model(&models.UserFollow{}).Where("follower_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// FindFollowingIDs lấy ID của tất cả người mà userID đang theo dõi
+func (r *followRepository) FindFollowingIDs(ctx context.Context, userID int64) ([]int64, error) {
+	var ids []int64
+	err := r.db.Model(&models.UserFollow{}).Where("follower_id = ?", userID).Pluck("followee_id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jinzhu/gorm"
+	"userservice2/models"
+)
+
+// FriendListMembership là một danh sách bạn bè mà người xem thuộc về
+type FriendListMembership struct {
+	ListID  int64
+	OwnerID int64
+}
+
+// FriendListRepository đại diện cho tầng truy cập dữ liệu danh sách bạn bè
+type FriendListRepository interface {
+	Create(ctx context.Context, list *models.FriendList, memberIDs []int64) error
+	FindByID(ctx context.Context, id int64) (*models.FriendList, error)
+	ListByOwner(ctx context.Context, ownerID int64) ([]models.FriendList, error)
+	CountByOwner(ctx context.Context, ownerID int64) (int, error)
+	CountMembers(ctx context.Context, listIDs []int64) (map[int64]int, error)
+	Rename(ctx context.Context, id int64, name string) error
+	Delete(ctx context.Context, id int64) error
+	ListMembers(ctx context.Context, listID int64) ([]models.FriendListMember, error)
+	AddMembers(ctx context.Context, listID int64, userIDs []int64) error
+	RemoveMember(ctx context.Context, listID, userID int64) (bool, error)
+	FindOwnedIDs(ctx context.Context, ownerID int64, listIDs []int64) ([]int64, error)
+	FindMemberships(ctx context.Context, userID int64, ownerIDs []int64) ([]FriendListMembership, error)
+	FindListIDsByMember(ctx context.Context, userID int64) ([]int64, error)
+}
+
+// friendListRepository triển khai FriendListRepository
+type friendListRepository struct {
+	db *gorm.DB
+}
+
+// NewFriendListRepository tạo instance mới của FriendListRepository
+func NewFriendListRepository(db *gorm.DB) FriendListRepository {
+	return &friendListRepository{db: db}
+}
+
+// Create tạo danh sách bạn bè cùng các thành viên ban đầu
+func (r *friendListRepository) Create(ctx context.Context, list *models.FriendList, memberIDs []int64) error {
+	tx := r.db.Begin()
+	if err := tx.Create(list).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := addFriendListMembers(tx, list.ID, memberIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// FindByID tìm danh sách bạn bè theo ID
+func (r *friendListRepository) FindByID(ctx context.Context, id int64) (*models.FriendList, error) {
+	var list models.FriendList
+	if err := r.db.Where("id = ?", id).First(&list).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &list, nil
+}
+
+// ListByOwner lấy tất cả danh sách bạn bè của một người dùng
+func (r *friendListRepository) ListByOwner(ctx context.Context, ownerID int64) ([]models.FriendList, error) {
+	var lists []models.FriendList
+	err := r.db.Where("owner_id = ?", ownerID).Order("created_at ASC").Find(&lists).Error
+	return lists, err
+}
+
+// CountByOwner đếm số danh sách bạn bè của một người dùng
+func (r *friendListRepository) CountByOwner(ctx context.Context, ownerID int64) (int, error) {
+	var count int
+	err := r.db.Model(&models.FriendList{}).Where("owner_id = ?", ownerID).Count(&count).Error
+	return count, err
+}
+
+// CountMembers đếm số thành viên của từng danh sách
+func (r *friendListRepository) CountMembers(ctx context.Context, listIDs []int64) (map[int64]int, error) {
+	counts := make(map[int64]int, len(listIDs))
+	if len(listIDs) == 0 {
+		return counts, nil
+	}
+
+	var results []struct {
+		ListID int64
+		Count  int
+	}
+	if err := r.db.Model(&models.FriendListMember{}).
+		Select("list_id, COUNT(*) AS count").
+		Where("list_id IN (?)", listIDs).
+		Group("list_id").Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		counts[result.ListID] = result.Count
+	}
+	return counts, nil
+}
+
+// Rename đổi tên danh sách bạn bè
+func (r *friendListRepository) Rename(ctx context.Context, id int64, name string) error {
+	return r.db.Model(&models.FriendList{}).Where("id = ?", id).Update("name", name).Error
+}
+
+// Delete xóa danh sách bạn bè và các thành viên
+func (r *friendListRepository) Delete(ctx context.Context, id int64) error {
+	tx := r.db.Begin()
+	if err := tx.Where("list_id = ?", id).Delete(&models.FriendListMember{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("id = ?", id).Delete(&models.FriendList{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// ListMembers lấy thành viên của danh sách kèm thông tin người dùng
+func (r *friendListRepository) ListMembers(ctx context.Context, listID int64) ([]models.FriendListMember, error) {
+	var members []models.FriendListMember
+	err := r.db.Where("list_id = ?", listID).Preload("User").Order("created_at ASC").Find(&members).Error
+	return members, err
+}
+
+// AddMembers thêm thành viên vào danh sách, bỏ qua người đã có trong danh sách
+func (r *friendListRepository) AddMembers(ctx context.Context, listID int64, userIDs []int64) error {
+	return addFriendListMembers(r.db, listID, userIDs)
+}
+
+func addFriendListMembers(db *gorm.DB, listID int64, userIDs []int64) error {
+	for _, userID := range userIDs {
+		var member models.FriendListMember
+		if err := db.Where(models.FriendListMember{ListID: listID, UserID: userID}).FirstOrCreate(&member).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveMember xóa một thành viên khỏi danh sách, trả về false nếu người đó không có trong danh sách
+func (r *friendListRepository) RemoveMember(ctx context.Context, listID, userID int64) (bool, error) {
+	result := r.db.Where("list_id = ? AND user_id = ?", listID, userID).Delete(&models.FriendListMember{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindOwnedIDs lọc ra các ID danh sách thuộc về ownerID
+func (r *friendListRepository) FindOwnedIDs(ctx context.Context, ownerID int64, listIDs []int64) ([]int64, error) {
+	var ids []int64
+	if len(listIDs) == 0 {
+		return ids, nil
+	}
+	err := r.db.Model(&models.FriendList{}).Where("owner_id = ? AND id IN (?)", ownerID, listIDs).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// FindMemberships lấy các danh sách của ownerIDs mà userID là thành viên
+func (r *friendListRepository) FindMemberships(ctx context.Context, userID int64, ownerIDs []int64) ([]FriendListMembership, error) {
+	var memberships []FriendListMembership
+	if len(ownerIDs) == 0 {
+		return memberships, nil
+	}
+
+	err := r.db.Table("friend_list_members").
+		Select("friend_lists.id AS list_id, friend_lists.owner_id").
+		Joins("JOIN friend_lists ON friend_lists.id = friend_list_members.list_id").
+		Where("friend_list_members.user_id = ? AND friend_lists.owner_id IN (?)", userID, ownerIDs).
+		Scan(&memberships).Error
+	return memberships, err
+}
+
+// FindListIDsByMember lấy ID của mọi danh sách bạn bè (của bất kỳ chủ nào) có userID là thành viên
+func (r *friendListRepository) FindListIDsByMember(ctx context.Context, userID int64) ([]int64, error) {
+	var ids []int64
+	err := r.db.Table("friend_list_members").Where("user_id = ?", userID).Pluck("list_id", &ids).Error
+	return ids, err
+}
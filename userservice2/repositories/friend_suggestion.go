@@ -0,0 +1,230 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"userservice2/models"
+)
+
+// SuggestionSignal là số lượng của một tín hiệu gợi ý (bạn chung, nhóm chung) với một ứng viên
+type SuggestionSignal struct {
+	UserID int64
+	Count  int
+}
+
+// FriendSuggestionRepository đại diện cho tầng truy cập dữ liệu gợi ý kết bạn
+type FriendSuggestionRepository interface {
+	CountMutualFriends(ctx context.Context, userID int64, limit int) ([]SuggestionSignal, error)
+	CountSharedGroups(ctx context.Context, userID int64, limit int) ([]SuggestionSignal, error)
+	FindSimilarUserIDs(ctx context.Context, user *models.User, limit int) ([]int64, error)
+	FindCandidates(ctx context.Context, userIDs []int64) ([]models.User, error)
+	Replace(ctx context.Context, userID int64, suggestions []models.FriendSuggestion) error
+	List(ctx context.Context, userID int64, limit int) ([]models.FriendSuggestion, error)
+	LastComputedAt(ctx context.Context, userID int64) (*time.Time, error)
+	Dismiss(ctx context.Context, userID, suggestedUserID int64) error
+	FindActiveUserIDs(ctx context.Context, since time.Time, afterID int64, limit int) ([]int64, error)
+}
+
+// friendSuggestionRepository triển khai FriendSuggestionRepository
+type friendSuggestionRepository struct {
+	db *gorm.DB
+}
+
+// NewFriendSuggestionRepository tạo instance mới của FriendSuggestionRepository
+func NewFriendSuggestionRepository(db *gorm.DB) FriendSuggestionRepository {
+	return &friendSuggestionRepository{db: db}
+}
+
+// eligibleCandidate là điều kiện SQL cho ứng viên ở cột column: còn hoạt động, không bị ẩn, chưa có quan hệ
+// bạn bè ở bất kỳ trạng thái nào (bạn bè, đang chờ, chặn) với userID và chưa bị userID bỏ qua. Điều kiện
+// nằm trong truy vấn để LIMIT chỉ tính các ứng viên hợp lệ. Cần truyền userID ba lần.
+func eligibleCandidate(column string) string {
+	return `EXISTS (SELECT 1 FROM users cu WHERE cu.id = ` + column + ` AND cu.is_active = true AND cu.is_hidden = false)
+		AND NOT EXISTS (
+			SELECT 1 FROM friendships fx
+			WHERE (fx.user_id = ? AND fx.friend_id = ` + column + `) OR (fx.friend_id = ? AND fx.user_id = ` + column + `)
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM friend_suggestion_dismissals dx WHERE dx.user_id = ? AND dx.suggested_user_id = ` + column + `
+		)`
+}
+
+// CountMutualFriends đếm số bạn chung với từng người là bạn của bạn bè userID, nhiều bạn chung xếp trước
+func (r *friendSuggestionRepository) CountMutualFriends(ctx context.Context, userID int64, limit int) ([]SuggestionSignal, error) {
+	var signals []SuggestionSignal
+	err := r.db.Raw(`
+		SELECT f2.other AS user_id, COUNT(*) AS count FROM
+		(
+			SELECT friend_id AS friend FROM friendships WHERE user_id = ? AND status = ?
+			UNION
+			SELECT user_id FROM friendships WHERE friend_id = ? AND status = ?
+		) mine
+		INNER JOIN
+		(
+			SELECT user_id AS owner, friend_id AS other FROM friendships WHERE status = ?
+			UNION ALL
+			SELECT friend_id, user_id FROM friendships WHERE status = ?
+		) f2
+		ON f2.owner = mine.friend
+		WHERE f2.other != ? AND `+eligibleCandidate("f2.other")+`
+		GROUP BY f2.other
+		ORDER BY count DESC
+		LIMIT ?
+	`, userID, models.FriendshipStatusAccepted, userID, models.FriendshipStatusAccepted,
+		models.FriendshipStatusAccepted, models.FriendshipStatusAccepted, userID, userID, userID, userID, limit).Scan(&signals).Error
+	return signals, err
+}
+
+// CountSharedGroups đếm số nhóm mà từng thành viên khác cùng tham gia với userID
+func (r *friendSuggestionRepository) CountSharedGroups(ctx context.Context, userID int64, limit int) ([]SuggestionSignal, error) {
+	var signals []SuggestionSignal
+	err := r.db.Raw(`
+		SELECT gm2.user_id AS user_id, COUNT(*) AS count
+		FROM group_members gm1
+		INNER JOIN group_members gm2 ON gm2.group_id = gm1.group_id
+		WHERE gm1.user_id = ? AND gm1.status = ? AND gm1.left_at IS NULL
+		AND gm2.user_id != ? AND gm2.status = ? AND gm2.left_at IS NULL
+		AND `+eligibleCandidate("gm2.user_id")+`
+		GROUP BY gm2.user_id
+		ORDER BY count DESC
+		LIMIT ?
+	`, userID, models.GroupMemberStatusApproved, userID, models.GroupMemberStatusApproved,
+		userID, userID, userID, limit).Scan(&signals).Error
+	return signals, err
+}
+
+// FindSimilarUserIDs tìm người dùng cùng quận/huyện, tỉnh/thành, nơi làm việc hoặc trường học với user,
+// người hoạt động gần đây xếp trước
+func (r *friendSuggestionRepository) FindSimilarUserIDs(ctx context.Context, user *models.User, limit int) ([]int64, error) {
+	var conditions []string
+	var args []interface{}
+	if user.DistrictID != 0 {
+		conditions = append(conditions, "district_id = ?")
+		args = append(args, user.DistrictID)
+	}
+	if user.ProvinceID != 0 {
+		conditions = append(conditions, "province_id = ?")
+		args = append(args, user.ProvinceID)
+	}
+	if work := strings.TrimSpace(user.Work); work != "" {
+		conditions = append(conditions, "work = ?")
+		args = append(args, work)
+	}
+	if education := strings.TrimSpace(user.Education); education != "" {
+		conditions = append(conditions, "education = ?")
+		args = append(args, education)
+	}
+
+	var ids []int64
+	if len(conditions) == 0 {
+		return ids, nil
+	}
+	err := r.db.Model(&models.User{}).
+		Where("id != ? AND is_active = ? AND is_hidden = ?", user.ID, true, false).
+		Where(strings.Join(conditions, " OR "), args...).
+		Where(eligibleCandidate("users.id"), user.ID, user.ID, user.ID).
+		Order("last_login_at DESC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// FindCandidates lấy thông tin các ứng viên còn hoạt động và không bị ẩn
+func (r *friendSuggestionRepository) FindCandidates(ctx context.Context, userIDs []int64) ([]models.User, error) {
+	var users []models.User
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+	err := r.db.Where("id IN (?) AND is_active = ? AND is_hidden = ?", userIDs, true, false).Find(&users).Error
+	return users, err
+}
+
+// Replace thay toàn bộ gợi ý của userID bằng suggestions và ghi lại thời điểm tính
+func (r *friendSuggestionRepository) Replace(ctx context.Context, userID int64, suggestions []models.FriendSuggestion) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := tx.Where("user_id = ?", userID).Delete(&models.FriendSuggestion{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for i := range suggestions {
+		if err := tx.Create(&suggestions[i]).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	state := models.FriendSuggestionState{UserID: userID, ComputedAt: time.Now()}
+	if err := tx.Save(&state).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// List lấy các gợi ý điểm cao nhất của userID, bỏ qua những người đã có quan hệ bạn bè
+// kể từ lần tính gần nhất
+func (r *friendSuggestionRepository) List(ctx context.Context, userID int64, limit int) ([]models.FriendSuggestion, error) {
+	var suggestions []models.FriendSuggestion
+	err := r.db.Where("user_id = ?", userID).
+		Where(`suggested_user_id NOT IN (
+			SELECT friend_id FROM friendships WHERE user_id = ?
+			UNION
+			SELECT user_id FROM friendships WHERE friend_id = ?
+		)`, userID, userID).
+		Preload("SuggestedUser").
+		Order("score DESC").
+		Limit(limit).
+		Find(&suggestions).Error
+	return suggestions, err
+}
+
+// LastComputedAt trả về thời điểm gợi ý của userID được tính gần nhất, nil nếu chưa tính lần nào
+func (r *friendSuggestionRepository) LastComputedAt(ctx context.Context, userID int64) (*time.Time, error) {
+	var state models.FriendSuggestionState
+	if err := r.db.Where("user_id = ?", userID).First(&state).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &state.ComputedAt, nil
+}
+
+// Dismiss ghi nhận userID bỏ qua suggestedUserID và xóa gợi ý hiện có
+func (r *friendSuggestionRepository) Dismiss(ctx context.Context, userID, suggestedUserID int64) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	dismissal := models.FriendSuggestionDismissal{UserID: userID, SuggestedUserID: suggestedUserID}
+	if err := tx.Where(dismissal).FirstOrCreate(&dismissal).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("user_id = ? AND suggested_user_id = ?", userID, suggestedUserID).
+		Delete(&models.FriendSuggestion{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// FindActiveUserIDs lấy ID người dùng đăng nhập từ since trở lại đây, phân trang theo ID tăng dần
+func (r *friendSuggestionRepository) FindActiveUserIDs(ctx context.Context, since time.Time, afterID int64, limit int) ([]int64, error) {
+	var ids []int64
+	err := r.db.Model(&models.User{}).
+		Where("id > ? AND is_active = ? AND last_login_at >= ?", afterID, true, since).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
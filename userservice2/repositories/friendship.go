@@ -18,9 +18,13 @@ type FriendshipRepository interface {
 	Delete(ctx context.Context, id int64) error
 	GetFriends(ctx context.Context, userID int64, page, pageSize int) ([]models.Friendship, int64, error)
 	GetFriendRequests(ctx context.Context, userID int64, incoming bool, page, pageSize int) ([]models.Friendship, int64, error)
-	GetFriendSuggestions(ctx context.Context, userID int64, limit int) ([]models.User, error)
 	GetMutualFriendsCount(ctx context.Context, userID, otherUserID int64) (int, error)
+	GetMutualFriends(ctx context.Context, userID, otherUserID int64, page, pageSize int) ([]models.User, int64, error)
+	GetMutualFriendsCounts(ctx context.Context, userID int64, otherUserIDs []int64) (map[int64]int, error)
 	GetFriendCount(ctx context.Context, userID int64) (int, error)
+	FindBlocksAmong(ctx context.Context, userIDs []int64) ([]models.Friendship, error)
+	FindBlockedUserIDs(ctx context.Context, userID int64) ([]int64, error)
+	FindFriendIDs(ctx context.Context, userID int64) ([]int64, error)
 }
 
 // friendshipRepository triển khai FriendshipRepository
@@ -150,47 +154,6 @@ func (r *friendshipRepository) GetFriendRequests(ctx context.Context, userID int
 	return friendships, total, nil
 }
 
-// GetFriendSuggestions lấy gợi ý kết bạn
-func (r *friendshipRepository) GetFriendSuggestions(ctx context.Context, userID int64, limit int) ([]models.User, error) {
-	// Tìm danh sách ID của người dùng tiềm năng
-	var userIDs []int64
-	err := r.db.Raw(`
-		SELECT u.id FROM users u
-		WHERE u.id != ? AND u.is_active = true
-		AND u.id NOT IN (
-			SELECT user_id FROM friendships 
-			WHERE friend_id = ? AND (status = 'accepted' OR status = 'pending' OR status = 'blocked')
-			UNION
-			SELECT friend_id FROM friendships
-			WHERE user_id = ? AND (status = 'accepted' OR status = 'pending' OR status = 'blocked')
-		)
-		ORDER BY RAND()
-		LIMIT ?
-	`, userID, userID, userID, limit).Pluck("id", &userIDs).Error
-
-	if err != nil {
-		return nil, err
-	}
-
-	if len(userIDs) == 0 {
-		return []models.User{}, nil
-	}
-
-	// Lấy thông tin đầy đủ của người dùng bao gồm các preload cần thiết
-	var users []models.User
-	err = r.db.Where("id IN (?)", userIDs).
-		Preload("Country").
-		Preload("Province").
-		Preload("District").
-		Find(&users).Error
-
-	if err != nil {
-		return nil, err
-	}
-
-	return users, nil
-}
-
 // GetMutualFriendsCount lấy số lượng bạn chung
 func (r *friendshipRepository) GetMutualFriendsCount(ctx context.Context, userID, otherUserID int64) (int, error) {
 	var count int
@@ -218,6 +181,68 @@ func (r *friendshipRepository) GetMutualFriendsCount(ctx context.Context, userID
 	return count, nil
 }
 
+// friendIDsSubQuery là truy vấn con lấy ID bạn bè của một người dùng, nhận tham số userID, status, userID, status
+const friendIDsSubQuery = `
+	SELECT friend_id FROM friendships WHERE user_id = ? AND status = ?
+	UNION
+	SELECT user_id FROM friendships WHERE friend_id = ? AND status = ?`
+
+// GetMutualFriends lấy danh sách bạn chung của hai người dùng, sắp xếp theo tên.
+// Bỏ qua tài khoản bị vô hiệu hóa hoặc đang ẩn.
+func (r *friendshipRepository) GetMutualFriends(ctx context.Context, userID, otherUserID int64, page, pageSize int) ([]models.User, int64, error) {
+	var users []models.User
+	var total int64
+
+	accepted := models.FriendshipStatusAccepted
+	query := r.db.Model(&models.User{}).Where(
+		"id IN ("+friendIDsSubQuery+") AND id IN ("+friendIDsSubQuery+") AND is_active = ? AND is_hidden = ?",
+		userID, accepted, userID, accepted, otherUserID, accepted, otherUserID, accepted, true, false,
+	)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("full_name ASC").Offset(offset).Limit(pageSize).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// GetMutualFriendsCounts đếm số bạn chung giữa userID và từng người trong otherUserIDs bằng một truy vấn.
+// Người không có bạn chung nào không có trong kết quả.
+func (r *friendshipRepository) GetMutualFriendsCounts(ctx context.Context, userID int64, otherUserIDs []int64) (map[int64]int, error) {
+	counts := make(map[int64]int, len(otherUserIDs))
+	if len(otherUserIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		UserID int64
+		Count  int
+	}
+	accepted := models.FriendshipStatusAccepted
+	err := r.db.Raw(`
+		SELECT f.owner AS user_id, COUNT(*) AS count FROM
+		(
+			SELECT user_id AS owner, friend_id AS other FROM friendships WHERE status = ? AND user_id IN (?)
+			UNION ALL
+			SELECT friend_id, user_id FROM friendships WHERE status = ? AND friend_id IN (?)
+		) f
+		WHERE f.other IN (`+friendIDsSubQuery+`)
+		GROUP BY f.owner
+	`, accepted, otherUserIDs, accepted, otherUserIDs, userID, accepted, userID, accepted).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.UserID] = row.Count
+	}
+	return counts, nil
+}
+
 // GetFriendCount lấy số lượng bạn bè của người dùng
 func (r *friendshipRepository) GetFriendCount(ctx context.Context, userID int64) (int, error) {
 	var count int
@@ -227,3 +252,36 @@ func (r *friendshipRepository) GetFriendCount(ctx context.Context, userID int64)
 
 	return count, err
 }
+
+// FindBlocksAmong lấy các quan hệ chặn mà cả người chặn và người bị chặn đều thuộc userIDs
+func (r *friendshipRepository) FindBlocksAmong(ctx context.Context, userIDs []int64) ([]models.Friendship, error) {
+	var blocks []models.Friendship
+	if len(userIDs) == 0 {
+		return blocks, nil
+	}
+	err := r.db.Where("status = ? AND user_id IN (?) AND friend_id IN (?)", models.FriendshipStatusBlocked, userIDs, userIDs).
+		Find(&blocks).Error
+	return blocks, err
+}
+
+// FindBlockedUserIDs lấy ID của những người userID đang chặn và những người đang chặn userID
+func (r *friendshipRepository) FindBlockedUserIDs(ctx context.Context, userID int64) ([]int64, error) {
+	var ids []int64
+	err := r.db.Raw(`
+		SELECT friend_id FROM friendships WHERE user_id = ? AND status = ?
+		UNION
+		SELECT user_id FROM friendships WHERE friend_id = ? AND status = ?
+	`, userID, models.FriendshipStatusBlocked, userID, models.FriendshipStatusBlocked).Pluck("friend_id", &ids).Error
+	return ids, err
+}
+
+// FindFriendIDs lấy ID của tất cả bạn bè của người dùng
+func (r *friendshipRepository) FindFriendIDs(ctx context.Context, userID int64) ([]int64, error) {
+	var ids []int64
+	err := r.db.Raw(`
+		SELECT friend_id FROM friendships WHERE user_id = ? AND status = ?
+		UNION
+		SELECT user_id FROM friendships WHERE friend_id = ? AND status = ?
+	`, userID, models.FriendshipStatusAccepted, userID, models.FriendshipStatusAccepted).Pluck("friend_id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"userservice2/models"
+	"userservice2/utils"
+)
+
+// GroupAnnouncementRepository đại diện cho tầng truy cập dữ liệu thông báo ghim của nhóm
+type GroupAnnouncementRepository interface {
+	Create(ctx context.Context, announcement *models.GroupAnnouncement, now time.Time) (bool, error)
+	FindByID(ctx context.Context, id int64) (*models.GroupAnnouncement, error)
+	Update(ctx context.Context, announcement *models.GroupAnnouncement, now time.Time) (bool, error)
+	Delete(ctx context.Context, id int64) error
+	ListActive(ctx context.Context, groupID int64, now time.Time) ([]models.GroupAnnouncement, error)
+	List(ctx context.Context, groupID int64, includeExpired bool, now time.Time, page, pageSize int) ([]models.GroupAnnouncement, int64, error)
+}
+
+// groupAnnouncementRepository triển khai GroupAnnouncementRepository
+type groupAnnouncementRepository struct {
+	db *gorm.DB
+}
+
+// NewGroupAnnouncementRepository tạo instance mới của GroupAnnouncementRepository
+func NewGroupAnnouncementRepository(db *gorm.DB) GroupAnnouncementRepository {
+	return &groupAnnouncementRepository{db: db}
+}
+
+// activeAnnouncementScope lọc các thông báo chưa hết hạn tại thời điểm now
+func activeAnnouncementScope(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.Where("expires_at IS NULL OR expires_at > ?", now)
+}
+
+// countActive đếm số thông báo còn được ghim của nhóm trong transaction tx, bỏ qua thông báo exceptID
+func countActive(tx *gorm.DB, groupID, exceptID int64, now time.Time) (int, error) {
+	var count int
+	err := activeAnnouncementScope(tx.Model(&models.GroupAnnouncement{}), now).
+		Where("group_id = ? AND id <> ?", groupID, exceptID).
+		Count(&count).Error
+	return count, err
+}
+
+// Create tạo thông báo mới. Trả về false nếu nhóm đã ghim đủ models.MaxActiveGroupAnnouncements thông báo.
+func (r *groupAnnouncementRepository) Create(ctx context.Context, announcement *models.GroupAnnouncement, now time.Time) (bool, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	if err := lockGroup(tx, announcement.GroupID); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if announcement.IsActive(now) {
+		count, err := countActive(tx, announcement.GroupID, 0, now)
+		if err != nil {
+			tx.Rollback()
+			return false, err
+		}
+		if count >= models.MaxActiveGroupAnnouncements {
+			tx.Rollback()
+			return false, nil
+		}
+	}
+	if err := tx.Create(announcement).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit().Error
+}
+
+// FindByID tìm thông báo theo ID kèm người đăng
+func (r *groupAnnouncementRepository) FindByID(ctx context.Context, id int64) (*models.GroupAnnouncement, error) {
+	var announcement models.GroupAnnouncement
+	if err := r.db.Preload("Author").First(&announcement, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+// Update cập nhật tiêu đề, nội dung và thời hạn của thông báo. Trả về false nếu việc gia hạn ghim lại một thông báo
+// đã hết hạn trong khi nhóm đã ghim đủ models.MaxActiveGroupAnnouncements thông báo.
+func (r *groupAnnouncementRepository) Update(ctx context.Context, announcement *models.GroupAnnouncement, now time.Time) (bool, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	if err := lockGroup(tx, announcement.GroupID); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if announcement.IsActive(now) {
+		var current models.GroupAnnouncement
+		if err := tx.Select("id, expires_at").First(&current, announcement.ID).Error; err != nil {
+			tx.Rollback()
+			return false, err
+		}
+		if !current.IsActive(now) {
+			count, err := countActive(tx, announcement.GroupID, announcement.ID, now)
+			if err != nil {
+				tx.Rollback()
+				return false, err
+			}
+			if count >= models.MaxActiveGroupAnnouncements {
+				tx.Rollback()
+				return false, nil
+			}
+		}
+	}
+	err := tx.Model(announcement).Updates(map[string]interface{}{
+		"title":      announcement.Title,
+		"content":    announcement.Content,
+		"expires_at": announcement.ExpiresAt,
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit().Error
+}
+
+// Delete xóa thông báo
+func (r *groupAnnouncementRepository) Delete(ctx context.Context, id int64) error {
+	return r.db.Where("id = ?", id).Delete(&models.GroupAnnouncement{}).Error
+}
+
+// ListActive lấy các thông báo còn được ghim của nhóm, mới nhất trước
+func (r *groupAnnouncementRepository) ListActive(ctx context.Context, groupID int64, now time.Time) ([]models.GroupAnnouncement, error) {
+	var announcements []models.GroupAnnouncement
+	err := activeAnnouncementScope(r.db.Preload("Author"), now).
+		Where("group_id = ?", groupID).
+		Order("created_at DESC, id DESC").
+		Find(&announcements).Error
+	return announcements, err
+}
+
+// List lấy thông báo của nhóm có phân trang, mới nhất trước. includeExpired để lấy cả thông báo đã hết hạn.
+func (r *groupAnnouncementRepository) List(ctx context.Context, groupID int64, includeExpired bool, now time.Time, page, pageSize int) ([]models.GroupAnnouncement, int64, error) {
+	var announcements []models.GroupAnnouncement
+	var total int64
+
+	query := r.db.Model(&models.GroupAnnouncement{}).Where("group_id = ?", groupID)
+	if !includeExpired {
+		query = activeAnnouncementScope(query, now)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset, limit := utils.Pagination(page, pageSize)
+	err := query.Preload("Author").
+		Order("created_at DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&announcements).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return announcements, total, nil
+}
@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"userservice2/models"
+	"userservice2/utils"
+)
+
+// GroupInvitationRepository đại diện cho tầng truy cập dữ liệu lời mời vào nhóm
+type GroupInvitationRepository interface {
+	Create(ctx context.Context, invitation *models.GroupInvitation) error
+	FindByID(ctx context.Context, id int64) (*models.GroupInvitation, error)
+	FindPending(ctx context.Context, groupID, inviteeID int64) (*models.GroupInvitation, error)
+	ListByInvitee(ctx context.Context, inviteeID int64, status models.GroupInvitationStatus, page, pageSize int) ([]models.GroupInvitation, int64, error)
+	Accept(ctx context.Context, invitation *models.GroupInvitation, rulesAccepted bool) (bool, error)
+	Decline(ctx context.Context, id int64) (bool, error)
+	ExpireStale(ctx context.Context, now time.Time) (int64, error)
+}
+
+// groupInvitationRepository triển khai GroupInvitationRepository
+type groupInvitationRepository struct {
+	db *gorm.DB
+}
+
+// NewGroupInvitationRepository tạo instance mới của GroupInvitationRepository
+func NewGroupInvitationRepository(db *gorm.DB) GroupInvitationRepository {
+	return &groupInvitationRepository{db: db}
+}
+
+// pendingInvitationScope chỉ lấy các lời mời đang chờ và chưa quá hạn, kể cả khi chưa được đánh dấu expired
+func pendingInvitationScope(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.Where("status = ? AND (expires_at IS NULL OR expires_at > ?)", models.GroupInvitationStatusPending, now)
+}
+
+// Create tạo lời mời mới
+func (r *groupInvitationRepository) Create(ctx context.Context, invitation *models.GroupInvitation) error {
+	return r.db.Create(invitation).Error
+}
+
+// FindByID tìm lời mời theo ID
+func (r *groupInvitationRepository) FindByID(ctx context.Context, id int64) (*models.GroupInvitation, error) {
+	var invitation models.GroupInvitation
+	if err := r.db.First(&invitation, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// FindPending tìm lời mời đang chờ của người được mời trong nhóm
+func (r *groupInvitationRepository) FindPending(ctx context.Context, groupID, inviteeID int64) (*models.GroupInvitation, error) {
+	var invitation models.GroupInvitation
+	err := pendingInvitationScope(r.db, time.Now()).
+		Where("group_id = ? AND invitee_id = ?", groupID, inviteeID).
+		First(&invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// ListByInvitee lấy các lời mời gửi tới một người dùng, mới nhất trước
+func (r *groupInvitationRepository) ListByInvitee(ctx context.Context, inviteeID int64, status models.GroupInvitationStatus, page, pageSize int) ([]models.GroupInvitation, int64, error) {
+	var invitations []models.GroupInvitation
+	var total int64
+
+	offset, limit := utils.Pagination(page, pageSize)
+
+	query := r.db.Model(&models.GroupInvitation{}).Where("invitee_id = ?", inviteeID)
+	if status == models.GroupInvitationStatusPending {
+		query = pendingInvitationScope(query, time.Now())
+	} else if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Group").
+		Preload("Inviter").
+		Order("created_at DESC").
+		Offset(offset).Limit(limit).
+		Find(&invitations).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return invitations, total, nil
+}
+
+// Accept chấp nhận lời mời đang chờ: đánh dấu đã chấp nhận, thêm người được mời làm thành viên và đóng
+// yêu cầu tham gia đang chờ của họ trong cùng một transaction. rulesAccepted cho biết người được mời đã chấp nhận
+// nội quy nhóm. Trả về false nếu lời mời không còn hiệu lực.
+func (r *groupInvitationRepository) Accept(ctx context.Context, invitation *models.GroupInvitation, rulesAccepted bool) (bool, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	now := time.Now()
+	result := pendingInvitationScope(tx.Model(&models.GroupInvitation{}), now).
+		Where("id = ?", invitation.ID).
+		Updates(map[string]interface{}{
+			"status":       models.GroupInvitationStatusAccepted,
+			"responded_at": now,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	var rulesAcceptedAt *time.Time
+	if rulesAccepted {
+		rulesAcceptedAt = &now
+	}
+	if err := addApprovedMember(tx, invitation.GroupID, invitation.InviteeID, "", rulesAcceptedAt, now); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	// Yêu cầu tham gia đang chờ của người được mời được coi như đã duyệt
+	err := tx.Model(&models.GroupJoinRequest{}).
+		Where("group_id = ? AND user_id = ? AND status = ?", invitation.GroupID, invitation.InviteeID, models.JoinRequestStatusPending).
+		Updates(map[string]interface{}{"status": models.JoinRequestStatusApproved, "reviewed_at": now}).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit().Error
+}
+
+// Decline từ chối lời mời đang chờ. Trả về false nếu lời mời không còn hiệu lực.
+func (r *groupInvitationRepository) Decline(ctx context.Context, id int64) (bool, error) {
+	result := pendingInvitationScope(r.db.Model(&models.GroupInvitation{}), time.Now()).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       models.GroupInvitationStatusDeclined,
+			"responded_at": time.Now(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ExpireStale đánh dấu expired cho các lời mời đang chờ đã quá hạn
+func (r *groupInvitationRepository) ExpireStale(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.Model(&models.GroupInvitation{}).
+		Where("status = ? AND expires_at IS NOT NULL AND expires_at <= ?", models.GroupInvitationStatusPending, now).
+		UpdateColumn("status", models.GroupInvitationStatusExpired)
+	return result.RowsAffected, result.Error
+}
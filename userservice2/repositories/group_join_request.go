@@ -0,0 +1,233 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"userservice2/models"
+	"userservice2/utils"
+)
+
+// JoinRequestFilter là điều kiện lọc danh sách yêu cầu tham gia nhóm
+type JoinRequestFilter struct {
+	Status models.JoinRequestStatus
+	Query  string // Tìm theo username hoặc họ tên người gửi
+	Oldest bool   // Sắp xếp yêu cầu cũ nhất lên trước
+}
+
+// GroupJoinRequestRepository đại diện cho tầng truy cập dữ liệu yêu cầu tham gia nhóm
+type GroupJoinRequestRepository interface {
+	Create(ctx context.Context, joinRequest *models.GroupJoinRequest) error
+	FindPending(ctx context.Context, groupID, userID int64) (*models.GroupJoinRequest, error)
+	FindPendingByIDs(ctx context.Context, groupID int64, ids []int64) ([]models.GroupJoinRequest, error)
+	List(ctx context.Context, groupID int64, filter JoinRequestFilter, page, pageSize int) ([]models.GroupJoinRequest, int64, error)
+	Approve(ctx context.Context, joinRequest *models.GroupJoinRequest, reviewerID int64) (bool, error)
+	Close(ctx context.Context, id int64, status models.JoinRequestStatus, reviewerID *int64) (bool, error)
+	RejectAllPending(ctx context.Context, groupID, reviewerID int64) (int64, error)
+	ExpireStale(ctx context.Context, now time.Time) (int64, error)
+}
+
+// groupJoinRequestRepository triển khai GroupJoinRequestRepository
+type groupJoinRequestRepository struct {
+	db *gorm.DB
+}
+
+// NewGroupJoinRequestRepository tạo instance mới của GroupJoinRequestRepository
+func NewGroupJoinRequestRepository(db *gorm.DB) GroupJoinRequestRepository {
+	return &groupJoinRequestRepository{db: db}
+}
+
+// pendingScope chỉ lấy các yêu cầu đang chờ và chưa quá hạn, kể cả khi chưa được đánh dấu expired
+func pendingScope(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.Where("group_join_requests.status = ? AND (group_join_requests.expires_at IS NULL OR group_join_requests.expires_at > ?)",
+		models.JoinRequestStatusPending, now)
+}
+
+// Create tạo yêu cầu tham gia mới
+func (r *groupJoinRequestRepository) Create(ctx context.Context, joinRequest *models.GroupJoinRequest) error {
+	return r.db.Create(joinRequest).Error
+}
+
+// FindPending tìm yêu cầu đang chờ của người dùng trong nhóm
+func (r *groupJoinRequestRepository) FindPending(ctx context.Context, groupID, userID int64) (*models.GroupJoinRequest, error) {
+	var joinRequest models.GroupJoinRequest
+	err := pendingScope(r.db, time.Now()).
+		Where("group_id = ? AND user_id = ?", groupID, userID).
+		First(&joinRequest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &joinRequest, nil
+}
+
+// FindPendingByIDs lấy các yêu cầu đang chờ của nhóm trong danh sách ids, bỏ qua ID không hợp lệ
+func (r *groupJoinRequestRepository) FindPendingByIDs(ctx context.Context, groupID int64, ids []int64) ([]models.GroupJoinRequest, error) {
+	var joinRequests []models.GroupJoinRequest
+	err := pendingScope(r.db, time.Now()).
+		Where("group_id = ? AND id IN (?)", groupID, ids).
+		Find(&joinRequests).Error
+	return joinRequests, err
+}
+
+// List lấy danh sách yêu cầu tham gia của nhóm theo bộ lọc
+func (r *groupJoinRequestRepository) List(ctx context.Context, groupID int64, filter JoinRequestFilter, page, pageSize int) ([]models.GroupJoinRequest, int64, error) {
+	var joinRequests []models.GroupJoinRequest
+	var total int64
+
+	offset, limit := utils.Pagination(page, pageSize)
+
+	query := r.db.Model(&models.GroupJoinRequest{}).Where("group_join_requests.group_id = ?", groupID)
+	if filter.Status == models.JoinRequestStatusPending {
+		query = pendingScope(query, time.Now())
+	} else if filter.Status != "" {
+		query = query.Where("group_join_requests.status = ?", filter.Status)
+	}
+	if filter.Query != "" {
+		like := "%" + filter.Query + "%"
+		query = query.
+			Joins("JOIN users ON users.id = group_join_requests.user_id").
+			Where("users.username LIKE ? OR users.full_name LIKE ?", like, like)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "group_join_requests.created_at DESC"
+	if filter.Oldest {
+		order = "group_join_requests.created_at ASC"
+	}
+	err := query.
+		Preload("User").
+		Order(order).
+		Offset(offset).Limit(limit).
+		Find(&joinRequests).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return joinRequests, total, nil
+}
+
+// Approve chấp nhận yêu cầu đang chờ: đánh dấu đã duyệt, thêm người gửi làm thành viên và đóng các lời
+// mời đang chờ của họ trong cùng một transaction. Trả về false nếu yêu cầu không còn ở trạng thái chờ.
+func (r *groupJoinRequestRepository) Approve(ctx context.Context, joinRequest *models.GroupJoinRequest, reviewerID int64) (bool, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	now := time.Now()
+	result := tx.Model(&models.GroupJoinRequest{}).
+		Where("id = ? AND status = ?", joinRequest.ID, models.JoinRequestStatusPending).
+		Updates(map[string]interface{}{
+			"status":      models.JoinRequestStatusApproved,
+			"reviewed_by": reviewerID,
+			"reviewed_at": now,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err := addApprovedMember(tx, joinRequest.GroupID, joinRequest.UserID, joinRequest.Nickname, joinRequest.RulesAcceptedAt, now); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	// Lời mời đang chờ của người gửi trở nên không cần thiết
+	err := tx.Model(&models.GroupInvitation{}).
+		Where("group_id = ? AND invitee_id = ? AND status = ?", joinRequest.GroupID, joinRequest.UserID, models.GroupInvitationStatusPending).
+		Updates(map[string]interface{}{"status": models.GroupInvitationStatusAccepted, "responded_at": now}).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit().Error
+}
+
+// Close chuyển yêu cầu đang chờ sang status (từ chối hoặc rút lại).
+// Trả về false nếu yêu cầu không còn ở trạng thái chờ.
+func (r *groupJoinRequestRepository) Close(ctx context.Context, id int64, status models.JoinRequestStatus, reviewerID *int64) (bool, error) {
+	updates := map[string]interface{}{"status": status}
+	if reviewerID != nil {
+		updates["reviewed_by"] = *reviewerID
+		updates["reviewed_at"] = time.Now()
+	}
+
+	result := r.db.Model(&models.GroupJoinRequest{}).
+		Where("id = ? AND status = ?", id, models.JoinRequestStatusPending).
+		Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+// RejectAllPending từ chối mọi yêu cầu đang chờ của nhóm, trả về số yêu cầu đã bị từ chối
+func (r *groupJoinRequestRepository) RejectAllPending(ctx context.Context, groupID, reviewerID int64) (int64, error) {
+	result := r.db.Model(&models.GroupJoinRequest{}).
+		Where("group_id = ? AND status = ?", groupID, models.JoinRequestStatusPending).
+		Updates(map[string]interface{}{
+			"status":      models.JoinRequestStatusRejected,
+			"reviewed_by": reviewerID,
+			"reviewed_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// addApprovedMember thêm userID làm thành viên đã duyệt của nhóm trong transaction tx, tăng số lượng
+// thành viên và ghi nhận hoạt động mới của nhóm. Bản ghi thành viên chưa được duyệt (ví dụ yêu cầu tham gia cũ) được chuyển sang đã duyệt,
+// không làm gì nếu userID đã là thành viên. rulesAcceptedAt là thời điểm người đó chấp nhận nội quy nhóm, nil nếu chưa.
+func addApprovedMember(tx *gorm.DB, groupID, userID int64, nickname string, rulesAcceptedAt *time.Time, now time.Time) error {
+	var member models.GroupMember
+	err := tx.Where("user_id = ? AND group_id = ?", userID, groupID).First(&member).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		member = models.GroupMember{
+			GroupID:         groupID,
+			UserID:          userID,
+			Role:            models.MemberRoleMember,
+			Nickname:        nickname,
+			Status:          models.GroupMemberStatusApproved,
+			JoinedAt:        now,
+			RulesAcceptedAt: rulesAcceptedAt,
+		}
+		err = tx.Create(&member).Error
+	case err == nil && member.Status != models.GroupMemberStatusApproved:
+		err = tx.Model(&member).Updates(map[string]interface{}{
+			"status":            models.GroupMemberStatusApproved,
+			"joined_at":         now,
+			"rules_accepted_at": rulesAcceptedAt,
+		}).Error
+	case err == nil:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&models.UserGroup{}).
+		Where("id = ?", groupID).
+		UpdateColumns(map[string]interface{}{
+			"member_count":     gorm.Expr("member_count + ?", 1),
+			"last_activity_at": now,
+		}).
+		Error
+}
+
+// ExpireStale đánh dấu expired cho các yêu cầu đang chờ đã quá hạn
+func (r *groupJoinRequestRepository) ExpireStale(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.Model(&models.GroupJoinRequest{}).
+		Where("status = ? AND expires_at IS NOT NULL AND expires_at <= ?", models.JoinRequestStatusPending, now).
+		UpdateColumn("status", models.JoinRequestStatusExpired)
+	return result.RowsAffected, result.Error
+}
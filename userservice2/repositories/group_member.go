@@ -16,6 +16,8 @@ type GroupMemberRepository interface {
 	Delete(ctx context.Context, id int64) error
 	ListByGroup(ctx context.Context, groupID int64, page, pageSize int) ([]models.GroupMember, int64, error)
 	ListByUser(ctx context.Context, userID int64, page, pageSize int) ([]models.GroupMember, int64, error)
+	CountActiveAdmins(ctx context.Context, groupID int64) (int64, error)
+	FindApprovedGroupIDs(ctx context.Context, userID int64) ([]int64, error)
 }
 
 // groupMemberRepository triển khai GroupMemberRepository
@@ -64,9 +66,27 @@ func (r *groupMemberRepository) Update(ctx context.Context, member *models.Group
 	return r.db.Save(member).Error
 }
 
-// Delete xóa thành viên nhóm
+// Delete xóa thành viên nhóm cùng các vai trò đã được gán cho thành viên đó
 func (r *groupMemberRepository) Delete(ctx context.Context, id int64) error {
-	return r.db.Delete(&models.GroupMember{ID: id}).Error
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := deleteMember(tx, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// deleteMember xóa thành viên nhóm cùng các vai trò đã được gán trong transaction tx
+func deleteMember(tx *gorm.DB, id int64) error {
+	if err := tx.Where("group_member_id = ?", id).Delete(&models.GroupMemberRole{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(&models.GroupMember{ID: id}).Error
 }
 
 // ListByGroup lấy danh sách thành viên trong nhóm
@@ -124,3 +144,21 @@ func (r *groupMemberRepository) ListByUser(ctx context.Context, userID int64, pa
 
 	return members, total, nil
 }
+
+// CountActiveAdmins đếm số admin đã duyệt của nhóm có tài khoản còn hoạt động
+func (r *groupMemberRepository) CountActiveAdmins(ctx context.Context, groupID int64) (int64, error) {
+	var count int64
+	err := activeMembersScope(r.db.Model(&models.GroupMember{}), groupID).
+		Where("group_members.role = ?", models.MemberRoleAdmin).
+		Count(&count).Error
+	return count, err
+}
+
+// FindApprovedGroupIDs lấy ID các nhóm mà người dùng là thành viên đã được duyệt
+func (r *groupMemberRepository) FindApprovedGroupIDs(ctx context.Context, userID int64) ([]int64, error) {
+	var ids []int64
+	err := r.db.Model(&models.GroupMember{}).
+		Where("user_id = ? AND status = ?", userID, models.GroupMemberStatusApproved).
+		Pluck("group_id", &ids).Error
+	return ids, err
+}
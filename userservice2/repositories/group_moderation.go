@@ -0,0 +1,276 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"userservice2/models"
+	"userservice2/utils"
+)
+
+// GroupModerationRepository đại diện cho tầng truy cập dữ liệu kiểm duyệt nhóm.
+// Mỗi thao tác được ghi nhật ký kiểm duyệt trong cùng transaction với thay đổi dữ liệu.
+type GroupModerationRepository interface {
+	RemoveMember(ctx context.Context, member *models.GroupMember, entry *models.GroupModerationLog) error
+	Ban(ctx context.Context, ban *models.GroupBan, entry *models.GroupModerationLog) error
+	Unban(ctx context.Context, groupID, userID int64, entry *models.GroupModerationLog) (bool, error)
+	FindBan(ctx context.Context, groupID, userID int64) (*models.GroupBan, error)
+	ListBans(ctx context.Context, groupID int64, page, pageSize int) ([]models.GroupBan, int64, error)
+	SetMute(ctx context.Context, memberID int64, mutedUntil *time.Time, reason string, entry *models.GroupModerationLog) error
+	ClearMute(ctx context.Context, memberID int64, entry *models.GroupModerationLog) error
+	ExpireMutes(ctx context.Context, now time.Time) (int64, error)
+	CreateLog(ctx context.Context, entry *models.GroupModerationLog) error
+	ListLogs(ctx context.Context, groupID int64, action models.GroupModerationAction, page, pageSize int) ([]models.GroupModerationLog, int64, error)
+}
+
+// groupModerationRepository triển khai GroupModerationRepository
+type groupModerationRepository struct {
+	db *gorm.DB
+}
+
+// NewGroupModerationRepository tạo instance mới của GroupModerationRepository
+func NewGroupModerationRepository(db *gorm.DB) GroupModerationRepository {
+	return &groupModerationRepository{db: db}
+}
+
+// withModerationLog chạy fn và ghi entry trong cùng một transaction
+func (r *groupModerationRepository) withModerationLog(entry *models.GroupModerationLog, fn func(tx *gorm.DB) error) error {
+	tx := r.db.Begin()
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Create(entry).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// removeMember xóa thành viên khỏi nhóm trong transaction tx, giảm số lượng thành viên nếu đã được duyệt
+func removeMember(tx *gorm.DB, member *models.GroupMember) error {
+	if err := deleteMember(tx, member.ID); err != nil {
+		return err
+	}
+	if member.Status != models.GroupMemberStatusApproved {
+		return nil
+	}
+	return tx.Model(&models.UserGroup{}).
+		Where("id = ? AND member_count > 0", member.GroupID).
+		UpdateColumn("member_count", gorm.Expr("member_count - ?", 1)).
+		Error
+}
+
+// RemoveMember xóa thành viên khỏi nhóm, người bị xóa vẫn có thể tham gia lại
+func (r *groupModerationRepository) RemoveMember(ctx context.Context, member *models.GroupMember, entry *models.GroupModerationLog) error {
+	return r.withModerationLog(entry, func(tx *gorm.DB) error {
+		return removeMember(tx, member)
+	})
+}
+
+// Ban cấm người dùng khỏi nhóm: xóa tư cách thành viên (nếu có), đóng yêu cầu tham gia và lời mời đang chờ
+// của họ. Cấm lại người đã bị cấm chỉ cập nhật lý do.
+func (r *groupModerationRepository) Ban(ctx context.Context, ban *models.GroupBan, entry *models.GroupModerationLog) error {
+	return r.withModerationLog(entry, func(tx *gorm.DB) error {
+		err := tx.Where(models.GroupBan{GroupID: ban.GroupID, UserID: ban.UserID}).
+			Assign(models.GroupBan{BannedBy: ban.BannedBy, Reason: ban.Reason}).
+			FirstOrCreate(ban).Error
+		if err != nil {
+			return err
+		}
+
+		var member models.GroupMember
+		err = tx.Where("group_id = ? AND user_id = ?", ban.GroupID, ban.UserID).First(&member).Error
+		if err == nil {
+			err = removeMember(tx, &member)
+		} else if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = nil
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		err = tx.Model(&models.GroupJoinRequest{}).
+			Where("group_id = ? AND user_id = ? AND status = ?", ban.GroupID, ban.UserID, models.JoinRequestStatusPending).
+			Updates(map[string]interface{}{
+				"status":      models.JoinRequestStatusRejected,
+				"reviewed_by": ban.BannedBy,
+				"reviewed_at": now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.GroupInvitation{}).
+			Where("group_id = ? AND invitee_id = ? AND status = ?", ban.GroupID, ban.UserID, models.GroupInvitationStatusPending).
+			Updates(map[string]interface{}{
+				"status":       models.GroupInvitationStatusDeclined,
+				"responded_at": now,
+			}).Error
+	})
+}
+
+// Unban gỡ cấm người dùng khỏi nhóm. Trả về false nếu người dùng không bị cấm.
+func (r *groupModerationRepository) Unban(ctx context.Context, groupID, userID int64, entry *models.GroupModerationLog) (bool, error) {
+	var unbanned bool
+	err := r.withModerationLog(entry, func(tx *gorm.DB) error {
+		result := tx.Where("group_id = ? AND user_id = ?", groupID, userID).Delete(&models.GroupBan{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		unbanned = true
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return unbanned, err
+}
+
+// FindBan tìm lệnh cấm của người dùng trong nhóm
+func (r *groupModerationRepository) FindBan(ctx context.Context, groupID, userID int64) (*models.GroupBan, error) {
+	var ban models.GroupBan
+	err := r.db.Where("group_id = ? AND user_id = ?", groupID, userID).First(&ban).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ban, nil
+}
+
+// ListBans lấy danh sách người bị cấm của nhóm, mới nhất trước
+func (r *groupModerationRepository) ListBans(ctx context.Context, groupID int64, page, pageSize int) ([]models.GroupBan, int64, error) {
+	var bans []models.GroupBan
+	var total int64
+
+	offset, limit := utils.Pagination(page, pageSize)
+
+	query := r.db.Model(&models.GroupBan{}).Where("group_id = ?", groupID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("User").
+		Order("created_at DESC").
+		Offset(offset).Limit(limit).
+		Find(&bans).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return bans, total, nil
+}
+
+// SetMute tắt tiếng thành viên tới mutedUntil (nil là vô thời hạn)
+func (r *groupModerationRepository) SetMute(ctx context.Context, memberID int64, mutedUntil *time.Time, reason string, entry *models.GroupModerationLog) error {
+	return r.withModerationLog(entry, func(tx *gorm.DB) error {
+		return tx.Model(&models.GroupMember{ID: memberID}).Updates(map[string]interface{}{
+			"is_muted":    true,
+			"muted_until": mutedUntil,
+			"mute_reason": reason,
+		}).Error
+	})
+}
+
+// ClearMute gỡ tắt tiếng thành viên
+func (r *groupModerationRepository) ClearMute(ctx context.Context, memberID int64, entry *models.GroupModerationLog) error {
+	return r.withModerationLog(entry, func(tx *gorm.DB) error {
+		return tx.Model(&models.GroupMember{ID: memberID}).Updates(map[string]interface{}{
+			"is_muted":    false,
+			"muted_until": nil,
+			"mute_reason": "",
+		}).Error
+	})
+}
+
+// ExpireMutes gỡ tắt tiếng các thành viên đã hết thời hạn và ghi nhật ký cho từng người
+func (r *groupModerationRepository) ExpireMutes(ctx context.Context, now time.Time) (int64, error) {
+	var members []models.GroupMember
+	err := r.db.
+		Where("is_muted = ? AND muted_until IS NOT NULL AND muted_until <= ?", true, now).
+		Find(&members).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var expired int64
+	for _, member := range members {
+		entry := &models.GroupModerationLog{
+			GroupID:      member.GroupID,
+			TargetUserID: member.UserID,
+			Action:       models.GroupModerationMuteExpire,
+			Details:      fmt.Sprintf("muted_until=%s", member.MutedUntil.Format(time.RFC3339)),
+		}
+		var updated bool
+		err := r.withModerationLog(entry, func(tx *gorm.DB) error {
+			// Chỉ gỡ nếu thời hạn chưa bị thay đổi kể từ lúc đọc
+			result := tx.Model(&models.GroupMember{}).
+				Where("id = ? AND is_muted = ? AND muted_until = ?", member.ID, true, member.MutedUntil).
+				Updates(map[string]interface{}{
+					"is_muted":    false,
+					"muted_until": nil,
+					"mute_reason": "",
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+			updated = true
+			return nil
+		})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+		if updated {
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+// CreateLog ghi một mục nhật ký kiểm duyệt
+func (r *groupModerationRepository) CreateLog(ctx context.Context, entry *models.GroupModerationLog) error {
+	return r.db.Create(entry).Error
+}
+
+// ListLogs lấy nhật ký kiểm duyệt của nhóm, mới nhất trước
+func (r *groupModerationRepository) ListLogs(ctx context.Context, groupID int64, action models.GroupModerationAction, page, pageSize int) ([]models.GroupModerationLog, int64, error) {
+	var logs []models.GroupModerationLog
+	var total int64
+
+	offset, limit := utils.Pagination(page, pageSize)
+
+	query := r.db.Model(&models.GroupModerationLog{}).Where("group_id = ?", groupID)
+	if action != "" {
+		query = query.Where("action = ?", action)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Actor").
+		Preload("TargetUser").
+		Order("created_at DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&logs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
@@ -0,0 +1,244 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"userservice2/models"
+)
+
+// GroupOwnershipRepository đại diện cho tầng truy cập dữ liệu quyền sở hữu nhóm
+type GroupOwnershipRepository interface {
+	CreateTransfer(ctx context.Context, transfer *models.GroupOwnershipTransfer) error
+	FindPendingTransfer(ctx context.Context, groupID int64) (*models.GroupOwnershipTransfer, error)
+	AcceptTransfer(ctx context.Context, transfer *models.GroupOwnershipTransfer) (bool, error)
+	CloseTransfer(ctx context.Context, id int64, status models.GroupOwnershipTransferStatus) (bool, error)
+	RecoverLeadership(ctx context.Context) (int64, error)
+}
+
+// groupOwnershipRepository triển khai GroupOwnershipRepository
+type groupOwnershipRepository struct {
+	db *gorm.DB
+}
+
+// NewGroupOwnershipRepository tạo instance mới của GroupOwnershipRepository
+func NewGroupOwnershipRepository(db *gorm.DB) GroupOwnershipRepository {
+	return &groupOwnershipRepository{db: db}
+}
+
+// cancelPendingTransfers hủy các yêu cầu chuyển quyền sở hữu đang chờ của nhóm trong transaction tx
+func cancelPendingTransfers(tx *gorm.DB, groupID int64, now time.Time) error {
+	return tx.Model(&models.GroupOwnershipTransfer{}).
+		Where("group_id = ? AND status = ?", groupID, models.GroupOwnershipTransferStatusPending).
+		Updates(map[string]interface{}{
+			"status":       models.GroupOwnershipTransferStatusCancelled,
+			"responded_at": now,
+		}).Error
+}
+
+// CreateTransfer tạo yêu cầu chuyển quyền sở hữu mới, thay thế yêu cầu đang chờ trước đó của nhóm
+func (r *groupOwnershipRepository) CreateTransfer(ctx context.Context, transfer *models.GroupOwnershipTransfer) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := cancelPendingTransfers(tx, transfer.GroupID, time.Now()); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Create(transfer).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// FindPendingTransfer tìm yêu cầu chuyển quyền sở hữu đang chờ của nhóm
+func (r *groupOwnershipRepository) FindPendingTransfer(ctx context.Context, groupID int64) (*models.GroupOwnershipTransfer, error) {
+	var transfer models.GroupOwnershipTransfer
+	err := r.db.
+		Preload("FromUser").
+		Preload("ToUser").
+		Where("group_id = ? AND status = ?", groupID, models.GroupOwnershipTransferStatusPending).
+		First(&transfer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// AcceptTransfer chuyển quyền sở hữu cho người nhận và đưa họ lên admin trong cùng một transaction.
+// Trả về false nếu yêu cầu không còn chờ hoặc người gửi không còn là chủ nhóm.
+func (r *groupOwnershipRepository) AcceptTransfer(ctx context.Context, transfer *models.GroupOwnershipTransfer) (bool, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	now := time.Now()
+	result := tx.Model(&models.GroupOwnershipTransfer{}).
+		Where("id = ? AND status = ?", transfer.ID, models.GroupOwnershipTransferStatusPending).
+		Updates(map[string]interface{}{
+			"status":       models.GroupOwnershipTransferStatusAccepted,
+			"responded_at": now,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	result = tx.Model(&models.UserGroup{}).
+		Where("id = ? AND owner_id = ?", transfer.GroupID, transfer.FromUserID).
+		UpdateColumn("owner_id", transfer.ToUserID)
+	if result.Error != nil {
+		tx.Rollback()
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	err := tx.Model(&models.GroupMember{}).
+		Where("group_id = ? AND user_id = ? AND status = ?", transfer.GroupID, transfer.ToUserID, models.GroupMemberStatusApproved).
+		UpdateColumn("role", models.MemberRoleAdmin).
+		Error
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit().Error
+}
+
+// CloseTransfer chuyển yêu cầu đang chờ sang status (từ chối hoặc hủy).
+// Trả về false nếu yêu cầu không còn ở trạng thái chờ.
+func (r *groupOwnershipRepository) CloseTransfer(ctx context.Context, id int64, status models.GroupOwnershipTransferStatus) (bool, error) {
+	result := r.db.Model(&models.GroupOwnershipTransfer{}).
+		Where("id = ? AND status = ?", id, models.GroupOwnershipTransferStatusPending).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"responded_at": time.Now(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// activeMembersScope chỉ lấy các thành viên đã duyệt của nhóm có tài khoản còn hoạt động
+func activeMembersScope(db *gorm.DB, groupID int64) *gorm.DB {
+	return db.
+		Joins("JOIN users ON users.id = group_members.user_id").
+		Where("group_members.group_id = ? AND group_members.status = ? AND users.is_active = ?",
+			groupID, models.GroupMemberStatusApproved, true)
+}
+
+// longestServing sắp xếp thành viên lâu năm nhất lên trước
+const longestServing = "group_members.joined_at ASC, group_members.id ASC"
+
+// RecoverLeadership xử lý các nhóm mất người quản lý, ví dụ khi tài khoản của admin bị vô hiệu hóa:
+// nhóm không còn admin hoạt động được đưa thành viên lâu năm nhất lên admin, và nhóm có chủ không còn là
+// thành viên hoạt động được chuyển quyền sở hữu cho admin lâu năm nhất. Nhóm không còn thành viên hoạt
+// động nào được giữ nguyên. Trả về số nhóm đã được xử lý.
+func (r *groupOwnershipRepository) RecoverLeadership(ctx context.Context) (int64, error) {
+	// Nhóm tạo trước khi có chủ nhóm tường minh nhận người tạo làm chủ
+	err := r.db.Model(&models.UserGroup{}).
+		Where("owner_id = 0").
+		UpdateColumn("owner_id", gorm.Expr("created_by")).
+		Error
+	if err != nil {
+		return 0, err
+	}
+
+	var groupIDs []int64
+	err = r.db.Model(&models.UserGroup{}).
+		Where(`NOT EXISTS (SELECT 1 FROM group_members JOIN users ON users.id = group_members.user_id
+			WHERE group_members.group_id = user_groups.id AND group_members.status = ? AND group_members.role = ? AND users.is_active = ?)
+			OR NOT EXISTS (SELECT 1 FROM group_members JOIN users ON users.id = group_members.user_id
+			WHERE group_members.group_id = user_groups.id AND group_members.user_id = user_groups.owner_id
+			AND group_members.status = ? AND users.is_active = ?)`,
+			models.GroupMemberStatusApproved, models.MemberRoleAdmin, true,
+			models.GroupMemberStatusApproved, true).
+		Pluck("id", &groupIDs).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var recovered int64
+	for _, groupID := range groupIDs {
+		ok, err := r.recoverGroup(groupID)
+		if err != nil {
+			return recovered, err
+		}
+		if ok {
+			recovered++
+		}
+	}
+	return recovered, nil
+}
+
+// recoverGroup đảm bảo nhóm có ít nhất một admin hoạt động và chủ nhóm là thành viên hoạt động.
+// Trả về false nếu nhóm không còn thành viên hoạt động nào.
+func (r *groupOwnershipRepository) recoverGroup(groupID int64) (bool, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	var admin models.GroupMember
+	err := activeMembersScope(tx, groupID).
+		Where("group_members.role = ?", models.MemberRoleAdmin).
+		Order(longestServing).
+		First(&admin).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = activeMembersScope(tx, groupID).Order(longestServing).First(&admin).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			tx.Rollback()
+			return false, nil
+		}
+		if err == nil {
+			err = tx.Model(&admin).UpdateColumn("role", models.MemberRoleAdmin).Error
+		}
+	}
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	var group models.UserGroup
+	if err := tx.First(&group, groupID).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	var ownerCount int64
+	err = activeMembersScope(tx.Model(&models.GroupMember{}), groupID).
+		Where("group_members.user_id = ?", group.OwnerID).
+		Count(&ownerCount).Error
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if ownerCount == 0 {
+		now := time.Now()
+		if err := tx.Model(&group).UpdateColumn("owner_id", admin.UserID).Error; err != nil {
+			tx.Rollback()
+			return false, err
+		}
+		if err := cancelPendingTransfers(tx, groupID, now); err != nil {
+			tx.Rollback()
+			return false, err
+		}
+	}
+
+	return true, tx.Commit().Error
+}
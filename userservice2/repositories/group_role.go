@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jinzhu/gorm"
+	"userservice2/models"
+)
+
+// GroupRoleRepository đại diện cho tầng truy cập dữ liệu vai trò trong nhóm
+type GroupRoleRepository interface {
+	Create(ctx context.Context, role *models.GroupRole) error
+	FindByID(ctx context.Context, id int64) (*models.GroupRole, error)
+	FindByName(ctx context.Context, groupID int64, name string) (*models.GroupRole, error)
+	Update(ctx context.Context, role *models.GroupRole) error
+	Delete(ctx context.Context, id int64) error
+	ListByGroup(ctx context.Context, groupID int64) ([]models.GroupRole, error)
+	AssignToMember(ctx context.Context, memberID, roleID int64) error
+	RemoveFromMember(ctx context.Context, memberID, roleID int64) (bool, error)
+	ListByMember(ctx context.Context, memberID int64) ([]models.GroupRole, error)
+}
+
+// groupRoleRepository triển khai GroupRoleRepository
+type groupRoleRepository struct {
+	db *gorm.DB
+}
+
+// NewGroupRoleRepository tạo instance mới của GroupRoleRepository
+func NewGroupRoleRepository(db *gorm.DB) GroupRoleRepository {
+	return &groupRoleRepository{db: db}
+}
+
+// Create tạo vai trò mới
+func (r *groupRoleRepository) Create(ctx context.Context, role *models.GroupRole) error {
+	return r.db.Create(role).Error
+}
+
+// FindByID tìm vai trò theo ID
+func (r *groupRoleRepository) FindByID(ctx context.Context, id int64) (*models.GroupRole, error) {
+	var role models.GroupRole
+	if err := r.db.First(&role, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+// FindByName tìm vai trò theo tên trong một nhóm
+func (r *groupRoleRepository) FindByName(ctx context.Context, groupID int64, name string) (*models.GroupRole, error) {
+	var role models.GroupRole
+	if err := r.db.Where("group_id = ? AND name = ?", groupID, name).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+// Update cập nhật tên và quyền của vai trò
+func (r *groupRoleRepository) Update(ctx context.Context, role *models.GroupRole) error {
+	return r.db.Save(role).Error
+}
+
+// Delete xóa vai trò cùng các lần gán vai trò đó cho thành viên
+func (r *groupRoleRepository) Delete(ctx context.Context, id int64) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := tx.Where("group_role_id = ?", id).Delete(&models.GroupMemberRole{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("id = ?", id).Delete(&models.GroupRole{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// ListByGroup lấy tất cả vai trò của nhóm theo thứ tự tạo
+func (r *groupRoleRepository) ListByGroup(ctx context.Context, groupID int64) ([]models.GroupRole, error) {
+	var roles []models.GroupRole
+	err := r.db.Where("group_id = ?", groupID).Order("id ASC").Find(&roles).Error
+	return roles, err
+}
+
+// AssignToMember gán vai trò cho thành viên, không làm gì nếu đã được gán
+func (r *groupRoleRepository) AssignToMember(ctx context.Context, memberID, roleID int64) error {
+	assignment := models.GroupMemberRole{GroupMemberID: memberID, GroupRoleID: roleID}
+	return r.db.Where(assignment).FirstOrCreate(&assignment).Error
+}
+
+// RemoveFromMember gỡ vai trò khỏi thành viên, trả về false nếu thành viên không có vai trò này
+func (r *groupRoleRepository) RemoveFromMember(ctx context.Context, memberID, roleID int64) (bool, error) {
+	result := r.db.Where("group_member_id = ? AND group_role_id = ?", memberID, roleID).
+		Delete(&models.GroupMemberRole{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListByMember lấy các vai trò đã gán cho thành viên
+func (r *groupRoleRepository) ListByMember(ctx context.Context, memberID int64) ([]models.GroupRole, error) {
+	var roles []models.GroupRole
+	err := r.db.
+		Joins("JOIN group_member_roles ON group_member_roles.group_role_id = group_roles.id").
+		Where("group_member_roles.group_member_id = ?", memberID).
+		Order("group_roles.id ASC").
+		Find(&roles).Error
+	return roles, err
+}
@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"userservice2/models"
+)
+
+// GroupRuleRepository đại diện cho tầng truy cập dữ liệu nội quy nhóm
+type GroupRuleRepository interface {
+	Create(ctx context.Context, rule *models.GroupRule) (bool, error)
+	FindByID(ctx context.Context, id int64) (*models.GroupRule, error)
+	Update(ctx context.Context, rule *models.GroupRule) error
+	Delete(ctx context.Context, rule *models.GroupRule) error
+	ListByGroup(ctx context.Context, groupID int64) ([]models.GroupRule, error)
+	CountByGroup(ctx context.Context, groupID int64) (int, error)
+	Reorder(ctx context.Context, groupID int64, ruleIDs []int64) error
+	MarkAccepted(ctx context.Context, memberID int64, acceptedAt time.Time) error
+}
+
+// groupRuleRepository triển khai GroupRuleRepository
+type groupRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewGroupRuleRepository tạo instance mới của GroupRuleRepository
+func NewGroupRuleRepository(db *gorm.DB) GroupRuleRepository {
+	return &groupRuleRepository{db: db}
+}
+
+// lockGroup khóa bản ghi nhóm trong transaction tx để các thao tác trên nội quy hoặc thông báo của cùng nhóm
+// chạy tuần tự, kể cả việc đếm để kiểm tra giới hạn
+func lockGroup(tx *gorm.DB, groupID int64) error {
+	var group models.UserGroup
+	return tx.Set("gorm:query_option", "FOR UPDATE").Select("id").First(&group, groupID).Error
+}
+
+// Create thêm điều nội quy vào cuối danh sách của nhóm. Trả về false nếu nhóm đã có đủ models.MaxGroupRules điều.
+func (r *groupRuleRepository) Create(ctx context.Context, rule *models.GroupRule) (bool, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	if err := lockGroup(tx, rule.GroupID); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	var last struct {
+		Count    int
+		Position int
+	}
+	err := tx.Model(&models.GroupRule{}).Select("COUNT(*) AS count, COALESCE(MAX(position), 0) AS position").
+		Where("group_id = ?", rule.GroupID).Scan(&last).Error
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if last.Count >= models.MaxGroupRules {
+		tx.Rollback()
+		return false, nil
+	}
+
+	rule.Position = last.Position + 1
+	if err := tx.Create(rule).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit().Error
+}
+
+// FindByID tìm điều nội quy theo ID
+func (r *groupRuleRepository) FindByID(ctx context.Context, id int64) (*models.GroupRule, error) {
+	var rule models.GroupRule
+	if err := r.db.First(&rule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Update cập nhật tiêu đề và mô tả của điều nội quy, không đổi vị trí
+func (r *groupRuleRepository) Update(ctx context.Context, rule *models.GroupRule) error {
+	return r.db.Model(rule).Updates(map[string]interface{}{
+		"title":       rule.Title,
+		"description": rule.Description,
+	}).Error
+}
+
+// Delete xóa điều nội quy và dồn các điều phía sau lên một vị trí
+func (r *groupRuleRepository) Delete(ctx context.Context, rule *models.GroupRule) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := lockGroup(tx, rule.GroupID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("id = ?", rule.ID).Delete(&models.GroupRule{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	err := tx.Model(&models.GroupRule{}).
+		Where("group_id = ? AND position > ?", rule.GroupID, rule.Position).
+		UpdateColumn("position", gorm.Expr("position - 1")).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// ListByGroup lấy nội quy của nhóm theo thứ tự hiển thị
+func (r *groupRuleRepository) ListByGroup(ctx context.Context, groupID int64) ([]models.GroupRule, error) {
+	var rules []models.GroupRule
+	err := r.db.Where("group_id = ?", groupID).Order("position ASC, id ASC").Find(&rules).Error
+	return rules, err
+}
+
+// CountByGroup đếm số điều nội quy của nhóm
+func (r *groupRuleRepository) CountByGroup(ctx context.Context, groupID int64) (int, error) {
+	var count int
+	err := r.db.Model(&models.GroupRule{}).Where("group_id = ?", groupID).Count(&count).Error
+	return count, err
+}
+
+// Reorder đặt lại vị trí nội quy của nhóm theo thứ tự ruleIDs, ruleIDs phải gồm đúng các điều của nhóm
+func (r *groupRuleRepository) Reorder(ctx context.Context, groupID int64, ruleIDs []int64) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := lockGroup(tx, groupID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	for i, id := range ruleIDs {
+		err := tx.Model(&models.GroupRule{}).Where("id = ? AND group_id = ?", id, groupID).
+			UpdateColumn("position", i+1).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// MarkAccepted ghi nhận thời điểm thành viên chấp nhận nội quy nhóm
+func (r *groupRuleRepository) MarkAccepted(ctx context.Context, memberID int64, acceptedAt time.Time) error {
+	return r.db.Model(&models.GroupMember{}).Where("id = ?", memberID).
+		UpdateColumn("rules_accepted_at", acceptedAt).Error
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"userservice2/models"
+)
+
+// MediaReference là một bản ghi có tham chiếu tới ảnh trên storage
+type MediaReference struct {
+	ID   int64
+	URLs []string
+}
+
+// MediaReferenceRepository truy vấn các URL ảnh đang được sử dụng, phục vụ job dọn media mồ côi
+type MediaReferenceRepository interface {
+	ListUserMedia(ctx context.Context, afterID int64, limit int) ([]MediaReference, error)
+	ListGroupMedia(ctx context.Context, afterID int64, limit int) ([]MediaReference, error)
+}
+
+// mediaReferenceRepository triển khai MediaReferenceRepository
+type mediaReferenceRepository struct {
+	db *gorm.DB
+}
+
+// NewMediaReferenceRepository tạo instance mới của MediaReferenceRepository
+func NewMediaReferenceRepository(db *gorm.DB) MediaReferenceRepository {
+	return &mediaReferenceRepository{db: db}
+}
+
+// ListUserMedia lấy avatar, ảnh bìa (kèm variant) của người dùng, phân trang theo ID
+func (r *mediaReferenceRepository) ListUserMedia(ctx context.Context, afterID int64, limit int) ([]MediaReference, error) {
+	var users []models.User
+	err := r.db.Select("id, profile_picture_url, cover_picture_url, profile_picture_variants, cover_picture_variants").
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]MediaReference, 0, len(users))
+	for _, u := range users {
+		urls := nonEmpty(u.ProfilePictureURL, u.CoverPictureURL)
+		// Các variant được upload riêng cũng đang được tham chiếu
+		for _, v := range append(u.ProfilePictureVariants, u.CoverPictureVariants...) {
+			urls = append(urls, v.URL)
+		}
+		refs = append(refs, MediaReference{ID: u.ID, URLs: urls})
+	}
+	return refs, nil
+}
+
+// ListGroupMedia lấy ảnh bìa của nhóm, phân trang theo ID
+func (r *mediaReferenceRepository) ListGroupMedia(ctx context.Context, afterID int64, limit int) ([]MediaReference, error) {
+	var groups []models.UserGroup
+	err := r.db.Select("id, cover_image").
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&groups).Error
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]MediaReference, 0, len(groups))
+	for _, g := range groups {
+		refs = append(refs, MediaReference{ID: g.ID, URLs: nonEmpty(g.CoverImage)})
+	}
+	return refs, nil
+}
+
+// nonEmpty lọc bỏ các chuỗi rỗng
+func nonEmpty(values ...string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"userservice2/models"
+)
+
+// MuteRepository đại diện cho tầng truy cập dữ liệu tắt tiếng người dùng/nhóm
+type MuteRepository interface {
+	Upsert(ctx context.Context, mute *models.UserMute) error
+	Delete(ctx context.Context, userID int64, targetType models.MuteTargetType, targetID int64) (bool, error)
+	ListActive(ctx context.Context, userID int64, targetType models.MuteTargetType, page, pageSize int) ([]models.UserMute, int64, error)
+	FindActiveTargets(ctx context.Context, userID int64) ([]models.UserMute, error)
+}
+
+// muteRepository triển khai MuteRepository
+type muteRepository struct {
+	db *gorm.DB
+}
+
+// NewMuteRepository tạo instance mới của MuteRepository
+func NewMuteRepository(db *gorm.DB) MuteRepository {
+	return &muteRepository{db: db}
+}
+
+// active lọc các bản ghi chưa hết hạn
+func (r *muteRepository) active(userID int64) *gorm.DB {
+	return r.db.Model(&models.UserMute{}).
+		Where("user_id = ? AND (expires_at IS NULL OR expires_at > ?)", userID, time.Now())
+}
+
+// Upsert tạo mới hoặc ghi đè kiểu và thời hạn tắt tiếng của một đối tượng
+func (r *muteRepository) Upsert(ctx context.Context, mute *models.UserMute) error {
+	var existing models.UserMute
+	err := r.db.Where("user_id = ? AND target_type = ? AND target_id = ?", mute.UserID, mute.TargetType, mute.TargetID).
+		First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return r.db.Create(mute).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	existing.Kind = mute.Kind
+	existing.ExpiresAt = mute.ExpiresAt
+	existing.UpdatedAt = time.Now()
+	if err := r.db.Save(&existing).Error; err != nil {
+		return err
+	}
+	*mute = existing
+	return nil
+}
+
+// Delete bỏ tắt tiếng, trả về false nếu không có bản ghi nào
+func (r *muteRepository) Delete(ctx context.Context, userID int64, targetType models.MuteTargetType, targetID int64) (bool, error) {
+	result := r.db.Where("user_id = ? AND target_type = ? AND target_id = ?", userID, targetType, targetID).
+		Delete(&models.UserMute{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListActive lấy các đối tượng đang bị tắt tiếng, mới nhất xếp trước. targetType rỗng là lấy tất cả.
+func (r *muteRepository) ListActive(ctx context.Context, userID int64, targetType models.MuteTargetType, page, pageSize int) ([]models.UserMute, int64, error) {
+	var mutes []models.UserMute
+	var total int64
+
+	query := r.active(userID)
+	if targetType != "" {
+		query = query.Where("target_type = ?", targetType)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("updated_at DESC").Offset(offset).Limit(pageSize).Find(&mutes).Error; err != nil {
+		return nil, 0, err
+	}
+	return mutes, total, nil
+}
+
+// FindActiveTargets lấy toàn bộ đối tượng đang bị tắt tiếng để lọc feed
+func (r *muteRepository) FindActiveTargets(ctx context.Context, userID int64) ([]models.UserMute, error) {
+	var mutes []models.UserMute
+	if err := r.active(userID).Select("target_type, target_id").Find(&mutes).Error; err != nil {
+		return nil, err
+	}
+	return mutes, nil
+}
@@ -101,6 +101,20 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 		"last_login_at":       user.LastLoginAt,
 	}
 
+	// Variant ảnh chỉ cập nhật khi có giá trị để không ghi đè bằng dữ liệu rỗng
+	if user.ProfilePictureVariants != nil {
+		updates["profile_picture_variants"] = user.ProfilePictureVariants
+	}
+	if user.CoverPictureVariants != nil {
+		updates["cover_picture_variants"] = user.CoverPictureVariants
+	}
+	updates["profile_picture_width"] = user.ProfilePictureWidth
+	updates["profile_picture_height"] = user.ProfilePictureHeight
+	updates["profile_picture_blur_hash"] = user.ProfilePictureBlurHash
+	updates["cover_picture_width"] = user.CoverPictureWidth
+	updates["cover_picture_height"] = user.CoverPictureHeight
+	updates["cover_picture_blur_hash"] = user.CoverPictureBlurHash
+
 	// Chỉ cập nhật các ID tham chiếu khi chúng có giá trị lớn hơn 0
 	if user.CountryID > 0 {
 		updates["country_id"] = user.CountryID
@@ -134,12 +148,14 @@ func (r *userRepository) List(ctx context.Context, page, pageSize int) ([]models
 	var users []models.User
 	var total int64
 
-	if err := r.db.Model(&models.User{}).Count(&total).Error; err != nil {
+	// Không liệt kê người dùng đang bị ẩn bởi kiểm duyệt hoặc bị vô hiệu hóa
+	if err := r.db.Model(&models.User{}).Where("is_hidden = ? AND is_active = ?", false, true).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	offset := (page - 1) * pageSize
-	if err := r.db.Preload("Country").Preload("Province").Preload("District").Offset(offset).Limit(pageSize).Find(&users).Error; err != nil {
+	if err := r.db.Preload("Country").Preload("Province").Preload("District").Where("is_hidden = ? AND is_active = ?", false, true).
+		Offset(offset).Limit(pageSize).Find(&users).Error; err != nil {
 		return nil, 0, err
 	}
 
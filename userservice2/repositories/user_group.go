@@ -2,21 +2,59 @@ package repositories
 
 import (
 	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
 	"github.com/jinzhu/gorm"
 	"userservice2/models"
 	"userservice2/utils"
 )
 
+// Các cách sắp xếp khi tìm kiếm nhóm
+const (
+	GroupSortRelevance = "relevance"
+	GroupSortMembers   = "members"
+	GroupSortActivity  = "activity"
+	GroupSortNewest    = "newest"
+)
+
+// GroupFilter là điều kiện lọc và sắp xếp khi tìm kiếm nhóm
+type GroupFilter struct {
+	Query    string // Tìm toàn văn theo tên và mô tả
+	Privacy  models.GroupPrivacy
+	Category models.GroupCategory
+	Tag      string
+	Sort     string
+}
+
+// GroupSignal là số bạn bè của người dùng đang là thành viên một nhóm
+type GroupSignal struct {
+	GroupID int64
+	Count   int
+}
+
+// GroupLocationSignal là số thành viên của một nhóm sống cùng tỉnh/thành và cùng quận/huyện với người dùng
+type GroupLocationSignal struct {
+	GroupID       int64
+	ProvinceCount int
+	DistrictCount int
+}
+
 // UserGroupRepository đại diện cho tầng truy cập dữ liệu nhóm
 type UserGroupRepository interface {
 	Create(ctx context.Context, group *models.UserGroup) error
 	FindByID(ctx context.Context, id int64) (*models.UserGroup, error)
 	Update(ctx context.Context, group *models.UserGroup) error
 	Delete(ctx context.Context, id int64) error
-	List(ctx context.Context, page, pageSize int) ([]models.UserGroup, int64, error)
+	FindByIDs(ctx context.Context, ids []int64) ([]models.UserGroup, error)
+	List(ctx context.Context, filter GroupFilter, page, pageSize int) ([]models.UserGroup, int64, error)
 	ListUserGroups(ctx context.Context, userID int64, page, pageSize int) ([]models.UserGroup, int64, error)
 	IncrementMemberCount(ctx context.Context, groupID int64) error
 	DecrementMemberCount(ctx context.Context, groupID int64) error
+	CountFriendMembers(ctx context.Context, userID int64, limit int) ([]GroupSignal, error)
+	CountNearbyMembers(ctx context.Context, userID int64, provinceID, districtID, limit int) ([]GroupLocationSignal, error)
+	ListPopularFor(ctx context.Context, userID int64, limit int) ([]models.UserGroup, error)
 }
 
 // userGroupRepository triển khai UserGroupRepository
@@ -47,31 +85,148 @@ func (r *userGroupRepository) FindByID(ctx context.Context, id int64) (*models.U
 	return &group, nil
 }
 
+// FindByIDs lấy các nhóm theo danh sách ID, không đảm bảo thứ tự
+func (r *userGroupRepository) FindByIDs(ctx context.Context, ids []int64) ([]models.UserGroup, error) {
+	var groups []models.UserGroup
+	if len(ids) == 0 {
+		return groups, nil
+	}
+	err := r.db.Preload("Creator").Where("id IN (?)", ids).Find(&groups).Error
+	return groups, err
+}
+
 // Update cập nhật thông tin nhóm
 func (r *userGroupRepository) Update(ctx context.Context, group *models.UserGroup) error {
 	return r.db.Save(group).Error
 }
 
-// Delete xóa nhóm
+// Delete xóa nhóm cùng mọi dữ liệu thuộc nhóm trong một transaction
 func (r *userGroupRepository) Delete(ctx context.Context, id int64) error {
-	return r.db.Delete(&models.UserGroup{ID: id}).Error
+	tx := r.db.Begin()
+	if err := deleteGroupCascade(tx, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// deleteGroupCascade xóa nhóm cùng thành viên, chức vụ, yêu cầu tham gia, lời mời, nội quy, thông báo,
+// sự kiện, phản hồi sự kiện và các lượt tắt tiếng nhóm trong transaction tx. Dùng chung cho chủ nhóm và quản trị viên xóa nhóm.
+func deleteGroupCascade(tx *gorm.DB, groupID int64) error {
+	memberIDs := tx.Model(&models.GroupMember{}).Select("id").Where("group_id = ?", groupID).SubQuery()
+	if err := tx.Where("group_member_id IN ?", memberIDs).Delete(&models.GroupMemberRole{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("group_id = ?", groupID).Delete(&models.GroupRole{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("group_id = ?", groupID).Delete(&models.GroupJoinRequest{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("group_id = ?", groupID).Delete(&models.GroupInvitation{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("group_id = ?", groupID).Delete(&models.GroupOwnershipTransfer{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("group_id = ?", groupID).Delete(&models.GroupBan{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("group_id = ?", groupID).Delete(&models.GroupModerationLog{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("group_id = ?", groupID).Delete(&models.GroupRule{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("group_id = ?", groupID).Delete(&models.GroupAnnouncement{}).Error; err != nil {
+		return err
+	}
+	eventIDs := tx.Model(&models.Event{}).Select("id").Where("group_id = ?", groupID).SubQuery()
+	if err := tx.Where("event_id IN ?", eventIDs).Delete(&models.EventRSVP{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("group_id = ?", groupID).Delete(&models.Event{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("target_type = ? AND target_id = ?", models.MuteTargetGroup, groupID).Delete(&models.UserMute{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("group_id = ?", groupID).Delete(&models.GroupMember{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("id = ?", groupID).Delete(&models.UserGroup{}).Error
 }
 
-// List lấy danh sách tất cả nhóm
-func (r *userGroupRepository) List(ctx context.Context, page, pageSize int) ([]models.UserGroup, int64, error) {
+// fullTextTerms chuyển chuỗi tìm kiếm thành biểu thức cho MATCH ... IN BOOLEAN MODE: mọi từ đều bắt buộc và
+// khớp theo tiền tố. Các từ ngắn hơn độ dài tối thiểu của chỉ mục FULLTEXT bị bỏ qua, trả về chuỗi rỗng
+// nếu không còn từ nào.
+func fullTextTerms(query string) string {
+	const minTokenLength = 3 // innodb_ft_min_token_size mặc định
+
+	var terms []string
+	for _, word := range strings.Fields(query) {
+		word = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(`+-<>()~*"@'`, r) {
+				return -1
+			}
+			return r
+		}, word)
+		if utf8.RuneCountInString(word) < minTokenLength {
+			continue
+		}
+		terms = append(terms, "+"+word+"*")
+	}
+	return strings.Join(terms, " ")
+}
+
+// List tìm kiếm nhóm theo filter, không bao gồm nhóm bí mật. Từ khóa được tìm toàn văn trên tên và mô tả,
+// nếu mọi từ đều quá ngắn cho chỉ mục FULLTEXT thì tìm theo chuỗi con.
+func (r *userGroupRepository) List(ctx context.Context, filter GroupFilter, page, pageSize int) ([]models.UserGroup, int64, error) {
 	var groups []models.UserGroup
 	var total int64
 
 	offset, limit := utils.Pagination(page, pageSize)
+	query := r.db.Model(&models.UserGroup{}).Where("privacy <> ?", models.GroupPrivacySecret)
+	if filter.Privacy != "" {
+		query = query.Where("privacy = ?", filter.Privacy)
+	}
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.Tag != "" {
+		query = query.Where("JSON_CONTAINS(tags, JSON_QUOTE(?))", filter.Tag)
+	}
+
+	var terms string
+	if keyword := strings.TrimSpace(filter.Query); keyword != "" {
+		terms = fullTextTerms(keyword)
+		if terms != "" {
+			query = query.Where("MATCH(name, description) AGAINST (? IN BOOLEAN MODE)", terms)
+		} else {
+			like := "%" + keyword + "%"
+			query = query.Where("name LIKE ? OR description LIKE ?", like, like)
+		}
+	}
 
 	// Đếm tổng số record
-	err := r.db.Model(&models.UserGroup{}).Count(&total).Error
+	err := query.Count(&total).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
+	switch {
+	case filter.Sort == GroupSortNewest:
+		query = query.Order("created_at DESC")
+	case filter.Sort == GroupSortActivity:
+		query = query.Order("COALESCE(last_activity_at, created_at) DESC")
+	case filter.Sort == GroupSortRelevance && terms != "":
+		query = query.Order(gorm.Expr("MATCH(name, description) AGAINST (? IN BOOLEAN MODE) DESC", terms))
+	default:
+		query = query.Order("member_count DESC")
+	}
+
 	// Lấy danh sách có phân trang
-	err = r.db.Offset(offset).Limit(limit).Find(&groups).Error
+	err = query.Order("id DESC").Offset(offset).Limit(limit).Find(&groups).Error
 	if err != nil {
 		return nil, 0, err
 	}
@@ -108,11 +263,14 @@ func (r *userGroupRepository) ListUserGroups(ctx context.Context, userID int64,
 	return groups, total, nil
 }
 
-// IncrementMemberCount tăng số lượng thành viên của nhóm lên 1
+// IncrementMemberCount tăng số lượng thành viên của nhóm lên 1 và ghi nhận hoạt động mới của nhóm
 func (r *userGroupRepository) IncrementMemberCount(ctx context.Context, groupID int64) error {
 	return r.db.Model(&models.UserGroup{}).
 		Where("id = ?", groupID).
-		UpdateColumn("member_count", gorm.Expr("member_count + ?", 1)).
+		UpdateColumns(map[string]interface{}{
+			"member_count":     gorm.Expr("member_count + ?", 1),
+			"last_activity_at": time.Now(),
+		}).
 		Error
 }
 
@@ -123,3 +281,80 @@ func (r *userGroupRepository) DecrementMemberCount(ctx context.Context, groupID
 		UpdateColumn("member_count", gorm.Expr("member_count - ?", 1)).
 		Error
 }
+
+// recommendableGroupCondition giới hạn các nhóm có thể gợi ý cho một người dùng: không phải nhóm bí mật và người
+// dùng chưa là thành viên, không bị cấm, không có yêu cầu tham gia đang chờ. Dùng kèm recommendableGroupArgs.
+const recommendableGroupCondition = `g.privacy <> ?
+	AND NOT EXISTS (SELECT 1 FROM group_members me WHERE me.group_id = g.id AND me.user_id = ?)
+	AND NOT EXISTS (SELECT 1 FROM group_bans b WHERE b.group_id = g.id AND b.user_id = ?)
+	AND NOT EXISTS (SELECT 1 FROM group_join_requests jr WHERE jr.group_id = g.id AND jr.user_id = ? AND jr.status = ?)`
+
+// recommendableGroupArgs là tham số cho recommendableGroupCondition
+func recommendableGroupArgs(userID int64) []interface{} {
+	return []interface{}{models.GroupPrivacySecret, userID, userID, userID, models.JoinRequestStatusPending}
+}
+
+// CountFriendMembers đếm số bạn bè của userID trong từng nhóm có thể gợi ý, nhiều bạn bè xếp trước
+func (r *userGroupRepository) CountFriendMembers(ctx context.Context, userID int64, limit int) ([]GroupSignal, error) {
+	args := []interface{}{models.GroupMemberStatusApproved,
+		userID, models.FriendshipStatusAccepted, userID, models.FriendshipStatusAccepted}
+	args = append(args, recommendableGroupArgs(userID)...)
+	args = append(args, limit)
+
+	var signals []GroupSignal
+	err := r.db.Raw(`
+		SELECT g.id AS group_id, COUNT(*) AS count
+		FROM user_groups g
+		INNER JOIN group_members gm ON gm.group_id = g.id AND gm.status = ? AND gm.left_at IS NULL
+		INNER JOIN
+		(
+			SELECT friend_id AS friend FROM friendships WHERE user_id = ? AND status = ?
+			UNION
+			SELECT user_id FROM friendships WHERE friend_id = ? AND status = ?
+		) mine ON mine.friend = gm.user_id
+		WHERE `+recommendableGroupCondition+`
+		GROUP BY g.id
+		ORDER BY count DESC
+		LIMIT ?
+	`, args...).Scan(&signals).Error
+	return signals, err
+}
+
+// CountNearbyMembers đếm số thành viên đang hoạt động sống cùng tỉnh/thành (và cùng quận/huyện) với userID trong
+// từng nhóm có thể gợi ý, nhiều thành viên ở gần xếp trước
+func (r *userGroupRepository) CountNearbyMembers(ctx context.Context, userID int64, provinceID, districtID, limit int) ([]GroupLocationSignal, error) {
+	var signals []GroupLocationSignal
+	if provinceID == 0 {
+		return signals, nil
+	}
+
+	args := []interface{}{districtID, districtID, models.GroupMemberStatusApproved, provinceID, true}
+	args = append(args, recommendableGroupArgs(userID)...)
+	args = append(args, limit)
+
+	err := r.db.Raw(`
+		SELECT g.id AS group_id, COUNT(*) AS province_count,
+			SUM(CASE WHEN ? <> 0 AND u.district_id = ? THEN 1 ELSE 0 END) AS district_count
+		FROM user_groups g
+		INNER JOIN group_members gm ON gm.group_id = g.id AND gm.status = ? AND gm.left_at IS NULL
+		INNER JOIN users u ON u.id = gm.user_id AND u.province_id = ? AND u.is_active = ?
+		WHERE `+recommendableGroupCondition+`
+		GROUP BY g.id
+		ORDER BY district_count DESC, province_count DESC
+		LIMIT ?
+	`, args...).Scan(&signals).Error
+	return signals, err
+}
+
+// ListPopularFor lấy các nhóm có thể gợi ý cho userID, đông thành viên nhất trước
+func (r *userGroupRepository) ListPopularFor(ctx context.Context, userID int64, limit int) ([]models.UserGroup, error) {
+	var groups []models.UserGroup
+	err := r.db.Preload("Creator").
+		Table("user_groups g").
+		Select("g.*").
+		Where(recommendableGroupCondition, recommendableGroupArgs(userID)...).
+		Order("g.member_count DESC").
+		Limit(limit).
+		Find(&groups).Error
+	return groups, err
+}
@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"userservice2/models"
+)
+
+// UserReportRepository đại diện cho tầng truy cập dữ liệu báo cáo và kiểm duyệt người dùng
+type UserReportRepository interface {
+	Create(ctx context.Context, report *models.UserReport) error
+	Exists(ctx context.Context, reportedUserID, reporterID int64) (bool, error)
+	FindByID(ctx context.Context, id int64) (*models.UserReport, error)
+	List(ctx context.Context, status models.ReportStatus, page, pageSize int) ([]models.UserReport, int64, error)
+	ListByUser(ctx context.Context, reportedUserID int64) ([]models.UserReport, error)
+	CountOpenByUser(ctx context.Context, reportedUserID int64) (int, error)
+	ApplyDecision(ctx context.Context, action *models.UserModerationAction, status models.ReportStatus) error
+	ListActions(ctx context.Context, userID, moderatorID int64, page, pageSize int) ([]models.UserModerationAction, int64, error)
+}
+
+// userReportRepository triển khai UserReportRepository
+type userReportRepository struct {
+	db *gorm.DB
+}
+
+// NewUserReportRepository tạo instance mới của UserReportRepository
+func NewUserReportRepository(db *gorm.DB) UserReportRepository {
+	return &userReportRepository{db: db}
+}
+
+// Create tạo báo cáo mới
+func (r *userReportRepository) Create(ctx context.Context, report *models.UserReport) error {
+	return r.db.Create(report).Error
+}
+
+// Exists kiểm tra người báo cáo đã báo cáo người dùng này chưa
+func (r *userReportRepository) Exists(ctx context.Context, reportedUserID, reporterID int64) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.UserReport{}).
+		Where("reported_user_id = ? AND reporter_id = ?", reportedUserID, reporterID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// FindByID tìm báo cáo theo ID
+func (r *userReportRepository) FindByID(ctx context.Context, id int64) (*models.UserReport, error) {
+	var report models.UserReport
+	if err := r.db.Where("id = ?", id).First(&report).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &report, nil
+}
+
+// List lấy hàng đợi báo cáo theo trạng thái, báo cáo cũ nhất xếp trước
+func (r *userReportRepository) List(ctx context.Context, status models.ReportStatus, page, pageSize int) ([]models.UserReport, int64, error) {
+	var reports []models.UserReport
+	var total int64
+
+	query := r.db.Model(&models.UserReport{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at ASC").Offset(offset).Limit(pageSize).Find(&reports).Error; err != nil {
+		return nil, 0, err
+	}
+	return reports, total, nil
+}
+
+// ListByUser lấy tất cả báo cáo về một người dùng
+func (r *userReportRepository) ListByUser(ctx context.Context, reportedUserID int64) ([]models.UserReport, error) {
+	var reports []models.UserReport
+	if err := r.db.Where("reported_user_id = ?", reportedUserID).Order("created_at ASC").Find(&reports).Error; err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// CountOpenByUser đếm số người khác nhau đang báo cáo một người dùng
+func (r *userReportRepository) CountOpenByUser(ctx context.Context, reportedUserID int64) (int, error) {
+	var count int
+	if err := r.db.Model(&models.UserReport{}).
+		Where("reported_user_id = ? AND status = ?", reportedUserID, models.ReportStatusOpen).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ApplyDecision ẩn/hiện người dùng theo hành động kiểm duyệt, chuyển các báo cáo đang mở sang
+// status (bỏ qua nếu rỗng) và ghi lại hành động, tất cả trong một transaction
+func (r *userReportRepository) ApplyDecision(ctx context.Context, action *models.UserModerationAction, status models.ReportStatus) error {
+	hidden := action.Action != models.ModerationActionDismiss
+
+	tx := r.db.Begin()
+	if err := tx.Model(&models.User{}).Where("id = ?", action.UserID).
+		Update("is_hidden", hidden).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if status != "" {
+		now := time.Now()
+		result := tx.Model(&models.UserReport{}).
+			Where("reported_user_id = ? AND status = ?", action.UserID, models.ReportStatusOpen).
+			Updates(map[string]interface{}{
+				"status":      status,
+				"resolved_by": action.ModeratorID,
+				"resolved_at": now,
+				"updated_at":  now,
+			})
+		if result.Error != nil {
+			tx.Rollback()
+			return result.Error
+		}
+		action.ReportCount = int(result.RowsAffected)
+	}
+
+	if err := tx.Create(action).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// ListActions lấy nhật ký kiểm duyệt, lọc theo người dùng hoặc moderator nếu có, mới nhất trước
+func (r *userReportRepository) ListActions(ctx context.Context, userID, moderatorID int64, page, pageSize int) ([]models.UserModerationAction, int64, error) {
+	var actions []models.UserModerationAction
+	var total int64
+
+	query := r.db.Model(&models.UserModerationAction{})
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if moderatorID != 0 {
+		query = query.Where("moderator_id = ?", moderatorID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&actions).Error; err != nil {
+		return nil, 0, err
+	}
+	return actions, total, nil
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/gin-gonic/gin"
+	"shared/ratelimit"
 	"userservice2/controllers"
 	"userservice2/middlewares"
 )
@@ -12,6 +13,14 @@ func SetupRoutes(
 	userController *controllers.UserController,
 	friendshipController *controllers.FriendshipController,
 	groupController *controllers.GroupController,
+	reportController *controllers.ReportController,
+	adminController *controllers.AdminController,
+	muteController *controllers.MuteController,
+	followController *controllers.FollowController,
+	friendListController *controllers.FriendListController,
+	suggestionController *controllers.FriendSuggestionController,
+	eventController *controllers.EventController,
+	limiter *ratelimit.Limiter,
 ) {
 	// Middleware global
 	router.Use(gin.Logger())
@@ -26,6 +35,8 @@ func SetupRoutes(
 		// Các route không yêu cầu xác thực
 		userRoutes.GET("", userController.GetUsers)
 		userRoutes.GET("/:username", userController.GetUser)
+		userRoutes.GET("/:username/followers", followController.GetFollowers)
+		userRoutes.GET("/:username/following", followController.GetFollowing)
 
 		// Các route yêu cầu xác thực
 		protectedRoutes := userRoutes.Group("")
@@ -35,6 +46,19 @@ func SetupRoutes(
 			protectedRoutes.PUT("/me", userController.UpdateProfile)
 			protectedRoutes.PUT("/me/profile-picture", userController.UploadProfilePicture)
 			protectedRoutes.PUT("/me/cover-picture", userController.UploadCoverPicture)
+			protectedRoutes.POST("/:username/follow", middlewares.RateLimit(limiter, middlewares.RateLimitFriendAction), followController.Follow)
+			protectedRoutes.DELETE("/:username/follow", middlewares.RateLimit(limiter, middlewares.RateLimitFriendAction), followController.Unfollow)
+			protectedRoutes.POST("/:username/report", middlewares.RateLimit(limiter, middlewares.RateLimitUserReport), reportController.ReportUser)
+		}
+
+		// Hàng đợi kiểm duyệt báo cáo người dùng, chỉ dành cho moderator/admin
+		moderationRoutes := userRoutes.Group("/moderation")
+		moderationRoutes.Use(middlewares.JWTMiddleware(), middlewares.RequireRole(middlewares.RoleModerator, middlewares.RoleAdmin))
+		{
+			moderationRoutes.GET("/reports", reportController.ListReports)
+			moderationRoutes.GET("/reports/:id", reportController.GetReport)
+			moderationRoutes.POST("/reports/:id/decision", reportController.DecideReport)
+			moderationRoutes.GET("/actions", reportController.ListModerationActions)
 		}
 	}
 
@@ -47,7 +71,8 @@ func SetupRoutes(
 			// Lấy danh sách bạn bè và lời mời kết bạn
 			friendshipRoutes.GET("", friendshipController.GetFriends)
 			friendshipRoutes.GET("/requests", friendshipController.GetFriendRequests)
-			friendshipRoutes.GET("/suggestions", friendshipController.GetFriendSuggestions)
+			friendshipRoutes.GET("/suggestions", suggestionController.GetSuggestions)
+			friendshipRoutes.DELETE("/suggestions/:user_id", suggestionController.DismissSuggestion)
 
 			// Lấy danh sách bạn bè của người dùng khác
 			friendshipRoutes.GET("/user/:username", friendshipController.GetUserFriends)
@@ -55,8 +80,12 @@ func SetupRoutes(
 			// Lấy số lượng bạn chung
 			friendshipRoutes.GET("/mutual/:username", friendshipController.GetMutualFriends)
 
+			// Danh sách bạn chung và số bạn chung với nhiều người dùng cùng lúc
+			friendshipRoutes.GET("/mutual/:username/list", friendshipController.GetMutualFriendsList)
+			friendshipRoutes.GET("/mutual-counts", friendshipController.GetMutualFriendsCounts)
+
 			// API đa năng xử lý các hành động bạn bè theo action
-			friendshipRoutes.POST("/:action", friendshipController.FriendshipActionHandler)
+			friendshipRoutes.POST("/:action", middlewares.RateLimit(limiter, middlewares.RateLimitFriendAction), friendshipController.FriendshipActionHandler)
 
 			// Lấy trạng thái bạn bè với một người dùng
 			friendshipRoutes.GET("/status/:username", friendshipController.GetFriendshipStatus)
@@ -65,34 +94,138 @@ func SetupRoutes(
 
 	// Group routes
 	groupRoutes := router.Group("/groups")
+	// Đọc người dùng từ token nếu có, kể cả ở các route công khai, để nhận diện thành viên nhóm riêng tư và bí mật
+	groupRoutes.Use(middlewares.JWTMiddleware())
 	{
-		// Các route không yêu cầu xác thực (chỉ cho nhóm public)
+		// Các route không yêu cầu xác thực (nhóm bí mật chỉ hiện với thành viên)
 		groupRoutes.GET("", groupController.ListGroups)
 		groupRoutes.GET("/:id", groupController.GetGroup)
 		groupRoutes.GET("/:id/members", groupController.GetGroupMembers)
+		groupRoutes.GET("/:id/roles", groupController.GetGroupRoles)
+		groupRoutes.GET("/:id/questions", groupController.GetMembershipQuestions)
+		groupRoutes.GET("/:id/rules", groupController.ListGroupRules)
+		groupRoutes.GET("/:id/announcements", groupController.ListGroupAnnouncements)
+		groupRoutes.GET("/:id/events", eventController.ListGroupEvents)
+		groupRoutes.GET("/:id/events/ics", eventController.ExportGroupICS)
 
 		// Các route yêu cầu xác thực
 		protectedGroupRoutes := groupRoutes.Group("")
-		protectedGroupRoutes.Use(middlewares.JWTMiddleware())
 		{
 			// Quản lý nhóm
-			protectedGroupRoutes.POST("", groupController.CreateGroup)
+			protectedGroupRoutes.POST("", middlewares.RateLimit(limiter, middlewares.RateLimitGroupCreate), groupController.CreateGroup)
 			protectedGroupRoutes.PUT("/:id", groupController.UpdateGroup)
 			protectedGroupRoutes.DELETE("/:id", groupController.DeleteGroup)
 			protectedGroupRoutes.GET("/me", groupController.ListMyGroups)
+			protectedGroupRoutes.GET("/recommended", groupController.RecommendGroups)
+
+			// Chuyển quyền sở hữu nhóm, người nhận phải xác nhận
+			protectedGroupRoutes.GET("/:id/ownership-transfer", groupController.GetOwnershipTransfer)
+			protectedGroupRoutes.POST("/:id/ownership-transfer", groupController.RequestOwnershipTransfer)
+			protectedGroupRoutes.DELETE("/:id/ownership-transfer", groupController.CancelOwnershipTransfer)
+			protectedGroupRoutes.POST("/:id/ownership-transfer/:action", groupController.RespondOwnershipTransfer) // action = accept/decline
 
 			// Tham gia/rời nhóm
-			protectedGroupRoutes.POST("/join", groupController.JoinGroup)
+			protectedGroupRoutes.POST("/join", middlewares.RateLimit(limiter, middlewares.RateLimitGroupJoin), groupController.JoinGroup)
 			protectedGroupRoutes.POST("/:id/leave", groupController.LeaveGroup)
+			protectedGroupRoutes.GET("/:id/join-request", groupController.GetMyJoinRequest)
+			protectedGroupRoutes.DELETE("/:id/join-request", groupController.WithdrawJoinRequest)
+
+			// Lời mời vào nhóm gửi tới người dùng hiện tại
+			protectedGroupRoutes.GET("/invitations", groupController.ListMyInvitations)
+			protectedGroupRoutes.POST("/invitations/:invitation_id/:action", groupController.RespondInvitation) // action = accept/decline
 
 			// Quản lý thành viên
 			protectedGroupRoutes.POST("/:id/invite", groupController.InviteMember)
 			protectedGroupRoutes.POST("/:id/members/:action", groupController.HandleMemberRequest) // action = approve/reject
 			protectedGroupRoutes.DELETE("/:id/members", groupController.RemoveMember)
 			protectedGroupRoutes.PUT("/:id/members/:member_id", groupController.UpdateMember)
+
+			// Cấm, tắt tiếng thành viên và nhật ký kiểm duyệt
+			protectedGroupRoutes.GET("/:id/bans", groupController.ListBans)
+			protectedGroupRoutes.POST("/:id/bans", groupController.BanMember)
+			protectedGroupRoutes.DELETE("/:id/bans/:user_id", groupController.UnbanMember)
+			protectedGroupRoutes.PUT("/:id/members/:member_id/mute", groupController.MuteMember)
+			protectedGroupRoutes.DELETE("/:id/members/:member_id/mute", groupController.UnmuteMember)
+			protectedGroupRoutes.GET("/:id/moderation-log", groupController.ListModerationLog)
+
+			// Duyệt yêu cầu tham gia nhóm riêng tư
+			protectedGroupRoutes.PUT("/:id/questions", groupController.UpdateMembershipQuestions)
+			protectedGroupRoutes.GET("/:id/join-requests", groupController.ListJoinRequests)
+			protectedGroupRoutes.POST("/:id/join-requests/:action", groupController.HandleJoinRequests) // action = approve/reject
+
+			// Vai trò tùy chỉnh và phân quyền trong nhóm
+			protectedGroupRoutes.POST("/:id/roles", groupController.CreateGroupRole)
+			protectedGroupRoutes.PUT("/:id/roles/:role_id", groupController.UpdateGroupRole)
+			protectedGroupRoutes.DELETE("/:id/roles/:role_id", groupController.DeleteGroupRole)
+			protectedGroupRoutes.PUT("/:id/members/:member_id/roles/:role_id", groupController.AssignRoleToMember)
+			protectedGroupRoutes.DELETE("/:id/members/:member_id/roles/:role_id", groupController.RemoveRoleFromMember)
+
+			// Nội quy nhóm (cần quyền manage_rules) và đồng ý nội quy
+			protectedGroupRoutes.POST("/:id/rules", groupController.CreateGroupRule)
+			protectedGroupRoutes.PUT("/:id/rules/order", groupController.ReorderGroupRules)
+			protectedGroupRoutes.PUT("/:id/rules/:rule_id", groupController.UpdateGroupRule)
+			protectedGroupRoutes.DELETE("/:id/rules/:rule_id", groupController.DeleteGroupRule)
+			protectedGroupRoutes.POST("/:id/rules/accept", groupController.AcceptGroupRules)
+
+			// Thông báo ghim (cần quyền manage_announcements)
+			protectedGroupRoutes.POST("/:id/announcements", groupController.CreateGroupAnnouncement)
+			protectedGroupRoutes.PUT("/:id/announcements/:announcement_id", groupController.UpdateGroupAnnouncement)
+			protectedGroupRoutes.DELETE("/:id/announcements/:announcement_id", groupController.DeleteGroupAnnouncement)
+
+			// Sự kiện của nhóm
+			protectedGroupRoutes.POST("/:id/events", eventController.CreateGroupEvent)
 		}
 	}
 
+	// Sự kiện nhóm và sự kiện cá nhân
+	eventRoutes := router.Group("/events")
+	// Xem sự kiện của nhóm công khai không cần đăng nhập
+	eventRoutes.Use(middlewares.JWTMiddleware())
+	{
+		eventRoutes.POST("", eventController.CreateUserEvent)
+		eventRoutes.GET("/me", eventController.ListMyEvents)
+		eventRoutes.GET("/:id", eventController.GetEvent)
+		eventRoutes.PUT("/:id", eventController.UpdateEvent)
+		eventRoutes.DELETE("/:id", eventController.DeleteEvent)
+		eventRoutes.PUT("/:id/rsvp", eventController.RespondToEvent)
+		eventRoutes.DELETE("/:id/rsvp", eventController.CancelRSVP)
+		eventRoutes.GET("/:id/attendees", eventController.ListAttendees)
+		eventRoutes.GET("/:id/ics", eventController.ExportEventICS)
+	}
+
+	// Danh sách bạn bè dùng làm người xem cho bài đăng CUSTOM
+	friendListRoutes := router.Group("/friend-lists")
+	friendListRoutes.Use(middlewares.JWTMiddleware())
+	{
+		friendListRoutes.GET("", friendListController.GetLists)
+		friendListRoutes.POST("", friendListController.CreateList)
+		friendListRoutes.GET("/:id", friendListController.GetList)
+		friendListRoutes.PUT("/:id", friendListController.RenameList)
+		friendListRoutes.DELETE("/:id", friendListController.DeleteList)
+		friendListRoutes.POST("/:id/members", friendListController.AddMembers)
+		friendListRoutes.DELETE("/:id/members/:user_id", friendListController.RemoveMember)
+	}
+
+	// Tắt tiếng/tạm ẩn người dùng và nhóm khỏi feed
+	muteRoutes := router.Group("/mutes")
+	muteRoutes.Use(middlewares.JWTMiddleware())
+	{
+		muteRoutes.GET("", muteController.ListMutes)
+		muteRoutes.POST("", muteController.Mute)
+		muteRoutes.DELETE("/:target_type/:target_id", muteController.Unmute)
+	}
+
+	// API quản trị người dùng và nhóm, chỉ dành cho admin
+	adminRoutes := router.Group("/admin")
+	adminRoutes.Use(middlewares.JWTMiddleware(), middlewares.RequireRole(middlewares.RoleAdmin))
+	{
+		adminRoutes.PUT("/users/:id/status", adminController.SetUserStatus)
+		adminRoutes.PUT("/users/:id/verification", adminController.SetUserVerification)
+		adminRoutes.DELETE("/groups/:id", adminController.DeleteGroup)
+		adminRoutes.POST("/groups/:id/transfer", adminController.TransferGroup)
+		adminRoutes.GET("/audit-logs", adminController.ListAuditLogs)
+	}
+
 	// Healthcheck
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "UP"})
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"userservice2/dto/request"
+	"userservice2/dto/response"
+	"userservice2/models"
+	"userservice2/repositories"
+)
+
+// Khai báo lỗi
+var (
+	ErrGroupNotFound        = errors.New("nhóm không tồn tại")
+	ErrCannotDeactivateSelf = errors.New("không thể tự vô hiệu hóa tài khoản của chính mình")
+	ErrAlreadyGroupOwner    = errors.New("người dùng đã là chủ sở hữu nhóm")
+)
+
+// AdminService xử lý các thao tác quản trị người dùng và nhóm. Mọi thao tác đều được ghi
+// vào nhật ký quản trị.
+type AdminService interface {
+	SetUserActive(ctx context.Context, adminID, userID int64, req *request.AdminUserStatusRequest) (*models.AdminAuditLog, error)
+	SetUserVerified(ctx context.Context, adminID, userID int64, req *request.AdminUserVerificationRequest) (*models.AdminAuditLog, error)
+	DeleteGroup(ctx context.Context, adminID, groupID int64, req *request.AdminNoteRequest) (*models.AdminAuditLog, error)
+	TransferGroup(ctx context.Context, adminID, groupID int64, req *request.AdminGroupTransferRequest) (*models.AdminAuditLog, error)
+	ListAuditLogs(ctx context.Context, req *request.AdminAuditLogListRequest) (*response.AdminAuditLogListResponse, error)
+}
+
+// adminService triển khai AdminService
+type adminService struct {
+	adminRepo repositories.AdminRepository
+	userRepo  repositories.UserRepository
+	groupRepo repositories.UserGroupRepository
+}
+
+// NewAdminService tạo instance mới của AdminService
+func NewAdminService(
+	adminRepo repositories.AdminRepository,
+	userRepo repositories.UserRepository,
+	groupRepo repositories.UserGroupRepository,
+) AdminService {
+	return &adminService{
+		adminRepo: adminRepo,
+		userRepo:  userRepo,
+		groupRepo: groupRepo,
+	}
+}
+
+// SetUserActive kích hoạt hoặc vô hiệu hóa tài khoản người dùng
+func (s *adminService) SetUserActive(ctx context.Context, adminID, userID int64, req *request.AdminUserStatusRequest) (*models.AdminAuditLog, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	action := models.AdminActionUserActivate
+	if !*req.IsActive {
+		if userID == adminID {
+			return nil, ErrCannotDeactivateSelf
+		}
+		action = models.AdminActionUserDeactivate
+	}
+
+	entry := &models.AdminAuditLog{
+		AdminID:    adminID,
+		Action:     action,
+		TargetType: models.AdminTargetUser,
+		TargetID:   userID,
+		Note:       req.Note,
+	}
+	if err := s.adminRepo.SetUserActive(ctx, userID, *req.IsActive, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// SetUserVerified gắn hoặc gỡ huy hiệu xác minh của người dùng
+func (s *adminService) SetUserVerified(ctx context.Context, adminID, userID int64, req *request.AdminUserVerificationRequest) (*models.AdminAuditLog, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	action := models.AdminActionUserVerify
+	if !*req.IsVerified {
+		action = models.AdminActionUserUnverify
+	}
+
+	entry := &models.AdminAuditLog{
+		AdminID:    adminID,
+		Action:     action,
+		TargetType: models.AdminTargetUser,
+		TargetID:   userID,
+		Note:       req.Note,
+	}
+	if err := s.adminRepo.SetUserVerified(ctx, userID, *req.IsVerified, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// DeleteGroup xóa bất kỳ nhóm nào, tên và chủ sở hữu được lưu lại trong nhật ký
+func (s *adminService) DeleteGroup(ctx context.Context, adminID, groupID int64, req *request.AdminNoteRequest) (*models.AdminAuditLog, error) {
+	group, err := s.groupRepo.FindByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	entry := &models.AdminAuditLog{
+		AdminID:    adminID,
+		Action:     models.AdminActionGroupDelete,
+		TargetType: models.AdminTargetGroup,
+		TargetID:   groupID,
+		Details:    fmt.Sprintf("name=%q owner=%d members=%d", group.Name, group.OwnerID, group.MemberCount),
+		Note:       req.Note,
+	}
+	if err := s.adminRepo.DeleteGroup(ctx, groupID, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// TransferGroup chuyển quyền sở hữu nhóm cho người dùng khác
+func (s *adminService) TransferGroup(ctx context.Context, adminID, groupID int64, req *request.AdminGroupTransferRequest) (*models.AdminAuditLog, error) {
+	group, err := s.groupRepo.FindByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+	if group.OwnerID == req.NewOwnerID {
+		return nil, ErrAlreadyGroupOwner
+	}
+	if err := s.ensureUser(ctx, req.NewOwnerID); err != nil {
+		return nil, err
+	}
+
+	entry := &models.AdminAuditLog{
+		AdminID:    adminID,
+		Action:     models.AdminActionGroupTransfer,
+		TargetType: models.AdminTargetGroup,
+		TargetID:   groupID,
+		Details:    fmt.Sprintf("from=%d to=%d", group.OwnerID, req.NewOwnerID),
+		Note:       req.Note,
+	}
+	if err := s.adminRepo.TransferGroup(ctx, groupID, req.NewOwnerID, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ListAuditLogs lấy nhật ký quản trị
+func (s *adminService) ListAuditLogs(ctx context.Context, req *request.AdminAuditLogListRequest) (*response.AdminAuditLogListResponse, error) {
+	logs, total, err := s.adminRepo.ListAuditLogs(ctx, req.AdminID, models.AdminTargetType(req.TargetType), req.TargetID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.AdminAuditLogListResponse{
+		Logs:  logs,
+		Total: total,
+		Page:  req.Page,
+		Size:  req.PageSize,
+	}, nil
+}
+
+// ensureUser kiểm tra người dùng tồn tại
+func (s *adminService) ensureUser(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	return nil
+}
@@ -0,0 +1,495 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"userservice2/dto/request"
+	"userservice2/dto/response"
+	"userservice2/models"
+	"userservice2/repositories"
+	"userservice2/utils"
+)
+
+// Khai báo lỗi sự kiện
+var (
+	ErrEventNotFound         = errors.New("sự kiện không tồn tại")
+	ErrEventPermissionDenied = errors.New("bạn không có quyền chỉnh sửa sự kiện này")
+	ErrInvalidTimezone       = errors.New("múi giờ không hợp lệ")
+	ErrInvalidEventTime      = errors.New("thời điểm kết thúc phải sau thời điểm bắt đầu")
+	ErrEventEnded            = errors.New("sự kiện đã kết thúc")
+	ErrRSVPNotFound          = errors.New("bạn chưa phản hồi sự kiện này")
+	ErrMutedInGroup          = errors.New("bạn đang bị tắt tiếng trong nhóm này")
+)
+
+const (
+	// groupCalendarLookback là khoảng thời gian trong quá khứ vẫn được đưa vào lịch .ics của nhóm
+	groupCalendarLookback = 90 * 24 * time.Hour
+	// groupCalendarLimit là số sự kiện tối đa trong lịch .ics của nhóm
+	groupCalendarLimit = 500
+)
+
+// EventService xử lý logic liên quan đến sự kiện nhóm và sự kiện cá nhân
+type EventService interface {
+	CreateGroupEvent(ctx context.Context, userID, groupID int64, req *request.EventCreateRequest) (*response.EventResponse, error)
+	CreateUserEvent(ctx context.Context, userID int64, req *request.EventCreateRequest) (*response.EventResponse, error)
+	GetEvent(ctx context.Context, userID, eventID int64) (*response.EventResponse, error)
+	UpdateEvent(ctx context.Context, userID, eventID int64, req *request.EventUpdateRequest) (*response.EventResponse, error)
+	DeleteEvent(ctx context.Context, userID, eventID int64) error
+	ListGroupEvents(ctx context.Context, userID, groupID int64, req *request.EventListRequest) (*response.EventListResponse, error)
+	ListMyEvents(ctx context.Context, userID int64, req *request.EventListRequest) (*response.EventListResponse, error)
+
+	// Phản hồi sự kiện
+	RespondToEvent(ctx context.Context, userID, eventID int64, req *request.EventRSVPRequest) (*response.EventResponse, error)
+	CancelRSVP(ctx context.Context, userID, eventID int64) error
+	ListAttendees(ctx context.Context, userID, eventID int64, req *request.EventAttendeeListRequest) (*response.EventAttendeeListResponse, error)
+
+	// Xuất lịch iCalendar
+	ExportEventICS(ctx context.Context, userID, eventID int64) ([]byte, error)
+	ExportGroupICS(ctx context.Context, userID, groupID int64) ([]byte, error)
+}
+
+// eventService triển khai EventService
+type eventService struct {
+	eventRepo      repositories.EventRepository
+	groupRepo      repositories.UserGroupRepository
+	memberRepo     repositories.GroupMemberRepository
+	friendshipRepo repositories.FriendshipRepository
+	moderationRepo repositories.GroupModerationRepository
+	groupService   GroupService
+}
+
+// NewEventService tạo instance mới của EventService
+func NewEventService(
+	eventRepo repositories.EventRepository,
+	groupRepo repositories.UserGroupRepository,
+	memberRepo repositories.GroupMemberRepository,
+	friendshipRepo repositories.FriendshipRepository,
+	moderationRepo repositories.GroupModerationRepository,
+	groupService GroupService,
+) EventService {
+	return &eventService{
+		eventRepo:      eventRepo,
+		groupRepo:      groupRepo,
+		memberRepo:     memberRepo,
+		friendshipRepo: friendshipRepo,
+		moderationRepo: moderationRepo,
+		groupService:   groupService,
+	}
+}
+
+// resolveTimezone trả về múi giờ mặc định nếu timezone rỗng, lỗi nếu timezone không phải tên IANA hợp lệ
+func resolveTimezone(timezone string) (string, error) {
+	if timezone == "" {
+		return models.DefaultEventTimezone, nil
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return "", ErrInvalidTimezone
+	}
+	return timezone, nil
+}
+
+// approvedMember trả về tư cách thành viên đã duyệt của userID trong nhóm, nil nếu không phải thành viên
+func (s *eventService) approvedMember(ctx context.Context, userID, groupID int64) (*models.GroupMember, error) {
+	if userID == 0 {
+		return nil, nil
+	}
+	member, err := s.memberRepo.FindByUserAndGroup(ctx, userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.Status != models.GroupMemberStatusApproved {
+		return nil, nil
+	}
+	return member, nil
+}
+
+// findGroupForEvents lấy nhóm và tư cách thành viên của userID khi xem lịch sự kiện của nhóm. Nhóm bí mật
+// trả về ErrGroupNotFound với người ngoài nhóm, nhóm riêng tư trả về ErrGroupPermissionDenied.
+func (s *eventService) findGroupForEvents(ctx context.Context, userID, groupID int64) (*models.UserGroup, *models.GroupMember, error) {
+	group, err := s.groupRepo.FindByID(ctx, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if group == nil {
+		return nil, nil, ErrGroupNotFound
+	}
+
+	member, err := s.approvedMember(ctx, userID, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if member == nil {
+		switch group.Privacy {
+		case models.GroupPrivacySecret:
+			return nil, nil, ErrGroupNotFound
+		case models.GroupPrivacyPrivate:
+			return nil, nil, ErrGroupPermissionDenied
+		}
+	}
+	return group, member, nil
+}
+
+// canViewEvent kiểm tra userID có được xem sự kiện không. Sự kiện của nhóm công khai ai cũng xem được, của
+// nhóm riêng tư/bí mật chỉ thành viên xem được. Sự kiện cá nhân chỉ người tạo và bạn bè của họ xem được.
+func (s *eventService) canViewEvent(ctx context.Context, userID int64, event *models.Event) (bool, error) {
+	if event.GroupID != nil {
+		if event.Group != nil && event.Group.Privacy == models.GroupPrivacyPublic {
+			return true, nil
+		}
+		member, err := s.approvedMember(ctx, userID, *event.GroupID)
+		if err != nil {
+			return false, err
+		}
+		return member != nil, nil
+	}
+
+	if userID == 0 {
+		return false, nil
+	}
+	if userID == event.CreatedBy {
+		return true, nil
+	}
+	friendship, err := s.friendshipRepo.FindByUserAndFriend(ctx, userID, event.CreatedBy)
+	if err != nil {
+		return false, err
+	}
+	return friendship != nil && friendship.Status == models.FriendshipStatusAccepted, nil
+}
+
+// findVisibleEvent lấy sự kiện mà userID được phép xem, trả về ErrEventNotFound nếu không tồn tại hoặc bị ẩn
+func (s *eventService) findVisibleEvent(ctx context.Context, userID, eventID int64) (*models.Event, error) {
+	event, err := s.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, ErrEventNotFound
+	}
+
+	visible, err := s.canViewEvent(ctx, userID, event)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, ErrEventNotFound
+	}
+	return event, nil
+}
+
+// findManageableEvent lấy sự kiện mà userID được phép chỉnh sửa: người tạo, hoặc người có quyền
+// manage_events trong nhóm tổ chức sự kiện
+func (s *eventService) findManageableEvent(ctx context.Context, userID, eventID int64) (*models.Event, error) {
+	event, err := s.findVisibleEvent(ctx, userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.CreatedBy == userID {
+		return event, nil
+	}
+	if event.GroupID == nil {
+		return nil, ErrEventPermissionDenied
+	}
+
+	allowed, err := s.groupService.HasGroupPermission(ctx, userID, *event.GroupID, models.GroupPermissionManageEvents)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, ErrEventPermissionDenied
+	}
+	return event, nil
+}
+
+// eventResponse tạo response cho sự kiện kèm phản hồi của userID
+func (s *eventService) eventResponse(ctx context.Context, userID int64, event *models.Event) (*response.EventResponse, error) {
+	statuses, err := s.eventRepo.FindRSVPStatuses(ctx, []int64{event.ID}, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp := response.ConvertToEventResponse(event, statuses[event.ID])
+	return &resp, nil
+}
+
+// eventListResponse tạo response cho một trang sự kiện kèm phản hồi của userID với từng sự kiện
+func (s *eventService) eventListResponse(ctx context.Context, userID int64, events []models.Event, total int64, req *request.EventListRequest) (*response.EventListResponse, error) {
+	eventIDs := make([]int64, len(events))
+	for i, event := range events {
+		eventIDs[i] = event.ID
+	}
+	statuses, err := s.eventRepo.FindRSVPStatuses(ctx, eventIDs, userID)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy phản hồi sự kiện: %v", err)
+	}
+
+	resp := &response.EventListResponse{
+		Events: make([]response.EventResponse, len(events)),
+		Total:  total,
+		Page:   req.Page,
+		Size:   req.PageSize,
+	}
+	for i := range events {
+		resp.Events[i] = response.ConvertToEventResponse(&events[i], statuses[events[i].ID])
+	}
+	return resp, nil
+}
+
+// createEvent kiểm tra thời gian, múi giờ và lưu sự kiện mới
+func (s *eventService) createEvent(ctx context.Context, userID int64, groupID *int64, req *request.EventCreateRequest) (*response.EventResponse, error) {
+	timezone, err := resolveTimezone(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, ErrInvalidEventTime
+	}
+
+	event := &models.Event{
+		GroupID:     groupID,
+		CreatedBy:   userID,
+		Title:       req.Title,
+		Description: req.Description,
+		StartsAt:    req.StartsAt.UTC(),
+		EndsAt:      req.EndsAt.UTC(),
+		Timezone:    timezone,
+		Location:    req.Location,
+		CoverImage:  req.CoverImage,
+	}
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		return nil, fmt.Errorf("lỗi khi tạo sự kiện: %v", err)
+	}
+
+	created, err := s.eventRepo.FindByID(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.eventResponse(ctx, userID, created)
+}
+
+// CreateGroupEvent tạo sự kiện cho nhóm, chỉ thành viên đã duyệt và không bị tắt tiếng mới tạo được
+func (s *eventService) CreateGroupEvent(ctx context.Context, userID, groupID int64, req *request.EventCreateRequest) (*response.EventResponse, error) {
+	_, member, err := s.findGroupForEvents(ctx, userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, ErrGroupPermissionDenied
+	}
+	if member.IsMuted && (member.MutedUntil == nil || member.MutedUntil.After(time.Now())) {
+		return nil, ErrMutedInGroup
+	}
+
+	return s.createEvent(ctx, userID, &groupID, req)
+}
+
+// CreateUserEvent tạo sự kiện cá nhân, chỉ người tạo và bạn bè của họ xem được
+func (s *eventService) CreateUserEvent(ctx context.Context, userID int64, req *request.EventCreateRequest) (*response.EventResponse, error) {
+	return s.createEvent(ctx, userID, nil, req)
+}
+
+// GetEvent lấy thông tin sự kiện
+func (s *eventService) GetEvent(ctx context.Context, userID, eventID int64) (*response.EventResponse, error) {
+	event, err := s.findVisibleEvent(ctx, userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return s.eventResponse(ctx, userID, event)
+}
+
+// UpdateEvent cập nhật sự kiện, cần là người tạo hoặc có quyền manage_events trong nhóm
+func (s *eventService) UpdateEvent(ctx context.Context, userID, eventID int64, req *request.EventUpdateRequest) (*response.EventResponse, error) {
+	event, err := s.findManageableEvent(ctx, userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != "" {
+		event.Title = req.Title
+	}
+	if req.Description != "" {
+		event.Description = req.Description
+	}
+	if req.StartsAt != nil {
+		event.StartsAt = req.StartsAt.UTC()
+	}
+	if req.EndsAt != nil {
+		event.EndsAt = req.EndsAt.UTC()
+	}
+	if req.Timezone != "" {
+		timezone, err := resolveTimezone(req.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		event.Timezone = timezone
+	}
+	if req.Location != "" {
+		event.Location = req.Location
+	}
+	if req.CoverImage != "" {
+		event.CoverImage = req.CoverImage
+	}
+	if !event.EndsAt.After(event.StartsAt) {
+		return nil, ErrInvalidEventTime
+	}
+
+	if err := s.eventRepo.Update(ctx, event); err != nil {
+		return nil, fmt.Errorf("lỗi khi cập nhật sự kiện: %v", err)
+	}
+	return s.eventResponse(ctx, userID, event)
+}
+
+// DeleteEvent xóa sự kiện, cần là người tạo hoặc có quyền manage_events trong nhóm
+func (s *eventService) DeleteEvent(ctx context.Context, userID, eventID int64) error {
+	if _, err := s.findManageableEvent(ctx, userID, eventID); err != nil {
+		return err
+	}
+	if err := s.eventRepo.Delete(ctx, eventID); err != nil {
+		return fmt.Errorf("lỗi khi xóa sự kiện: %v", err)
+	}
+	return nil
+}
+
+// ListGroupEvents lấy sự kiện sắp diễn ra hoặc đã qua của nhóm
+func (s *eventService) ListGroupEvents(ctx context.Context, userID, groupID int64, req *request.EventListRequest) (*response.EventListResponse, error) {
+	if _, _, err := s.findGroupForEvents(ctx, userID, groupID); err != nil {
+		return nil, err
+	}
+
+	events, total, err := s.eventRepo.ListByGroup(ctx, groupID, req.Period, req.Page, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy danh sách sự kiện: %v", err)
+	}
+	return s.eventListResponse(ctx, userID, events, total, req)
+}
+
+// ListMyEvents lấy sự kiện người dùng đã tạo hoặc đã phản hồi sẽ tham gia/quan tâm
+func (s *eventService) ListMyEvents(ctx context.Context, userID int64, req *request.EventListRequest) (*response.EventListResponse, error) {
+	events, total, err := s.eventRepo.ListForUser(ctx, userID, req.Period, req.Page, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy danh sách sự kiện: %v", err)
+	}
+	return s.eventListResponse(ctx, userID, events, total, req)
+}
+
+// RespondToEvent ghi nhận hoặc đổi phản hồi của người dùng với sự kiện chưa kết thúc. Người bị cấm khỏi
+// nhóm không thể phản hồi sự kiện của nhóm.
+func (s *eventService) RespondToEvent(ctx context.Context, userID, eventID int64, req *request.EventRSVPRequest) (*response.EventResponse, error) {
+	event, err := s.findVisibleEvent(ctx, userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.EndsAt.After(time.Now()) {
+		return nil, ErrEventEnded
+	}
+	if event.GroupID != nil {
+		ban, err := s.moderationRepo.FindBan(ctx, *event.GroupID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if ban != nil {
+			return nil, ErrBannedFromGroup
+		}
+	}
+
+	if err := s.eventRepo.SetRSVP(ctx, eventID, userID, models.EventRSVPStatus(req.Status)); err != nil {
+		return nil, fmt.Errorf("lỗi khi phản hồi sự kiện: %v", err)
+	}
+
+	updated, err := s.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, ErrEventNotFound
+	}
+	return s.eventResponse(ctx, userID, updated)
+}
+
+// CancelRSVP hủy phản hồi của người dùng với sự kiện
+func (s *eventService) CancelRSVP(ctx context.Context, userID, eventID int64) error {
+	if _, err := s.findVisibleEvent(ctx, userID, eventID); err != nil {
+		return err
+	}
+
+	deleted, err := s.eventRepo.DeleteRSVP(ctx, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("lỗi khi hủy phản hồi sự kiện: %v", err)
+	}
+	if !deleted {
+		return ErrRSVPNotFound
+	}
+	return nil
+}
+
+// ListAttendees lấy danh sách người đã phản hồi sự kiện theo trạng thái (mặc định là sẽ tham gia)
+func (s *eventService) ListAttendees(ctx context.Context, userID, eventID int64, req *request.EventAttendeeListRequest) (*response.EventAttendeeListResponse, error) {
+	if _, err := s.findVisibleEvent(ctx, userID, eventID); err != nil {
+		return nil, err
+	}
+
+	status := models.EventRSVPGoing
+	if req.Status != "" {
+		status = models.EventRSVPStatus(req.Status)
+	}
+	rsvps, total, err := s.eventRepo.ListRSVPs(ctx, eventID, status, req.Page, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy danh sách người tham gia: %v", err)
+	}
+
+	resp := &response.EventAttendeeListResponse{
+		Attendees: make([]response.EventAttendeeResponse, len(rsvps)),
+		Total:     total,
+		Page:      req.Page,
+		Size:      req.PageSize,
+	}
+	for i := range rsvps {
+		resp.Attendees[i] = response.ConvertToEventAttendeeResponse(&rsvps[i])
+	}
+	return resp, nil
+}
+
+// toCalendarEvent chuyển sự kiện sang dạng dùng để xuất iCalendar
+func toCalendarEvent(event *models.Event) utils.CalendarEvent {
+	return utils.CalendarEvent{
+		UID:         fmt.Sprintf("event-%d@hoanhao-social", event.ID),
+		Summary:     event.Title,
+		Description: event.Description,
+		Location:    event.Location,
+		Start:       event.StartsAt,
+		End:         event.EndsAt,
+		Created:     event.CreatedAt,
+		Modified:    event.UpdatedAt,
+	}
+}
+
+// ExportEventICS xuất một sự kiện ra tệp .ics
+func (s *eventService) ExportEventICS(ctx context.Context, userID, eventID int64) ([]byte, error) {
+	event, err := s.findVisibleEvent(ctx, userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return utils.BuildICalendar(event.Title, []utils.CalendarEvent{toCalendarEvent(event)}, time.Now()), nil
+}
+
+// ExportGroupICS xuất lịch sự kiện của nhóm ra tệp .ics, gồm các sự kiện sắp tới và sự kiện đã kết thúc
+// trong 90 ngày gần nhất
+func (s *eventService) ExportGroupICS(ctx context.Context, userID, groupID int64) ([]byte, error) {
+	group, _, err := s.findGroupForEvents(ctx, userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	events, err := s.eventRepo.ListGroupCalendar(ctx, groupID, now.Add(-groupCalendarLookback), groupCalendarLimit)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy lịch sự kiện của nhóm: %v", err)
+	}
+
+	calendar := make([]utils.CalendarEvent, len(events))
+	for i := range events {
+		calendar[i] = toCalendarEvent(&events[i])
+	}
+	return utils.BuildICalendar(group.Name, calendar, now), nil
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"userservice2/dto/request"
+	"userservice2/dto/response"
+	"userservice2/models"
+	"userservice2/repositories"
+)
+
+// Khai báo lỗi
+var (
+	ErrCannotFollowSelf = errors.New("không thể tự theo dõi chính mình")
+	ErrFollowNotAllowed = errors.New("người dùng này không cho phép bạn theo dõi")
+	ErrNotFollowing     = errors.New("bạn chưa theo dõi người dùng này")
+)
+
+// FollowService xử lý việc theo dõi một chiều giữa người dùng
+type FollowService interface {
+	Follow(ctx context.Context, followerID int64, username string) error
+	Unfollow(ctx context.Context, followerID int64, username string) error
+	GetFollowers(ctx context.Context, username string, req *request.FollowListRequest) (*response.FollowListResponse, error)
+	GetFollowing(ctx context.Context, username string, req *request.FollowListRequest) (*response.FollowListResponse, error)
+	GetFeedSources(ctx context.Context, userID int64) (followingIDs, friendIDs []int64, err error)
+}
+
+// followService triển khai FollowService
+type followService struct {
+	followRepo     repositories.FollowRepository
+	friendshipRepo repositories.FriendshipRepository
+	userRepo       repositories.UserRepository
+}
+
+// NewFollowService tạo instance mới của FollowService
+func NewFollowService(
+	followRepo repositories.FollowRepository,
+	friendshipRepo repositories.FriendshipRepository,
+	userRepo repositories.UserRepository,
+) FollowService {
+	return &followService{
+		followRepo:     followRepo,
+		friendshipRepo: friendshipRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// findActiveUser tìm người dùng đang hoạt động theo username
+func (s *followService) findActiveUser(ctx context.Context, username string) (*models.User, error) {
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.IsActive {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// Follow theo dõi một người dùng theo cài đặt follow_policy của họ. Hai người chặn nhau
+// không thể theo dõi nhau.
+func (s *followService) Follow(ctx context.Context, followerID int64, username string) error {
+	followee, err := s.findActiveUser(ctx, username)
+	if err != nil {
+		return err
+	}
+	if followee.ID == followerID {
+		return ErrCannotFollowSelf
+	}
+
+	friendship, err := s.friendshipRepo.FindByUserAndFriend(ctx, followerID, followee.ID)
+	if err != nil {
+		return err
+	}
+	if friendship != nil && friendship.Status == models.FriendshipStatusBlocked {
+		return ErrFollowNotAllowed
+	}
+
+	switch followee.FollowPolicy {
+	case models.FollowPolicyNobody:
+		return ErrFollowNotAllowed
+	case models.FollowPolicyFriends:
+		if friendship == nil || friendship.Status != models.FriendshipStatusAccepted {
+			return ErrFollowNotAllowed
+		}
+	}
+
+	return s.followRepo.Create(ctx, followerID, followee.ID)
+}
+
+// Unfollow bỏ theo dõi một người dùng, kể cả khi vẫn là bạn bè
+func (s *followService) Unfollow(ctx context.Context, followerID int64, username string) error {
+	followee, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if followee == nil {
+		return ErrUserNotFound
+	}
+
+	deleted, err := s.followRepo.Delete(ctx, followerID, followee.ID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrNotFollowing
+	}
+	return nil
+}
+
+// GetFollowers lấy danh sách người theo dõi một người dùng
+func (s *followService) GetFollowers(ctx context.Context, username string, req *request.FollowListRequest) (*response.FollowListResponse, error) {
+	user, err := s.findActiveUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	follows, total, err := s.followRepo.ListFollowers(ctx, user.ID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]response.UserBasic, 0, len(follows))
+	for _, follow := range follows {
+		users = append(users, response.ToUserBasic(&follow.Follower))
+	}
+	return &response.FollowListResponse{Users: users, Total: total, Page: req.Page, Size: req.PageSize}, nil
+}
+
+// GetFollowing lấy danh sách người mà một người dùng đang theo dõi
+func (s *followService) GetFollowing(ctx context.Context, username string, req *request.FollowListRequest) (*response.FollowListResponse, error) {
+	user, err := s.findActiveUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	follows, total, err := s.followRepo.ListFollowing(ctx, user.ID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]response.UserBasic, 0, len(follows))
+	for _, follow := range follows {
+		users = append(users, response.ToUserBasic(&follow.Followee))
+	}
+	return &response.FollowListResponse{Users: users, Total: total, Page: req.Page, Size: req.PageSize}, nil
+}
+
+// GetFeedSources trả về những người userID đang theo dõi và bạn bè của userID để dựng home feed
+func (s *followService) GetFeedSources(ctx context.Context, userID int64) ([]int64, []int64, error) {
+	followingIDs, err := s.followRepo.FindFollowingIDs(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	friendIDs, err := s.friendshipRepo.FindFriendIDs(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return followingIDs, friendIDs, nil
+}
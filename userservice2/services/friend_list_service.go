@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"userservice2/dto/request"
+	"userservice2/dto/response"
+	"userservice2/models"
+	"userservice2/repositories"
+)
+
+// maxFriendListsPerUser là số danh sách bạn bè tối đa của một người dùng
+const maxFriendListsPerUser = 50
+
+// Khai báo lỗi
+var (
+	ErrFriendListNotFound  = errors.New("danh sách bạn bè không tồn tại")
+	ErrFriendListLimit     = fmt.Errorf("chỉ được tạo tối đa %d danh sách bạn bè", maxFriendListsPerUser)
+	ErrNotFriend           = errors.New("chỉ có thể thêm bạn bè vào danh sách")
+	ErrNotFriendListMember = errors.New("người dùng không có trong danh sách")
+)
+
+// AudienceCheck là một bài đăng CUSTOM cần kiểm tra người xem: người xem phải là bạn của OwnerID,
+// thuộc một trong IncludeListIDs (nếu có) và không thuộc ExcludeListIDs
+type AudienceCheck struct {
+	OwnerID        int64
+	IncludeListIDs []int64
+	ExcludeListIDs []int64
+}
+
+// FriendListService xử lý danh sách bạn bè và kiểm tra người xem của bài đăng CUSTOM
+type FriendListService interface {
+	CreateList(ctx context.Context, ownerID int64, req *request.CreateFriendListRequest) (*response.FriendListDetailResponse, error)
+	GetLists(ctx context.Context, ownerID int64) ([]response.FriendListDetailResponse, error)
+	GetList(ctx context.Context, ownerID, listID int64) (*response.FriendListDetailResponse, error)
+	RenameList(ctx context.Context, ownerID, listID int64, req *request.RenameFriendListRequest) error
+	DeleteList(ctx context.Context, ownerID, listID int64) error
+	AddMembers(ctx context.Context, ownerID, listID int64, req *request.FriendListMembersRequest) error
+	RemoveMember(ctx context.Context, ownerID, listID, userID int64) error
+	FilterOwnedLists(ctx context.Context, ownerID int64, listIDs []int64) (invalidIDs []int64, err error)
+	CheckAudiences(ctx context.Context, viewerID int64, checks []AudienceCheck) ([]bool, error)
+	GetViewerAudience(ctx context.Context, viewerID int64) (friendIDs, listIDs []int64, err error)
+}
+
+// friendListService triển khai FriendListService
+type friendListService struct {
+	friendListRepo repositories.FriendListRepository
+	friendshipRepo repositories.FriendshipRepository
+}
+
+// NewFriendListService tạo instance mới của FriendListService
+func NewFriendListService(friendListRepo repositories.FriendListRepository, friendshipRepo repositories.FriendshipRepository) FriendListService {
+	return &friendListService{
+		friendListRepo: friendListRepo,
+		friendshipRepo: friendshipRepo,
+	}
+}
+
+// findOwnedList tìm danh sách và kiểm tra quyền sở hữu, danh sách của người khác coi như không tồn tại
+func (s *friendListService) findOwnedList(ctx context.Context, ownerID, listID int64) (*models.FriendList, error) {
+	list, err := s.friendListRepo.FindByID(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil || list.OwnerID != ownerID {
+		return nil, ErrFriendListNotFound
+	}
+	return list, nil
+}
+
+// ensureFriends kiểm tra tất cả userIDs đều là bạn bè của ownerID
+func (s *friendListService) ensureFriends(ctx context.Context, ownerID int64, userIDs []int64) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	friendIDs, err := s.friendshipRepo.FindFriendIDs(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	friends := make(map[int64]bool, len(friendIDs))
+	for _, id := range friendIDs {
+		friends[id] = true
+	}
+	for _, id := range userIDs {
+		if !friends[id] {
+			return ErrNotFriend
+		}
+	}
+	return nil
+}
+
+// CreateList tạo danh sách bạn bè, các thành viên ban đầu phải là bạn bè
+func (s *friendListService) CreateList(ctx context.Context, ownerID int64, req *request.CreateFriendListRequest) (*response.FriendListDetailResponse, error) {
+	count, err := s.friendListRepo.CountByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxFriendListsPerUser {
+		return nil, ErrFriendListLimit
+	}
+	if err := s.ensureFriends(ctx, ownerID, req.MemberIDs); err != nil {
+		return nil, err
+	}
+
+	list := &models.FriendList{OwnerID: ownerID, Name: req.Name}
+	if err := s.friendListRepo.Create(ctx, list, req.MemberIDs); err != nil {
+		return nil, err
+	}
+	return s.GetList(ctx, ownerID, list.ID)
+}
+
+// GetLists lấy tất cả danh sách bạn bè của người dùng kèm số thành viên
+func (s *friendListService) GetLists(ctx context.Context, ownerID int64) ([]response.FriendListDetailResponse, error) {
+	lists, err := s.friendListRepo.ListByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	listIDs := make([]int64, 0, len(lists))
+	for _, list := range lists {
+		listIDs = append(listIDs, list.ID)
+	}
+	counts, err := s.friendListRepo.CountMembers(ctx, listIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.FriendListDetailResponse, 0, len(lists))
+	for _, list := range lists {
+		result = append(result, response.FriendListDetailResponse{
+			ID:          list.ID,
+			Name:        list.Name,
+			MemberCount: counts[list.ID],
+			CreatedAt:   list.CreatedAt,
+			UpdatedAt:   list.UpdatedAt,
+		})
+	}
+	return result, nil
+}
+
+// GetList lấy chi tiết một danh sách bạn bè kèm thành viên
+func (s *friendListService) GetList(ctx context.Context, ownerID, listID int64) (*response.FriendListDetailResponse, error) {
+	list, err := s.findOwnedList(ctx, ownerID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.friendListRepo.ListMembers(ctx, list.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]response.UserBasic, 0, len(members))
+	for _, member := range members {
+		users = append(users, response.ToUserBasic(&member.User))
+	}
+	return &response.FriendListDetailResponse{
+		ID:          list.ID,
+		Name:        list.Name,
+		MemberCount: len(users),
+		Members:     users,
+		CreatedAt:   list.CreatedAt,
+		UpdatedAt:   list.UpdatedAt,
+	}, nil
+}
+
+// RenameList đổi tên danh sách bạn bè
+func (s *friendListService) RenameList(ctx context.Context, ownerID, listID int64, req *request.RenameFriendListRequest) error {
+	if _, err := s.findOwnedList(ctx, ownerID, listID); err != nil {
+		return err
+	}
+	return s.friendListRepo.Rename(ctx, listID, req.Name)
+}
+
+// DeleteList xóa danh sách bạn bè. Không còn ai thuộc danh sách đã xóa, nên bài đăng CUSTOM chỉ có
+// danh sách này là danh sách được xem sẽ không còn ai xem được ngoài tác giả.
+func (s *friendListService) DeleteList(ctx context.Context, ownerID, listID int64) error {
+	if _, err := s.findOwnedList(ctx, ownerID, listID); err != nil {
+		return err
+	}
+	return s.friendListRepo.Delete(ctx, listID)
+}
+
+// AddMembers thêm bạn bè vào danh sách
+func (s *friendListService) AddMembers(ctx context.Context, ownerID, listID int64, req *request.FriendListMembersRequest) error {
+	if _, err := s.findOwnedList(ctx, ownerID, listID); err != nil {
+		return err
+	}
+	if err := s.ensureFriends(ctx, ownerID, req.UserIDs); err != nil {
+		return err
+	}
+	return s.friendListRepo.AddMembers(ctx, listID, req.UserIDs)
+}
+
+// RemoveMember xóa một người khỏi danh sách bạn bè
+func (s *friendListService) RemoveMember(ctx context.Context, ownerID, listID, userID int64) error {
+	if _, err := s.findOwnedList(ctx, ownerID, listID); err != nil {
+		return err
+	}
+	removed, err := s.friendListRepo.RemoveMember(ctx, listID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrNotFriendListMember
+	}
+	return nil
+}
+
+// FilterOwnedLists trả về các ID trong listIDs không phải danh sách của ownerID
+func (s *friendListService) FilterOwnedLists(ctx context.Context, ownerID int64, listIDs []int64) ([]int64, error) {
+	ownedIDs, err := s.friendListRepo.FindOwnedIDs(ctx, ownerID, listIDs)
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[int64]bool, len(ownedIDs))
+	for _, id := range ownedIDs {
+		owned[id] = true
+	}
+
+	invalidIDs := make([]int64, 0)
+	for _, id := range listIDs {
+		if !owned[id] {
+			invalidIDs = append(invalidIDs, id)
+		}
+	}
+	return invalidIDs, nil
+}
+
+// CheckAudiences kiểm tra viewerID có thuộc người xem của từng bài đăng CUSTOM hay không. Người
+// xem luôn phải là bạn bè của chủ bài đăng, kể cả khi vẫn còn trong danh sách sau khi hủy kết bạn.
+func (s *friendListService) CheckAudiences(ctx context.Context, viewerID int64, checks []AudienceCheck) ([]bool, error) {
+	allowed := make([]bool, len(checks))
+	if viewerID == 0 || len(checks) == 0 {
+		return allowed, nil
+	}
+
+	friendIDs, err := s.friendshipRepo.FindFriendIDs(ctx, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	friends := make(map[int64]bool, len(friendIDs))
+	for _, id := range friendIDs {
+		friends[id] = true
+	}
+
+	ownerIDs := make([]int64, 0, len(checks))
+	for _, check := range checks {
+		ownerIDs = append(ownerIDs, check.OwnerID)
+	}
+	memberships, err := s.friendListRepo.FindMemberships(ctx, viewerID, ownerIDs)
+	if err != nil {
+		return nil, err
+	}
+	// Danh sách người xem thuộc về -> chủ danh sách, để bỏ qua danh sách không phải của chủ bài đăng
+	listOwners := make(map[int64]int64, len(memberships))
+	for _, membership := range memberships {
+		listOwners[membership.ListID] = membership.OwnerID
+	}
+	inAny := func(ownerID int64, listIDs []int64) bool {
+		for _, id := range listIDs {
+			if owner, ok := listOwners[id]; ok && owner == ownerID {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i, check := range checks {
+		switch {
+		case check.OwnerID == viewerID:
+			allowed[i] = true
+		case !friends[check.OwnerID]:
+			allowed[i] = false
+		case len(check.IncludeListIDs) > 0 && !inAny(check.OwnerID, check.IncludeListIDs):
+			allowed[i] = false
+		default:
+			allowed[i] = !inAny(check.OwnerID, check.ExcludeListIDs)
+		}
+	}
+	return allowed, nil
+}
+
+// GetViewerAudience trả về bạn bè của viewerID và mọi danh sách bạn bè có viewerID là thành viên, để
+// PostService lọc bài đăng CUSTOM ngay trong truy vấn. ID danh sách là duy nhất và bài đăng CUSTOM chỉ
+// tham chiếu danh sách của tác giả, nên không cần trả về chủ của từng danh sách.
+func (s *friendListService) GetViewerAudience(ctx context.Context, viewerID int64) ([]int64, []int64, error) {
+	friendIDs, err := s.friendshipRepo.FindFriendIDs(ctx, viewerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	listIDs, err := s.friendListRepo.FindListIDsByMember(ctx, viewerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return friendIDs, listIDs, nil
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"userservice2/dto/request"
+	"userservice2/dto/response"
+	"userservice2/models"
+	"userservice2/repositories"
+)
+
+const (
+	// Số gợi ý lưu sẵn cho mỗi người dùng, bằng limit tối đa của API
+	maxStoredSuggestions = 50
+	// Số ứng viên tối đa lấy từ mỗi tín hiệu trước khi chấm điểm
+	maxCandidatesPerSignal = 200
+	// Số người dùng được tính lại gợi ý trong mỗi lô của lịch định kỳ
+	suggestionBatchSize = 100
+)
+
+// Trọng số của từng tín hiệu khi chấm điểm gợi ý
+const (
+	weightMutualFriend  = 10.0
+	weightSharedGroup   = 6.0
+	weightSameDistrict  = 5.0
+	weightSameProvince  = 3.0
+	weightSameWork      = 4.0
+	weightSameEducation = 4.0
+	weightActiveWeek    = 3.0
+	weightActiveMonth   = 1.0
+)
+
+// ErrCannotDismissSelf được trả về khi người dùng bỏ qua gợi ý là chính mình
+var ErrCannotDismissSelf = errors.New("không thể bỏ qua gợi ý là chính mình")
+
+// FriendSuggestionService tính và trả về gợi ý kết bạn theo điểm
+type FriendSuggestionService interface {
+	GetSuggestions(ctx context.Context, userID int64, req *request.FriendSuggestionsRequest) (*response.FriendSuggestionListResponse, error)
+	Dismiss(ctx context.Context, userID, suggestedUserID int64) error
+	Recompute(ctx context.Context, userID int64) error
+	RecomputeActive(ctx context.Context) (int, error)
+	StartSchedule(ctx context.Context, interval time.Duration)
+}
+
+// friendSuggestionService triển khai FriendSuggestionService
+type friendSuggestionService struct {
+	suggestionRepo repositories.FriendSuggestionRepository
+	userRepo       repositories.UserRepository
+	activeWindow   time.Duration
+}
+
+// NewFriendSuggestionService tạo instance mới của FriendSuggestionService. Lịch định kỳ chỉ tính lại
+// gợi ý cho người dùng đăng nhập trong activeWindow.
+func NewFriendSuggestionService(
+	suggestionRepo repositories.FriendSuggestionRepository,
+	userRepo repositories.UserRepository,
+	activeWindow time.Duration,
+) FriendSuggestionService {
+	return &friendSuggestionService{
+		suggestionRepo: suggestionRepo,
+		userRepo:       userRepo,
+		activeWindow:   activeWindow,
+	}
+}
+
+// GetSuggestions lấy các gợi ý điểm cao nhất. Người dùng chưa được tính gợi ý lần nào (mới đăng ký
+// hoặc không hoạt động trong activeWindow) được tính ngay tại request.
+func (s *friendSuggestionService) GetSuggestions(ctx context.Context, userID int64, req *request.FriendSuggestionsRequest) (*response.FriendSuggestionListResponse, error) {
+	limit := req.Limit
+	if limit <= 0 || limit > maxStoredSuggestions {
+		limit = 10
+	}
+
+	computedAt, err := s.suggestionRepo.LastComputedAt(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy gợi ý kết bạn: %w", err)
+	}
+	if computedAt == nil {
+		if err := s.Recompute(ctx, userID); err != nil {
+			return nil, fmt.Errorf("lỗi khi tính gợi ý kết bạn: %w", err)
+		}
+	}
+
+	suggestions, err := s.suggestionRepo.List(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy gợi ý kết bạn: %w", err)
+	}
+
+	suggestionResponses := make([]response.FriendSuggestionResponse, 0, len(suggestions))
+	for i := range suggestions {
+		suggestionResponses = append(suggestionResponses, response.ToFriendSuggestionResponse(&suggestions[i]))
+	}
+
+	return &response.FriendSuggestionListResponse{
+		Suggestions: suggestionResponses,
+	}, nil
+}
+
+// Dismiss bỏ qua một gợi ý, người đó sẽ không được gợi ý lại cho userID
+func (s *friendSuggestionService) Dismiss(ctx context.Context, userID, suggestedUserID int64) error {
+	if userID == suggestedUserID {
+		return ErrCannotDismissSelf
+	}
+	user, err := s.userRepo.FindByID(ctx, suggestedUserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	return s.suggestionRepo.Dismiss(ctx, userID, suggestedUserID)
+}
+
+// suggestionCandidate gom các tín hiệu của một ứng viên
+type suggestionCandidate struct {
+	mutualFriends int
+	sharedGroups  int
+}
+
+// Recompute tính lại và lưu gợi ý cho userID từ bạn của bạn bè, thành viên cùng nhóm và người
+// có cùng nơi sống, nơi làm việc hoặc trường học. Người đã có quan hệ bạn bè hoặc đã bị bỏ qua
+// được loại ngay trong truy vấn của từng tín hiệu.
+func (s *friendSuggestionService) Recompute(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	candidates := make(map[int64]*suggestionCandidate)
+	candidate := func(id int64) *suggestionCandidate {
+		if c, ok := candidates[id]; ok {
+			return c
+		}
+		c := &suggestionCandidate{}
+		candidates[id] = c
+		return c
+	}
+
+	mutual, err := s.suggestionRepo.CountMutualFriends(ctx, userID, maxCandidatesPerSignal)
+	if err != nil {
+		return err
+	}
+	for _, signal := range mutual {
+		candidate(signal.UserID).mutualFriends = signal.Count
+	}
+
+	groups, err := s.suggestionRepo.CountSharedGroups(ctx, userID, maxCandidatesPerSignal)
+	if err != nil {
+		return err
+	}
+	for _, signal := range groups {
+		candidate(signal.UserID).sharedGroups = signal.Count
+	}
+
+	similarIDs, err := s.suggestionRepo.FindSimilarUserIDs(ctx, user, maxCandidatesPerSignal)
+	if err != nil {
+		return err
+	}
+	for _, id := range similarIDs {
+		candidate(id)
+	}
+
+	delete(candidates, userID)
+
+	ids := make([]int64, 0, len(candidates))
+	for id := range candidates {
+		ids = append(ids, id)
+	}
+	users, err := s.suggestionRepo.FindCandidates(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	suggestions := make([]models.FriendSuggestion, 0, len(users))
+	for i := range users {
+		c := candidates[users[i].ID]
+		score, reason := scoreSuggestion(user, &users[i], c, now)
+		suggestions = append(suggestions, models.FriendSuggestion{
+			UserID:          userID,
+			SuggestedUserID: users[i].ID,
+			Score:           score,
+			MutualFriends:   c.mutualFriends,
+			SharedGroups:    c.sharedGroups,
+			Reason:          reason,
+			ComputedAt:      now,
+		})
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].SuggestedUserID < suggestions[j].SuggestedUserID
+	})
+	if len(suggestions) > maxStoredSuggestions {
+		suggestions = suggestions[:maxStoredSuggestions]
+	}
+
+	return s.suggestionRepo.Replace(ctx, userID, suggestions)
+}
+
+// scoreSuggestion chấm điểm ứng viên và chọn tín hiệu đóng góp nhiều điểm nhất làm lý do gợi ý
+func scoreSuggestion(user, other *models.User, c *suggestionCandidate, now time.Time) (float64, string) {
+	var score, best float64
+	reason := "Có thể bạn biết người này"
+	add := func(points float64, why string) {
+		score += points
+		if points > best {
+			best = points
+			reason = why
+		}
+	}
+
+	if c.mutualFriends > 0 {
+		add(weightMutualFriend*float64(c.mutualFriends), fmt.Sprintf("%d bạn chung", c.mutualFriends))
+	}
+	if c.sharedGroups > 0 {
+		add(weightSharedGroup*float64(c.sharedGroups), fmt.Sprintf("Cùng tham gia %d nhóm", c.sharedGroups))
+	}
+	if user.DistrictID != 0 && other.DistrictID == user.DistrictID {
+		add(weightSameDistrict, "Sống cùng quận/huyện với bạn")
+	} else if user.ProvinceID != 0 && other.ProvinceID == user.ProvinceID {
+		add(weightSameProvince, "Sống cùng tỉnh/thành phố với bạn")
+	}
+	if sameText(user.Work, other.Work) {
+		add(weightSameWork, "Cùng làm việc tại "+strings.TrimSpace(other.Work))
+	}
+	if sameText(user.Education, other.Education) {
+		add(weightSameEducation, "Cùng học tại "+strings.TrimSpace(other.Education))
+	}
+
+	// Người hoạt động gần đây được cộng điểm nhưng không dùng làm lý do gợi ý
+	if other.LastLoginAt != nil {
+		switch since := now.Sub(*other.LastLoginAt); {
+		case since <= 7*24*time.Hour:
+			score += weightActiveWeek
+		case since <= 30*24*time.Hour:
+			score += weightActiveMonth
+		}
+	}
+
+	return score, reason
+}
+
+// sameText so sánh hai chuỗi không phân biệt hoa thường và khoảng trắng hai đầu, chuỗi rỗng không khớp
+func sameText(a, b string) bool {
+	a = strings.TrimSpace(a)
+	return a != "" && strings.EqualFold(a, strings.TrimSpace(b))
+}
+
+// RecomputeActive tính lại gợi ý cho tất cả người dùng đăng nhập trong activeWindow, trả về số người
+// đã tính. Lỗi của từng người được ghi log và bỏ qua.
+func (s *friendSuggestionService) RecomputeActive(ctx context.Context) (int, error) {
+	since := time.Now().Add(-s.activeWindow)
+	var afterID int64
+	count := 0
+
+	for {
+		ids, err := s.suggestionRepo.FindActiveUserIDs(ctx, since, afterID, suggestionBatchSize)
+		if err != nil {
+			return count, err
+		}
+		for _, id := range ids {
+			if ctx.Err() != nil {
+				return count, ctx.Err()
+			}
+			if err := s.Recompute(ctx, id); err != nil {
+				log.Printf("Failed to recompute friend suggestions for user %d: %v", id, err)
+				continue
+			}
+			count++
+		}
+		if len(ids) < suggestionBatchSize {
+			return count, nil
+		}
+		afterID = ids[len(ids)-1]
+	}
+}
+
+// StartSchedule chạy RecomputeActive định kỳ cho tới khi ctx bị hủy
+func (s *friendSuggestionService) StartSchedule(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Friend suggestion schedule stopped")
+			return
+		case <-ticker.C:
+			count, err := s.RecomputeActive(ctx)
+			if err != nil {
+				log.Printf("Friend suggestion recompute failed: %v", err)
+			}
+			log.Printf("Recomputed friend suggestions for %d users", count)
+		}
+	}
+}
@@ -3,13 +3,16 @@ package services
 import (
 	"context"
 	"errors"
-	"fmt"
+	"log"
 	"userservice2/dto/request"
 	"userservice2/dto/response"
 	"userservice2/models"
 	"userservice2/repositories"
 )
 
+// ErrMutualFriendsBlocked được trả về khi xem bạn chung với người đã chặn hoặc bị mình chặn
+var ErrMutualFriendsBlocked = errors.New("không thể xem bạn chung với người dùng này")
+
 // FriendshipService xử lý logic liên quan đến quan hệ bạn bè
 type FriendshipService interface {
 	SendFriendRequest(ctx context.Context, userID, friendID int64) error
@@ -22,22 +25,28 @@ type FriendshipService interface {
 	GetFriendshipStatus(ctx context.Context, userID, friendID int64) (string, error)
 	GetFriends(ctx context.Context, userID int64, req *request.FriendListRequest) (*response.FriendListResponse, error)
 	GetFriendRequests(ctx context.Context, userID int64, req *request.FriendRequestsListRequest) (*response.FriendListResponse, error)
-	GetFriendSuggestions(ctx context.Context, userID int64, req *request.FriendSuggestionsRequest) (*response.FriendSuggestionListResponse, error)
 	GetMutualFriendsCount(ctx context.Context, userID, friendID int64) (int, error)
+	GetMutualFriends(ctx context.Context, userID, otherUserID int64, req *request.MutualFriendsRequest) (*response.MutualFriendListResponse, error)
+	GetMutualFriendsCounts(ctx context.Context, userID int64, otherUserIDs []int64) (map[int64]int, error)
 	GetUserByUsername(ctx context.Context, username string) (*response.UserResponse, error)
+	GetBlockRelations(ctx context.Context, pairs [][2]int64) ([]models.Friendship, error)
+	GetBlockedUserIDs(ctx context.Context, userID int64) ([]int64, error)
 }
 
 // friendshipService triển khai FriendshipService
 type friendshipService struct {
 	friendshipRepo repositories.FriendshipRepository
 	userRepo       repositories.UserRepository
+	followRepo     repositories.FollowRepository
 }
 
-// NewFriendshipService tạo instance mới của FriendshipService
-func NewFriendshipService(friendshipRepo repositories.FriendshipRepository, userRepo repositories.UserRepository) FriendshipService {
+// NewFriendshipService tạo instance mới của FriendshipService. Kết bạn luôn kéo theo theo dõi
+// hai chiều qua followRepo, hủy kết bạn hoặc chặn sẽ bỏ theo dõi hai chiều.
+func NewFriendshipService(friendshipRepo repositories.FriendshipRepository, userRepo repositories.UserRepository, followRepo repositories.FollowRepository) FriendshipService {
 	return &friendshipService{
 		friendshipRepo: friendshipRepo,
 		userRepo:       userRepo,
+		followRepo:     followRepo,
 	}
 }
 
@@ -105,7 +114,10 @@ func (s *friendshipService) AcceptFriendRequest(ctx context.Context, userID, fri
 	}
 
 	friendship.Status = models.FriendshipStatusAccepted
-	return s.friendshipRepo.Update(ctx, friendship)
+	if err := s.friendshipRepo.Update(ctx, friendship); err != nil {
+		return err
+	}
+	return s.followRepo.CreateMutual(ctx, userID, friendID)
 }
 
 // RejectFriendRequest từ chối lời mời kết bạn
@@ -162,7 +174,10 @@ func (s *friendshipService) Unfriend(ctx context.Context, userID, friendID int64
 		return errors.New("không phải là bạn bè")
 	}
 
-	return s.friendshipRepo.Delete(ctx, friendship.ID)
+	if err := s.friendshipRepo.Delete(ctx, friendship.ID); err != nil {
+		return err
+	}
+	return s.followRepo.DeleteMutual(ctx, userID, friendID)
 }
 
 // BlockFriend chặn một người dùng
@@ -171,6 +186,11 @@ func (s *friendshipService) BlockFriend(ctx context.Context, userID, friendID in
 		return errors.New("không thể chặn chính mình")
 	}
 
+	// Chặn nhau thì không còn theo dõi nhau
+	if err := s.followRepo.DeleteMutual(ctx, userID, friendID); err != nil {
+		return err
+	}
+
 	friendship, err := s.friendshipRepo.FindByUserAndFriend(ctx, userID, friendID)
 	if err != nil {
 		return err
@@ -218,7 +238,7 @@ func (s *friendshipService) UnblockFriend(ctx context.Context, userID, friendID
 	}
 
 	// Chỉ bỏ chặn khi người dùng là người đã chặn và trạng thái là blocked
-	if friendship.UserID != userID || friendship.Status != models.FriendshipStatusNone {
+	if friendship.UserID != userID || friendship.Status != models.FriendshipStatusBlocked {
 		return errors.New("người dùng này chưa bị chặn")
 	}
 
@@ -293,21 +313,7 @@ func (s *friendshipService) GetFriends(ctx context.Context, userID int64, req *r
 		}
 	}
 
-	friendResponses := make([]response.FriendResponse, 0, len(friendships))
-	for _, friendship := range friendships {
-		// Tính số bạn chung
-		var mutualCount int
-		var friendID int64
-
-		if friendship.UserID == userID {
-			friendID = friendship.FriendID
-		} else {
-			friendID = friendship.UserID
-		}
-
-		mutualCount, _ = s.GetMutualFriendsCount(ctx, userID, friendID)
-		friendResponses = append(friendResponses, response.ToFriendResponse(&friendship, userID, mutualCount))
-	}
+	friendResponses := s.toFriendResponses(ctx, userID, friendships)
 
 	return &response.FriendListResponse{
 		Friends:  friendResponses,
@@ -337,21 +343,7 @@ func (s *friendshipService) GetFriendRequests(ctx context.Context, userID int64,
 		return nil, err
 	}
 
-	friendResponses := make([]response.FriendResponse, 0, len(friendships))
-	for _, friendship := range friendships {
-		// Tính số bạn chung
-		var mutualCount int
-		var friendID int64
-
-		if friendship.UserID == userID {
-			friendID = friendship.FriendID
-		} else {
-			friendID = friendship.UserID
-		}
-
-		mutualCount, _ = s.GetMutualFriendsCount(ctx, userID, friendID)
-		friendResponses = append(friendResponses, response.ToFriendResponse(&friendship, userID, mutualCount))
-	}
+	friendResponses := s.toFriendResponses(ctx, userID, friendships)
 
 	return &response.FriendListResponse{
 		Friends:  friendResponses,
@@ -361,28 +353,28 @@ func (s *friendshipService) GetFriendRequests(ctx context.Context, userID int64,
 	}, nil
 }
 
-// GetFriendSuggestions lấy gợi ý kết bạn
-func (s *friendshipService) GetFriendSuggestions(ctx context.Context, userID int64, req *request.FriendSuggestionsRequest) (*response.FriendSuggestionListResponse, error) {
-	limit := req.Limit
-	if limit <= 0 || limit > 50 {
-		limit = 10
+// toFriendResponses chuyển danh sách quan hệ bạn bè sang response, số bạn chung được đếm trong một truy vấn
+func (s *friendshipService) toFriendResponses(ctx context.Context, userID int64, friendships []models.Friendship) []response.FriendResponse {
+	friendIDs := make([]int64, 0, len(friendships))
+	for _, friendship := range friendships {
+		if friendship.UserID == userID {
+			friendIDs = append(friendIDs, friendship.FriendID)
+		} else {
+			friendIDs = append(friendIDs, friendship.UserID)
+		}
 	}
 
-	suggestions, err := s.friendshipRepo.GetFriendSuggestions(ctx, userID, limit)
+	// Không đếm được bạn chung thì vẫn trả danh sách, số bạn chung bằng 0
+	mutualCounts, err := s.friendshipRepo.GetMutualFriendsCounts(ctx, userID, friendIDs)
 	if err != nil {
-		return nil, fmt.Errorf("lỗi khi lấy gợi ý kết bạn: %w", err)
+		log.Printf("Failed to count mutual friends for user %d: %v", userID, err)
 	}
 
-	suggestionResponses := make([]response.FriendSuggestionResponse, 0, len(suggestions))
-	for _, user := range suggestions {
-		// Tính số bạn chung cho mỗi gợi ý
-		mutualCount, _ := s.GetMutualFriendsCount(ctx, userID, user.ID)
-		suggestionResponses = append(suggestionResponses, response.ToFriendSuggestionResponse(&user, mutualCount))
+	friendResponses := make([]response.FriendResponse, 0, len(friendships))
+	for i, friendship := range friendships {
+		friendResponses = append(friendResponses, response.ToFriendResponse(&friendship, userID, mutualCounts[friendIDs[i]]))
 	}
-
-	return &response.FriendSuggestionListResponse{
-		Suggestions: suggestionResponses,
-	}, nil
+	return friendResponses
 }
 
 // GetMutualFriendsCount lấy số lượng bạn chung
@@ -390,6 +382,57 @@ func (s *friendshipService) GetMutualFriendsCount(ctx context.Context, userID, f
 	return s.friendshipRepo.GetMutualFriendsCount(ctx, userID, friendID)
 }
 
+// GetMutualFriends lấy danh sách bạn chung giữa userID và otherUserID có phân trang.
+// Từ chối nếu một trong hai người đã chặn người kia.
+func (s *friendshipService) GetMutualFriends(ctx context.Context, userID, otherUserID int64, req *request.MutualFriendsRequest) (*response.MutualFriendListResponse, error) {
+	blocks, err := s.friendshipRepo.FindBlocksAmong(ctx, []int64{userID, otherUserID})
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) > 0 {
+		return nil, ErrMutualFriendsBlocked
+	}
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 || req.PageSize > 100 {
+		req.PageSize = 10
+	}
+
+	users, total, err := s.friendshipRepo.GetMutualFriends(ctx, userID, otherUserID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	userResponses := make([]response.MutualFriend, 0, len(users))
+	for i := range users {
+		userResponses = append(userResponses, response.ToMutualFriend(&users[i]))
+	}
+
+	return &response.MutualFriendListResponse{
+		Users:    userResponses,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// GetMutualFriendsCounts đếm số bạn chung với nhiều người dùng cùng lúc, mọi ID được yêu cầu đều có
+// trong kết quả (0 nếu không có bạn chung)
+func (s *friendshipService) GetMutualFriendsCounts(ctx context.Context, userID int64, otherUserIDs []int64) (map[int64]int, error) {
+	counts, err := s.friendshipRepo.GetMutualFriendsCounts(ctx, userID, otherUserIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range otherUserIDs {
+		if _, ok := counts[id]; !ok {
+			counts[id] = 0
+		}
+	}
+	return counts, nil
+}
+
 // GetUserByUsername lấy thông tin người dùng theo username
 func (s *friendshipService) GetUserByUsername(ctx context.Context, username string) (*response.UserResponse, error) {
 	// Tìm người dùng từ repository
@@ -425,3 +468,44 @@ func (s *friendshipService) GetUserByUsername(ctx context.Context, username stri
 
 	return userResponse, nil
 }
+
+// GetBlockRelations trả về các quan hệ chặn (theo cả hai chiều) giữa các cặp người dùng,
+// cặp không có quan hệ chặn không có trong kết quả
+func (s *friendshipService) GetBlockRelations(ctx context.Context, pairs [][2]int64) ([]models.Friendship, error) {
+	wanted := make(map[[2]int64]struct{}, len(pairs))
+	seen := make(map[int64]struct{})
+	var userIDs []int64
+	for _, pair := range pairs {
+		if pair[0] == pair[1] {
+			continue
+		}
+		wanted[pair] = struct{}{}
+		for _, id := range pair {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				userIDs = append(userIDs, id)
+			}
+		}
+	}
+
+	blocks, err := s.friendshipRepo.FindBlocksAmong(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Truy vấn theo tập ID có thể trả về cặp không được hỏi, chỉ giữ các cặp có trong request
+	result := make([]models.Friendship, 0, len(blocks))
+	for _, block := range blocks {
+		_, forward := wanted[[2]int64{block.UserID, block.FriendID}]
+		_, backward := wanted[[2]int64{block.FriendID, block.UserID}]
+		if forward || backward {
+			result = append(result, block)
+		}
+	}
+	return result, nil
+}
+
+// GetBlockedUserIDs trả về ID của mọi người dùng có quan hệ chặn (theo cả hai chiều) với userID
+func (s *friendshipService) GetBlockedUserIDs(ctx context.Context, userID int64) ([]int64, error) {
+	return s.friendshipRepo.FindBlockedUserIDs(ctx, userID)
+}
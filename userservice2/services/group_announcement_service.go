@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"userservice2/dto/request"
+	"userservice2/dto/response"
+	"userservice2/models"
+)
+
+// Khai báo lỗi thông báo ghim của nhóm
+var (
+	ErrGroupAnnouncementNotFound     = errors.New("không tìm thấy thông báo")
+	ErrGroupAnnouncementLimit        = fmt.Errorf("nhóm chỉ có thể ghim tối đa %d thông báo cùng lúc", models.MaxActiveGroupAnnouncements)
+	ErrInvalidAnnouncementExpiration = errors.New("thời điểm hết hạn của thông báo phải ở tương lai")
+)
+
+// findGroupAnnouncement tìm thông báo thuộc nhóm groupID
+func (s *groupService) findGroupAnnouncement(ctx context.Context, groupID, announcementID int64) (*models.GroupAnnouncement, error) {
+	announcement, err := s.announcementRepo.FindByID(ctx, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	if announcement == nil || announcement.GroupID != groupID {
+		return nil, ErrGroupAnnouncementNotFound
+	}
+	return announcement, nil
+}
+
+// listPinnedAnnouncements lấy các thông báo đang được ghim của nhóm dưới dạng response
+func (s *groupService) listPinnedAnnouncements(ctx context.Context, groupID int64) ([]response.GroupAnnouncementResponse, error) {
+	now := time.Now()
+	announcements, err := s.announcementRepo.ListActive(ctx, groupID, now)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy thông báo của nhóm: %v", err)
+	}
+
+	resp := make([]response.GroupAnnouncementResponse, len(announcements))
+	for i := range announcements {
+		resp[i] = response.ConvertToGroupAnnouncementResponse(&announcements[i], now)
+	}
+	return resp, nil
+}
+
+// ListGroupAnnouncements lấy thông báo của nhóm. Nhóm riêng tư chỉ thành viên xem được, xem cả thông báo đã hết
+// hạn cần quyền manage_announcements.
+func (s *groupService) ListGroupAnnouncements(ctx context.Context, userID, groupID int64, req *request.GroupAnnouncementListRequest) (*response.GroupAnnouncementListResponse, error) {
+	group, err := s.findVisibleGroup(ctx, userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	member, permissions, err := s.memberPermissions(ctx, userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Privacy == models.GroupPrivacyPrivate && member == nil {
+		return nil, ErrGroupPermissionDenied
+	}
+	if req.IncludeExpired && !permissions.Has(models.GroupPermissionManageAnnouncements) {
+		return nil, ErrGroupPermissionDenied
+	}
+
+	now := time.Now()
+	announcements, total, err := s.announcementRepo.List(ctx, groupID, req.IncludeExpired, now, req.Page, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy thông báo của nhóm: %v", err)
+	}
+
+	resp := &response.GroupAnnouncementListResponse{
+		Announcements: make([]response.GroupAnnouncementResponse, len(announcements)),
+		Total:         total,
+		Page:          req.Page,
+		Size:          req.PageSize,
+	}
+	for i := range announcements {
+		resp.Announcements[i] = response.ConvertToGroupAnnouncementResponse(&announcements[i], now)
+	}
+	return resp, nil
+}
+
+// CreateGroupAnnouncement ghim thông báo mới lên đầu trang nhóm, cần quyền manage_announcements
+func (s *groupService) CreateGroupAnnouncement(ctx context.Context, userID, groupID int64, req *request.GroupAnnouncementCreateRequest) (*response.GroupAnnouncementResponse, error) {
+	if _, _, err := s.authorizeModerator(ctx, userID, groupID, models.GroupPermissionManageAnnouncements); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, ErrInvalidAnnouncementExpiration
+	}
+
+	announcement := &models.GroupAnnouncement{
+		GroupID:   groupID,
+		AuthorID:  userID,
+		Title:     strings.TrimSpace(req.Title),
+		Content:   strings.TrimSpace(req.Content),
+		ExpiresAt: req.ExpiresAt,
+	}
+	pinned, err := s.announcementRepo.Create(ctx, announcement, now)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi ghim thông báo: %v", err)
+	}
+	if !pinned {
+		return nil, ErrGroupAnnouncementLimit
+	}
+
+	created, err := s.announcementRepo.FindByID(ctx, announcement.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp := response.ConvertToGroupAnnouncementResponse(created, now)
+	return &resp, nil
+}
+
+// UpdateGroupAnnouncement sửa thông báo hoặc gia hạn, cần quyền manage_announcements. Gia hạn thông báo đã hết
+// hạn sẽ ghim lại thông báo nếu nhóm còn chỗ.
+func (s *groupService) UpdateGroupAnnouncement(ctx context.Context, userID, groupID, announcementID int64, req *request.GroupAnnouncementUpdateRequest) (*response.GroupAnnouncementResponse, error) {
+	if _, _, err := s.authorizeModerator(ctx, userID, groupID, models.GroupPermissionManageAnnouncements); err != nil {
+		return nil, err
+	}
+
+	announcement, err := s.findGroupAnnouncement(ctx, groupID, announcementID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if req.Title != "" {
+		announcement.Title = strings.TrimSpace(req.Title)
+	}
+	if req.Content != "" {
+		announcement.Content = strings.TrimSpace(req.Content)
+	}
+	switch {
+	case req.NoExpiry:
+		announcement.ExpiresAt = nil
+	case req.ExpiresAt != nil:
+		if !req.ExpiresAt.After(now) {
+			return nil, ErrInvalidAnnouncementExpiration
+		}
+		announcement.ExpiresAt = req.ExpiresAt
+	}
+
+	updated, err := s.announcementRepo.Update(ctx, announcement, now)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi cập nhật thông báo: %v", err)
+	}
+	if !updated {
+		return nil, ErrGroupAnnouncementLimit
+	}
+
+	resp := response.ConvertToGroupAnnouncementResponse(announcement, now)
+	return &resp, nil
+}
+
+// DeleteGroupAnnouncement gỡ thông báo khỏi nhóm, cần quyền manage_announcements
+func (s *groupService) DeleteGroupAnnouncement(ctx context.Context, userID, groupID, announcementID int64) error {
+	if _, _, err := s.authorizeModerator(ctx, userID, groupID, models.GroupPermissionManageAnnouncements); err != nil {
+		return err
+	}
+
+	if _, err := s.findGroupAnnouncement(ctx, groupID, announcementID); err != nil {
+		return err
+	}
+	if err := s.announcementRepo.Delete(ctx, announcementID); err != nil {
+		return fmt.Errorf("lỗi khi gỡ thông báo: %v", err)
+	}
+	return nil
+}
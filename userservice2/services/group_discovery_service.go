@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"userservice2/dto/request"
+	"userservice2/dto/response"
+	"userservice2/models"
+)
+
+// Trọng số các tín hiệu khi gợi ý nhóm
+const (
+	weightFriendMember   = 5.0
+	weightDistrictMember = 2.0
+	weightProvinceMember = 1.0
+	// Số nhóm tối đa lấy ra từ mỗi tín hiệu trước khi chấm điểm
+	maxGroupCandidatesPerSignal = 200
+)
+
+// normalizeGroupTag đưa thẻ về dạng chữ thường, bỏ dấu # và khoảng trắng hai đầu
+func normalizeGroupTag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
+
+// normalizeGroupTags chuẩn hóa danh sách thẻ, bỏ thẻ rỗng, thẻ trùng và giữ tối đa models.MaxGroupTags thẻ
+func normalizeGroupTags(tags []string) models.GroupTags {
+	result := models.GroupTags{}
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = normalizeGroupTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+		if len(result) == models.MaxGroupTags {
+			break
+		}
+	}
+	return result
+}
+
+// groupCandidate gom các tín hiệu gợi ý của một nhóm
+type groupCandidate struct {
+	friendMembers   int
+	provinceMembers int
+	districtMembers int
+}
+
+// RecommendGroups gợi ý các nhóm người dùng chưa tham gia, xếp theo số bạn bè là thành viên và số thành viên sống
+// gần người dùng. Khi không có tín hiệu nào, trả về các nhóm đông thành viên nhất.
+func (s *groupService) RecommendGroups(ctx context.Context, userID int64, req *request.GroupRecommendationRequest) (*response.GroupRecommendationListResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	candidates := make(map[int64]*groupCandidate)
+	candidate := func(groupID int64) *groupCandidate {
+		c, ok := candidates[groupID]
+		if !ok {
+			c = &groupCandidate{}
+			candidates[groupID] = c
+		}
+		return c
+	}
+
+	friendSignals, err := s.groupRepo.CountFriendMembers(ctx, userID, maxGroupCandidatesPerSignal)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi đếm bạn bè trong nhóm: %v", err)
+	}
+	for _, signal := range friendSignals {
+		candidate(signal.GroupID).friendMembers = signal.Count
+	}
+
+	locationSignals, err := s.groupRepo.CountNearbyMembers(ctx, userID, user.ProvinceID, user.DistrictID, maxGroupCandidatesPerSignal)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi đếm thành viên ở gần: %v", err)
+	}
+	for _, signal := range locationSignals {
+		c := candidate(signal.GroupID)
+		c.provinceMembers = signal.ProvinceCount
+		c.districtMembers = signal.DistrictCount
+	}
+
+	result := &response.GroupRecommendationListResponse{
+		Groups: make([]response.GroupRecommendationResponse, 0, req.Limit),
+	}
+
+	if len(candidates) == 0 {
+		groups, err := s.groupRepo.ListPopularFor(ctx, userID, req.Limit)
+		if err != nil {
+			return nil, fmt.Errorf("lỗi khi lấy nhóm nổi bật: %v", err)
+		}
+		for i := range groups {
+			result.Groups = append(result.Groups, response.GroupRecommendationResponse{
+				GroupResponse: response.ConvertToGroupResponse(&groups[i]),
+				Reason:        "Nhóm nổi bật",
+			})
+		}
+		return result, nil
+	}
+
+	ids := make([]int64, 0, len(candidates))
+	for id := range candidates {
+		ids = append(ids, id)
+	}
+	groups, err := s.groupRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy thông tin nhóm: %v", err)
+	}
+
+	type scoredGroup struct {
+		group  *models.UserGroup
+		score  float64
+		reason string
+	}
+	scored := make([]scoredGroup, 0, len(groups))
+	for i := range groups {
+		score, reason := scoreGroupRecommendation(candidates[groups[i].ID])
+		scored = append(scored, scoredGroup{group: &groups[i], score: score, reason: reason})
+	}
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+		if scored[i].group.MemberCount != scored[j].group.MemberCount {
+			return scored[i].group.MemberCount > scored[j].group.MemberCount
+		}
+		return scored[i].group.ID < scored[j].group.ID
+	})
+	if len(scored) > req.Limit {
+		scored = scored[:req.Limit]
+	}
+
+	for _, item := range scored {
+		c := candidates[item.group.ID]
+		result.Groups = append(result.Groups, response.GroupRecommendationResponse{
+			GroupResponse:     response.ConvertToGroupResponse(item.group),
+			FriendMemberCount: c.friendMembers,
+			NearbyMemberCount: c.provinceMembers,
+			Reason:            item.reason,
+		})
+	}
+
+	return result, nil
+}
+
+// scoreGroupRecommendation chấm điểm nhóm và chọn tín hiệu đóng góp nhiều điểm nhất làm lý do gợi ý
+func scoreGroupRecommendation(c *groupCandidate) (float64, string) {
+	var score, best float64
+	reason := "Nhóm nổi bật"
+	add := func(points float64, why string) {
+		score += points
+		if points > best {
+			best = points
+			reason = why
+		}
+	}
+
+	if c.friendMembers > 0 {
+		add(weightFriendMember*float64(c.friendMembers), fmt.Sprintf("%d bạn bè là thành viên", c.friendMembers))
+	}
+	if c.districtMembers > 0 {
+		add(weightDistrictMember*float64(c.districtMembers), fmt.Sprintf("%d thành viên sống cùng quận/huyện với bạn", c.districtMembers))
+	}
+	if c.provinceMembers > 0 {
+		add(weightProvinceMember*float64(c.provinceMembers), fmt.Sprintf("%d thành viên sống cùng tỉnh/thành phố với bạn", c.provinceMembers))
+	}
+
+	return score, reason
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"userservice2/dto/request"
+	"userservice2/dto/response"
+	"userservice2/models"
+)
+
+// Khai báo lỗi lời mời vào nhóm
+var (
+	ErrInvitationNotFound    = errors.New("không tìm thấy lời mời")
+	ErrInvitationExists      = errors.New("người dùng đã được mời vào nhóm này")
+	ErrGroupInviteNotAllowed = errors.New("người dùng này không cho phép bạn mời vào nhóm")
+	ErrCannotInviteSelf      = errors.New("bạn không thể tự mời chính mình")
+	ErrAlreadyGroupMember    = errors.New("người dùng đã là thành viên của nhóm")
+)
+
+// ensureCanInvite kiểm tra cài đặt group_invite_policy của người được mời. Hai người chặn nhau
+// không thể mời nhau vào nhóm.
+func (s *groupService) ensureCanInvite(ctx context.Context, inviterID int64, invitee *models.User) error {
+	friendship, err := s.friendshipRepo.FindByUserAndFriend(ctx, inviterID, invitee.ID)
+	if err != nil {
+		return err
+	}
+	if friendship != nil && friendship.Status == models.FriendshipStatusBlocked {
+		return ErrGroupInviteNotAllowed
+	}
+
+	switch invitee.GroupInvitePolicy {
+	case models.GroupInvitePolicyNobody:
+		return ErrGroupInviteNotAllowed
+	case models.GroupInvitePolicyFriends:
+		if friendship == nil || friendship.Status != models.FriendshipStatusAccepted {
+			return ErrGroupInviteNotAllowed
+		}
+	}
+	return nil
+}
+
+// ListMyInvitations lấy các lời mời vào nhóm gửi tới người dùng, mặc định là các lời mời đang chờ
+func (s *groupService) ListMyInvitations(ctx context.Context, userID int64, req *request.GroupInvitationListRequest) (*response.GroupInvitationListResponse, error) {
+	status := models.GroupInvitationStatus(req.Status)
+	if status == "" {
+		status = models.GroupInvitationStatusPending
+	}
+
+	invitations, total, err := s.invitationRepo.ListByInvitee(ctx, userID, status, req.Page, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy danh sách lời mời: %v", err)
+	}
+
+	resp := &response.GroupInvitationListResponse{
+		Invitations: make([]response.GroupInvitationResponse, len(invitations)),
+		Total:       total,
+		Page:        req.Page,
+		Size:        req.PageSize,
+	}
+	for i := range invitations {
+		resp.Invitations[i] = response.ConvertToGroupInvitationResponse(&invitations[i])
+	}
+
+	return resp, nil
+}
+
+// findOwnInvitation tìm lời mời gửi tới userID, lời mời của người khác được coi như không tồn tại
+func (s *groupService) findOwnInvitation(ctx context.Context, userID, invitationID int64) (*models.GroupInvitation, error) {
+	invitation, err := s.invitationRepo.FindByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation == nil || invitation.InviteeID != userID {
+		return nil, ErrInvitationNotFound
+	}
+	return invitation, nil
+}
+
+// AcceptInvitation chấp nhận lời mời và tham gia nhóm, không cần qua duyệt kể cả với nhóm riêng tư. Nhóm có
+// nội quy yêu cầu người được mời đồng ý nội quy.
+func (s *groupService) AcceptInvitation(ctx context.Context, userID, invitationID int64, req *request.GroupInvitationAcceptRequest) error {
+	invitation, err := s.findOwnInvitation(ctx, userID, invitationID)
+	if err != nil {
+		return err
+	}
+
+	group, err := s.groupRepo.FindByID(ctx, invitation.GroupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return ErrGroupNotFound
+	}
+
+	rulesAcceptedAt, err := s.checkRulesAccepted(ctx, group.ID, req.AcceptRules)
+	if err != nil {
+		return err
+	}
+
+	accepted, err := s.invitationRepo.Accept(ctx, invitation, rulesAcceptedAt != nil)
+	if err != nil {
+		return fmt.Errorf("lỗi khi chấp nhận lời mời: %v", err)
+	}
+	if !accepted {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// DeclineInvitation từ chối lời mời vào nhóm
+func (s *groupService) DeclineInvitation(ctx context.Context, userID, invitationID int64) error {
+	invitation, err := s.findOwnInvitation(ctx, userID, invitationID)
+	if err != nil {
+		return err
+	}
+
+	declined, err := s.invitationRepo.Decline(ctx, invitation.ID)
+	if err != nil {
+		return fmt.Errorf("lỗi khi từ chối lời mời: %v", err)
+	}
+	if !declined {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// ExpireInvitations đánh dấu hết hạn các lời mời chưa được trả lời quá invitationTTL
+func (s *groupService) ExpireInvitations(ctx context.Context) (int64, error) {
+	return s.invitationRepo.ExpireStale(ctx, time.Now())
+}
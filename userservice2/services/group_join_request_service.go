@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"userservice2/dto/request"
+	"userservice2/dto/response"
+	"userservice2/models"
+	"userservice2/repositories"
+)
+
+// Khai báo lỗi yêu cầu tham gia nhóm
+var (
+	ErrJoinRequestNotFound = errors.New("không tìm thấy yêu cầu tham gia")
+	ErrJoinRequestExists   = errors.New("bạn đã gửi yêu cầu tham gia nhóm này, vui lòng chờ duyệt")
+	ErrJoinAnswersRequired = errors.New("vui lòng trả lời tất cả câu hỏi của nhóm")
+)
+
+// createJoinRequest tạo yêu cầu tham gia nhóm riêng tư, bắt buộc trả lời đủ các câu hỏi của nhóm.
+// rulesAcceptedAt là thời điểm người gửi đồng ý nội quy, được ghi nhận cho thành viên khi yêu cầu được duyệt.
+func (s *groupService) createJoinRequest(ctx context.Context, userID int64, group *models.UserGroup, req *request.GroupJoinRequest, rulesAcceptedAt *time.Time) error {
+	existing, err := s.joinRequestRepo.FindPending(ctx, group.ID, userID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return ErrJoinRequestExists
+	}
+
+	var answers models.JoinRequestAnswers
+	if len(group.MembershipQuestions) > 0 {
+		if len(req.Answers) != len(group.MembershipQuestions) {
+			return ErrJoinAnswersRequired
+		}
+		answers = make(models.JoinRequestAnswers, len(group.MembershipQuestions))
+		for i, question := range group.MembershipQuestions {
+			answer := strings.TrimSpace(req.Answers[i])
+			if answer == "" {
+				return ErrJoinAnswersRequired
+			}
+			answers[i] = models.JoinRequestAnswer{Question: question, Answer: answer}
+		}
+	}
+
+	joinRequest := &models.GroupJoinRequest{
+		GroupID:         group.ID,
+		UserID:          userID,
+		Nickname:        req.Nickname,
+		Message:         strings.TrimSpace(req.Message),
+		Answers:         answers,
+		Status:          models.JoinRequestStatusPending,
+		RulesAcceptedAt: rulesAcceptedAt,
+	}
+	if s.joinRequestTTL > 0 {
+		expiresAt := time.Now().Add(s.joinRequestTTL)
+		joinRequest.ExpiresAt = &expiresAt
+	}
+
+	if err := s.joinRequestRepo.Create(ctx, joinRequest); err != nil {
+		return fmt.Errorf("lỗi khi gửi yêu cầu tham gia: %v", err)
+	}
+	return nil
+}
+
+// GetMembershipQuestions lấy các câu hỏi cho người xin tham gia nhóm, ai thấy được nhóm cũng xem được để trả lời
+// trước khi gửi yêu cầu
+func (s *groupService) GetMembershipQuestions(ctx context.Context, userID, groupID int64) ([]string, error) {
+	group, err := s.findVisibleGroup(ctx, userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	questions := []string(group.MembershipQuestions)
+	if questions == nil {
+		questions = []string{}
+	}
+	return questions, nil
+}
+
+// UpdateMembershipQuestions thay toàn bộ câu hỏi cho người xin tham gia, cần quyền edit_group.
+// Các yêu cầu đã gửi giữ nguyên câu hỏi lúc gửi.
+func (s *groupService) UpdateMembershipQuestions(ctx context.Context, userID, groupID int64, req *request.GroupQuestionsRequest) ([]string, error) {
+	group, err := s.groupRepo.FindByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	allowed, err := s.HasGroupPermission(ctx, userID, groupID, models.GroupPermissionEditGroup)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, ErrGroupPermissionDenied
+	}
+
+	questions := make([]string, 0, len(req.Questions))
+	for _, question := range req.Questions {
+		if question = strings.TrimSpace(question); question != "" {
+			questions = append(questions, question)
+		}
+	}
+
+	group.MembershipQuestions = questions
+	if err := s.groupRepo.Update(ctx, group); err != nil {
+		return nil, fmt.Errorf("lỗi khi cập nhật câu hỏi: %v", err)
+	}
+	return questions, nil
+}
+
+// GetMyJoinRequest lấy yêu cầu tham gia đang chờ của người dùng trong nhóm
+func (s *groupService) GetMyJoinRequest(ctx context.Context, userID, groupID int64) (*response.GroupJoinRequestResponse, error) {
+	joinRequest, err := s.joinRequestRepo.FindPending(ctx, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if joinRequest == nil {
+		return nil, ErrJoinRequestNotFound
+	}
+
+	resp := response.ConvertToGroupJoinRequestResponse(joinRequest)
+	return &resp, nil
+}
+
+// WithdrawJoinRequest rút lại yêu cầu tham gia đang chờ của người dùng
+func (s *groupService) WithdrawJoinRequest(ctx context.Context, userID, groupID int64) error {
+	joinRequest, err := s.joinRequestRepo.FindPending(ctx, groupID, userID)
+	if err != nil {
+		return err
+	}
+	if joinRequest == nil {
+		return ErrJoinRequestNotFound
+	}
+
+	withdrawn, err := s.joinRequestRepo.Close(ctx, joinRequest.ID, models.JoinRequestStatusWithdrawn, nil)
+	if err != nil {
+		return fmt.Errorf("lỗi khi rút lại yêu cầu tham gia: %v", err)
+	}
+	if !withdrawn {
+		return ErrJoinRequestNotFound
+	}
+	return nil
+}
+
+// authorizeJoinRequestReviewer kiểm tra nhóm tồn tại và userID có quyền approve_members
+func (s *groupService) authorizeJoinRequestReviewer(ctx context.Context, userID, groupID int64) error {
+	group, err := s.groupRepo.FindByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return ErrGroupNotFound
+	}
+
+	allowed, err := s.HasGroupPermission(ctx, userID, groupID, models.GroupPermissionApproveMembers)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrGroupPermissionDenied
+	}
+	return nil
+}
+
+// ListJoinRequests lấy danh sách yêu cầu tham gia của nhóm, mặc định là các yêu cầu đang chờ
+func (s *groupService) ListJoinRequests(ctx context.Context, userID, groupID int64, req *request.GroupJoinRequestListRequest) (*response.GroupJoinRequestListResponse, error) {
+	if err := s.authorizeJoinRequestReviewer(ctx, userID, groupID); err != nil {
+		return nil, err
+	}
+
+	filter := repositories.JoinRequestFilter{
+		Status: models.JoinRequestStatus(req.Status),
+		Query:  strings.TrimSpace(req.Query),
+		Oldest: req.Sort == "oldest",
+	}
+	if filter.Status == "" {
+		filter.Status = models.JoinRequestStatusPending
+	}
+
+	joinRequests, total, err := s.joinRequestRepo.List(ctx, groupID, filter, req.Page, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy danh sách yêu cầu tham gia: %v", err)
+	}
+
+	resp := &response.GroupJoinRequestListResponse{
+		Requests: make([]response.GroupJoinRequestResponse, len(joinRequests)),
+		Total:    total,
+		Page:     req.Page,
+		Size:     req.PageSize,
+	}
+	for i := range joinRequests {
+		resp.Requests[i] = response.ConvertToGroupJoinRequestResponse(&joinRequests[i])
+	}
+
+	return resp, nil
+}
+
+// BulkApproveJoinRequests chấp nhận nhiều yêu cầu tham gia cùng lúc
+func (s *groupService) BulkApproveJoinRequests(ctx context.Context, userID, groupID int64, req *request.GroupJoinRequestBulkRequest) (*response.GroupJoinRequestBulkResponse, error) {
+	return s.processJoinRequests(ctx, userID, groupID, req.RequestIDs, func(joinRequest *models.GroupJoinRequest) (bool, error) {
+		return s.joinRequestRepo.Approve(ctx, joinRequest, userID)
+	})
+}
+
+// BulkRejectJoinRequests từ chối nhiều yêu cầu tham gia cùng lúc
+func (s *groupService) BulkRejectJoinRequests(ctx context.Context, userID, groupID int64, req *request.GroupJoinRequestBulkRequest) (*response.GroupJoinRequestBulkResponse, error) {
+	return s.processJoinRequests(ctx, userID, groupID, req.RequestIDs, func(joinRequest *models.GroupJoinRequest) (bool, error) {
+		return s.joinRequestRepo.Close(ctx, joinRequest.ID, models.JoinRequestStatusRejected, &userID)
+	})
+}
+
+// processJoinRequests áp dụng process cho từng yêu cầu đang chờ trong ids. Các ID không tồn tại, không thuộc
+// nhóm hoặc đã được xử lý được trả về trong SkippedIDs thay vì làm hỏng cả lô.
+func (s *groupService) processJoinRequests(ctx context.Context, userID, groupID int64, ids []int64, process func(*models.GroupJoinRequest) (bool, error)) (*response.GroupJoinRequestBulkResponse, error) {
+	if err := s.authorizeJoinRequestReviewer(ctx, userID, groupID); err != nil {
+		return nil, err
+	}
+
+	joinRequests, err := s.joinRequestRepo.FindPendingByIDs(ctx, groupID, ids)
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[int64]*models.GroupJoinRequest, len(joinRequests))
+	for i := range joinRequests {
+		pending[joinRequests[i].ID] = &joinRequests[i]
+	}
+
+	resp := &response.GroupJoinRequestBulkResponse{
+		ProcessedIDs: []int64{},
+		SkippedIDs:   []int64{},
+	}
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		joinRequest, ok := pending[id]
+		if !ok {
+			resp.SkippedIDs = append(resp.SkippedIDs, id)
+			continue
+		}
+		processed, err := process(joinRequest)
+		if err != nil {
+			return nil, fmt.Errorf("lỗi khi xử lý yêu cầu %d: %v", id, err)
+		}
+		if processed {
+			resp.ProcessedIDs = append(resp.ProcessedIDs, id)
+		} else {
+			resp.SkippedIDs = append(resp.SkippedIDs, id)
+		}
+	}
+
+	return resp, nil
+}
+
+// ExpireJoinRequests đánh dấu hết hạn các yêu cầu tham gia đang chờ quá joinRequestTTL
+func (s *groupService) ExpireJoinRequests(ctx context.Context) (int64, error) {
+	return s.joinRequestRepo.ExpireStale(ctx, time.Now())
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"userservice2/dto/request"
+	"userservice2/dto/response"
+	"userservice2/models"
+)
+
+// Khai báo lỗi kiểm duyệt nhóm
+var (
+	ErrBannedFromGroup     = errors.New("bạn đã bị cấm tham gia nhóm này")
+	ErrUserBannedFromGroup = errors.New("người dùng đã bị cấm khỏi nhóm này")
+	ErrGroupBanNotFound    = errors.New("người dùng không bị cấm khỏi nhóm này")
+	ErrCannotModerateSelf  = errors.New("bạn không thể tự cấm hoặc tắt tiếng chính mình")
+	ErrCannotModerateOwner = errors.New("không thể cấm hoặc tắt tiếng chủ nhóm")
+	ErrCannotModerateAdmin = errors.New("chỉ admin mới có thể cấm hoặc tắt tiếng admin khác")
+)
+
+// authorizeModerator kiểm tra nhóm tồn tại và userID có quyền permission, trả về nhóm và thành viên thực hiện
+func (s *groupService) authorizeModerator(ctx context.Context, userID, groupID int64, permission string) (*models.UserGroup, *models.GroupMember, error) {
+	group, err := s.groupRepo.FindByID(ctx, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if group == nil {
+		return nil, nil, ErrGroupNotFound
+	}
+
+	actor, permissions, err := s.memberPermissions(ctx, userID, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !permissions.Has(permission) {
+		return nil, nil, ErrGroupPermissionDenied
+	}
+	return group, actor, nil
+}
+
+// checkModerationTarget kiểm tra actor được phép cấm/tắt tiếng targetUserID. target là tư cách thành viên
+// hiện tại của người đó, nil nếu họ không còn trong nhóm.
+func checkModerationTarget(group *models.UserGroup, actor, target *models.GroupMember, targetUserID int64) error {
+	if actor.UserID == targetUserID {
+		return ErrCannotModerateSelf
+	}
+	if group.OwnerID == targetUserID {
+		return ErrCannotModerateOwner
+	}
+	if target != nil && target.Role == models.MemberRoleAdmin && actor.Role != models.MemberRoleAdmin {
+		return ErrCannotModerateAdmin
+	}
+	return nil
+}
+
+// isBanned kiểm tra userID có đang bị cấm khỏi nhóm không
+func (s *groupService) isBanned(ctx context.Context, groupID, userID int64) (bool, error) {
+	ban, err := s.moderationRepo.FindBan(ctx, groupID, userID)
+	if err != nil {
+		return false, err
+	}
+	return ban != nil, nil
+}
+
+// BanMember cấm người dùng khỏi nhóm, cần quyền remove_members. Người bị cấm bị xóa khỏi nhóm nếu đang là
+// thành viên, các yêu cầu tham gia và lời mời đang chờ của họ bị đóng.
+func (s *groupService) BanMember(ctx context.Context, userID, groupID int64, req *request.GroupBanRequest) (*response.GroupBanResponse, error) {
+	group, actor, err := s.authorizeModerator(ctx, userID, groupID, models.GroupPermissionRemoveMembers)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, ErrUserNotFound
+	}
+	member, err := s.memberRepo.FindByUserAndGroup(ctx, req.UserID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkModerationTarget(group, actor, member, req.UserID); err != nil {
+		return nil, err
+	}
+
+	ban := &models.GroupBan{
+		GroupID:  groupID,
+		UserID:   req.UserID,
+		BannedBy: userID,
+		Reason:   req.Reason,
+	}
+	entry := &models.GroupModerationLog{
+		GroupID:      groupID,
+		ActorID:      &userID,
+		TargetUserID: req.UserID,
+		Action:       models.GroupModerationMemberBan,
+		Reason:       req.Reason,
+	}
+	if err := s.moderationRepo.Ban(ctx, ban, entry); err != nil {
+		return nil, fmt.Errorf("lỗi khi cấm thành viên: %v", err)
+	}
+
+	ban.User = *target
+	resp := response.ConvertToGroupBanResponse(ban)
+	return &resp, nil
+}
+
+// UnbanMember gỡ cấm người dùng khỏi nhóm, cần quyền remove_members
+func (s *groupService) UnbanMember(ctx context.Context, userID, groupID, targetUserID int64) error {
+	if _, _, err := s.authorizeModerator(ctx, userID, groupID, models.GroupPermissionRemoveMembers); err != nil {
+		return err
+	}
+
+	entry := &models.GroupModerationLog{
+		GroupID:      groupID,
+		ActorID:      &userID,
+		TargetUserID: targetUserID,
+		Action:       models.GroupModerationMemberUnban,
+	}
+	unbanned, err := s.moderationRepo.Unban(ctx, groupID, targetUserID, entry)
+	if err != nil {
+		return fmt.Errorf("lỗi khi gỡ cấm thành viên: %v", err)
+	}
+	if !unbanned {
+		return ErrGroupBanNotFound
+	}
+	return nil
+}
+
+// ListBans lấy danh sách người bị cấm khỏi nhóm, cần quyền remove_members
+func (s *groupService) ListBans(ctx context.Context, userID, groupID int64, req *request.GroupBanListRequest) (*response.GroupBanListResponse, error) {
+	if _, _, err := s.authorizeModerator(ctx, userID, groupID, models.GroupPermissionRemoveMembers); err != nil {
+		return nil, err
+	}
+
+	bans, total, err := s.moderationRepo.ListBans(ctx, groupID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy danh sách người bị cấm: %v", err)
+	}
+
+	resp := &response.GroupBanListResponse{
+		Bans:  make([]response.GroupBanResponse, len(bans)),
+		Total: total,
+		Page:  req.Page,
+		Size:  req.PageSize,
+	}
+	for i := range bans {
+		resp.Bans[i] = response.ConvertToGroupBanResponse(&bans[i])
+	}
+
+	return resp, nil
+}
+
+// MuteMember tắt tiếng thành viên trong DurationMinutes phút (vô thời hạn nếu không có), cần quyền
+// mute_members. Tắt tiếng lại sẽ thay thời hạn và lý do cũ.
+func (s *groupService) MuteMember(ctx context.Context, userID, groupID, memberID int64, req *request.GroupMuteRequest) (*response.GroupMemberResponse, error) {
+	group, actor, err := s.authorizeModerator(ctx, userID, groupID, models.GroupPermissionMuteMembers)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.findApprovedMember(ctx, groupID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkModerationTarget(group, actor, member, member.UserID); err != nil {
+		return nil, err
+	}
+
+	var mutedUntil *time.Time
+	details := "until=indefinite"
+	if req.DurationMinutes > 0 {
+		until := time.Now().Add(time.Duration(req.DurationMinutes) * time.Minute)
+		mutedUntil = &until
+		details = "until=" + until.Format(time.RFC3339)
+	}
+
+	entry := &models.GroupModerationLog{
+		GroupID:      groupID,
+		ActorID:      &userID,
+		TargetUserID: member.UserID,
+		Action:       models.GroupModerationMemberMute,
+		Reason:       req.Reason,
+		Details:      details,
+	}
+	if err := s.moderationRepo.SetMute(ctx, member.ID, mutedUntil, req.Reason, entry); err != nil {
+		return nil, fmt.Errorf("lỗi khi tắt tiếng thành viên: %v", err)
+	}
+
+	return s.GetGroupMemberByID(ctx, member.ID)
+}
+
+// UnmuteMember gỡ tắt tiếng thành viên, cần quyền mute_members
+func (s *groupService) UnmuteMember(ctx context.Context, userID, groupID, memberID int64) error {
+	group, actor, err := s.authorizeModerator(ctx, userID, groupID, models.GroupPermissionMuteMembers)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.findApprovedMember(ctx, groupID, memberID)
+	if err != nil {
+		return err
+	}
+	if err := checkModerationTarget(group, actor, member, member.UserID); err != nil {
+		return err
+	}
+	if !member.IsMuted {
+		return nil
+	}
+
+	entry := &models.GroupModerationLog{
+		GroupID:      groupID,
+		ActorID:      &userID,
+		TargetUserID: member.UserID,
+		Action:       models.GroupModerationMemberUnmute,
+	}
+	if err := s.moderationRepo.ClearMute(ctx, member.ID, entry); err != nil {
+		return fmt.Errorf("lỗi khi gỡ tắt tiếng thành viên: %v", err)
+	}
+	return nil
+}
+
+// ListModerationLog lấy nhật ký kiểm duyệt của nhóm, cần quyền remove_members hoặc mute_members
+func (s *groupService) ListModerationLog(ctx context.Context, userID, groupID int64, req *request.GroupModerationLogListRequest) (*response.GroupModerationLogListResponse, error) {
+	group, err := s.groupRepo.FindByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	_, permissions, err := s.memberPermissions(ctx, userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if !permissions.Has(models.GroupPermissionRemoveMembers) && !permissions.Has(models.GroupPermissionMuteMembers) {
+		return nil, ErrGroupPermissionDenied
+	}
+
+	logs, total, err := s.moderationRepo.ListLogs(ctx, groupID, models.GroupModerationAction(req.Action), req.Page, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy nhật ký kiểm duyệt: %v", err)
+	}
+
+	resp := &response.GroupModerationLogListResponse{
+		Logs:  make([]response.GroupModerationLogResponse, len(logs)),
+		Total: total,
+		Page:  req.Page,
+		Size:  req.PageSize,
+	}
+	for i := range logs {
+		resp.Logs[i] = response.ConvertToGroupModerationLogResponse(&logs[i])
+	}
+
+	return resp, nil
+}
+
+// ExpireMutes gỡ tắt tiếng các thành viên đã hết thời hạn tắt tiếng
+func (s *groupService) ExpireMutes(ctx context.Context) (int64, error) {
+	return s.moderationRepo.ExpireMutes(ctx, time.Now())
+}
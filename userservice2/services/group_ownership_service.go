@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"userservice2/dto/request"
+	"userservice2/dto/response"
+	"userservice2/models"
+)
+
+// Khai báo lỗi quyền sở hữu và quản trị nhóm
+var (
+	ErrNotGroupOwner             = errors.New("chỉ chủ nhóm mới có thể thực hiện thao tác này")
+	ErrOwnershipTransferNotFound = errors.New("không có yêu cầu chuyển quyền sở hữu nào đang chờ")
+	ErrOwnerCannotLeave          = errors.New("chủ nhóm cần chuyển quyền sở hữu hoặc xóa nhóm trước khi rời nhóm")
+	ErrCannotRemoveOwner         = errors.New("không thể xóa chủ nhóm")
+	ErrCannotDemoteOwner         = errors.New("không thể hạ vai trò của chủ nhóm")
+	ErrLastGroupAdmin            = errors.New("bạn là admin cuối cùng của nhóm, hãy chỉ định admin khác trước")
+)
+
+// ensureNotLastAdmin trả về ErrLastGroupAdmin nếu member là admin hoạt động duy nhất còn lại của nhóm
+func (s *groupService) ensureNotLastAdmin(ctx context.Context, member *models.GroupMember) error {
+	if member.Role != models.MemberRoleAdmin {
+		return nil
+	}
+
+	count, err := s.memberRepo.CountActiveAdmins(ctx, member.GroupID)
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return ErrLastGroupAdmin
+	}
+	return nil
+}
+
+// findOwnedGroup lấy nhóm và kiểm tra userID là chủ nhóm
+func (s *groupService) findOwnedGroup(ctx context.Context, userID, groupID int64) (*models.UserGroup, error) {
+	group, err := s.groupRepo.FindByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+	if group.OwnerID != userID {
+		return nil, ErrNotGroupOwner
+	}
+	return group, nil
+}
+
+// RequestOwnershipTransfer gửi yêu cầu chuyển quyền sở hữu nhóm cho một thành viên. Quyền sở hữu chỉ đổi khi
+// người nhận xác nhận, yêu cầu mới thay thế yêu cầu đang chờ trước đó.
+func (s *groupService) RequestOwnershipTransfer(ctx context.Context, userID, groupID int64, req *request.GroupMemberActionRequest) (*response.GroupOwnershipTransferResponse, error) {
+	if _, err := s.findOwnedGroup(ctx, userID, groupID); err != nil {
+		return nil, err
+	}
+	if req.UserID == userID {
+		return nil, ErrAlreadyGroupOwner
+	}
+
+	member, err := s.memberRepo.FindByUserAndGroup(ctx, req.UserID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.Status != models.GroupMemberStatusApproved {
+		return nil, ErrGroupMemberNotFound
+	}
+	recipient, err := s.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if recipient == nil || !recipient.IsActive {
+		return nil, ErrUserNotFound
+	}
+
+	transfer := &models.GroupOwnershipTransfer{
+		GroupID:    groupID,
+		FromUserID: userID,
+		ToUserID:   req.UserID,
+		Status:     models.GroupOwnershipTransferStatusPending,
+	}
+	if err := s.ownershipRepo.CreateTransfer(ctx, transfer); err != nil {
+		return nil, fmt.Errorf("lỗi khi tạo yêu cầu chuyển quyền sở hữu: %v", err)
+	}
+
+	return s.GetOwnershipTransfer(ctx, userID, groupID)
+}
+
+// GetOwnershipTransfer lấy yêu cầu chuyển quyền sở hữu đang chờ, chỉ admin của nhóm và người nhận xem được
+func (s *groupService) GetOwnershipTransfer(ctx context.Context, userID, groupID int64) (*response.GroupOwnershipTransferResponse, error) {
+	transfer, err := s.ownershipRepo.FindPendingTransfer(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer == nil {
+		return nil, ErrOwnershipTransferNotFound
+	}
+
+	if transfer.ToUserID != userID {
+		member, err := s.memberRepo.FindByUserAndGroup(ctx, userID, groupID)
+		if err != nil {
+			return nil, err
+		}
+		if member == nil || member.Status != models.GroupMemberStatusApproved || member.Role != models.MemberRoleAdmin {
+			return nil, ErrOwnershipTransferNotFound
+		}
+	}
+
+	resp := response.ConvertToGroupOwnershipTransferResponse(transfer)
+	return &resp, nil
+}
+
+// CancelOwnershipTransfer hủy yêu cầu chuyển quyền sở hữu đang chờ, chỉ chủ nhóm được hủy
+func (s *groupService) CancelOwnershipTransfer(ctx context.Context, userID, groupID int64) error {
+	if _, err := s.findOwnedGroup(ctx, userID, groupID); err != nil {
+		return err
+	}
+
+	transfer, err := s.ownershipRepo.FindPendingTransfer(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if transfer == nil {
+		return ErrOwnershipTransferNotFound
+	}
+
+	cancelled, err := s.ownershipRepo.CloseTransfer(ctx, transfer.ID, models.GroupOwnershipTransferStatusCancelled)
+	if err != nil {
+		return fmt.Errorf("lỗi khi hủy yêu cầu chuyển quyền sở hữu: %v", err)
+	}
+	if !cancelled {
+		return ErrOwnershipTransferNotFound
+	}
+	return nil
+}
+
+// findTransferFor tìm yêu cầu chuyển quyền sở hữu đang chờ gửi tới userID
+func (s *groupService) findTransferFor(ctx context.Context, userID, groupID int64) (*models.GroupOwnershipTransfer, error) {
+	transfer, err := s.ownershipRepo.FindPendingTransfer(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer == nil || transfer.ToUserID != userID {
+		return nil, ErrOwnershipTransferNotFound
+	}
+	return transfer, nil
+}
+
+// AcceptOwnershipTransfer xác nhận nhận quyền sở hữu nhóm. Người nhận trở thành chủ nhóm và admin,
+// chủ cũ vẫn là admin.
+func (s *groupService) AcceptOwnershipTransfer(ctx context.Context, userID, groupID int64) error {
+	transfer, err := s.findTransferFor(ctx, userID, groupID)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.memberRepo.FindByUserAndGroup(ctx, userID, groupID)
+	if err != nil {
+		return err
+	}
+	if member == nil || member.Status != models.GroupMemberStatusApproved {
+		return ErrGroupMemberNotFound
+	}
+
+	accepted, err := s.ownershipRepo.AcceptTransfer(ctx, transfer)
+	if err != nil {
+		return fmt.Errorf("lỗi khi nhận quyền sở hữu: %v", err)
+	}
+	if !accepted {
+		return ErrOwnershipTransferNotFound
+	}
+	return nil
+}
+
+// DeclineOwnershipTransfer từ chối nhận quyền sở hữu nhóm
+func (s *groupService) DeclineOwnershipTransfer(ctx context.Context, userID, groupID int64) error {
+	transfer, err := s.findTransferFor(ctx, userID, groupID)
+	if err != nil {
+		return err
+	}
+
+	declined, err := s.ownershipRepo.CloseTransfer(ctx, transfer.ID, models.GroupOwnershipTransferStatusDeclined)
+	if err != nil {
+		return fmt.Errorf("lỗi khi từ chối quyền sở hữu: %v", err)
+	}
+	if !declined {
+		return ErrOwnershipTransferNotFound
+	}
+	return nil
+}
+
+// RecoverGroupLeadership chỉ định admin và chủ nhóm mới cho các nhóm không còn admin hoặc chủ nhóm hoạt động
+func (s *groupService) RecoverGroupLeadership(ctx context.Context) (int64, error) {
+	return s.ownershipRepo.RecoverLeadership(ctx)
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"userservice2/models"
+)
+
+// ErrSecretGroupInviteOnly là lỗi khi xin tham gia nhóm bí mật mà không qua lời mời
+var ErrSecretGroupInviteOnly = errors.New("nhóm bí mật chỉ có thể tham gia qua lời mời")
+
+// findVisibleGroup lấy nhóm mà userID được phép biết đến. Nhóm bí mật chỉ hiện với thành viên đã duyệt và
+// người đang có lời mời chờ trả lời, với những người khác trả về ErrGroupNotFound như nhóm không tồn tại.
+func (s *groupService) findVisibleGroup(ctx context.Context, userID, groupID int64) (*models.UserGroup, error) {
+	group, err := s.groupRepo.FindByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+	if group.Privacy != models.GroupPrivacySecret {
+		return group, nil
+	}
+
+	visible, err := s.canSeeSecretGroup(ctx, userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, ErrGroupNotFound
+	}
+	return group, nil
+}
+
+// canSeeSecretGroup kiểm tra userID là thành viên đã duyệt hoặc đang được mời vào nhóm bí mật
+func (s *groupService) canSeeSecretGroup(ctx context.Context, userID, groupID int64) (bool, error) {
+	if userID == 0 {
+		return false, nil
+	}
+
+	member, err := s.memberRepo.FindByUserAndGroup(ctx, userID, groupID)
+	if err != nil {
+		return false, err
+	}
+	if member != nil && member.Status == models.GroupMemberStatusApproved {
+		return true, nil
+	}
+
+	invitation, err := s.invitationRepo.FindPending(ctx, groupID, userID)
+	if err != nil {
+		return false, err
+	}
+	return invitation != nil, nil
+}
@@ -0,0 +1,342 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"userservice2/dto/request"
+	"userservice2/dto/response"
+	"userservice2/models"
+)
+
+// Khai báo lỗi vai trò trong nhóm
+var (
+	ErrGroupPermissionDenied  = errors.New("bạn không có quyền thực hiện thao tác này trong nhóm")
+	ErrGroupRoleNotFound      = errors.New("vai trò không tồn tại")
+	ErrGroupRoleNameTaken     = errors.New("tên vai trò đã tồn tại trong nhóm")
+	ErrGroupRoleNotAssigned   = errors.New("thành viên không có vai trò này")
+	ErrInvalidGroupPermission = errors.New("quyền không hợp lệ")
+	ErrGroupMemberNotFound    = errors.New("không tìm thấy thành viên")
+)
+
+// memberPermissions trả về thành viên đã được duyệt của userID trong nhóm cùng các quyền hiệu lực.
+// Admin có tất cả các quyền, thành viên khác có hợp các quyền của những vai trò được gán.
+// Người không phải thành viên nhận về member nil và không có quyền nào.
+func (s *groupService) memberPermissions(ctx context.Context, userID, groupID int64) (*models.GroupMember, models.RolePermissions, error) {
+	permissions := models.RolePermissions{}
+
+	member, err := s.memberRepo.FindByUserAndGroup(ctx, userID, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if member == nil || member.Status != models.GroupMemberStatusApproved {
+		return nil, permissions, nil
+	}
+
+	if member.Role == models.MemberRoleAdmin {
+		for _, permission := range models.GroupPermissions {
+			permissions[permission] = true
+		}
+		return member, permissions, nil
+	}
+
+	roles, err := s.roleRepo.ListByMember(ctx, member.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, role := range roles {
+		for permission, granted := range role.Permissions {
+			if granted {
+				permissions[permission] = true
+			}
+		}
+	}
+	return member, permissions, nil
+}
+
+// HasGroupPermission kiểm tra userID có quyền permission trong nhóm không
+func (s *groupService) HasGroupPermission(ctx context.Context, userID, groupID int64, permission string) (bool, error) {
+	_, permissions, err := s.memberPermissions(ctx, userID, groupID)
+	if err != nil {
+		return false, err
+	}
+	return permissions.Has(permission), nil
+}
+
+// GetPostingStatus cho biết userID có phải thành viên đã duyệt của nhóm và có đang bị tắt tiếng không,
+// dùng để kiểm tra quyền đăng bài vào nhóm ở PostService
+func (s *groupService) GetPostingStatus(ctx context.Context, userID, groupID int64) (bool, bool, error) {
+	member, err := s.memberRepo.FindByUserAndGroup(ctx, userID, groupID)
+	if err != nil {
+		return false, false, err
+	}
+	if member == nil || member.Status != models.GroupMemberStatusApproved {
+		return false, false, nil
+	}
+	muted := member.IsMuted && (member.MutedUntil == nil || member.MutedUntil.After(time.Now()))
+	return true, muted, nil
+}
+
+// GetMemberGroupIDs lấy các nhóm mà userID là thành viên đã duyệt, dùng để PostService chỉ hiển thị
+// bài đăng trong nhóm cho thành viên của nhóm
+func (s *groupService) GetMemberGroupIDs(ctx context.Context, userID int64) ([]int64, error) {
+	return s.memberRepo.FindApprovedGroupIDs(ctx, userID)
+}
+
+// permissionList chuyển các quyền đang bật sang danh sách theo thứ tự của models.GroupPermissions
+func permissionList(permissions models.RolePermissions) []string {
+	list := make([]string, 0, len(permissions))
+	for _, permission := range models.GroupPermissions {
+		if permissions.Has(permission) {
+			list = append(list, permission)
+		}
+	}
+	return list
+}
+
+// authorizeRoleManager kiểm tra userID có quyền manage_roles trong nhóm và trả về các quyền của họ
+func (s *groupService) authorizeRoleManager(ctx context.Context, userID, groupID int64) (models.RolePermissions, error) {
+	group, err := s.groupRepo.FindByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	_, permissions, err := s.memberPermissions(ctx, userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if !permissions.Has(models.GroupPermissionManageRoles) {
+		return nil, ErrGroupPermissionDenied
+	}
+	return permissions, nil
+}
+
+// ensureCanGrant kiểm tra người quản lý có đủ tất cả các quyền của vai trò, để không ai tự nâng
+// quyền của mình hoặc người khác lên cao hơn quyền đang có
+func ensureCanGrant(manager, role models.RolePermissions) error {
+	for permission, granted := range role {
+		if granted && !manager.Has(permission) {
+			return fmt.Errorf("%w: bạn không có quyền %s", ErrGroupPermissionDenied, permission)
+		}
+	}
+	return nil
+}
+
+// normalizePermissions kiểm tra các quyền hợp lệ và chỉ giữ lại các quyền được bật
+func normalizePermissions(requested map[string]bool) (models.RolePermissions, error) {
+	permissions := models.RolePermissions{}
+	for permission, granted := range requested {
+		if !models.IsValidGroupPermission(permission) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidGroupPermission, permission)
+		}
+		if granted {
+			permissions[permission] = true
+		}
+	}
+	return permissions, nil
+}
+
+// findGroupRole tìm vai trò thuộc nhóm groupID
+func (s *groupService) findGroupRole(ctx context.Context, groupID, roleID int64) (*models.GroupRole, error) {
+	role, err := s.roleRepo.FindByID(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil || role.GroupID != groupID {
+		return nil, ErrGroupRoleNotFound
+	}
+	return role, nil
+}
+
+// ensureRoleNameAvailable kiểm tra tên vai trò chưa được dùng trong nhóm (trừ chính vai trò exceptID)
+func (s *groupService) ensureRoleNameAvailable(ctx context.Context, groupID int64, name string, exceptID int64) error {
+	existing, err := s.roleRepo.FindByName(ctx, groupID, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != exceptID {
+		return ErrGroupRoleNameTaken
+	}
+	return nil
+}
+
+// CreateGroupRole tạo vai trò mới trong nhóm
+func (s *groupService) CreateGroupRole(ctx context.Context, userID, groupID int64, req *request.GroupRoleCreateRequest) (*response.GroupRoleResponse, error) {
+	managerPermissions, err := s.authorizeRoleManager(ctx, userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := normalizePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureCanGrant(managerPermissions, permissions); err != nil {
+		return nil, err
+	}
+	if err := s.ensureRoleNameAvailable(ctx, groupID, req.Name, 0); err != nil {
+		return nil, err
+	}
+
+	role := &models.GroupRole{
+		GroupID:     groupID,
+		Name:        req.Name,
+		Permissions: permissions,
+	}
+	if err := s.roleRepo.Create(ctx, role); err != nil {
+		return nil, fmt.Errorf("lỗi khi tạo vai trò: %v", err)
+	}
+
+	resp := response.ConvertToGroupRoleResponse(role)
+	return &resp, nil
+}
+
+// UpdateGroupRole cập nhật tên và/hoặc quyền của vai trò trong nhóm
+func (s *groupService) UpdateGroupRole(ctx context.Context, userID, groupID, roleID int64, req *request.GroupRoleUpdateRequest) (*response.GroupRoleResponse, error) {
+	managerPermissions, err := s.authorizeRoleManager(ctx, userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.findGroupRole(ctx, groupID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureCanGrant(managerPermissions, role.Permissions); err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" && req.Name != role.Name {
+		if err := s.ensureRoleNameAvailable(ctx, groupID, req.Name, role.ID); err != nil {
+			return nil, err
+		}
+		role.Name = req.Name
+	}
+	if req.Permissions != nil {
+		permissions, err := normalizePermissions(req.Permissions)
+		if err != nil {
+			return nil, err
+		}
+		if err := ensureCanGrant(managerPermissions, permissions); err != nil {
+			return nil, err
+		}
+		role.Permissions = permissions
+	}
+
+	if err := s.roleRepo.Update(ctx, role); err != nil {
+		return nil, fmt.Errorf("lỗi khi cập nhật vai trò: %v", err)
+	}
+
+	resp := response.ConvertToGroupRoleResponse(role)
+	return &resp, nil
+}
+
+// DeleteGroupRole xóa vai trò trong nhóm, các thành viên đang có vai trò này mất các quyền tương ứng
+func (s *groupService) DeleteGroupRole(ctx context.Context, userID, groupID, roleID int64) error {
+	managerPermissions, err := s.authorizeRoleManager(ctx, userID, groupID)
+	if err != nil {
+		return err
+	}
+
+	role, err := s.findGroupRole(ctx, groupID, roleID)
+	if err != nil {
+		return err
+	}
+	if err := ensureCanGrant(managerPermissions, role.Permissions); err != nil {
+		return err
+	}
+
+	return s.roleRepo.Delete(ctx, role.ID)
+}
+
+// GetGroupRoles lấy danh sách vai trò trong nhóm
+func (s *groupService) GetGroupRoles(ctx context.Context, userID, groupID int64) (*response.GroupRoleListResponse, error) {
+	if _, err := s.findVisibleGroup(ctx, userID, groupID); err != nil {
+		return nil, err
+	}
+
+	roles, err := s.roleRepo.ListByGroup(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy danh sách vai trò: %v", err)
+	}
+
+	resp := &response.GroupRoleListResponse{
+		Roles: make([]response.GroupRoleResponse, len(roles)),
+		Total: int64(len(roles)),
+		Page:  1,
+		Size:  len(roles),
+	}
+	for i := range roles {
+		resp.Roles[i] = response.ConvertToGroupRoleResponse(&roles[i])
+	}
+
+	return resp, nil
+}
+
+// findApprovedMember tìm thành viên đã được duyệt theo ID thành viên trong nhóm groupID
+func (s *groupService) findApprovedMember(ctx context.Context, groupID, memberID int64) (*models.GroupMember, error) {
+	member, err := s.memberRepo.FindByID(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.GroupID != groupID || member.Status != models.GroupMemberStatusApproved {
+		return nil, ErrGroupMemberNotFound
+	}
+	return member, nil
+}
+
+// AssignRoleToMember gán vai trò cho thành viên
+func (s *groupService) AssignRoleToMember(ctx context.Context, userID, groupID, memberID int64, req *request.GroupMemberRoleRequest) error {
+	managerPermissions, err := s.authorizeRoleManager(ctx, userID, groupID)
+	if err != nil {
+		return err
+	}
+
+	role, err := s.findGroupRole(ctx, groupID, req.RoleID)
+	if err != nil {
+		return err
+	}
+	if err := ensureCanGrant(managerPermissions, role.Permissions); err != nil {
+		return err
+	}
+	member, err := s.findApprovedMember(ctx, groupID, memberID)
+	if err != nil {
+		return err
+	}
+
+	return s.roleRepo.AssignToMember(ctx, member.ID, role.ID)
+}
+
+// RemoveRoleFromMember gỡ bỏ vai trò khỏi thành viên
+func (s *groupService) RemoveRoleFromMember(ctx context.Context, userID, groupID, memberID, roleID int64) error {
+	managerPermissions, err := s.authorizeRoleManager(ctx, userID, groupID)
+	if err != nil {
+		return err
+	}
+
+	role, err := s.findGroupRole(ctx, groupID, roleID)
+	if err != nil {
+		return err
+	}
+	if err := ensureCanGrant(managerPermissions, role.Permissions); err != nil {
+		return err
+	}
+	member, err := s.findApprovedMember(ctx, groupID, memberID)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.roleRepo.RemoveFromMember(ctx, member.ID, role.ID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrGroupRoleNotAssigned
+	}
+	return nil
+}
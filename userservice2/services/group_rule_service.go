@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"userservice2/dto/request"
+	"userservice2/dto/response"
+	"userservice2/models"
+)
+
+// Khai báo lỗi nội quy nhóm
+var (
+	ErrGroupRuleNotFound     = errors.New("không tìm thấy điều nội quy")
+	ErrGroupRuleLimit        = fmt.Errorf("nhóm chỉ có thể có tối đa %d điều nội quy", models.MaxGroupRules)
+	ErrInvalidRuleOrder      = errors.New("danh sách sắp xếp phải gồm đúng tất cả các điều nội quy của nhóm, không trùng lặp")
+	ErrGroupRulesNotAccepted = errors.New("bạn cần đồng ý với nội quy của nhóm để tham gia")
+)
+
+// checkRulesAccepted kiểm tra người tham gia đã đồng ý nội quy nếu nhóm có nội quy. Trả về thời điểm chấp nhận
+// cần ghi nhận cho thành viên, nil nếu nhóm chưa có nội quy.
+func (s *groupService) checkRulesAccepted(ctx context.Context, groupID int64, accepted bool) (*time.Time, error) {
+	count, err := s.ruleRepo.CountByGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	if !accepted {
+		return nil, ErrGroupRulesNotAccepted
+	}
+	now := time.Now()
+	return &now, nil
+}
+
+// findGroupRule tìm điều nội quy thuộc nhóm groupID
+func (s *groupService) findGroupRule(ctx context.Context, groupID, ruleID int64) (*models.GroupRule, error) {
+	rule, err := s.ruleRepo.FindByID(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil || rule.GroupID != groupID {
+		return nil, ErrGroupRuleNotFound
+	}
+	return rule, nil
+}
+
+// listRuleResponses lấy nội quy của nhóm theo thứ tự hiển thị dưới dạng response
+func (s *groupService) listRuleResponses(ctx context.Context, groupID int64) ([]response.GroupRuleResponse, error) {
+	rules, err := s.ruleRepo.ListByGroup(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi lấy nội quy nhóm: %v", err)
+	}
+
+	resp := make([]response.GroupRuleResponse, len(rules))
+	for i := range rules {
+		resp[i] = response.ConvertToGroupRuleResponse(&rules[i])
+	}
+	return resp, nil
+}
+
+// ListGroupRules lấy nội quy của nhóm, ai thấy được nhóm cũng xem được để đọc trước khi tham gia
+func (s *groupService) ListGroupRules(ctx context.Context, userID, groupID int64) (*response.GroupRuleListResponse, error) {
+	if _, err := s.findVisibleGroup(ctx, userID, groupID); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.listRuleResponses(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return &response.GroupRuleListResponse{Rules: rules}, nil
+}
+
+// CreateGroupRule thêm điều nội quy vào cuối nội quy nhóm, cần quyền manage_rules
+func (s *groupService) CreateGroupRule(ctx context.Context, userID, groupID int64, req *request.GroupRuleCreateRequest) (*response.GroupRuleResponse, error) {
+	if _, _, err := s.authorizeModerator(ctx, userID, groupID, models.GroupPermissionManageRules); err != nil {
+		return nil, err
+	}
+
+	rule := &models.GroupRule{
+		GroupID:     groupID,
+		Title:       strings.TrimSpace(req.Title),
+		Description: strings.TrimSpace(req.Description),
+		CreatedBy:   userID,
+	}
+	created, err := s.ruleRepo.Create(ctx, rule)
+	if err != nil {
+		return nil, fmt.Errorf("lỗi khi thêm nội quy: %v", err)
+	}
+	if !created {
+		return nil, ErrGroupRuleLimit
+	}
+
+	resp := response.ConvertToGroupRuleResponse(rule)
+	return &resp, nil
+}
+
+// UpdateGroupRule sửa tiêu đề, mô tả của điều nội quy, cần quyền manage_rules
+func (s *groupService) UpdateGroupRule(ctx context.Context, userID, groupID, ruleID int64, req *request.GroupRuleUpdateRequest) (*response.GroupRuleResponse, error) {
+	if _, _, err := s.authorizeModerator(ctx, userID, groupID, models.GroupPermissionManageRules); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.findGroupRule(ctx, groupID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Title != "" {
+		rule.Title = strings.TrimSpace(req.Title)
+	}
+	if req.Description != nil {
+		rule.Description = strings.TrimSpace(*req.Description)
+	}
+
+	if err := s.ruleRepo.Update(ctx, rule); err != nil {
+		return nil, fmt.Errorf("lỗi khi cập nhật nội quy: %v", err)
+	}
+
+	resp := response.ConvertToGroupRuleResponse(rule)
+	return &resp, nil
+}
+
+// DeleteGroupRule xóa điều nội quy, các điều phía sau được dồn lên, cần quyền manage_rules
+func (s *groupService) DeleteGroupRule(ctx context.Context, userID, groupID, ruleID int64) error {
+	if _, _, err := s.authorizeModerator(ctx, userID, groupID, models.GroupPermissionManageRules); err != nil {
+		return err
+	}
+
+	rule, err := s.findGroupRule(ctx, groupID, ruleID)
+	if err != nil {
+		return err
+	}
+	if err := s.ruleRepo.Delete(ctx, rule); err != nil {
+		return fmt.Errorf("lỗi khi xóa nội quy: %v", err)
+	}
+	return nil
+}
+
+// ReorderGroupRules sắp xếp lại nội quy nhóm theo thứ tự trong req.RuleIDs, cần quyền manage_rules
+func (s *groupService) ReorderGroupRules(ctx context.Context, userID, groupID int64, req *request.GroupRuleOrderRequest) (*response.GroupRuleListResponse, error) {
+	if _, _, err := s.authorizeModerator(ctx, userID, groupID, models.GroupPermissionManageRules); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.ruleRepo.ListByGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.RuleIDs) != len(rules) {
+		return nil, ErrInvalidRuleOrder
+	}
+	remaining := make(map[int64]bool, len(rules))
+	for _, rule := range rules {
+		remaining[rule.ID] = true
+	}
+	for _, id := range req.RuleIDs {
+		if !remaining[id] {
+			return nil, ErrInvalidRuleOrder
+		}
+		delete(remaining, id)
+	}
+
+	if err := s.ruleRepo.Reorder(ctx, groupID, req.RuleIDs); err != nil {
+		return nil, fmt.Errorf("lỗi khi sắp xếp nội quy: %v", err)
+	}
+	return s.ListGroupRules(ctx, userID, groupID)
+}
+
+// AcceptGroupRules ghi nhận thành viên đồng ý với nội quy hiện tại của nhóm, dùng khi nội quy thay đổi sau khi
+// họ đã tham gia
+func (s *groupService) AcceptGroupRules(ctx context.Context, userID, groupID int64) (*response.GroupMemberResponse, error) {
+	member, err := s.memberRepo.FindByUserAndGroup(ctx, userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.Status != models.GroupMemberStatusApproved {
+		return nil, ErrGroupPermissionDenied
+	}
+
+	if err := s.ruleRepo.MarkAccepted(ctx, member.ID, time.Now()); err != nil {
+		return nil, fmt.Errorf("lỗi khi ghi nhận đồng ý nội quy: %v", err)
+	}
+	return s.GetGroupMemberByID(ctx, member.ID)
+}
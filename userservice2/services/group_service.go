@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 	"userservice2/dto/request"
 	"userservice2/dto/response"
@@ -20,6 +22,7 @@ type GroupService interface {
 	DeleteGroup(ctx context.Context, userID, groupID int64) error
 	ListGroups(ctx context.Context, req *request.GroupListRequest) (*response.GroupListResponse, error)
 	ListUserGroups(ctx context.Context, userID int64, req *request.GroupListRequest) (*response.GroupListResponse, error)
+	RecommendGroups(ctx context.Context, userID int64, req *request.GroupRecommendationRequest) (*response.GroupRecommendationListResponse, error)
 
 	// Quản lý thành viên
 	JoinGroup(ctx context.Context, userID int64, req *request.GroupJoinRequest) error
@@ -30,15 +33,67 @@ type GroupService interface {
 	RemoveMember(ctx context.Context, userID, groupID int64, req *request.GroupMemberActionRequest) error
 	UpdateMember(ctx context.Context, userID, groupID, memberID int64, req *request.GroupMemberUpdateRequest) (*response.GroupMemberResponse, error)
 	GetGroupMemberByID(ctx context.Context, memberID int64) (*response.GroupMemberResponse, error)
-	GetGroupMembers(ctx context.Context, groupID int64, req *request.GroupMemberListRequest) (*response.GroupMemberListResponse, error)
+	GetGroupMembers(ctx context.Context, userID, groupID int64, req *request.GroupMemberListRequest) (*response.GroupMemberListResponse, error)
 
 	// Quản lý vai trò
 	CreateGroupRole(ctx context.Context, userID, groupID int64, req *request.GroupRoleCreateRequest) (*response.GroupRoleResponse, error)
 	UpdateGroupRole(ctx context.Context, userID, groupID, roleID int64, req *request.GroupRoleUpdateRequest) (*response.GroupRoleResponse, error)
 	DeleteGroupRole(ctx context.Context, userID, groupID, roleID int64) error
-	GetGroupRoles(ctx context.Context, groupID int64) (*response.GroupRoleListResponse, error)
+	GetGroupRoles(ctx context.Context, userID, groupID int64) (*response.GroupRoleListResponse, error)
 	AssignRoleToMember(ctx context.Context, userID, groupID, memberID int64, req *request.GroupMemberRoleRequest) error
 	RemoveRoleFromMember(ctx context.Context, userID, groupID, memberID, roleID int64) error
+	HasGroupPermission(ctx context.Context, userID, groupID int64, permission string) (bool, error)
+	GetPostingStatus(ctx context.Context, userID, groupID int64) (isMember, isMuted bool, err error)
+	GetMemberGroupIDs(ctx context.Context, userID int64) ([]int64, error)
+
+	// Yêu cầu tham gia nhóm
+	GetMembershipQuestions(ctx context.Context, userID, groupID int64) ([]string, error)
+	UpdateMembershipQuestions(ctx context.Context, userID, groupID int64, req *request.GroupQuestionsRequest) ([]string, error)
+	GetMyJoinRequest(ctx context.Context, userID, groupID int64) (*response.GroupJoinRequestResponse, error)
+	WithdrawJoinRequest(ctx context.Context, userID, groupID int64) error
+	ListJoinRequests(ctx context.Context, userID, groupID int64, req *request.GroupJoinRequestListRequest) (*response.GroupJoinRequestListResponse, error)
+	BulkApproveJoinRequests(ctx context.Context, userID, groupID int64, req *request.GroupJoinRequestBulkRequest) (*response.GroupJoinRequestBulkResponse, error)
+	BulkRejectJoinRequests(ctx context.Context, userID, groupID int64, req *request.GroupJoinRequestBulkRequest) (*response.GroupJoinRequestBulkResponse, error)
+	ExpireJoinRequests(ctx context.Context) (int64, error)
+
+	// Lời mời vào nhóm
+	ListMyInvitations(ctx context.Context, userID int64, req *request.GroupInvitationListRequest) (*response.GroupInvitationListResponse, error)
+	AcceptInvitation(ctx context.Context, userID, invitationID int64, req *request.GroupInvitationAcceptRequest) error
+	DeclineInvitation(ctx context.Context, userID, invitationID int64) error
+	ExpireInvitations(ctx context.Context) (int64, error)
+
+	// Quyền sở hữu nhóm
+	RequestOwnershipTransfer(ctx context.Context, userID, groupID int64, req *request.GroupMemberActionRequest) (*response.GroupOwnershipTransferResponse, error)
+	GetOwnershipTransfer(ctx context.Context, userID, groupID int64) (*response.GroupOwnershipTransferResponse, error)
+	CancelOwnershipTransfer(ctx context.Context, userID, groupID int64) error
+	AcceptOwnershipTransfer(ctx context.Context, userID, groupID int64) error
+	DeclineOwnershipTransfer(ctx context.Context, userID, groupID int64) error
+	RecoverGroupLeadership(ctx context.Context) (int64, error)
+
+	// Kiểm duyệt thành viên
+	BanMember(ctx context.Context, userID, groupID int64, req *request.GroupBanRequest) (*response.GroupBanResponse, error)
+	UnbanMember(ctx context.Context, userID, groupID, targetUserID int64) error
+	ListBans(ctx context.Context, userID, groupID int64, req *request.GroupBanListRequest) (*response.GroupBanListResponse, error)
+	MuteMember(ctx context.Context, userID, groupID, memberID int64, req *request.GroupMuteRequest) (*response.GroupMemberResponse, error)
+	UnmuteMember(ctx context.Context, userID, groupID, memberID int64) error
+	ListModerationLog(ctx context.Context, userID, groupID int64, req *request.GroupModerationLogListRequest) (*response.GroupModerationLogListResponse, error)
+	ExpireMutes(ctx context.Context) (int64, error)
+
+	// Nội quy nhóm
+	ListGroupRules(ctx context.Context, userID, groupID int64) (*response.GroupRuleListResponse, error)
+	CreateGroupRule(ctx context.Context, userID, groupID int64, req *request.GroupRuleCreateRequest) (*response.GroupRuleResponse, error)
+	UpdateGroupRule(ctx context.Context, userID, groupID, ruleID int64, req *request.GroupRuleUpdateRequest) (*response.GroupRuleResponse, error)
+	DeleteGroupRule(ctx context.Context, userID, groupID, ruleID int64) error
+	ReorderGroupRules(ctx context.Context, userID, groupID int64, req *request.GroupRuleOrderRequest) (*response.GroupRuleListResponse, error)
+	AcceptGroupRules(ctx context.Context, userID, groupID int64) (*response.GroupMemberResponse, error)
+
+	// Thông báo ghim
+	ListGroupAnnouncements(ctx context.Context, userID, groupID int64, req *request.GroupAnnouncementListRequest) (*response.GroupAnnouncementListResponse, error)
+	CreateGroupAnnouncement(ctx context.Context, userID, groupID int64, req *request.GroupAnnouncementCreateRequest) (*response.GroupAnnouncementResponse, error)
+	UpdateGroupAnnouncement(ctx context.Context, userID, groupID, announcementID int64, req *request.GroupAnnouncementUpdateRequest) (*response.GroupAnnouncementResponse, error)
+	DeleteGroupAnnouncement(ctx context.Context, userID, groupID, announcementID int64) error
+
+	StartMaintenance(ctx context.Context, interval time.Duration)
 }
 
 // groupService triển khai GroupService
@@ -46,18 +101,51 @@ type groupService struct {
 	groupRepo  repositories.UserGroupRepository
 	memberRepo repositories.GroupMemberRepository
 	userRepo   repositories.UserRepository
+	roleRepo   repositories.GroupRoleRepository
+
+	joinRequestRepo  repositories.GroupJoinRequestRepository
+	joinRequestTTL   time.Duration
+	invitationRepo   repositories.GroupInvitationRepository
+	invitationTTL    time.Duration
+	friendshipRepo   repositories.FriendshipRepository
+	ownershipRepo    repositories.GroupOwnershipRepository
+	moderationRepo   repositories.GroupModerationRepository
+	ruleRepo         repositories.GroupRuleRepository
+	announcementRepo repositories.GroupAnnouncementRepository
 }
 
-// NewGroupService tạo instance mới của GroupService
+// NewGroupService tạo instance mới của GroupService. Yêu cầu tham gia nhóm chưa được xử lý và lời mời
+// chưa được trả lời hết hạn sau joinRequestTTL và invitationTTL (0 là không hết hạn).
 func NewGroupService(
 	groupRepo repositories.UserGroupRepository,
 	memberRepo repositories.GroupMemberRepository,
 	userRepo repositories.UserRepository,
+	roleRepo repositories.GroupRoleRepository,
+	joinRequestRepo repositories.GroupJoinRequestRepository,
+	joinRequestTTL time.Duration,
+	invitationRepo repositories.GroupInvitationRepository,
+	invitationTTL time.Duration,
+	friendshipRepo repositories.FriendshipRepository,
+	ownershipRepo repositories.GroupOwnershipRepository,
+	moderationRepo repositories.GroupModerationRepository,
+	ruleRepo repositories.GroupRuleRepository,
+	announcementRepo repositories.GroupAnnouncementRepository,
 ) GroupService {
 	return &groupService{
 		groupRepo:  groupRepo,
 		memberRepo: memberRepo,
 		userRepo:   userRepo,
+		roleRepo:   roleRepo,
+
+		joinRequestRepo:  joinRequestRepo,
+		joinRequestTTL:   joinRequestTTL,
+		invitationRepo:   invitationRepo,
+		invitationTTL:    invitationTTL,
+		friendshipRepo:   friendshipRepo,
+		ownershipRepo:    ownershipRepo,
+		moderationRepo:   moderationRepo,
+		ruleRepo:         ruleRepo,
+		announcementRepo: announcementRepo,
 	}
 }
 
@@ -73,18 +161,23 @@ func (s *groupService) CreateGroup(ctx context.Context, userID int64, req *reque
 	}
 
 	privacy := models.GroupPrivacyPublic
-	if req.Privacy == "private" {
-		privacy = models.GroupPrivacyPrivate
+	if req.Privacy != "" {
+		privacy = models.GroupPrivacy(req.Privacy)
 	}
 
 	// Tạo nhóm mới
+	now := time.Now()
 	group := &models.UserGroup{
-		Name:        req.Name,
-		Description: req.Description,
-		Privacy:     privacy,
-		CoverImage:  req.CoverImage,
-		CreatedBy:   userID,
-		MemberCount: 0, // Bắt đầu với số lượng thành viên là 0
+		Name:           req.Name,
+		Description:    req.Description,
+		Privacy:        privacy,
+		CoverImage:     req.CoverImage,
+		Category:       models.GroupCategory(req.Category),
+		Tags:           normalizeGroupTags(req.Tags),
+		CreatedBy:      userID,
+		OwnerID:        userID,
+		MemberCount:    0, // Bắt đầu với số lượng thành viên là 0
+		LastActivityAt: &now,
 	}
 
 	err = s.groupRepo.Create(ctx, group)
@@ -123,16 +216,13 @@ func (s *groupService) CreateGroup(ctx context.Context, userID int64, req *reque
 	return &resp, nil
 }
 
-// GetGroupByID lấy thông tin chi tiết của nhóm
+// GetGroupByID lấy thông tin chi tiết của nhóm. Nhóm bí mật được coi như không tồn tại với người ngoài nhóm.
 func (s *groupService) GetGroupByID(ctx context.Context, userID, groupID int64) (*response.GroupDetailResponse, error) {
 	// Lấy thông tin nhóm
-	group, err := s.groupRepo.FindByID(ctx, groupID)
+	group, err := s.findVisibleGroup(ctx, userID, groupID)
 	if err != nil {
 		return nil, err
 	}
-	if group == nil {
-		return nil, errors.New("nhóm không tồn tại")
-	}
 
 	// Kiểm tra quyền truy cập nếu là nhóm private
 	if group.Privacy == models.GroupPrivacyPrivate {
@@ -158,6 +248,20 @@ func (s *groupService) GetGroupByID(ctx context.Context, userID, groupID int64)
 			memberResp := response.ConvertToGroupMemberResponse(member)
 			result.CurrentUserMember = &memberResp
 		}
+
+		// Các quyền hiệu lực để client biết nên hiển thị những chức năng quản lý nào
+		if _, permissions, err := s.memberPermissions(ctx, userID, groupID); err == nil {
+			result.CurrentUserPermissions = permissionList(permissions)
+		}
+	}
+	if group.Privacy == models.GroupPrivacyPrivate {
+		result.MembershipQuestions = group.MembershipQuestions
+	}
+	if result.Rules, err = s.listRuleResponses(ctx, groupID); err != nil {
+		return nil, err
+	}
+	if result.PinnedAnnouncements, err = s.listPinnedAnnouncements(ctx, groupID); err != nil {
+		return nil, err
 	}
 
 	return result, nil
@@ -174,12 +278,12 @@ func (s *groupService) UpdateGroup(ctx context.Context, userID, groupID int64, r
 		return nil, errors.New("nhóm không tồn tại")
 	}
 
-	// Kiểm tra quyền admin
-	member, err := s.memberRepo.FindByUserAndGroup(ctx, userID, groupID)
+	// Kiểm tra quyền chỉnh sửa nhóm
+	allowed, err := s.HasGroupPermission(ctx, userID, groupID, models.GroupPermissionEditGroup)
 	if err != nil {
 		return nil, err
 	}
-	if member == nil || member.Status != models.GroupMemberStatusApproved || member.Role != models.MemberRoleAdmin {
+	if !allowed {
 		return nil, errors.New("bạn không có quyền chỉnh sửa nhóm này")
 	}
 
@@ -193,12 +297,18 @@ func (s *groupService) UpdateGroup(ctx context.Context, userID, groupID int64, r
 	if req.CoverImage != "" {
 		group.CoverImage = req.CoverImage
 	}
+	if req.Category != "" {
+		group.Category = models.GroupCategory(req.Category)
+	}
+	if req.Tags != nil {
+		group.Tags = normalizeGroupTags(*req.Tags)
+	}
+	now := time.Now()
+	group.LastActivityAt = &now
+	becomesSecret := false
 	if req.Privacy != "" {
-		if req.Privacy == "public" {
-			group.Privacy = models.GroupPrivacyPublic
-		} else {
-			group.Privacy = models.GroupPrivacyPrivate
-		}
+		becomesSecret = req.Privacy == string(models.GroupPrivacySecret) && group.Privacy != models.GroupPrivacySecret
+		group.Privacy = models.GroupPrivacy(req.Privacy)
 	}
 
 	err = s.groupRepo.Update(ctx, group)
@@ -206,6 +316,13 @@ func (s *groupService) UpdateGroup(ctx context.Context, userID, groupID int64, r
 		return nil, fmt.Errorf("lỗi khi cập nhật nhóm: %v", err)
 	}
 
+	// Nhóm bí mật chỉ nhận thành viên qua lời mời nên các yêu cầu tham gia đang chờ bị từ chối
+	if becomesSecret {
+		if _, err := s.joinRequestRepo.RejectAllPending(ctx, groupID, userID); err != nil {
+			return nil, fmt.Errorf("lỗi khi từ chối các yêu cầu tham gia đang chờ: %v", err)
+		}
+	}
+
 	// Chuyển đổi sang response
 	resp := response.ConvertToGroupResponse(group)
 	return &resp, nil
@@ -222,26 +339,29 @@ func (s *groupService) DeleteGroup(ctx context.Context, userID, groupID int64) e
 		return errors.New("nhóm không tồn tại")
 	}
 
-	// Kiểm tra quyền admin hoặc người tạo
-	if group.CreatedBy != userID {
-		member, err := s.memberRepo.FindByUserAndGroup(ctx, userID, groupID)
-		if err != nil {
-			return err
-		}
-		if member == nil || member.Status != models.GroupMemberStatusApproved || member.Role != models.MemberRoleAdmin {
-			return errors.New("bạn không có quyền xóa nhóm này")
-		}
+	// Chỉ chủ nhóm mới được xóa nhóm
+	if group.OwnerID != userID {
+		return ErrNotGroupOwner
 	}
 
 	return s.groupRepo.Delete(ctx, groupID)
 }
 
-// ListGroups lấy danh sách tất cả các nhóm công khai
+// ListGroups lấy danh sách các nhóm, không bao gồm nhóm bí mật
 func (s *groupService) ListGroups(ctx context.Context, req *request.GroupListRequest) (*response.GroupListResponse, error) {
-	// TODO: Implement search by query and filter by privacy
+	filter := repositories.GroupFilter{
+		Query:    req.Query,
+		Privacy:  models.GroupPrivacy(req.Privacy),
+		Category: models.GroupCategory(req.Category),
+		Tag:      normalizeGroupTag(req.Tag),
+		Sort:     req.Sort,
+	}
+	if filter.Sort == "" && strings.TrimSpace(req.Query) != "" {
+		filter.Sort = repositories.GroupSortRelevance
+	}
 
 	// Lấy danh sách nhóm có phân trang
-	groups, total, err := s.groupRepo.List(ctx, req.Page, req.PageSize)
+	groups, total, err := s.groupRepo.List(ctx, filter, req.Page, req.PageSize)
 	if err != nil {
 		return nil, fmt.Errorf("lỗi khi lấy danh sách nhóm: %v", err)
 	}
@@ -284,15 +404,22 @@ func (s *groupService) ListUserGroups(ctx context.Context, userID int64, req *re
 	return resp, nil
 }
 
-// JoinGroup xin tham gia nhóm
+// JoinGroup xin tham gia nhóm. Nhóm công khai cho tham gia ngay, nhóm riêng tư tạo yêu cầu tham gia
+// kèm lời nhắn và câu trả lời cho các câu hỏi của nhóm để người có quyền duyệt xem xét.
 func (s *groupService) JoinGroup(ctx context.Context, userID int64, req *request.GroupJoinRequest) error {
 	// Kiểm tra nhóm có tồn tại không
-	group, err := s.groupRepo.FindByID(ctx, req.GroupID)
+	group, err := s.findVisibleGroup(ctx, userID, req.GroupID)
 	if err != nil {
 		return err
 	}
-	if group == nil {
-		return errors.New("nhóm không tồn tại")
+
+	// Người bị cấm không thể tham gia hay gửi yêu cầu tham gia
+	banned, err := s.isBanned(ctx, req.GroupID, userID)
+	if err != nil {
+		return err
+	}
+	if banned {
+		return ErrBannedFromGroup
 	}
 
 	// Kiểm tra người dùng đã là thành viên chưa
@@ -304,33 +431,39 @@ func (s *groupService) JoinGroup(ctx context.Context, userID int64, req *request
 		return errors.New("bạn đã là thành viên hoặc đã gửi yêu cầu tham gia trước đó")
 	}
 
-	// Xác định trạng thái thành viên dựa vào loại nhóm
-	status := models.GroupMemberStatusPending
-	if group.Privacy == models.GroupPrivacyPublic {
-		status = models.GroupMemberStatusApproved
+	// Người đang được mời vào nhóm bí mật cần chấp nhận lời mời thay vì xin tham gia
+	if group.Privacy == models.GroupPrivacySecret {
+		return ErrSecretGroupInviteOnly
+	}
+
+	// Nhóm có nội quy yêu cầu người tham gia đồng ý trước
+	rulesAcceptedAt, err := s.checkRulesAccepted(ctx, group.ID, req.AcceptRules)
+	if err != nil {
+		return err
+	}
+	if group.Privacy == models.GroupPrivacyPrivate {
+		return s.createJoinRequest(ctx, userID, group, req, rulesAcceptedAt)
 	}
 
-	// Tạo yêu cầu tham gia
+	// Nhóm công khai: tham gia ngay
 	member := &models.GroupMember{
-		GroupID:  req.GroupID,
-		UserID:   userID,
-		Role:     models.MemberRoleMember,
-		Nickname: req.Nickname,
-		Status:   status,
-		JoinedAt: time.Now(),
+		GroupID:         req.GroupID,
+		UserID:          userID,
+		Role:            models.MemberRoleMember,
+		Nickname:        req.Nickname,
+		Status:          models.GroupMemberStatusApproved,
+		JoinedAt:        time.Now(),
+		RulesAcceptedAt: rulesAcceptedAt,
 	}
 
 	err = s.memberRepo.Create(ctx, member)
 	if err != nil {
-		return fmt.Errorf("lỗi khi gửi yêu cầu tham gia: %v", err)
+		return fmt.Errorf("lỗi khi tham gia nhóm: %v", err)
 	}
 
-	// Nếu là nhóm công khai, tăng số lượng thành viên
-	if group.Privacy == models.GroupPrivacyPublic {
-		err = s.groupRepo.IncrementMemberCount(ctx, group.ID)
-		if err != nil {
-			return fmt.Errorf("lỗi khi cập nhật số lượng thành viên: %v", err)
-		}
+	err = s.groupRepo.IncrementMemberCount(ctx, group.ID)
+	if err != nil {
+		return fmt.Errorf("lỗi khi cập nhật số lượng thành viên: %v", err)
 	}
 
 	return nil
@@ -347,13 +480,21 @@ func (s *groupService) LeaveGroup(ctx context.Context, userID, groupID int64) er
 		return errors.New("bạn không phải là thành viên của nhóm này")
 	}
 
-	// Không cho phép người tạo nhóm rời nhóm, chỉ có thể xóa nhóm
+	// Chủ nhóm phải chuyển quyền sở hữu trước, admin cuối cùng phải chỉ định admin khác nếu nhóm còn người
 	group, err := s.groupRepo.FindByID(ctx, groupID)
 	if err != nil {
 		return err
 	}
-	if group.CreatedBy == userID {
-		return errors.New("người tạo nhóm không thể rời nhóm, chỉ có thể xóa nhóm")
+	if group == nil {
+		return ErrGroupNotFound
+	}
+	if group.OwnerID == userID {
+		return ErrOwnerCannotLeave
+	}
+	if group.MemberCount > 1 {
+		if err := s.ensureNotLastAdmin(ctx, member); err != nil {
+			return err
+		}
 	}
 
 	// Xóa thành viên
@@ -371,24 +512,37 @@ func (s *groupService) LeaveGroup(ctx context.Context, userID, groupID int64) er
 	return nil
 }
 
-// InviteMember mời người dùng tham gia nhóm
+// InviteMember gửi lời mời tham gia nhóm. Người được mời chỉ trở thành thành viên khi chấp nhận lời mời,
+// và có thể giới hạn ai được mời mình qua cài đặt group_invite_policy.
 func (s *groupService) InviteMember(ctx context.Context, userID, groupID int64, req *request.GroupMemberActionRequest) error {
-	// Kiểm tra quyền mời thành viên (phải là admin)
-	member, err := s.memberRepo.FindByUserAndGroup(ctx, userID, groupID)
+	group, err := s.groupRepo.FindByID(ctx, groupID)
 	if err != nil {
 		return err
 	}
-	if member == nil || member.Status != models.GroupMemberStatusApproved || member.Role != models.MemberRoleAdmin {
-		return errors.New("bạn không có quyền mời thành viên")
+	if group == nil {
+		return ErrGroupNotFound
+	}
+
+	// Kiểm tra quyền mời thành viên
+	allowed, err := s.HasGroupPermission(ctx, userID, groupID, models.GroupPermissionInviteMembers)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrGroupPermissionDenied
+	}
+
+	if req.UserID == userID {
+		return ErrCannotInviteSelf
 	}
 
 	// Kiểm tra người được mời có tồn tại không
-	user, err := s.userRepo.FindByID(ctx, req.UserID)
+	invitee, err := s.userRepo.FindByID(ctx, req.UserID)
 	if err != nil {
 		return err
 	}
-	if user == nil {
-		return errors.New("người dùng không tồn tại")
+	if invitee == nil || !invitee.IsActive {
+		return ErrUserNotFound
 	}
 
 	// Kiểm tra người dùng đã là thành viên chưa
@@ -396,34 +550,73 @@ func (s *groupService) InviteMember(ctx context.Context, userID, groupID int64,
 	if err != nil {
 		return err
 	}
-	if existingMember != nil {
-		return errors.New("người dùng đã là thành viên hoặc đã được mời trước đó")
+	if existingMember != nil && existingMember.Status == models.GroupMemberStatusApproved {
+		return ErrAlreadyGroupMember
+	}
+	banned, err := s.isBanned(ctx, groupID, req.UserID)
+	if err != nil {
+		return err
+	}
+	if banned {
+		return ErrUserBannedFromGroup
 	}
 
-	// Tạo lời mời tham gia
-	newMember := &models.GroupMember{
-		GroupID:  groupID,
-		UserID:   req.UserID,
-		Role:     models.MemberRoleMember,
-		Status:   models.GroupMemberStatusPending,
-		JoinedAt: time.Now(),
+	if err := s.ensureCanInvite(ctx, userID, invitee); err != nil {
+		return err
+	}
+
+	existingInvitation, err := s.invitationRepo.FindPending(ctx, groupID, req.UserID)
+	if err != nil {
+		return err
+	}
+	if existingInvitation != nil {
+		return ErrInvitationExists
 	}
 
-	return s.memberRepo.Create(ctx, newMember)
+	invitation := &models.GroupInvitation{
+		GroupID:   groupID,
+		InviterID: userID,
+		InviteeID: req.UserID,
+		Status:    models.GroupInvitationStatusPending,
+	}
+	if s.invitationTTL > 0 {
+		expiresAt := time.Now().Add(s.invitationTTL)
+		invitation.ExpiresAt = &expiresAt
+	}
+
+	if err := s.invitationRepo.Create(ctx, invitation); err != nil {
+		return fmt.Errorf("lỗi khi gửi lời mời: %v", err)
+	}
+	return nil
 }
 
 // ApproveJoinRequest chấp nhận yêu cầu tham gia
 func (s *groupService) ApproveJoinRequest(ctx context.Context, userID, groupID int64, req *request.GroupMemberActionRequest) error {
-	// Kiểm tra quyền phê duyệt (phải là admin)
-	admin, err := s.memberRepo.FindByUserAndGroup(ctx, userID, groupID)
+	// Kiểm tra quyền phê duyệt
+	allowed, err := s.HasGroupPermission(ctx, userID, groupID, models.GroupPermissionApproveMembers)
 	if err != nil {
 		return err
 	}
-	if admin == nil || admin.Status != models.GroupMemberStatusApproved || admin.Role != models.MemberRoleAdmin {
+	if !allowed {
 		return errors.New("bạn không có quyền phê duyệt yêu cầu tham gia")
 	}
 
-	// Lấy thông tin yêu cầu tham gia
+	joinRequest, err := s.joinRequestRepo.FindPending(ctx, groupID, req.UserID)
+	if err != nil {
+		return err
+	}
+	if joinRequest != nil {
+		approved, err := s.joinRequestRepo.Approve(ctx, joinRequest, userID)
+		if err != nil {
+			return fmt.Errorf("lỗi khi chấp nhận yêu cầu: %v", err)
+		}
+		if !approved {
+			return ErrJoinRequestNotFound
+		}
+		return nil
+	}
+
+	// Yêu cầu tham gia cũ được lưu dưới dạng thành viên đang chờ duyệt
 	member, err := s.memberRepo.FindByUserAndGroup(ctx, req.UserID, groupID)
 	if err != nil {
 		return err
@@ -450,16 +643,31 @@ func (s *groupService) ApproveJoinRequest(ctx context.Context, userID, groupID i
 
 // RejectJoinRequest từ chối yêu cầu tham gia
 func (s *groupService) RejectJoinRequest(ctx context.Context, userID, groupID int64, req *request.GroupMemberActionRequest) error {
-	// Kiểm tra quyền từ chối (phải là admin)
-	admin, err := s.memberRepo.FindByUserAndGroup(ctx, userID, groupID)
+	// Kiểm tra quyền từ chối, dùng chung quyền phê duyệt
+	allowed, err := s.HasGroupPermission(ctx, userID, groupID, models.GroupPermissionApproveMembers)
 	if err != nil {
 		return err
 	}
-	if admin == nil || admin.Status != models.GroupMemberStatusApproved || admin.Role != models.MemberRoleAdmin {
+	if !allowed {
 		return errors.New("bạn không có quyền từ chối yêu cầu tham gia")
 	}
 
-	// Lấy thông tin yêu cầu tham gia
+	joinRequest, err := s.joinRequestRepo.FindPending(ctx, groupID, req.UserID)
+	if err != nil {
+		return err
+	}
+	if joinRequest != nil {
+		rejected, err := s.joinRequestRepo.Close(ctx, joinRequest.ID, models.JoinRequestStatusRejected, &userID)
+		if err != nil {
+			return fmt.Errorf("lỗi khi từ chối yêu cầu: %v", err)
+		}
+		if !rejected {
+			return ErrJoinRequestNotFound
+		}
+		return nil
+	}
+
+	// Yêu cầu tham gia cũ được lưu dưới dạng thành viên đang chờ duyệt
 	member, err := s.memberRepo.FindByUserAndGroup(ctx, req.UserID, groupID)
 	if err != nil {
 		return err
@@ -475,12 +683,12 @@ func (s *groupService) RejectJoinRequest(ctx context.Context, userID, groupID in
 
 // RemoveMember xóa thành viên khỏi nhóm
 func (s *groupService) RemoveMember(ctx context.Context, userID, groupID int64, req *request.GroupMemberActionRequest) error {
-	// Kiểm tra quyền xóa thành viên (phải là admin)
-	admin, err := s.memberRepo.FindByUserAndGroup(ctx, userID, groupID)
+	// Kiểm tra quyền xóa thành viên
+	actor, permissions, err := s.memberPermissions(ctx, userID, groupID)
 	if err != nil {
 		return err
 	}
-	if admin == nil || admin.Status != models.GroupMemberStatusApproved || admin.Role != models.MemberRoleAdmin {
+	if !permissions.Has(models.GroupPermissionRemoveMembers) {
 		return errors.New("bạn không có quyền xóa thành viên")
 	}
 
@@ -493,25 +701,29 @@ func (s *groupService) RemoveMember(ctx context.Context, userID, groupID int64,
 		return errors.New("không tìm thấy thành viên này")
 	}
 
-	// Không thể xóa người tạo nhóm
+	// Chỉ admin mới xóa được admin khác
+	if member.Role == models.MemberRoleAdmin && actor.Role != models.MemberRoleAdmin {
+		return errors.New("bạn không có quyền xóa admin của nhóm")
+	}
+
+	// Không thể xóa chủ nhóm
 	group, err := s.groupRepo.FindByID(ctx, groupID)
 	if err != nil {
 		return err
 	}
-	if group.CreatedBy == req.UserID {
-		return errors.New("không thể xóa người tạo nhóm")
+	if group.OwnerID == req.UserID {
+		return ErrCannotRemoveOwner
 	}
 
-	// Xóa thành viên
-	err = s.memberRepo.Delete(ctx, member.ID)
-	if err != nil {
-		return fmt.Errorf("lỗi khi xóa thành viên: %v", err)
+	// Xóa thành viên và ghi nhật ký kiểm duyệt, người bị xóa vẫn có thể tham gia lại (dùng cấm để ngăn việc này)
+	entry := &models.GroupModerationLog{
+		GroupID:      groupID,
+		ActorID:      &userID,
+		TargetUserID: req.UserID,
+		Action:       models.GroupModerationMemberRemove,
 	}
-
-	// Giảm số lượng thành viên
-	err = s.groupRepo.DecrementMemberCount(ctx, groupID)
-	if err != nil {
-		return fmt.Errorf("lỗi khi cập nhật số lượng thành viên: %v", err)
+	if err := s.moderationRepo.RemoveMember(ctx, member, entry); err != nil {
+		return fmt.Errorf("lỗi khi xóa thành viên: %v", err)
 	}
 
 	return nil
@@ -519,22 +731,20 @@ func (s *groupService) RemoveMember(ctx context.Context, userID, groupID int64,
 
 // UpdateMember cập nhật thông tin thành viên
 func (s *groupService) UpdateMember(ctx context.Context, userID, groupID, memberID int64, req *request.GroupMemberUpdateRequest) (*response.GroupMemberResponse, error) {
-	// Kiểm tra quyền cập nhật (phải là admin hoặc chính thành viên đó)
-	isAdmin := false
-	currentMember, err := s.memberRepo.FindByUserAndGroup(ctx, userID, groupID)
+	// Kiểm tra quyền cập nhật (admin, người có quyền tắt tiếng thành viên hoặc chính thành viên đó)
+	currentMember, permissions, err := s.memberPermissions(ctx, userID, groupID)
 	if err != nil {
 		return nil, err
 	}
-
-	if currentMember != nil && currentMember.Status == models.GroupMemberStatusApproved {
-		if currentMember.Role == models.MemberRoleAdmin {
-			isAdmin = true
-		} else if currentMember.ID != memberID {
-			return nil, errors.New("bạn không có quyền cập nhật thông tin thành viên khác")
-		}
-	} else {
+	if currentMember == nil {
 		return nil, errors.New("bạn không có quyền cập nhật thông tin thành viên")
 	}
+	isAdmin := currentMember.Role == models.MemberRoleAdmin
+	isSelf := currentMember.ID == memberID
+	canMute := permissions.Has(models.GroupPermissionMuteMembers)
+	if !isAdmin && !isSelf && !canMute {
+		return nil, errors.New("bạn không có quyền cập nhật thông tin thành viên khác")
+	}
 
 	// Lấy thông tin thành viên cần cập nhật
 	member, err := s.memberRepo.FindByID(ctx, memberID)
@@ -544,24 +754,55 @@ func (s *groupService) UpdateMember(ctx context.Context, userID, groupID, member
 	if member == nil || member.GroupID != groupID {
 		return nil, errors.New("không tìm thấy thành viên")
 	}
+	group, err := s.groupRepo.FindByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
 
 	// Cập nhật thông tin
-	if req.Nickname != "" {
+	if req.Nickname != "" && (isAdmin || isSelf) {
 		member.Nickname = req.Nickname
 	}
 
-	// Chỉ admin mới có thể cập nhật vai trò và trạng thái chặn
-	if isAdmin {
-		if req.Role != "" {
-			if req.Role == "admin" {
-				member.Role = models.MemberRoleAdmin
-			} else {
-				member.Role = models.MemberRoleMember
+	// Chỉ admin mới có thể cập nhật vai trò. Chủ nhóm luôn là admin, và admin cuối cùng không thể tự hạ vai trò.
+	if isAdmin && req.Role != "" {
+		if req.Role == "admin" {
+			member.Role = models.MemberRoleAdmin
+		} else if member.Role == models.MemberRoleAdmin {
+			if group.OwnerID == member.UserID {
+				return nil, ErrCannotDemoteOwner
+			}
+			if err := s.ensureNotLastAdmin(ctx, member); err != nil {
+				return nil, err
 			}
+			member.Role = models.MemberRoleMember
 		}
+	}
 
-		if req.IsMuted != nil {
-			member.IsMuted = *req.IsMuted
+	// Người có quyền mute_members tắt tiếng được thành viên thường, admin tắt tiếng được tất cả trừ chủ nhóm.
+	// Tắt tiếng ở đây là vô thời hạn, thời hạn và lý do được đặt qua API tắt tiếng.
+	var muteEntry *models.GroupModerationLog
+	if req.IsMuted != nil && *req.IsMuted != member.IsMuted && !isSelf &&
+		(isAdmin || (canMute && member.Role != models.MemberRoleAdmin)) {
+		if group.OwnerID == member.UserID {
+			return nil, ErrCannotModerateOwner
+		}
+		member.IsMuted = *req.IsMuted
+		member.MutedUntil = nil
+		member.MuteReason = ""
+
+		muteEntry = &models.GroupModerationLog{
+			GroupID:      groupID,
+			ActorID:      &userID,
+			TargetUserID: member.UserID,
+			Action:       models.GroupModerationMemberUnmute,
+		}
+		if member.IsMuted {
+			muteEntry.Action = models.GroupModerationMemberMute
+			muteEntry.Details = "until=indefinite"
 		}
 	}
 
@@ -570,6 +811,11 @@ func (s *groupService) UpdateMember(ctx context.Context, userID, groupID, member
 	if err != nil {
 		return nil, fmt.Errorf("lỗi khi cập nhật thông tin thành viên: %v", err)
 	}
+	if muteEntry != nil {
+		if err := s.moderationRepo.CreateLog(ctx, muteEntry); err != nil {
+			return nil, fmt.Errorf("lỗi khi ghi nhật ký kiểm duyệt: %v", err)
+		}
+	}
 
 	// Lấy thông tin đầy đủ
 	updatedMember, err := s.memberRepo.FindByID(ctx, memberID)
@@ -597,7 +843,11 @@ func (s *groupService) GetGroupMemberByID(ctx context.Context, memberID int64) (
 }
 
 // GetGroupMembers lấy danh sách thành viên nhóm
-func (s *groupService) GetGroupMembers(ctx context.Context, groupID int64, req *request.GroupMemberListRequest) (*response.GroupMemberListResponse, error) {
+func (s *groupService) GetGroupMembers(ctx context.Context, userID, groupID int64, req *request.GroupMemberListRequest) (*response.GroupMemberListResponse, error) {
+	if _, err := s.findVisibleGroup(ctx, userID, groupID); err != nil {
+		return nil, err
+	}
+
 	// Lấy danh sách thành viên có phân trang
 	members, total, err := s.memberRepo.ListByGroup(ctx, groupID, req.Page, req.PageSize)
 	if err != nil {
@@ -619,38 +869,40 @@ func (s *groupService) GetGroupMembers(ctx context.Context, groupID int64, req *
 	return resp, nil
 }
 
-// CreateGroupRole tạo vai trò mới trong nhóm
-func (s *groupService) CreateGroupRole(ctx context.Context, userID, groupID int64, req *request.GroupRoleCreateRequest) (*response.GroupRoleResponse, error) {
-	// TODO: Implement when needed
-	return nil, errors.New("chức năng này chưa được triển khai")
-}
-
-// UpdateGroupRole cập nhật vai trò trong nhóm
-func (s *groupService) UpdateGroupRole(ctx context.Context, userID, groupID, roleID int64, req *request.GroupRoleUpdateRequest) (*response.GroupRoleResponse, error) {
-	// TODO: Implement when needed
-	return nil, errors.New("chức năng này chưa được triển khai")
-}
-
-// DeleteGroupRole xóa vai trò trong nhóm
-func (s *groupService) DeleteGroupRole(ctx context.Context, userID, groupID, roleID int64) error {
-	// TODO: Implement when needed
-	return errors.New("chức năng này chưa được triển khai")
-}
-
-// GetGroupRoles lấy danh sách vai trò trong nhóm
-func (s *groupService) GetGroupRoles(ctx context.Context, groupID int64) (*response.GroupRoleListResponse, error) {
-	// TODO: Implement when needed
-	return nil, errors.New("chức năng này chưa được triển khai")
-}
-
-// AssignRoleToMember gán vai trò cho thành viên
-func (s *groupService) AssignRoleToMember(ctx context.Context, userID, groupID, memberID int64, req *request.GroupMemberRoleRequest) error {
-	// TODO: Implement when needed
-	return errors.New("chức năng này chưa được triển khai")
-}
+// StartMaintenance định kỳ đánh dấu hết hạn các yêu cầu tham gia và lời mời quá hạn, gỡ tắt tiếng hết hạn và
+// chỉ định người quản lý mới cho các nhóm mất admin hoặc chủ nhóm, cho tới khi ctx bị hủy. Lần đầu chạy ngay khi khởi động để các
+// nhóm cũ có chủ nhóm tường minh.
+func (s *groupService) StartMaintenance(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if count, err := s.ExpireJoinRequests(ctx); err != nil {
+			log.Printf("Group join request expiry failed: %v", err)
+		} else if count > 0 {
+			log.Printf("Expired %d group join requests", count)
+		}
+		if count, err := s.ExpireInvitations(ctx); err != nil {
+			log.Printf("Group invitation expiry failed: %v", err)
+		} else if count > 0 {
+			log.Printf("Expired %d group invitations", count)
+		}
+		if count, err := s.ExpireMutes(ctx); err != nil {
+			log.Printf("Group mute expiry failed: %v", err)
+		} else if count > 0 {
+			log.Printf("Lifted %d expired group mutes", count)
+		}
+		if count, err := s.RecoverGroupLeadership(ctx); err != nil {
+			log.Printf("Group leadership recovery failed: %v", err)
+		} else if count > 0 {
+			log.Printf("Recovered admins or owners for %d groups", count)
+		}
 
-// RemoveRoleFromMember gỡ bỏ vai trò khỏi thành viên
-func (s *groupService) RemoveRoleFromMember(ctx context.Context, userID, groupID, memberID, roleID int64) error {
-	// TODO: Implement when needed
-	return errors.New("chức năng này chưa được triển khai")
+		select {
+		case <-ctx.Done():
+			log.Println("Group maintenance stopped")
+			return
+		case <-ticker.C:
+		}
+	}
 }
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"userservice2/repositories"
+)
+
+// Nguồn dữ liệu có tham chiếu tới ảnh
+const (
+	MediaSourceUsers  = "users"
+	MediaSourceGroups = "groups"
+)
+
+const maxMediaReferencePageSize = 1000
+
+// ErrInvalidMediaSource trả về khi nguồn media không được hỗ trợ
+var ErrInvalidMediaSource = errors.New("nguồn media không hợp lệ")
+
+// MediaReferenceService cung cấp danh sách URL ảnh đang được sử dụng cho job dọn media
+type MediaReferenceService interface {
+	ListMediaReferences(ctx context.Context, source string, afterID int64, limit int) ([]string, int64, bool, error)
+}
+
+// mediaReferenceService triển khai MediaReferenceService
+type mediaReferenceService struct {
+	repo repositories.MediaReferenceRepository
+}
+
+// NewMediaReferenceService tạo instance mới của MediaReferenceService
+func NewMediaReferenceService(repo repositories.MediaReferenceRepository) MediaReferenceService {
+	return &mediaReferenceService{repo: repo}
+}
+
+// ListMediaReferences trả về các URL của một trang, ID cuối cùng để lấy trang tiếp theo và còn dữ liệu hay không
+func (s *mediaReferenceService) ListMediaReferences(ctx context.Context, source string, afterID int64, limit int) ([]string, int64, bool, error) {
+	if limit <= 0 || limit > maxMediaReferencePageSize {
+		limit = maxMediaReferencePageSize
+	}
+
+	var refs []repositories.MediaReference
+	var err error
+	switch source {
+	case MediaSourceUsers:
+		refs, err = s.repo.ListUserMedia(ctx, afterID, limit)
+	case MediaSourceGroups:
+		refs, err = s.repo.ListGroupMedia(ctx, afterID, limit)
+	default:
+		return nil, 0, false, ErrInvalidMediaSource
+	}
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	urls := make([]string, 0, len(refs))
+	nextAfterID := afterID
+	for _, ref := range refs {
+		urls = append(urls, ref.URLs...)
+		nextAfterID = ref.ID
+	}
+	return urls, nextAfterID, len(refs) == limit, nil
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"userservice2/dto/request"
+	"userservice2/dto/response"
+	"userservice2/models"
+	"userservice2/repositories"
+)
+
+// Khai báo lỗi
+var (
+	ErrCannotMuteSelf = errors.New("không thể tắt tiếng chính mình")
+	ErrMuteNotFound   = errors.New("đối tượng này chưa bị tắt tiếng")
+)
+
+// MuteService xử lý việc tắt tiếng/tạm ẩn người dùng và nhóm khỏi feed
+type MuteService interface {
+	Mute(ctx context.Context, userID int64, req *request.MuteRequest) (*models.UserMute, error)
+	Unmute(ctx context.Context, userID int64, targetType models.MuteTargetType, targetID int64) error
+	ListMutes(ctx context.Context, userID int64, req *request.MuteListRequest) (*response.MuteListResponse, error)
+	GetMutedTargets(ctx context.Context, userID int64) (userIDs, groupIDs []int64, err error)
+}
+
+// muteService triển khai MuteService
+type muteService struct {
+	muteRepo   repositories.MuteRepository
+	userRepo   repositories.UserRepository
+	groupRepo  repositories.UserGroupRepository
+	memberRepo repositories.GroupMemberRepository
+}
+
+// NewMuteService tạo instance mới của MuteService
+func NewMuteService(
+	muteRepo repositories.MuteRepository,
+	userRepo repositories.UserRepository,
+	groupRepo repositories.UserGroupRepository,
+	memberRepo repositories.GroupMemberRepository,
+) MuteService {
+	return &muteService{
+		muteRepo:   muteRepo,
+		userRepo:   userRepo,
+		groupRepo:  groupRepo,
+		memberRepo: memberRepo,
+	}
+}
+
+// Mute tắt tiếng vô thời hạn hoặc tạm ẩn trong 30 ngày. Gọi lại với đối tượng đã bị tắt tiếng
+// sẽ ghi đè kiểu và thời hạn cũ.
+func (s *muteService) Mute(ctx context.Context, userID int64, req *request.MuteRequest) (*models.UserMute, error) {
+	targetType := models.MuteTargetType(req.TargetType)
+	switch targetType {
+	case models.MuteTargetUser:
+		if req.TargetID == userID {
+			return nil, ErrCannotMuteSelf
+		}
+		user, err := s.userRepo.FindByID(ctx, req.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, ErrUserNotFound
+		}
+	case models.MuteTargetGroup:
+		group, err := s.groupRepo.FindByID(ctx, req.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		if group == nil {
+			return nil, ErrGroupNotFound
+		}
+		// Không để lộ sự tồn tại của nhóm bí mật cho người ngoài nhóm
+		if group.Privacy == models.GroupPrivacySecret {
+			member, err := s.memberRepo.FindByUserAndGroup(ctx, userID, group.ID)
+			if err != nil {
+				return nil, err
+			}
+			if member == nil || member.Status != models.GroupMemberStatusApproved {
+				return nil, ErrGroupNotFound
+			}
+		}
+	}
+
+	mute := &models.UserMute{
+		UserID:     userID,
+		TargetType: targetType,
+		TargetID:   req.TargetID,
+		Kind:       models.MuteKindMute,
+	}
+	if req.Kind == string(models.MuteKindSnooze) {
+		expiresAt := time.Now().Add(models.SnoozeDuration)
+		mute.Kind = models.MuteKindSnooze
+		mute.ExpiresAt = &expiresAt
+	}
+
+	if err := s.muteRepo.Upsert(ctx, mute); err != nil {
+		return nil, err
+	}
+	return mute, nil
+}
+
+// Unmute bỏ tắt tiếng một người dùng/nhóm
+func (s *muteService) Unmute(ctx context.Context, userID int64, targetType models.MuteTargetType, targetID int64) error {
+	deleted, err := s.muteRepo.Delete(ctx, userID, targetType, targetID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrMuteNotFound
+	}
+	return nil
+}
+
+// ListMutes lấy danh sách đang tắt tiếng kèm thời hạn, các lần tạm ẩn đã hết hạn không được trả về
+func (s *muteService) ListMutes(ctx context.Context, userID int64, req *request.MuteListRequest) (*response.MuteListResponse, error) {
+	mutes, total, err := s.muteRepo.ListActive(ctx, userID, models.MuteTargetType(req.TargetType), req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.MuteListResponse{
+		Mutes: mutes,
+		Total: total,
+		Page:  req.Page,
+		Size:  req.PageSize,
+	}, nil
+}
+
+// GetMutedTargets trả về ID người dùng và nhóm đang bị userID tắt tiếng, dùng để lọc feed
+func (s *muteService) GetMutedTargets(ctx context.Context, userID int64) ([]int64, []int64, error) {
+	mutes, err := s.muteRepo.FindActiveTargets(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userIDs := make([]int64, 0, len(mutes))
+	groupIDs := make([]int64, 0)
+	for _, mute := range mutes {
+		if mute.TargetType == models.MuteTargetGroup {
+			groupIDs = append(groupIDs, mute.TargetID)
+		} else {
+			userIDs = append(userIDs, mute.TargetID)
+		}
+	}
+	return userIDs, groupIDs, nil
+}
@@ -26,8 +26,8 @@ type UserService interface {
 	GetUserByUsername(ctx context.Context, username string) (*response.UserResponse, error)
 	UpdateProfile(ctx context.Context, id int64, req *request.UserProfileUpdateRequest) error
 	ChangePassword(ctx context.Context, id int64, currentPassword, newPassword string) error
-	UploadProfilePicture(ctx context.Context, id int64, fileURL string) error
-	UploadCoverPicture(ctx context.Context, id int64, fileURL string) error
+	UploadProfilePicture(ctx context.Context, id int64, image models.ImageInfo) error
+	UploadCoverPicture(ctx context.Context, id int64, image models.ImageInfo) error
 	ListUsers(ctx context.Context, page, pageSize int) (*response.UserListResponse, error)
 	CreateUserProfileFromAuth(ctx context.Context, user *models.User) error
 	GetFriendshipStatus(ctx context.Context, userID, friendID int64) (string, error)
@@ -37,13 +37,15 @@ type UserService interface {
 type userService struct {
 	userRepo       repositories.UserRepository
 	friendshipRepo repositories.FriendshipRepository
+	followRepo     repositories.FollowRepository
 }
 
 // NewUserService tạo instance mới của UserService
-func NewUserService(userRepo repositories.UserRepository, friendshipRepo repositories.FriendshipRepository) UserService {
+func NewUserService(userRepo repositories.UserRepository, friendshipRepo repositories.FriendshipRepository, followRepo repositories.FollowRepository) UserService {
 	return &userService{
 		userRepo:       userRepo,
 		friendshipRepo: friendshipRepo,
+		followRepo:     followRepo,
 	}
 }
 
@@ -111,6 +113,21 @@ func (s *userService) convertToUserResponse(ctx context.Context, user *models.Us
 		Relationship:      user.Relationship,
 		IsVerified:        user.IsVerified,
 		CreatedAt:         user.CreatedAt,
+
+		ProfilePictureVariants: user.ProfilePictureVariants,
+		CoverPictureVariants:   user.CoverPictureVariants,
+
+		ProfilePictureWidth:    user.ProfilePictureWidth,
+		ProfilePictureHeight:   user.ProfilePictureHeight,
+		ProfilePictureBlurHash: user.ProfilePictureBlurHash,
+		CoverPictureWidth:      user.CoverPictureWidth,
+		CoverPictureHeight:     user.CoverPictureHeight,
+		CoverPictureBlurHash:   user.CoverPictureBlurHash,
+
+		IsHidden:          user.IsHidden,
+		IsDeactivated:     !user.IsActive,
+		FollowPolicy:      string(user.FollowPolicy),
+		GroupInvitePolicy: string(user.GroupInvitePolicy),
 	}
 
 	// Thêm thông tin chi tiết về vị trí nếu có
@@ -124,6 +141,14 @@ func (s *userService) convertToUserResponse(ctx context.Context, user *models.Us
 		userResp.FriendCount = friendCount
 	}
 
+	// Thêm số người theo dõi và đang theo dõi
+	if followerCount, err := s.followRepo.CountFollowers(ctx, user.ID); err == nil {
+		userResp.FollowerCount = followerCount
+	}
+	if followingCount, err := s.followRepo.CountFollowing(ctx, user.ID); err == nil {
+		userResp.FollowingCount = followingCount
+	}
+
 	return userResp
 }
 
@@ -203,6 +228,12 @@ func (s *userService) UpdateProfile(ctx context.Context, id int64, req *request.
 	user.Work = req.Work
 	user.Education = req.Education
 	user.Relationship = req.Relationship
+	if req.FollowPolicy != "" {
+		user.FollowPolicy = models.FollowPolicy(req.FollowPolicy)
+	}
+	if req.GroupInvitePolicy != "" {
+		user.GroupInvitePolicy = models.GroupInvitePolicy(req.GroupInvitePolicy)
+	}
 
 	// Xử lý ngày sinh
 	if req.DateOfBirth != "" {
@@ -229,7 +260,7 @@ func (s *userService) ChangePassword(ctx context.Context, id int64, currentPassw
 }
 
 // UploadProfilePicture cập nhật ảnh đại diện
-func (s *userService) UploadProfilePicture(ctx context.Context, id int64, fileURL string) error {
+func (s *userService) UploadProfilePicture(ctx context.Context, id int64, image models.ImageInfo) error {
 	user, err := s.userRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
@@ -239,13 +270,21 @@ func (s *userService) UploadProfilePicture(ctx context.Context, id int64, fileUR
 		return ErrUserNotFound
 	}
 
-	user.ProfilePictureURL = fileURL
+	user.ProfilePictureURL = image.URL
+	user.ProfilePictureVariants = image.Variants
+	if user.ProfilePictureVariants == nil {
+		// Ghi đè variant cũ khi ảnh mới không có variant
+		user.ProfilePictureVariants = models.MediaVariants{}
+	}
+	user.ProfilePictureWidth = image.Width
+	user.ProfilePictureHeight = image.Height
+	user.ProfilePictureBlurHash = image.BlurHash
 
 	return s.userRepo.Update(ctx, user)
 }
 
 // UploadCoverPicture cập nhật ảnh bìa
-func (s *userService) UploadCoverPicture(ctx context.Context, id int64, fileURL string) error {
+func (s *userService) UploadCoverPicture(ctx context.Context, id int64, image models.ImageInfo) error {
 	user, err := s.userRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
@@ -255,7 +294,15 @@ func (s *userService) UploadCoverPicture(ctx context.Context, id int64, fileURL
 		return ErrUserNotFound
 	}
 
-	user.CoverPictureURL = fileURL
+	user.CoverPictureURL = image.URL
+	user.CoverPictureVariants = image.Variants
+	if user.CoverPictureVariants == nil {
+		// Ghi đè variant cũ khi ảnh mới không có variant
+		user.CoverPictureVariants = models.MediaVariants{}
+	}
+	user.CoverPictureWidth = image.Width
+	user.CoverPictureHeight = image.Height
+	user.CoverPictureBlurHash = image.BlurHash
 
 	return s.userRepo.Update(ctx, user)
 }
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"userservice2/dto/request"
+	"userservice2/dto/response"
+	"userservice2/models"
+	"userservice2/repositories"
+)
+
+// Khai báo lỗi
+var (
+	ErrReportNotFound   = errors.New("báo cáo không tồn tại")
+	ErrAlreadyReported  = errors.New("bạn đã báo cáo người dùng này")
+	ErrCannotReportSelf = errors.New("không thể tự báo cáo chính mình")
+)
+
+// UserReportService xử lý báo cáo người dùng và hàng đợi kiểm duyệt
+type UserReportService interface {
+	ReportUser(ctx context.Context, reporterID int64, username string, req *request.UserReportRequest) (*models.UserReport, error)
+	ListReports(ctx context.Context, req *request.ReportListRequest) (*response.UserReportListResponse, error)
+	GetReport(ctx context.Context, reportID int64) (*response.UserReportDetailResponse, error)
+	Decide(ctx context.Context, moderatorID, reportID int64, req *request.ModerationDecisionRequest) (*models.UserModerationAction, error)
+	ListActions(ctx context.Context, req *request.ModerationActionListRequest) (*response.ModerationActionListResponse, error)
+}
+
+// userReportService triển khai UserReportService
+type userReportService struct {
+	reportRepo        repositories.UserReportRepository
+	userRepo          repositories.UserRepository
+	autoHideThreshold int
+}
+
+// NewUserReportService tạo instance mới của UserReportService. Trang cá nhân tự động bị ẩn
+// khi có autoHideThreshold người khác nhau báo cáo (<= 0 là tắt tự động ẩn).
+func NewUserReportService(reportRepo repositories.UserReportRepository, userRepo repositories.UserRepository, autoHideThreshold int) UserReportService {
+	return &userReportService{
+		reportRepo:        reportRepo,
+		userRepo:          userRepo,
+		autoHideThreshold: autoHideThreshold,
+	}
+}
+
+// ReportUser ghi nhận báo cáo và tự động ẩn trang cá nhân khi đủ số người báo cáo
+func (s *userReportService) ReportUser(ctx context.Context, reporterID int64, username string, req *request.UserReportRequest) (*models.UserReport, error) {
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	if user.ID == reporterID {
+		return nil, ErrCannotReportSelf
+	}
+
+	exists, err := s.reportRepo.Exists(ctx, user.ID, reporterID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrAlreadyReported
+	}
+
+	report := &models.UserReport{
+		ReportedUserID: user.ID,
+		ReporterID:     reporterID,
+		Reason:         models.ReportReason(req.Reason),
+		Details:        req.Details,
+		Status:         models.ReportStatusOpen,
+	}
+	if err := s.reportRepo.Create(ctx, report); err != nil {
+		return nil, err
+	}
+
+	if !user.IsHidden && s.autoHideThreshold > 0 {
+		s.autoHide(ctx, user.ID)
+	}
+	return report, nil
+}
+
+// autoHide ẩn trang cá nhân khi số báo cáo đang mở đạt ngưỡng, lỗi chỉ được ghi log
+func (s *userReportService) autoHide(ctx context.Context, userID int64) {
+	count, err := s.reportRepo.CountOpenByUser(ctx, userID)
+	if err != nil {
+		log.Printf("Không thể đếm báo cáo của người dùng %d: %v", userID, err)
+		return
+	}
+	if count < s.autoHideThreshold {
+		return
+	}
+
+	action := &models.UserModerationAction{
+		UserID:      userID,
+		Action:      models.ModerationActionAutoHide,
+		ReportCount: count,
+	}
+	// Báo cáo vẫn giữ trạng thái open để moderator xem xét
+	if err := s.reportRepo.ApplyDecision(ctx, action, ""); err != nil {
+		log.Printf("Không thể tự động ẩn người dùng %d: %v", userID, err)
+		return
+	}
+	log.Printf("Đã tự động ẩn người dùng %d sau %d báo cáo", userID, count)
+}
+
+// ListReports lấy hàng đợi báo cáo theo trạng thái
+func (s *userReportService) ListReports(ctx context.Context, req *request.ReportListRequest) (*response.UserReportListResponse, error) {
+	reports, total, err := s.reportRepo.List(ctx, models.ReportStatus(req.Status), req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.UserReportListResponse{
+		Reports: reports,
+		Total:   total,
+		Page:    req.Page,
+		Size:    req.PageSize,
+	}, nil
+}
+
+// GetReport lấy chi tiết báo cáo kèm các báo cáo khác về cùng người dùng và lịch sử kiểm duyệt
+func (s *userReportService) GetReport(ctx context.Context, reportID int64) (*response.UserReportDetailResponse, error) {
+	report, err := s.reportRepo.FindByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, ErrReportNotFound
+	}
+
+	userReports, err := s.reportRepo.ListByUser(ctx, report.ReportedUserID)
+	if err != nil {
+		return nil, err
+	}
+	actions, _, err := s.reportRepo.ListActions(ctx, report.ReportedUserID, 0, 1, 100)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &response.UserReportDetailResponse{
+		Report:      *report,
+		UserReports: userReports,
+		Actions:     actions,
+	}
+	if user, err := s.userRepo.FindByID(ctx, report.ReportedUserID); err == nil && user != nil {
+		detail.UserIsHidden = user.IsHidden
+	}
+	return detail, nil
+}
+
+// Decide áp dụng quyết định của moderator cho người dùng bị báo cáo. Quyết định xử lý
+// toàn bộ báo cáo đang mở về người dùng đó và được ghi vào nhật ký kiểm duyệt.
+func (s *userReportService) Decide(ctx context.Context, moderatorID, reportID int64, req *request.ModerationDecisionRequest) (*models.UserModerationAction, error) {
+	report, err := s.reportRepo.FindByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, ErrReportNotFound
+	}
+
+	action := &models.UserModerationAction{
+		UserID:      report.ReportedUserID,
+		ModeratorID: moderatorID,
+		Action:      models.ModerationActionType(req.Action),
+		Note:        req.Note,
+	}
+
+	status := models.ReportStatusActioned
+	if action.Action == models.ModerationActionDismiss {
+		status = models.ReportStatusDismissed
+	}
+	if err := s.reportRepo.ApplyDecision(ctx, action, status); err != nil {
+		return nil, err
+	}
+	return action, nil
+}
+
+// ListActions lấy nhật ký kiểm duyệt
+func (s *userReportService) ListActions(ctx context.Context, req *request.ModerationActionListRequest) (*response.ModerationActionListResponse, error) {
+	actions, total, err := s.reportRepo.ListActions(ctx, req.UserID, req.ModeratorID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.ModerationActionListResponse{
+		Actions: actions,
+		Total:   total,
+		Page:    req.Page,
+		Size:    req.PageSize,
+	}, nil
+}
@@ -0,0 +1,6 @@
+-- Chạy một lần sau khi bảng user_follows được tạo (AutoMigrate) để các cặp bạn bè có từ trước
+-- cũng theo dõi nhau hai chiều như luồng kết bạn mới
+INSERT IGNORE INTO user_follows (follower_id, followee_id, created_at)
+SELECT user_id, friend_id, updated_at FROM friendships WHERE status = 'accepted'
+UNION
+SELECT friend_id, user_id, updated_at FROM friendships WHERE status = 'accepted';
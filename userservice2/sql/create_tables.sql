@@ -90,7 +90,7 @@ CREATE TABLE user_groups (
     id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
     name VARCHAR(100) NOT NULL,
     description TEXT,
-    privacy ENUM('public', 'private') NOT NULL DEFAULT 'public',
+    privacy ENUM('public', 'private', 'secret') NOT NULL DEFAULT 'public',
     cover_image VARCHAR(255),
     created_by BIGINT NOT NULL,
     member_count INT DEFAULT 0,
@@ -1,8 +1,10 @@
 package utils
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
@@ -12,6 +14,8 @@ import (
 	"github.com/cloudinary/cloudinary-go/v2/api"
 	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
 	"github.com/joho/godotenv"
+
+	"userservice2/models"
 )
 
 // CloudinaryUploader là struct chứa client Cloudinary
@@ -46,8 +50,96 @@ func NewCloudinaryUploader() (*CloudinaryUploader, error) {
 	return &CloudinaryUploader{client: cld}, nil
 }
 
-// UploadImage upload một file ảnh lên Cloudinary và trả về URL
+// UploadedImage là ảnh đã được xử lý và upload cùng các variant
+type UploadedImage struct {
+	URL           string
+	Width         int
+	Height        int
+	BlurHash      string
+	DominantColor string
+	Variants      models.MediaVariants
+}
+
+// ToImageInfo chuyển kết quả upload sang models.ImageInfo để lưu vào người dùng
+func (img *UploadedImage) ToImageInfo() models.ImageInfo {
+	return models.ImageInfo{
+		URL:      img.URL,
+		Width:    img.Width,
+		Height:   img.Height,
+		BlurHash: img.BlurHash,
+		Variants: img.Variants,
+	}
+}
+
+// UploadImage xử lý ảnh (xoay, giới hạn kích thước, xóa metadata) rồi upload lên Cloudinary và trả về URL
 func (u *CloudinaryUploader) UploadImage(file interface{}, publicID string) (string, error) {
+	reader, ok := file.(io.Reader)
+	if !ok {
+		// Không đọc được nội dung (vd: URL), upload nguyên trạng
+		return u.uploadToCloudinary(file, publicID)
+	}
+
+	original, _, err := ProcessImage(reader)
+	if err != nil {
+		return "", err
+	}
+	return u.uploadToCloudinary(bytes.NewReader(original.Data), publicID)
+}
+
+// UploadImageWithVariants xử lý ảnh, upload ảnh gốc và các variant (thumbnail/medium/large)
+func (u *CloudinaryUploader) UploadImageWithVariants(file interface{}, publicID string) (*UploadedImage, error) {
+	reader, ok := file.(io.Reader)
+	if !ok {
+		url, err := u.uploadToCloudinary(file, publicID)
+		if err != nil {
+			return nil, err
+		}
+		return &UploadedImage{URL: url}, nil
+	}
+
+	original, variants, err := ProcessImage(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := u.uploadToCloudinary(bytes.NewReader(original.Data), publicID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &UploadedImage{
+		URL:           url,
+		Width:         original.Width,
+		Height:        original.Height,
+		BlurHash:      original.Placeholder.BlurHash,
+		DominantColor: original.Placeholder.DominantColor,
+	}
+	generated := make(map[string]ProcessedImage, len(variants))
+	for _, v := range variants {
+		generated[v.Name] = v
+	}
+	for _, spec := range imageVariantSpecs {
+		v, ok := generated[spec.name]
+		if !ok {
+			// Ảnh gốc đã đủ nhỏ, variant dùng lại ảnh gốc
+			result.Variants = append(result.Variants, models.MediaVariant{
+				Name: spec.name, URL: url, Width: original.Width, Height: original.Height,
+			})
+			continue
+		}
+		variantURL, err := u.uploadToCloudinary(bytes.NewReader(v.Data), publicID+"_"+v.Name)
+		if err != nil {
+			return nil, err
+		}
+		result.Variants = append(result.Variants, models.MediaVariant{
+			Name: v.Name, URL: variantURL, Width: v.Width, Height: v.Height,
+		})
+	}
+	return result, nil
+}
+
+// uploadToCloudinary upload nguyên trạng một file lên Cloudinary và trả về URL
+func (u *CloudinaryUploader) uploadToCloudinary(file interface{}, publicID string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -136,6 +228,24 @@ func (u *CloudinaryUploader) DeleteImage(mediaURL string) error {
 	return nil
 }
 
+// DeleteImageWithVariants xóa ảnh gốc và các variant được upload riêng
+func (u *CloudinaryUploader) DeleteImageWithVariants(mediaURL string, variants models.MediaVariants) error {
+	if err := u.DeleteImage(mediaURL); err != nil {
+		return err
+	}
+
+	for _, v := range variants {
+		// Variant dùng lại ảnh gốc thì đã bị xóa cùng ảnh gốc
+		if v.URL == mediaURL {
+			continue
+		}
+		if err := u.DeleteImage(v.URL); err != nil {
+			log.Printf("Không thể xóa variant %s: %v", v.URL, err)
+		}
+	}
+	return nil
+}
+
 // extractPublicIDFromURL trích xuất PublicID từ SecureURL của Cloudinary
 func extractPublicIDFromURL(url string) string {
 	// Ví dụ URL:
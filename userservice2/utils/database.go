@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"fmt"
+
 	"userservice2/models"
 
 	"github.com/jinzhu/gorm"
@@ -9,14 +11,58 @@ import (
 // SetupDatabase thực hiện auto migrate cho các model trong database
 func SetupDatabase(db *gorm.DB) error {
 	// Thực hiện auto migrate
-	return db.AutoMigrate(
+	err := db.AutoMigrate(
 		&models.User{},
 		&models.Friendship{},
 		&models.UserGroup{},
 		&models.GroupMember{},
+		&models.GroupJoinRequest{},
+		&models.GroupInvitation{},
+		&models.GroupOwnershipTransfer{},
+		&models.GroupBan{},
+		&models.GroupModerationLog{},
+		&models.GroupRule{},
+		&models.GroupAnnouncement{},
 		&models.GroupRole{},
+		&models.Event{},
+		&models.EventRSVP{},
 		&models.GroupMemberRole{},
+		&models.UserReport{},
+		&models.UserModerationAction{},
+		&models.AdminAuditLog{},
+		&models.UserMute{},
+		&models.UserFollow{},
+		&models.FriendList{},
+		&models.FriendListMember{},
+		&models.FriendSuggestion{},
+		&models.FriendSuggestionDismissal{},
+		&models.FriendSuggestionState{},
 	).Error
+	if err != nil {
+		return err
+	}
+
+	// AutoMigrate không sửa cột đã có, thêm giá trị secret vào enum privacy của bảng cũ
+	if err := db.Model(&models.UserGroup{}).ModifyColumn("privacy", "enum('public','private','secret') DEFAULT 'public'").Error; err != nil {
+		return err
+	}
+
+	// Chỉ mục FULLTEXT cho tìm kiếm nhóm, gorm v1 không khai báo được qua struct tag
+	return ensureFullTextIndex(db, "user_groups", "ft_user_groups_search", "name, description")
+}
+
+// ensureFullTextIndex tạo chỉ mục FULLTEXT name trên các cột columns của table nếu chưa có
+func ensureFullTextIndex(db *gorm.DB, table, name, columns string) error {
+	var count int
+	err := db.Raw(`SELECT COUNT(*) FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?`, table, name).Row().Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	return db.Exec(fmt.Sprintf("ALTER TABLE %s ADD FULLTEXT INDEX %s (%s)", table, name, columns)).Error
 }
 
 // Pagination tính toán offset và limit cho phân trang
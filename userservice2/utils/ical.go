@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// iCalProductID là PRODID ghi vào các tệp .ics do hệ thống xuất ra
+const iCalProductID = "-//Hoan Hao Social//Events//VI"
+
+// Độ dài tối đa (byte) của một dòng nội dung iCalendar trước khi phải gập dòng (RFC 5545 mục 3.1)
+const iCalLineLimit = 75
+
+// CalendarEvent là một sự kiện cần xuất ra iCalendar. Thời gian được ghi theo UTC nên không cần VTIMEZONE.
+type CalendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	Created     time.Time
+	Modified    time.Time
+}
+
+// BuildICalendar tạo nội dung tệp .ics (RFC 5545) chứa các sự kiện, name là tên lịch hiển thị trong ứng dụng lịch
+func BuildICalendar(name string, events []CalendarEvent, now time.Time) []byte {
+	var b strings.Builder
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:"+iCalProductID)
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+	writeICalLine(&b, "METHOD:PUBLISH")
+	if name != "" {
+		writeICalLine(&b, "X-WR-CALNAME:"+escapeICalText(name))
+	}
+
+	stamp := formatICalTime(now)
+	for _, event := range events {
+		writeICalLine(&b, "BEGIN:VEVENT")
+		writeICalLine(&b, "UID:"+event.UID)
+		writeICalLine(&b, "DTSTAMP:"+stamp)
+		writeICalLine(&b, "DTSTART:"+formatICalTime(event.Start))
+		writeICalLine(&b, "DTEND:"+formatICalTime(event.End))
+		writeICalLine(&b, "SUMMARY:"+escapeICalText(event.Summary))
+		if event.Description != "" {
+			writeICalLine(&b, "DESCRIPTION:"+escapeICalText(event.Description))
+		}
+		if event.Location != "" {
+			writeICalLine(&b, "LOCATION:"+escapeICalText(event.Location))
+		}
+		if !event.Created.IsZero() {
+			writeICalLine(&b, "CREATED:"+formatICalTime(event.Created))
+		}
+		if !event.Modified.IsZero() {
+			writeICalLine(&b, "LAST-MODIFIED:"+formatICalTime(event.Modified))
+		}
+		writeICalLine(&b, "END:VEVENT")
+	}
+
+	writeICalLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// formatICalTime định dạng thời điểm theo UTC, ví dụ 20261019T083000Z
+func formatICalTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escapeICalText thoát các ký tự đặc biệt trong giá trị kiểu TEXT
+func escapeICalText(value string) string {
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", `\n`).Replace(value)
+}
+
+// writeICalLine ghi một dòng nội dung, gập thành nhiều dòng (bắt đầu bằng dấu cách) khi dài quá iCalLineLimit byte
+// mà không cắt đôi ký tự UTF-8
+func writeICalLine(b *strings.Builder, line string) {
+	limit := iCalLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = iCalLineLimit - 1 // Dấu cách đầu dòng gập cũng được tính
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
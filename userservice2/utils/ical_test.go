@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscapeICalText(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", "Họp mặt cuối năm", "Họp mặt cuối năm"},
+		{"backslash", `C:\events`, `C:\\events`},
+		{"semicolon and comma", "Hà Nội; Việt Nam, 2026", `Hà Nội\; Việt Nam\, 2026`},
+		{"newline", "dòng 1\ndòng 2", `dòng 1\ndòng 2`},
+		{"crlf", "dòng 1\r\ndòng 2", `dòng 1\ndòng 2`},
+		{"carriage return", "dòng 1\rdòng 2", `dòng 1\ndòng 2`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeICalText(tt.value); got != tt.want {
+				t.Errorf("escapeICalText(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteICalLineShort(t *testing.T) {
+	var b strings.Builder
+	line := "SUMMARY:" + strings.Repeat("a", iCalLineLimit-len("SUMMARY:"))
+	writeICalLine(&b, line)
+	if got := b.String(); got != line+"\r\n" {
+		t.Errorf("line of exactly %d bytes was folded: %q", iCalLineLimit, got)
+	}
+}
+
+func TestWriteICalLineFolding(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{"ascii", "DESCRIPTION:" + strings.Repeat("abcdefghij", 20)},
+		// Mỗi "ệ" dài 3 byte nên ranh giới 75 byte rơi vào giữa ký tự
+		{"multibyte", "DESCRIPTION:" + strings.Repeat("ệ", 100)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			writeICalLine(&b, tt.line)
+			out := b.String()
+
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("output does not end with CRLF: %q", out)
+			}
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			if len(lines) < 2 {
+				t.Fatalf("line of %d bytes was not folded", len(tt.line))
+			}
+
+			var unfolded strings.Builder
+			for i, l := range lines {
+				if len(l) > iCalLineLimit {
+					t.Errorf("line %d is %d bytes, limit is %d", i, len(l), iCalLineLimit)
+				}
+				if i > 0 {
+					if !strings.HasPrefix(l, " ") {
+						t.Fatalf("continuation line %d does not start with a space: %q", i, l)
+					}
+					l = l[1:]
+				}
+				if !utf8.ValidString(l) {
+					t.Errorf("line %d splits a UTF-8 character: %q", i, l)
+				}
+				unfolded.WriteString(l)
+			}
+			if unfolded.String() != tt.line {
+				t.Errorf("unfolded output = %q, want %q", unfolded.String(), tt.line)
+			}
+		})
+	}
+}
+
+func TestBuildICalendar(t *testing.T) {
+	start := time.Date(2026, 10, 19, 15, 30, 0, 0, time.FixedZone("ICT", 7*3600))
+	events := []CalendarEvent{{
+		UID:      "event-1@hoanhao",
+		Summary:  "Gặp mặt, giao lưu",
+		Location: "Hà Nội",
+		Start:    start,
+		End:      start.Add(2 * time.Hour),
+	}}
+	out := string(BuildICalendar("Sự kiện của tôi", events, start))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Sự kiện của tôi\r\n",
+		"DTSTART:20261019T083000Z\r\n",
+		"DTEND:20261019T103000Z\r\n",
+		"SUMMARY:Gặp mặt\\, giao lưu\r\n",
+		"LOCATION:Hà Nội\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("calendar does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "DESCRIPTION:") || strings.Contains(out, "CREATED:") {
+		t.Errorf("calendar contains empty optional properties:\n%s", out)
+	}
+}
@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+
+	"github.com/disintegration/imaging"
+)
+
+// Số thành phần BlurHash theo chiều ngang và dọc
+const (
+	blurHashXComponents = 4
+	blurHashYComponents = 3
+)
+
+// Kích thước ảnh thu nhỏ dùng để tính placeholder, đủ cho BlurHash mà không tốn CPU
+const placeholderSampleSize = 32
+
+const base83Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// Placeholder chứa dữ liệu để client hiển thị trước khi ảnh tải xong
+type Placeholder struct {
+	BlurHash      string
+	DominantColor string // Dạng #rrggbb
+}
+
+// ComputePlaceholder tính BlurHash và màu chủ đạo của ảnh
+func ComputePlaceholder(img image.Image) Placeholder {
+	sample := imaging.Fit(img, placeholderSampleSize, placeholderSampleSize, imaging.Box)
+	return Placeholder{
+		BlurHash:      encodeBlurHash(sample, blurHashXComponents, blurHashYComponents),
+		DominantColor: dominantColor(sample),
+	}
+}
+
+// dominantColor lấy màu trung bình của ảnh
+func dominantColor(img image.Image) string {
+	avg := imaging.Resize(img, 1, 1, imaging.Box)
+	c := avg.NRGBAAt(0, 0)
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// encodeBlurHash mã hóa ảnh thành chuỗi BlurHash (https://blurha.sh)
+func encodeBlurHash(img image.Image, xComponents, yComponents int) string {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return ""
+	}
+
+	// Tính các hệ số DCT cho từng thành phần
+	factors := make([][3]float64, 0, xComponents*yComponents)
+	for y := 0; y < yComponents; y++ {
+		for x := 0; x < xComponents; x++ {
+			normalisation := 2.0
+			if x == 0 && y == 0 {
+				normalisation = 1.0
+			}
+			var r, g, b float64
+			for j := 0; j < height; j++ {
+				cosY := math.Cos(math.Pi * float64(y) * float64(j) / float64(height))
+				for i := 0; i < width; i++ {
+					basis := normalisation * math.Cos(math.Pi*float64(x)*float64(i)/float64(width)) * cosY
+					pr, pg, pb, _ := img.At(bounds.Min.X+i, bounds.Min.Y+j).RGBA()
+					r += basis * sRGBToLinear(int(pr>>8))
+					g += basis * sRGBToLinear(int(pg>>8))
+					b += basis * sRGBToLinear(int(pb>>8))
+				}
+			}
+			scale := 1.0 / float64(width*height)
+			factors = append(factors, [3]float64{r * scale, g * scale, b * scale})
+		}
+	}
+
+	var sb strings.Builder
+	sizeFlag := (xComponents - 1) + (yComponents-1)*9
+	sb.WriteString(encodeBase83(sizeFlag, 1))
+
+	dc, ac := factors[0], factors[1:]
+	maximumValue := 1.0
+	if len(ac) > 0 {
+		actualMax := 0.0
+		for _, f := range ac {
+			actualMax = math.Max(actualMax, math.Max(math.Abs(f[0]), math.Max(math.Abs(f[1]), math.Abs(f[2]))))
+		}
+		quantisedMax := int(math.Max(0, math.Min(82, math.Floor(actualMax*166-0.5))))
+		maximumValue = float64(quantisedMax+1) / 166
+		sb.WriteString(encodeBase83(quantisedMax, 1))
+	} else {
+		sb.WriteString(encodeBase83(0, 1))
+	}
+
+	dcValue := (linearToSRGB(dc[0]) << 16) + (linearToSRGB(dc[1]) << 8) + linearToSRGB(dc[2])
+	sb.WriteString(encodeBase83(dcValue, 4))
+
+	for _, f := range ac {
+		quant := func(v float64) int {
+			return int(math.Max(0, math.Min(18, math.Floor(signPow(v/maximumValue, 0.5)*9+9.5))))
+		}
+		sb.WriteString(encodeBase83(quant(f[0])*19*19+quant(f[1])*19+quant(f[2]), 2))
+	}
+	return sb.String()
+}
+
+func encodeBase83(value, length int) string {
+	var sb strings.Builder
+	for i := 1; i <= length; i++ {
+		digit := (value / int(math.Pow(83, float64(length-i)))) % 83
+		sb.WriteByte(base83Chars[digit])
+	}
+	return sb.String()
+}
+
+func sRGBToLinear(value int) float64 {
+	v := float64(value) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(value float64) int {
+	v := math.Max(0, math.Min(1, value))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(value, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(value), exp), value)
+}